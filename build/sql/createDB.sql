@@ -27,19 +27,25 @@ CREATE TABLE IF NOT EXISTS actor_in_film (
     id uuid DEFAULT gen_random_uuid() NOT NULL,
     actor_id uuid NOT NULL,
     film_id uuid NOT NULL,
+    role text DEFAULT 'actor' NOT NULL,
     "character" text,
     description text,
+    billing_order integer DEFAULT 0 NOT NULL,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
     updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT actor_in_film_role_check CHECK ((role = ANY (ARRAY['actor'::text, 'director'::text, 'writer'::text, 'producer'::text, 'composer'::text, 'operator'::text]))),
     CONSTRAINT actor_in_film_character_check CHECK ((("character" IS NULL) OR ((length(TRIM(BOTH FROM "character")) > 0) AND (length("character") <= 200)))),
-    CONSTRAINT actor_in_film_description_check CHECK (((description IS NULL) OR ((length(TRIM(BOTH FROM description)) > 0) AND (length(description) <= 1000))))
+    CONSTRAINT actor_in_film_character_role_check CHECK ((("character" IS NULL) OR (role = 'actor'::text))),
+    CONSTRAINT actor_in_film_description_check CHECK (((description IS NULL) OR ((length(TRIM(BOTH FROM description)) > 0) AND (length(description) <= 1000)))),
+    CONSTRAINT actor_in_film_billing_order_check CHECK ((billing_order >= 0))
 );
 
 CREATE TABLE IF NOT EXISTS country (
     id uuid DEFAULT gen_random_uuid() NOT NULL,
     name text NOT NULL,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
-    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT country_name_check CHECK (((length(name) > 0) AND (length(name) <= 100)))
 );
 
 CREATE TABLE IF NOT EXISTS film (
@@ -112,10 +118,116 @@ CREATE TABLE IF NOT EXISTS user_table (
     login text NOT NULL,
     password_hash bytea NOT NULL,
     avatar text DEFAULT 'avatars/default.png',
+    role text DEFAULT 'user' NOT NULL,
+    show_stats boolean DEFAULT true NOT NULL,
+    show_taste boolean DEFAULT true NOT NULL,
+    show_reviews boolean DEFAULT true NOT NULL,
+    followers_only boolean DEFAULT false NOT NULL,
+    email text,
+    email_verified boolean DEFAULT false NOT NULL,
+    password_set boolean DEFAULT true NOT NULL,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
     updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
     CONSTRAINT user_table_login_check CHECK (((length(login) >= 6) AND (length(login) <= 20))),
-    CONSTRAINT user_table_password_hash_check CHECK ((octet_length(password_hash) = 40))
+    CONSTRAINT user_table_password_hash_check CHECK (((octet_length(password_hash) >= 40) AND (octet_length(password_hash) <= 256))),
+    CONSTRAINT user_table_role_check CHECK ((role = ANY (ARRAY['user'::text, 'editor'::text, 'admin'::text])))
+);
+
+CREATE TABLE IF NOT EXISTS user_follow (
+    follower_id uuid NOT NULL,
+    followee_id uuid NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT user_follow_self_check CHECK ((follower_id <> followee_id))
+);
+
+CREATE TABLE IF NOT EXISTS notification (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    type text NOT NULL,
+    payload jsonb DEFAULT '{}'::jsonb NOT NULL,
+    read_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT notification_type_check CHECK ((type = ANY (ARRAY['film_review'::text, 'rating_shift'::text, 'security'::text])))
+);
+
+CREATE TABLE IF NOT EXISTS user_token (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    purpose text NOT NULL,
+    token_hash bytea NOT NULL,
+    email text,
+    attempts integer DEFAULT 0 NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT user_token_purpose_check CHECK ((purpose = ANY (ARRAY['email_verification'::text, 'password_reset'::text, 'two_factor'::text]))),
+    CONSTRAINT user_token_hash_check CHECK ((octet_length(token_hash) = 32))
+);
+
+CREATE TABLE IF NOT EXISTS user_totp (
+    user_id uuid NOT NULL,
+    secret text NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_recovery_code (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    user_id uuid NOT NULL,
+    code_hash bytea NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT user_recovery_code_hash_check CHECK ((octet_length(code_hash) = 32))
+);
+
+CREATE TABLE IF NOT EXISTS user_identity (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    user_id uuid NOT NULL,
+    provider text NOT NULL,
+    subject text NOT NULL,
+    email text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS oauth_state (
+    state_hash bytea NOT NULL,
+    provider text NOT NULL,
+    code_verifier text NOT NULL,
+    link_user_id uuid,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT oauth_state_hash_check CHECK ((octet_length(state_hash) = 32))
+);
+
+CREATE TABLE IF NOT EXISTS user_deletion (
+    user_id uuid NOT NULL,
+    delete_after timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_login_history (
+    login text NOT NULL,
+    user_id uuid NOT NULL,
+    changed_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    reserved_until timestamp with time zone NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS film_media (
+    id uuid DEFAULT gen_random_uuid() NOT NULL,
+    film_id uuid NOT NULL,
+    type text NOT NULL,
+    url text NOT NULL,
+    "position" integer DEFAULT 0 NOT NULL,
+    width integer,
+    height integer,
+    caption text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT film_media_type_check CHECK ((type = ANY (ARRAY['still'::text, 'poster'::text, 'backdrop'::text, 'trailer'::text]))),
+    CONSTRAINT film_media_url_check CHECK (((length(url) > 0) AND (length(url) <= 500))),
+    CONSTRAINT film_media_size_check CHECK ((((width IS NULL) AND (height IS NULL)) OR ((width > 0) AND (height > 0)))),
+    CONSTRAINT film_media_caption_check CHECK (((caption IS NULL) OR ((length(caption) > 0) AND (length(caption) <= 500))))
 );
 
 
@@ -123,7 +235,7 @@ ALTER TABLE ONLY actor_in_film
     ADD CONSTRAINT actor_in_film_pkey PRIMARY KEY (id);
 
 ALTER TABLE ONLY actor_in_film
-    ADD CONSTRAINT actor_in_film_unique UNIQUE (actor_id, film_id);
+    ADD CONSTRAINT actor_in_film_unique UNIQUE (actor_id, film_id, role);
 
 ALTER TABLE ONLY actor
     ADD CONSTRAINT actor_pkey PRIMARY KEY (id);
@@ -155,6 +267,67 @@ ALTER TABLE ONLY user_table
 ALTER TABLE ONLY user_table
     ADD CONSTRAINT user_table_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY user_follow
+    ADD CONSTRAINT user_follow_pkey PRIMARY KEY (follower_id, followee_id);
+
+CREATE INDEX user_follow_followee_idx ON user_follow (followee_id, created_at DESC);
+
+CREATE INDEX film_feedback_user_created_idx ON film_feedback (user_id, created_at DESC, id DESC);
+
+ALTER TABLE ONLY notification
+    ADD CONSTRAINT notification_pkey PRIMARY KEY (id);
+
+CREATE INDEX notification_user_created_idx ON notification (user_id, created_at DESC, id DESC);
+
+CREATE INDEX notification_user_unread_idx ON notification (user_id) WHERE read_at IS NULL;
+
+-- адрес занят только после подтверждения, иначе чужой ввод блокировал бы владельца почты
+CREATE UNIQUE INDEX user_table_email_idx ON user_table (lower(email)) WHERE email_verified;
+
+ALTER TABLE ONLY user_token
+    ADD CONSTRAINT user_token_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX user_token_hash_idx ON user_token (token_hash);
+
+CREATE INDEX user_token_user_purpose_idx ON user_token (user_id, purpose);
+
+ALTER TABLE ONLY user_totp
+    ADD CONSTRAINT user_totp_pkey PRIMARY KEY (user_id);
+
+ALTER TABLE ONLY user_recovery_code
+    ADD CONSTRAINT user_recovery_code_pkey PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX user_recovery_code_user_hash_idx ON user_recovery_code (user_id, code_hash);
+
+ALTER TABLE ONLY user_identity
+    ADD CONSTRAINT user_identity_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY user_identity
+    ADD CONSTRAINT user_identity_subject_unique UNIQUE (provider, subject);
+
+ALTER TABLE ONLY user_identity
+    ADD CONSTRAINT user_identity_user_provider_unique UNIQUE (user_id, provider);
+
+ALTER TABLE ONLY oauth_state
+    ADD CONSTRAINT oauth_state_pkey PRIMARY KEY (state_hash);
+
+CREATE INDEX oauth_state_expires_idx ON oauth_state (expires_at);
+
+ALTER TABLE ONLY user_deletion
+    ADD CONSTRAINT user_deletion_pkey PRIMARY KEY (user_id);
+
+CREATE INDEX user_deletion_delete_after_idx ON user_deletion (delete_after);
+
+ALTER TABLE ONLY user_login_history
+    ADD CONSTRAINT user_login_history_pkey PRIMARY KEY (login);
+
+CREATE INDEX user_login_history_user_idx ON user_login_history (user_id, changed_at DESC);
+
+ALTER TABLE ONLY film_media
+    ADD CONSTRAINT film_media_pkey PRIMARY KEY (id);
+
+CREATE INDEX film_media_film_position_idx ON film_media (film_id, "position");
+
 
 CREATE FUNCTION public.set_timestamps() RETURNS trigger
     LANGUAGE plpgsql
@@ -170,6 +343,21 @@ BEGIN
 END;
 $$;
 
+CREATE FUNCTION public.check_login_reserved() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM user_login_history
+        WHERE login = NEW.login AND user_id <> NEW.id AND reserved_until > CURRENT_TIMESTAMP
+    ) THEN
+        RAISE EXCEPTION 'login % is reserved', NEW.login
+            USING ERRCODE = 'unique_violation', CONSTRAINT = 'user_login_reserved';
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
 CREATE TRIGGER set_actor_in_film_timestamps BEFORE INSERT OR UPDATE ON actor_in_film FOR EACH ROW EXECUTE FUNCTION set_timestamps();
 
 CREATE TRIGGER set_actor_timestamps BEFORE INSERT OR UPDATE ON actor FOR EACH ROW EXECUTE FUNCTION set_timestamps();
@@ -180,10 +368,14 @@ CREATE TRIGGER set_film_feedback_timestamps BEFORE INSERT OR UPDATE ON film_feed
 
 CREATE TRIGGER set_film_timestamps BEFORE INSERT OR UPDATE ON film FOR EACH ROW EXECUTE FUNCTION set_timestamps();
 
+CREATE TRIGGER set_film_media_timestamps BEFORE INSERT OR UPDATE ON film_media FOR EACH ROW EXECUTE FUNCTION set_timestamps();
+
 CREATE TRIGGER set_genre_timestamps BEFORE INSERT OR UPDATE ON genre FOR EACH ROW EXECUTE FUNCTION set_timestamps();
 
 CREATE TRIGGER set_user_timestamps BEFORE INSERT OR UPDATE ON user_table FOR EACH ROW EXECUTE FUNCTION set_timestamps();
 
+CREATE TRIGGER check_user_login_reserved BEFORE INSERT OR UPDATE OF login ON user_table FOR EACH ROW EXECUTE FUNCTION check_login_reserved();
+
 ALTER TABLE ONLY actor_in_film
     ADD CONSTRAINT actor_in_film_actor_fk FOREIGN KEY (actor_id) REFERENCES actor(id) ON DELETE CASCADE;
 
@@ -200,4 +392,37 @@ ALTER TABLE ONLY film_feedback
     ADD CONSTRAINT film_feedback_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
 
 ALTER TABLE ONLY film
-    ADD CONSTRAINT film_genre_fk FOREIGN KEY (genre_id) REFERENCES genre(id) ON DELETE RESTRICT;
\ No newline at end of file
+    ADD CONSTRAINT film_genre_fk FOREIGN KEY (genre_id) REFERENCES genre(id) ON DELETE RESTRICT;
+
+ALTER TABLE ONLY user_follow
+    ADD CONSTRAINT user_follow_follower_fk FOREIGN KEY (follower_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_follow
+    ADD CONSTRAINT user_follow_followee_fk FOREIGN KEY (followee_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification
+    ADD CONSTRAINT notification_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_token
+    ADD CONSTRAINT user_token_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_totp
+    ADD CONSTRAINT user_totp_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_recovery_code
+    ADD CONSTRAINT user_recovery_code_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_identity
+    ADD CONSTRAINT user_identity_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY oauth_state
+    ADD CONSTRAINT oauth_state_user_fk FOREIGN KEY (link_user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_deletion
+    ADD CONSTRAINT user_deletion_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY user_login_history
+    ADD CONSTRAINT user_login_history_user_fk FOREIGN KEY (user_id) REFERENCES user_table(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY film_media
+    ADD CONSTRAINT film_media_film_fk FOREIGN KEY (film_id) REFERENCES film(id) ON DELETE CASCADE;
\ No newline at end of file
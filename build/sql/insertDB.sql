@@ -971,3 +971,16 @@ INSERT INTO actor_in_film (id, actor_id, film_id, character, description) VALUES
 ('a3bb189e-8bf9-3888-9712-6c2d5c7c5b9f', 'a3bb189e-8bf9-3888-9912-6c2d5c7c5b9a', '3b4c5d6e-7f8a-9b0c-1d2e-3f4a5b6c7d8e', 'Американский покупатель', 'Богатый клиент, желающий приобрести украденный алмаз'),
 ('a3bb189e-8bf9-3888-9612-6c2d5c7c5ba2', 'a3bb189e-8bf9-3888-9912-6c2d5c7c5b9a', '4a5b6c7d-8e9f-0a1b-2c3d-4e5f6a7b8c9d', 'Том из США', 'Американский партнер по бизнесу, втянутый в авантюру');
 
+INSERT INTO film_media (film_id, type, url, "position")
+SELECT f.id, legacy.type, legacy.url, legacy.position
+FROM film f
+CROSS JOIN LATERAL (VALUES
+    ('poster', f.poster, 0),
+    ('backdrop', f.cover, 1),
+    ('still', f.image1, 2),
+    ('still', f.image2, 3),
+    ('still', f.image3, 4),
+    ('trailer', f.trailer_url, 5)
+) AS legacy(type, url, position)
+WHERE legacy.url IS NOT NULL AND legacy.url <> ''
+    AND NOT EXISTS (SELECT 1 FROM film_media m WHERE m.film_id = f.id);
@@ -8,31 +8,66 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"expvar"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	actorHandlers "kinopoisk/internal/pkg/actors/delivery/http"
+	actorGraph "kinopoisk/internal/pkg/actors/graph"
 	actorRepo "kinopoisk/internal/pkg/actors/repo"
 	actorUsecase "kinopoisk/internal/pkg/actors/usecase"
+	adminHandlers "kinopoisk/internal/pkg/admin/delivery/http"
+	adminRepo "kinopoisk/internal/pkg/admin/repo"
+	adminMediaRepo "kinopoisk/internal/pkg/admin/repo/blob"
+	adminUsecase "kinopoisk/internal/pkg/admin/usecase"
+	"kinopoisk/internal/pkg/auth"
 	authHandlers "kinopoisk/internal/pkg/auth/delivery/http"
+	"kinopoisk/internal/pkg/auth/oidc"
 	authRepo "kinopoisk/internal/pkg/auth/repo"
 	authUsecase "kinopoisk/internal/pkg/auth/usecase"
+	countryHandlers "kinopoisk/internal/pkg/countries/delivery/http"
+	countryRepo "kinopoisk/internal/pkg/countries/repo"
+	countryUsecase "kinopoisk/internal/pkg/countries/usecase"
+	feedHandlers "kinopoisk/internal/pkg/feeds/delivery/http"
+	feedRepo "kinopoisk/internal/pkg/feeds/repo"
+	feedUsecase "kinopoisk/internal/pkg/feeds/usecase"
 	filmHandlers "kinopoisk/internal/pkg/films/delivery/http"
 	filmRepo "kinopoisk/internal/pkg/films/repo"
 	filmUsecase "kinopoisk/internal/pkg/films/usecase"
 	genreHandlers "kinopoisk/internal/pkg/genres/delivery/http"
 	genreRepo "kinopoisk/internal/pkg/genres/repo"
 	genreUsecase "kinopoisk/internal/pkg/genres/usecase"
+	"kinopoisk/internal/pkg/mail"
+	metaHandlers "kinopoisk/internal/pkg/meta/delivery/http"
+	metaUsecase "kinopoisk/internal/pkg/meta/usecase"
 	"kinopoisk/internal/pkg/middleware/cors"
 	logger "kinopoisk/internal/pkg/middleware/logger"
+	notificationHandlers "kinopoisk/internal/pkg/notifications/delivery/http"
+	notificationRepo "kinopoisk/internal/pkg/notifications/repo"
+	notificationUsecase "kinopoisk/internal/pkg/notifications/usecase"
+	"kinopoisk/internal/pkg/password"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/realtime"
+	realtimeHandlers "kinopoisk/internal/pkg/realtime/delivery/http"
+	sitemapHandlers "kinopoisk/internal/pkg/sitemap/delivery/http"
+	sitemapRepo "kinopoisk/internal/pkg/sitemap/repo"
+	sitemapUsecase "kinopoisk/internal/pkg/sitemap/usecase"
+	"kinopoisk/internal/pkg/social/compatibility"
+	socialHandlers "kinopoisk/internal/pkg/social/delivery/http"
+	socialRepo "kinopoisk/internal/pkg/social/repo"
+	socialUsecase "kinopoisk/internal/pkg/social/usecase"
+	"kinopoisk/internal/pkg/storage"
 	userHandlers "kinopoisk/internal/pkg/users/delivery/http"
+	avatarRepo "kinopoisk/internal/pkg/users/repo/blob"
 	userRepo "kinopoisk/internal/pkg/users/repo/pg"
-	storageRepo "kinopoisk/internal/pkg/users/repo/s3"
 	userUsecase "kinopoisk/internal/pkg/users/usecase"
 	"os"
 
@@ -51,6 +86,33 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
+// сколько пар пользователей держать в кэше совместимости
+const compatibilityCacheSize = 10000
+
+const (
+	// realtimeBufferSize сколько событий может накопиться у медленного клиента до отключения
+	realtimeBufferSize = 64
+	realtimeHeartbeat  = 25 * time.Second
+)
+
+// oauthTimeout ограничивает обмен кода и запрос userinfo у провайдера
+const oauthTimeout = 10 * time.Second
+
+// accountPurgeInterval как часто удаляются аккаунты, у которых истёк срок ожидания
+const accountPurgeInterval = time.Hour
+
+// avatarUploadCleanupInterval как часто удаляются неподтверждённые загрузки аватаров
+const avatarUploadCleanupInterval = 15 * time.Minute
+
+// avatarReconcileInterval как часто ищутся аватары, на которые никто не ссылается
+const avatarReconcileInterval = 6 * time.Hour
+
+// diskUploadPrefix сюда ведут ссылки для прямой загрузки, когда файлы хранятся на диске
+const diskUploadPrefix = "/api/storage"
+
+// defaultBaseURL адрес сайта, если APP_BASE_URL не задан
+const defaultBaseURL = "https://ddfilms.online"
+
 func initDB(ctx context.Context) (*pgxpool.Pool, error) {
 	host := os.Getenv("DB_HOST")
 	port := os.Getenv("DB_PORT")
@@ -76,13 +138,70 @@ func initDB(ctx context.Context) (*pgxpool.Pool, error) {
 	return pool, nil
 }
 
+// initMailSender отправляет письма через SMTP, если задан MAIL_SMTP_HOST. Без SMTP письма
+// сохраняются файлами в MAIL_DIR только для разработки; если не задано ни то, ни другое, сервер не стартует
+func initMailSender() (mail.Sender, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "DDFilms <noreply@localhost>"
+	}
+
+	host := os.Getenv("MAIL_SMTP_HOST")
+	if host == "" {
+		dir := os.Getenv("MAIL_DIR")
+		if dir == "" {
+			return nil, errors.New("neither MAIL_SMTP_HOST nor MAIL_DIR is set")
+		}
+		log.Printf("Warning: MAIL_SMTP_HOST is not set, mail is saved to %s and not delivered\n", dir)
+		return mail.NewFileSender(dir, from)
+	}
+
+	port := os.Getenv("MAIL_SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return mail.NewSMTPSender(host, port, os.Getenv("MAIL_SMTP_USER"), os.Getenv("MAIL_SMTP_PASSWORD"), from), nil
+}
+
+// initBaseURL адрес сайта для абсолютных ссылок в sitemap, лентах, мета-тегах, письмах, OAuth и загрузках на диск.
+// Без APP_BASE_URL берётся defaultBaseURL, а относительный адрес считается ошибкой конфигурации
+func initBaseURL() (string, error) {
+	value := os.Getenv("APP_BASE_URL")
+	if value == "" {
+		log.Printf("Warning: APP_BASE_URL is not set, using %s\n", defaultBaseURL)
+		return defaultBaseURL, nil
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("APP_BASE_URL must be an absolute http(s) URL, got %q", value)
+	}
+	return strings.TrimSuffix(value, "/"), nil
+}
+
+// initOAuthProviders включает провайдеров, для которых задан OIDC_<NAME>_CLIENT_ID
+func initOAuthProviders(baseURL string) map[string]auth.OAuthProvider {
+	client := &http.Client{Timeout: oauthTimeout}
+	providers := make(map[string]auth.OAuthProvider)
+	for _, cfg := range oidc.ConfigsFromEnv(baseURL) {
+		providers[cfg.Name] = oidc.NewProvider(cfg, client)
+	}
+	return providers
+}
+
+// initS3Client регион по умолчанию ru-7; проверку сертификата можно отключить только явно
 func initS3Client(ctx context.Context) (*s3.Client, string, error) {
 	endpoint := os.Getenv("AWS_S3_ENDPOINT")
 	bucket := os.Getenv("AWS_S3_BUCKET")
 	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
 	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
 
-	region := "ru-7"
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "ru-7"
+	}
+	if bucket == "" {
+		return nil, "", errors.New("AWS_S3_BUCKET is not set")
+	}
 
 	customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 		if service == s3.ServiceID && endpoint != "" {
@@ -97,7 +216,7 @@ func initS3Client(ctx context.Context) (*s3.Client, string, error) {
 	customHTTPClient := &http.Client{
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, // Пропускаем проверку SSL
+				InsecureSkipVerify: os.Getenv("AWS_S3_INSECURE_SKIP_VERIFY") == "true",
 			},
 		},
 	}
@@ -118,6 +237,46 @@ func initS3Client(ctx context.Context) (*s3.Client, string, error) {
 	return client, bucket, nil
 }
 
+// initStorage STORAGE_BACKEND=disk хранит файлы в STORAGE_DIR и раздаёт их сам через
+// возвращаемый handler, STORAGE_BACKEND=s3 хранит в S3 и handler равен nil.
+// Без STORAGE_BACKEND backend выбирается по AWS_S3_BUCKET: настроенный, но недоступный S3
+// останавливает запуск, чтобы файлы не разъезжались молча по дискам отдельных инстансов.
+// Ссылки для прямой загрузки на диск подписываются отдельным ключом STORAGE_SIGNING_KEY, без него сервер не стартует
+func initStorage(ctx context.Context, baseURL string) (storage.Bucket, storage.Presigner, http.Handler, error) {
+	backend := os.Getenv("STORAGE_BACKEND")
+	if backend == "" {
+		backend = "disk"
+		if os.Getenv("AWS_S3_BUCKET") != "" {
+			backend = "s3"
+		}
+		log.Printf("Warning: STORAGE_BACKEND is not set, using %s\n", backend)
+	}
+
+	switch backend {
+	case "s3":
+		s3Client, s3Bucket, err := initS3Client(ctx)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("s3: %w", err)
+		}
+		bucket := storage.NewS3Bucket(s3Client, s3Bucket)
+		return bucket, bucket, nil, nil
+	case "disk":
+	default:
+		return nil, nil, nil, fmt.Errorf("STORAGE_BACKEND must be disk or s3, got %q", backend)
+	}
+
+	dir := os.Getenv("STORAGE_DIR")
+	if dir == "" {
+		dir = "data"
+	}
+	bucket := storage.NewDiskBucket(dir)
+	presigner, err := storage.NewDiskPresigner(bucket, baseURL+diskUploadPrefix, []byte(os.Getenv("STORAGE_SIGNING_KEY")))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("STORAGE_SIGNING_KEY: %w", err)
+	}
+	return bucket, presigner, storage.NewFileHandler(bucket, ""), nil
+}
+
 func main() {
 	_ = godotenv.Load()
 	ctx := context.Background()
@@ -126,67 +285,207 @@ func main() {
 		log.Fatalf("Unable to connect to database: %v\n", err)
 	}
 
-	s3Client, s3Bucket, err := initS3Client(ctx)
+	// PASSWORD_BLOCKLIST дополняет встроенный список частых паролей
+	passwordPolicy, err := password.LoadPolicy(os.Getenv("PASSWORD_BLOCKLIST"))
+	if err != nil {
+		log.Fatalf("Unable to load password blocklist: %v\n", err)
+	}
+
+	baseURL, err := initBaseURL()
+	if err != nil {
+		log.Fatalf("Invalid base URL: %v\n", err)
+	}
+
+	bucket, presigner, staticHandler, err := initStorage(ctx, baseURL)
 	if err != nil {
-		log.Printf("Warning: Unable to connect to S3: %v\n", err)
+		log.Fatalf("Unable to init storage: %v\n", err)
+	}
+
+	mailSender, err := initMailSender()
+	if err != nil {
+		log.Fatalf("Unable to init mail: %v\n", err)
 	}
 
 	ddLogger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 
 	mainRouter := mux.NewRouter()
 	mainRouter.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
+	if staticHandler != nil {
+		staticRouter := mainRouter.PathPrefix("/static/").Subrouter()
+		staticRouter.Use(logger.LoggerMiddleware(ddLogger))
+		staticRouter.PathPrefix("/").Handler(staticHandler).Methods(http.MethodGet, http.MethodHead)
+	}
 
 	apiRouter := mainRouter.PathPrefix("/api").Subrouter()
-	apiRouter.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		http.Error(w, "I am not giving any films!", http.StatusTeapot)
-	})
+	// middleware роутера на ненайденные маршруты не действует, поэтому оборачиваем сами,
+	// чтобы и в этих ответах были request_id и заголовки CORS
+	fallback := func(status int) http.Handler {
+		return cors.CorsMiddleware(logger.LoggerMiddleware(ddLogger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			problem.WriteStatus(w, r, status)
+		})))
+	}
+	apiRouter.NotFoundHandler = fallback(http.StatusNotFound)
+	apiRouter.MethodNotAllowedHandler = fallback(http.StatusMethodNotAllowed)
 
 	apiRouter.Use(cors.CorsMiddleware)
 	apiRouter.Use(logger.LoggerMiddleware(ddLogger))
 
 	// Инициализация репозиториев, usecases и handlers
+	compatibilityCache := compatibility.NewCache(compatibilityCacheSize)
+
+	notificationRepo := notificationRepo.NewNotificationRepository(dbpool)
+	realtimeHub := realtime.NewHub(realtimeBufferSize)
+	notificationUsecase := notificationUsecase.NewNotificationUsecase(notificationRepo, realtimeHub)
+	notificationHandler := notificationHandlers.NewNotificationHandler(notificationUsecase)
+
 	filmRepo := filmRepo.NewFilmRepository(dbpool)
-	filmUsecase := filmUsecase.NewFilmUsecase(filmRepo)
+	filmUsecase := filmUsecase.NewFilmUsecase(filmRepo, notificationUsecase, compatibilityCache, realtimeHub)
 	filmHandler := filmHandlers.NewFilmHandler(filmUsecase)
+	realtimeHandler := realtimeHandlers.NewRealtimeHandler(realtimeHub, filmUsecase, realtimeHeartbeat)
 
 	authRepo := authRepo.NewAuthRepository(dbpool)
-	authUsecase := authUsecase.NewAuthUsecase(authRepo)
+	authUsecase := authUsecase.NewAuthUsecase(authRepo, initOAuthProviders(baseURL), passwordPolicy, notificationUsecase)
 	authHandler := authHandlers.NewAuthHandler(authUsecase)
 
 	genreRepo := genreRepo.NewGenreRepository(dbpool)
 	genreUsecase := genreUsecase.NewGenreUsecase(genreRepo)
 	genreHandler := genreHandlers.NewGenreHandler(genreUsecase)
 
+	countryRepo := countryRepo.NewCountryRepository(dbpool)
+	countryUsecase := countryUsecase.NewCountryUsecase(countryRepo)
+	countryHandler := countryHandlers.NewCountryHandler(countryUsecase)
+
 	actorRepo := actorRepo.NewActorRepository(dbpool)
-	actorUsecase := actorUsecase.NewActorUsecase(actorRepo)
+	costarGraph := actorGraph.NewCostarGraph(actorRepo)
+	graphCtx, stopGraph := context.WithCancel(context.WithValue(ctx, logger.LoggerKey, ddLogger))
+	defer stopGraph()
+	if err := costarGraph.Refresh(graphCtx); err != nil {
+		log.Printf("Warning: Unable to build costar graph: %v\n", err)
+	}
+	go costarGraph.Run(graphCtx)
+	actorUsecase := actorUsecase.NewActorUsecase(actorRepo, costarGraph)
 	actorHandler := actorHandlers.NewActorHandler(actorUsecase)
 
 	userRepo := userRepo.NewUserRepository(dbpool)
-	s3Repo := storageRepo.NewS3Repository(s3Client, s3Bucket)
-	userUsecase := userUsecase.NewUserUsecase(userRepo, s3Repo)
+	avatarRepo := avatarRepo.NewAvatarRepository(bucket, presigner)
+	userUsecase := userUsecase.NewUserUsecase(userRepo, avatarRepo, notificationUsecase, mailSender, passwordPolicy, baseURL)
 	userHandler := userHandlers.NewUserHandler(userUsecase)
+	purgeCtx, stopPurge := context.WithCancel(context.WithValue(ctx, logger.LoggerKey, ddLogger))
+	defer stopPurge()
+	go userUsecase.RunAccountPurge(purgeCtx, accountPurgeInterval)
+	go userUsecase.RunAvatarUploadCleanup(purgeCtx, avatarUploadCleanupInterval)
+	// AVATAR_GC_DRY_RUN=true только пишет в лог, что было бы удалено
+	go userUsecase.RunAvatarReconciler(purgeCtx, avatarReconcileInterval, os.Getenv("AVATAR_GC_DRY_RUN") == "true")
+
+	socialRepo := socialRepo.NewSocialRepository(dbpool)
+	socialUsecase := socialUsecase.NewSocialUsecase(socialRepo, compatibilityCache)
+	socialHandler := socialHandlers.NewSocialHandler(socialUsecase)
+
+	feedRepo := feedRepo.NewFeedRepository(dbpool)
+	feedUsecase := feedUsecase.NewFeedUsecase(feedRepo, baseURL)
+	feedHandler := feedHandlers.NewFeedHandler(feedUsecase)
+
+	metaUsecase := metaUsecase.NewMetaUsecase(filmRepo, actorRepo, genreRepo, baseURL)
+	metaHandler := metaHandlers.NewMetaHandler(metaUsecase)
+
+	sitemapRepo := sitemapRepo.NewSitemapRepository(dbpool)
+	sitemapUsecase := sitemapUsecase.NewSitemapUsecase(sitemapRepo, baseURL)
+	sitemapHandler := sitemapHandlers.NewSitemapHandler(sitemapUsecase)
+	sitemapCtx, stopSitemap := context.WithCancel(context.WithValue(ctx, logger.LoggerKey, ddLogger))
+	defer stopSitemap()
+	if err := sitemapUsecase.Refresh(sitemapCtx); err != nil {
+		log.Printf("Warning: Unable to build sitemap: %v\n", err)
+	}
+	go sitemapUsecase.Run(sitemapCtx)
 
-	apiRouter.HandleFunc("/sitemap.xml", filmHandler.SiteMap).Methods(http.MethodGet)
+	adminRepo := adminRepo.NewAdminRepository(dbpool)
+	adminMediaRepo := adminMediaRepo.NewMediaRepository(bucket)
+	adminUsecase := adminUsecase.NewAdminUsecase(adminRepo, adminMediaRepo, costarGraph, sitemapUsecase)
+	adminHandler := adminHandlers.NewAdminHandler(adminUsecase)
+
+	apiRouter.HandleFunc("/sitemap.xml", sitemapHandler.Index).Methods(http.MethodGet, http.MethodHead)
+	apiRouter.HandleFunc("/sitemap/{name:[a-z]+-[0-9]+}.xml", sitemapHandler.File).Methods(http.MethodGet, http.MethodHead)
+	if uploader, ok := presigner.(http.Handler); ok {
+		apiRouter.PathPrefix("/storage/").Handler(http.StripPrefix(diskUploadPrefix, uploader)).Methods(http.MethodPut, http.MethodOptions)
+	}
 
 	// Auth routes
 	authRouter := apiRouter.PathPrefix("/auth").Subrouter()
 	authRouter.HandleFunc("/signup", authHandler.SignupUser).Methods(http.MethodPost, http.MethodOptions)
 	authRouter.HandleFunc("/signin", authHandler.SignInUser).Methods(http.MethodPost, http.MethodOptions)
+	authRouter.HandleFunc("/signin/2fa", authHandler.VerifyTwoFactor).Methods(http.MethodPost, http.MethodOptions)
+	authRouter.HandleFunc("/oauth/providers", authHandler.OAuthProviders).Methods(http.MethodGet)
+	authRouter.HandleFunc("/oauth/{provider}/login", authHandler.StartOAuth).Methods(http.MethodGet)
+	authRouter.HandleFunc("/oauth/{provider}/callback", authHandler.OAuthCallback).Methods(http.MethodGet)
 
 	protectedAuthRouter := authRouter.PathPrefix("").Subrouter()
 	protectedAuthRouter.Use(authHandler.Middleware)
 	protectedAuthRouter.HandleFunc("/check", authHandler.CheckAuth).Methods(http.MethodGet, http.MethodOptions)
 	protectedAuthRouter.HandleFunc("/logout", authHandler.LogOutUser).Methods(http.MethodPost, http.MethodOptions)
+	protectedAuthRouter.HandleFunc("/2fa/setup", authHandler.SetupTwoFactor).Methods(http.MethodPost, http.MethodOptions)
+	protectedAuthRouter.HandleFunc("/2fa/enable", authHandler.EnableTwoFactor).Methods(http.MethodPost, http.MethodOptions)
+	protectedAuthRouter.HandleFunc("/2fa/disable", authHandler.DisableTwoFactor).Methods(http.MethodPost, http.MethodOptions)
+	protectedAuthRouter.HandleFunc("/oauth/identities", authHandler.GetIdentities).Methods(http.MethodGet, http.MethodOptions)
+	protectedAuthRouter.HandleFunc("/oauth/{provider}/link", authHandler.LinkOAuth).Methods(http.MethodPost, http.MethodOptions)
+	protectedAuthRouter.HandleFunc("/oauth/{provider}", authHandler.UnlinkIdentity).Methods(http.MethodDelete, http.MethodOptions)
 
 	// User routes
 	userRouter := apiRouter.PathPrefix("/users").Subrouter()
+	userRouter.HandleFunc("/by-login/{login}", userHandler.ResolveLogin).Methods(http.MethodGet)
 	userRouter.HandleFunc("/{id}", userHandler.GetUser).Methods(http.MethodGet)
+	userRouter.Handle("/{id}/profile", userHandler.OptionalMiddleware(http.HandlerFunc(userHandler.GetUserProfile))).Methods(http.MethodGet)
+	userRouter.HandleFunc("/{id}/reviews.rss", feedHandler.UserReviews).Methods(http.MethodGet)
+	userRouter.HandleFunc("/{id}/followers", socialHandler.GetFollowers).Methods(http.MethodGet)
+	userRouter.HandleFunc("/{id}/following", socialHandler.GetFollowing).Methods(http.MethodGet)
+	userRouter.HandleFunc("/email/verify", userHandler.VerifyEmail).Methods(http.MethodPost, http.MethodOptions)
+	userRouter.HandleFunc("/password/forgot", userHandler.ForgotPassword).Methods(http.MethodPost, http.MethodOptions)
+	userRouter.HandleFunc("/password/reset", userHandler.ResetPassword).Methods(http.MethodPost, http.MethodOptions)
 
 	// Protected user routes
 	protectedUserRouter := userRouter.PathPrefix("/change").Subrouter()
 	protectedUserRouter.Use(userHandler.Middleware)
 	protectedUserRouter.HandleFunc("/password", userHandler.ChangePassword).Methods(http.MethodPut, http.MethodOptions)
+	protectedUserRouter.HandleFunc("/login", userHandler.ChangeLogin).Methods(http.MethodPut, http.MethodOptions)
 	protectedUserRouter.HandleFunc("/avatar", userHandler.ChangeAvatar).Methods(http.MethodPut, http.MethodOptions)
+	protectedUserRouter.HandleFunc("/avatar/upload", userHandler.RequestAvatarUpload).Methods(http.MethodPost, http.MethodOptions)
+	protectedUserRouter.HandleFunc("/avatar/upload/{id}/confirm", userHandler.ConfirmAvatarUpload).Methods(http.MethodPost, http.MethodOptions)
+	protectedUserRouter.HandleFunc("/privacy", userHandler.ChangePrivacy).Methods(http.MethodPut, http.MethodOptions)
+	protectedUserRouter.HandleFunc("/email", userHandler.ChangeEmail).Methods(http.MethodPut, http.MethodOptions)
+
+	meRouter := userRouter.PathPrefix("/me").Subrouter()
+	meRouter.Use(userHandler.Middleware)
+	meRouter.HandleFunc("/email", userHandler.GetEmail).Methods(http.MethodGet)
+	meRouter.HandleFunc("/export", userHandler.ExportData).Methods(http.MethodGet)
+	meRouter.HandleFunc("", userHandler.DeleteAccount).Methods(http.MethodDelete, http.MethodOptions)
+
+	// Social routes
+	followRouter := userRouter.PathPrefix("").Subrouter()
+	followRouter.Use(authHandler.Middleware)
+	followRouter.HandleFunc("/{id}/follow", socialHandler.Follow).Methods(http.MethodPost, http.MethodOptions)
+	followRouter.HandleFunc("/{id}/follow", socialHandler.Unfollow).Methods(http.MethodDelete, http.MethodOptions)
+	followRouter.HandleFunc("/{id}/compatibility", socialHandler.GetCompatibility).Methods(http.MethodGet)
+
+	feedRouter := apiRouter.PathPrefix("/feed").Subrouter()
+	feedRouter.Use(authHandler.Middleware)
+	feedRouter.HandleFunc("", socialHandler.GetFeed).Methods(http.MethodGet)
+
+	// Feed routes
+	apiRouter.HandleFunc("/feeds/films.atom", feedHandler.NewFilms).Methods(http.MethodGet)
+
+	// Meta routes
+	apiRouter.HandleFunc("/meta", metaHandler.GetMeta).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/prerender", metaHandler.Prerender).Methods(http.MethodGet)
+
+	// Realtime routes
+	apiRouter.HandleFunc("/events", realtimeHandler.Subscribe).Methods(http.MethodGet)
+
+	// Notification routes
+	notificationRouter := apiRouter.PathPrefix("/notifications").Subrouter()
+	notificationRouter.Use(authHandler.Middleware)
+	notificationRouter.HandleFunc("", notificationHandler.GetNotifications).Methods(http.MethodGet)
+	notificationRouter.HandleFunc("/unread", notificationHandler.GetUnreadCount).Methods(http.MethodGet)
+	notificationRouter.HandleFunc("/read-all", notificationHandler.MarkAllRead).Methods(http.MethodPost, http.MethodOptions)
+	notificationRouter.HandleFunc("/{id}/read", notificationHandler.MarkRead).Methods(http.MethodPost, http.MethodOptions)
 
 	// Film routes
 	filmRouter := apiRouter.PathPrefix("/films").Subrouter()
@@ -195,6 +494,7 @@ func main() {
 	filmRouter.HandleFunc("/promo", filmHandler.GetPromoFilm).Methods(http.MethodGet)
 	filmRouter.HandleFunc("/{id}", filmHandler.GetFilm).Methods(http.MethodGet)
 	filmRouter.HandleFunc("/{id}/feedbacks", filmHandler.GetFilmFeedbacks).Methods(http.MethodGet)
+	filmRouter.HandleFunc("/{id}/feedbacks.atom", feedHandler.FilmReviews).Methods(http.MethodGet)
 
 	// Protected film routes
 	protectedFilmRouter := filmRouter.PathPrefix("").Subrouter()
@@ -208,15 +508,50 @@ func main() {
 	genreRouter.HandleFunc("/{id}", genreHandler.GetGenre).Methods(http.MethodGet)
 	genreRouter.HandleFunc("/{id}/films", genreHandler.GetFilmsByGenre).Methods(http.MethodGet)
 
+	// Country routes
+	countryRouter := apiRouter.PathPrefix("/countries").Subrouter()
+	countryRouter.HandleFunc("/", countryHandler.GetCountries).Methods(http.MethodGet)
+	countryRouter.HandleFunc("/{id}", countryHandler.GetCountry).Methods(http.MethodGet)
+	countryRouter.HandleFunc("/{id}/films", countryHandler.GetFilmsByCountry).Methods(http.MethodGet)
+
 	// Actor routes
 	actorRouter := apiRouter.PathPrefix("/actors").Subrouter()
+	actorRouter.HandleFunc("/path", actorHandler.GetCostarPath).Methods(http.MethodGet)
 	actorRouter.HandleFunc("/{id}", actorHandler.GetActor).Methods(http.MethodGet)
 	actorRouter.HandleFunc("/{id}/films", actorHandler.GetFilmsByActor).Methods(http.MethodGet)
+	actorRouter.HandleFunc("/{id}/costars", actorHandler.GetCostars).Methods(http.MethodGet)
+
+	// Admin routes
+	adminRouter := apiRouter.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(authHandler.Middleware)
+	adminRouter.Use(adminHandler.Middleware)
+	adminRouter.Handle("/metrics", expvar.Handler()).Methods(http.MethodGet)
+	adminRouter.HandleFunc("/films", adminHandler.CreateFilm).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}", adminHandler.UpdateFilm).Methods(http.MethodPut, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}", adminHandler.DeleteFilm).Methods(http.MethodDelete, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/actors", adminHandler.AddActorToFilm).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/actors/{actor_id}", adminHandler.UpdateActorInFilm).Methods(http.MethodPut, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/actors/{actor_id}", adminHandler.RemoveActorFromFilm).Methods(http.MethodDelete, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/media", adminHandler.UploadFilmMedia).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/media/trailer", adminHandler.AddFilmTrailer).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/media/order", adminHandler.ReorderFilmMedia).Methods(http.MethodPut, http.MethodOptions)
+	adminRouter.HandleFunc("/films/{id}/media/{media_id}", adminHandler.DeleteFilmMedia).Methods(http.MethodDelete, http.MethodOptions)
+	adminRouter.HandleFunc("/actors", adminHandler.CreateActor).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/actors/{id}", adminHandler.UpdateActor).Methods(http.MethodPut, http.MethodOptions)
+	adminRouter.HandleFunc("/actors/{id}", adminHandler.DeleteActor).Methods(http.MethodDelete, http.MethodOptions)
+	adminRouter.HandleFunc("/genres", adminHandler.CreateGenre).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/genres/{id}", adminHandler.UpdateGenre).Methods(http.MethodPut, http.MethodOptions)
+	adminRouter.HandleFunc("/genres/{id}", adminHandler.DeleteGenre).Methods(http.MethodDelete, http.MethodOptions)
+	adminRouter.HandleFunc("/countries", adminHandler.CreateCountry).Methods(http.MethodPost, http.MethodOptions)
+	adminRouter.HandleFunc("/countries/{id}", adminHandler.UpdateCountry).Methods(http.MethodPut, http.MethodOptions)
+	adminRouter.HandleFunc("/countries/{id}", adminHandler.DeleteCountry).Methods(http.MethodDelete, http.MethodOptions)
 
 	filmSrv := http.Server{
 		Handler: mainRouter,
 		Addr:    ":5458",
 	}
+	// Shutdown ждёт завершения активных запросов, поэтому потоки событий закрываем сразу
+	filmSrv.RegisterOnShutdown(realtimeHub.Close)
 
 	go func() {
 		log.Println("Starting server!")
@@ -232,6 +567,7 @@ func main() {
 
 	<-quitChannel
 	log.Printf("Shutting down gracefully...")
+	stopGraph()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
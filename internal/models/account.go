@@ -0,0 +1,62 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	TokenEmailVerification = "email_verification"
+	TokenPasswordReset     = "password_reset"
+	TokenTwoFactor         = "two_factor"
+)
+
+type UserEmail struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified" binding:"required"`
+}
+
+func (ue *UserEmail) Sanitize() {
+	ue.Email = html.EscapeString(ue.Email)
+}
+
+type ChangeEmailInput struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Sanitize экранирует пароль так же, как при входе, иначе он не совпадёт с хэшем
+func (cei *ChangeEmailInput) Sanitize() {
+	cei.Password = html.EscapeString(cei.Password)
+}
+
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type ResetPasswordInput struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Sanitize экранирует пароль так же, как при входе, иначе новый пароль не совпадёт при логине
+func (rpi *ResetPasswordInput) Sanitize() {
+	rpi.Password = html.EscapeString(rpi.Password)
+}
+
+// UserToken одноразовый токен из письма; в базе хранится только хэш
+type UserToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   string
+	TokenHash []byte
+	Email     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
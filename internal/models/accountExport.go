@@ -0,0 +1,73 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// UserExport все персональные данные пользователя для выгрузки по запросу
+type UserExport struct {
+	ExportedAt time.Time        `json:"exported_at" binding:"required"`
+	Profile    ExportProfile    `json:"profile" binding:"required"`
+	Ratings    []ExportRating   `json:"ratings" binding:"required"`
+	Reviews    []ExportFeedback `json:"reviews" binding:"required"`
+}
+
+type ExportProfile struct {
+	ID            uuid.UUID      `json:"id" binding:"required"`
+	Login         string         `json:"login" binding:"required"`
+	Email         *string        `json:"email"`
+	EmailVerified bool           `json:"email_verified" binding:"required"`
+	Avatar        string         `json:"avatar" binding:"required"`
+	Role          string         `json:"role" binding:"required"`
+	Privacy       ProfilePrivacy `json:"privacy" binding:"required"`
+	CreatedAt     time.Time      `json:"created_at" binding:"required"`
+	UpdatedAt     time.Time      `json:"updated_at" binding:"required"`
+}
+
+type ExportRating struct {
+	FilmID    uuid.UUID `json:"film_id" binding:"required"`
+	FilmTitle string    `json:"film_title" binding:"required"`
+	Rating    int       `json:"rating" binding:"required"`
+	CreatedAt time.Time `json:"created_at" binding:"required"`
+}
+
+// ExportFeedback строка film_feedback; отзыв, если есть заголовок или текст
+type ExportFeedback struct {
+	ID        uuid.UUID `json:"id" binding:"required"`
+	FilmID    uuid.UUID `json:"film_id" binding:"required"`
+	FilmTitle string    `json:"film_title" binding:"required"`
+	Title     *string   `json:"title"`
+	Text      *string   `json:"text"`
+	Rating    *int      `json:"rating"`
+	CreatedAt time.Time `json:"created_at" binding:"required"`
+	UpdatedAt time.Time `json:"updated_at" binding:"required"`
+}
+
+// IsReview true, если пользователь написал что-то кроме оценки
+func (ef *ExportFeedback) IsReview() bool {
+	return (ef.Title != nil && *ef.Title != "") || (ef.Text != nil && *ef.Text != "")
+}
+
+type DeleteAccountInput struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// Sanitize экранирует пароль так же, как при входе, иначе он не совпадёт с хэшем
+func (dai *DeleteAccountInput) Sanitize() {
+	dai.Password = html.EscapeString(dai.Password)
+}
+
+// AccountDeletion до DeleteAfter удаление отменяется обычным входом в аккаунт
+type AccountDeletion struct {
+	DeleteAfter time.Time `json:"delete_after" binding:"required"`
+}
+
+// DeletedUser то, что нужно подчистить вне базы после удаления пользователя
+type DeletedUser struct {
+	ID     uuid.UUID
+	Login  string
+	Avatar string
+}
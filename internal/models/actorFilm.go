@@ -0,0 +1,48 @@
+package models
+
+import (
+	"html"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	ActorFilmsSortYear       = "year"
+	ActorFilmsSortRating     = "rating"
+	ActorFilmsSortPopularity = "popularity"
+)
+
+func IsActorFilmsSort(sort string) bool {
+	switch sort {
+	case ActorFilmsSortYear, ActorFilmsSortRating, ActorFilmsSortPopularity:
+		return true
+	}
+	return false
+}
+
+type ActorFilm struct {
+	ID           uuid.UUID `json:"id" binding:"required"`
+	Cover        string    `json:"cover" binding:"required"`
+	Title        string    `json:"title" binding:"required"`
+	Year         int       `json:"year" binding:"required"`
+	Genre        string    `json:"genre" binding:"required"`
+	Rating       float64   `json:"rating" binding:"required"`
+	RatingsCount int       `json:"ratings_count" binding:"required"`
+	Role         string    `json:"role" binding:"required"`
+	Character    *string   `json:"character,omitempty"`
+	Description  *string   `json:"description,omitempty"`
+}
+
+func (af *ActorFilm) Sanitize() {
+	af.Cover = html.EscapeString(af.Cover)
+	af.Title = html.EscapeString(af.Title)
+	af.Genre = html.EscapeString(af.Genre)
+	if af.Character != nil {
+		sanitized := html.EscapeString(*af.Character)
+		af.Character = &sanitized
+	}
+	if af.Description != nil {
+		sanitized := html.EscapeString(*af.Description)
+		af.Description = &sanitized
+	}
+}
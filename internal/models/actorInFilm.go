@@ -8,16 +8,24 @@ import (
 )
 
 type ActorInFilm struct {
-	ID          uuid.UUID `json:"id" binding:"required"`
-	ActorID     uuid.UUID `json:"actor_id" binding:"required"`
-	FilmID      uuid.UUID `json:"film_id" binding:"required"`
-	Character   string    `json:"character" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           uuid.UUID `json:"id" binding:"required"`
+	ActorID      uuid.UUID `json:"actor_id" binding:"required"`
+	FilmID       uuid.UUID `json:"film_id" binding:"required"`
+	Role         string    `json:"role" binding:"required"`
+	Character    *string   `json:"character,omitempty"`
+	Description  *string   `json:"description,omitempty"`
+	BillingOrder int       `json:"billing_order" binding:"required"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 func (aif *ActorInFilm) Sanitize() {
-	aif.Character = html.EscapeString(aif.Character)
-	aif.Description = html.EscapeString(aif.Description)
+	if aif.Character != nil {
+		sanitized := html.EscapeString(*aif.Character)
+		aif.Character = &sanitized
+	}
+	if aif.Description != nil {
+		sanitized := html.EscapeString(*aif.Description)
+		aif.Description = &sanitized
+	}
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type ActorInFilmInput struct {
+	ActorID      uuid.UUID  `json:"actor_id" binding:"required"`
+	Role         string     `json:"role,omitempty"`
+	Character    *string    `json:"character,omitempty"`
+	Description  *string    `json:"description,omitempty"`
+	BillingOrder int        `json:"billing_order"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
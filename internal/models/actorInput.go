@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type ActorInput struct {
+	RussianName   string     `json:"russian_name" binding:"required"`
+	OriginalName  *string    `json:"original_name,omitempty"`
+	Photo         *string    `json:"photo,omitempty"`
+	Height        int        `json:"height" binding:"required"`
+	BirthDate     time.Time  `json:"birth_date" binding:"required"`
+	DeathDate     *time.Time `json:"death_date,omitempty"`
+	ZodiacSign    *string    `json:"zodiac_sign,omitempty"`
+	BirthPlace    *string    `json:"birth_place,omitempty"`
+	MaritalStatus *string    `json:"marital_status,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
@@ -8,17 +8,18 @@ import (
 )
 
 type ActorPage struct {
-	ID            uuid.UUID `json:"id" binding:"required"`
-	RussianName   string    `json:"russian_name" binding:"required"`
-	OriginalName  *string   `json:"original_name" binding:"required"`
-	Photo         string    `json:"photo" binding:"required"`
-	Height        int       `json:"height" binding:"required"`
-	BirthDate     time.Time `json:"birth_date" binding:"required"`
-	Age           int       `json:"age" binding:"required"`
-	ZodiacSign    string    `json:"zodiac_sign" binding:"required"`
-	BirthPlace    string    `json:"birth_place" binding:"required"`
-	MaritalStatus string    `json:"marital_status" binding:"required"`
-	FilmsNumber   int       `json:"films_number" binding:"required"`
+	ID            uuid.UUID          `json:"id" binding:"required"`
+	RussianName   string             `json:"russian_name" binding:"required"`
+	OriginalName  *string            `json:"original_name" binding:"required"`
+	Photo         string             `json:"photo" binding:"required"`
+	Height        int                `json:"height" binding:"required"`
+	BirthDate     time.Time          `json:"birth_date" binding:"required"`
+	Age           int                `json:"age" binding:"required"`
+	ZodiacSign    string             `json:"zodiac_sign" binding:"required"`
+	BirthPlace    string             `json:"birth_place" binding:"required"`
+	MaritalStatus string             `json:"marital_status" binding:"required"`
+	Filmography   []FilmographyGroup `json:"filmography" binding:"required"`
+	BestKnownFor  []FilmographyFilm  `json:"best_known_for" binding:"required"`
 }
 
 func (ap *ActorPage) Sanitize() {
@@ -31,4 +32,10 @@ func (ap *ActorPage) Sanitize() {
 	ap.ZodiacSign = html.EscapeString(ap.ZodiacSign)
 	ap.BirthPlace = html.EscapeString(ap.BirthPlace)
 	ap.MaritalStatus = html.EscapeString(ap.MaritalStatus)
+	for i := range ap.Filmography {
+		ap.Filmography[i].Sanitize()
+	}
+	for i := range ap.BestKnownFor {
+		ap.BestKnownFor[i].Sanitize()
+	}
 }
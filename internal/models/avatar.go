@@ -0,0 +1,63 @@
+package models
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AvatarSizes стороны квадратных аватаров в пикселях, по возрастанию
+var AvatarSizes = []int{64, 256, 512}
+
+const avatarExtension = ".jpg"
+
+// AvatarURLs пути к аватару каждого размера
+type AvatarURLs struct {
+	Small  string `json:"64" binding:"required"`
+	Medium string `json:"256" binding:"required"`
+	Large  string `json:"512" binding:"required"`
+}
+
+// AvatarPath путь к аватару размера size; в базе хранится путь самого большого
+func AvatarPath(picID string, size int) string {
+	return path.Join("avatars", picID, strconv.Itoa(size)+avatarExtension)
+}
+
+// AvatarPaths все размеры аватара по пути из базы; у аватаров, загруженных
+// до нарезки, и у аватара по умолчанию один файл на все размеры
+func AvatarPaths(avatar string) []string {
+	dir, file := path.Split(avatar)
+	picDir := strings.TrimSuffix(dir, "/")
+	if path.Dir(picDir) != "avatars" || file != strconv.Itoa(AvatarSizes[len(AvatarSizes)-1])+avatarExtension {
+		return []string{avatar}
+	}
+	paths := make([]string, 0, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		paths = append(paths, AvatarPath(path.Base(picDir), size))
+	}
+	return paths
+}
+
+func NewAvatarURLs(avatar string) AvatarURLs {
+	paths := AvatarPaths(avatar)
+	if len(paths) == 1 {
+		return AvatarURLs{Small: avatar, Medium: avatar, Large: avatar}
+	}
+	return AvatarURLs{Small: paths[0], Medium: paths[1], Large: paths[2]}
+}
+
+// AvatarObject файл аватара в хранилище; Path в том же виде, что и в user_table.avatar
+type AvatarObject struct {
+	Path    string
+	ModTime time.Time
+}
+
+// AvatarReconcileReport итог одного прохода сборщика осиротевших аватаров
+type AvatarReconcileReport struct {
+	Scanned  int
+	Orphaned int
+	Deleted  int
+	Failed   int
+	DryRun   bool
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type AvatarUploadInput struct {
+	ContentType string `json:"content_type" binding:"required"`
+	Size        int64  `json:"size" binding:"required"`
+}
+
+func (aui *AvatarUploadInput) Sanitize() {
+	aui.ContentType = html.EscapeString(aui.ContentType)
+}
+
+// AvatarUploadSlot куда и как клиент загружает файл; после загрузки слот подтверждается по ID
+type AvatarUploadSlot struct {
+	ID        uuid.UUID         `json:"id" binding:"required"`
+	URL       string            `json:"url" binding:"required"`
+	Method    string            `json:"method" binding:"required"`
+	Headers   map[string]string `json:"headers" binding:"required"`
+	ExpiresAt time.Time         `json:"expires_at" binding:"required"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"html"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type ChangeLoginInput struct {
+	Login    string `json:"login" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+func (cli *ChangeLoginInput) Sanitize() {
+	cli.Login = html.EscapeString(cli.Login)
+	cli.Password = html.EscapeString(cli.Password)
+}
+
+// LoginResolution владелец логина; Previous true, если логин прежний и ссылку надо перенаправить
+type LoginResolution struct {
+	ID       uuid.UUID
+	Login    string
+	Previous bool
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"html"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// CastEdge связь «актер снимался в фильме», из которой строится граф коллег
+type CastEdge struct {
+	ActorID    uuid.UUID
+	ActorName  string
+	ActorPhoto string
+	FilmID     uuid.UUID
+	FilmTitle  string
+	FilmYear   int
+}
+
+type CostarActor struct {
+	ID          uuid.UUID `json:"id" binding:"required"`
+	RussianName string    `json:"russian_name" binding:"required"`
+	Photo       string    `json:"photo" binding:"required"`
+}
+
+func (ca *CostarActor) Sanitize() {
+	ca.RussianName = html.EscapeString(ca.RussianName)
+	ca.Photo = html.EscapeString(ca.Photo)
+}
+
+type CostarFilm struct {
+	ID    uuid.UUID `json:"id" binding:"required"`
+	Title string    `json:"title" binding:"required"`
+	Year  int       `json:"year" binding:"required"`
+}
+
+func (cf *CostarFilm) Sanitize() {
+	cf.Title = html.EscapeString(cf.Title)
+}
+
+type Costar struct {
+	CostarActor
+	SharedFilms int `json:"shared_films" binding:"required"`
+}
+
+// CostarPath цепочка актеров, где Films[i] связывает Actors[i] и Actors[i+1]
+type CostarPath struct {
+	Degrees int           `json:"degrees" binding:"required"`
+	Actors  []CostarActor `json:"actors" binding:"required"`
+	Films   []CostarFilm  `json:"films" binding:"required"`
+}
+
+func (cp *CostarPath) Sanitize() {
+	for i := range cp.Actors {
+		cp.Actors[i].Sanitize()
+	}
+	for i := range cp.Films {
+		cp.Films[i].Sanitize()
+	}
+}
@@ -8,10 +8,12 @@ import (
 )
 
 type Country struct {
-	ID        uuid.UUID `json:"id" binding:"required"`
-	Name      string    `json:"name" binding:"required"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID `json:"id" binding:"required"`
+	Name       string    `json:"name" binding:"required"`
+	FilmsCount int       `json:"films_count"`
+	Rating     float64   `json:"rating"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 func (c *Country) Sanitize() {
@@ -0,0 +1,8 @@
+package models
+
+import "time"
+
+type CountryInput struct {
+	Name      string     `json:"name" binding:"required"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"html"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	CrewRoleActor    = "actor"
+	CrewRoleDirector = "director"
+	CrewRoleWriter   = "writer"
+	CrewRoleProducer = "producer"
+	CrewRoleComposer = "composer"
+	CrewRoleOperator = "operator"
+)
+
+// CrewRoles задает порядок, в котором группы выводятся на страницах
+var CrewRoles = []string{
+	CrewRoleDirector,
+	CrewRoleActor,
+	CrewRoleWriter,
+	CrewRoleProducer,
+	CrewRoleOperator,
+	CrewRoleComposer,
+}
+
+func IsCrewRole(role string) bool {
+	for _, r := range CrewRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type CrewMember struct {
+	ID           uuid.UUID `json:"id" binding:"required"`
+	RussianName  string    `json:"russian_name" binding:"required"`
+	OriginalName *string   `json:"original_name,omitempty"`
+	Photo        string    `json:"photo" binding:"required"`
+	Role         string    `json:"role" binding:"required"`
+	Character    *string   `json:"character,omitempty"`
+	BillingOrder int       `json:"billing_order" binding:"required"`
+}
+
+func (cm *CrewMember) Sanitize() {
+	cm.RussianName = html.EscapeString(cm.RussianName)
+	if cm.OriginalName != nil {
+		sanitized := html.EscapeString(*cm.OriginalName)
+		cm.OriginalName = &sanitized
+	}
+	cm.Photo = html.EscapeString(cm.Photo)
+	if cm.Character != nil {
+		sanitized := html.EscapeString(*cm.Character)
+		cm.Character = &sanitized
+	}
+}
+
+type CrewGroup struct {
+	Role    string       `json:"role" binding:"required"`
+	Members []CrewMember `json:"members" binding:"required"`
+}
+
+func (cg *CrewGroup) Sanitize() {
+	for i := range cg.Members {
+		cg.Members[i].Sanitize()
+	}
+}
+
+// GroupCrew раскладывает участников по ролям в порядке CrewRoles,
+// сохраняя порядок участников внутри роли
+func GroupCrew(members []CrewMember) []CrewGroup {
+	byRole := make(map[string][]CrewMember)
+	for _, member := range members {
+		byRole[member.Role] = append(byRole[member.Role], member)
+	}
+
+	groups := make([]CrewGroup, 0, len(byRole))
+	for _, role := range CrewRoles {
+		if len(byRole[role]) == 0 {
+			continue
+		}
+		groups = append(groups, CrewGroup{Role: role, Members: byRole[role]})
+	}
+	return groups
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const AtomXmlns = "http://www.w3.org/2005/Atom"
+
+// AtomFeed и RSS сериализуются encoding/xml, который сам экранирует текст,
+// поэтому данные в них кладутся без Sanitize
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  *AtomPerson `xml:"author"`
+	Link    []AtomLink  `xml:"link"`
+	Entry   []AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type AtomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published,omitempty"`
+	Link      []AtomLink  `xml:"link"`
+	Author    *AtomPerson `xml:"author"`
+	Summary   *AtomText   `xml:"summary"`
+	Content   *AtomText   `xml:"content"`
+}
+
+type RSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Item          []RSSItem `xml:"item"`
+}
+
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type RSSItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        RSSGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type FeedFilm struct {
+	ID               uuid.UUID
+	Title            string
+	ShortDescription *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type FeedReview struct {
+	ID        uuid.UUID
+	FilmID    uuid.UUID
+	FilmTitle string
+	UserLogin string
+	Title     string
+	Text      *string
+	Rating    *int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// FeedUser автор ленты отзывов и его настройки приватности
+type FeedUser struct {
+	ID      uuid.UUID
+	Login   string
+	Privacy ProfilePrivacy
+}
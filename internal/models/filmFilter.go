@@ -0,0 +1,7 @@
+package models
+
+import uuid "github.com/satori/go.uuid"
+
+type FilmFilter struct {
+	CountryID uuid.NullUUID `json:"country_id"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type FilmInput struct {
+	Title            string     `json:"title" binding:"required"`
+	OriginalTitle    *string    `json:"original_title,omitempty"`
+	Cover            *string    `json:"cover,omitempty"`
+	Poster           *string    `json:"poster,omitempty"`
+	GenreID          uuid.UUID  `json:"genre_id" binding:"required"`
+	CountryID        uuid.UUID  `json:"country_id" binding:"required"`
+	ShortDescription *string    `json:"short_description,omitempty"`
+	Description      *string    `json:"description,omitempty"`
+	AgeCategory      *string    `json:"age_category,omitempty"`
+	Budget           int        `json:"budget"`
+	WorldwideFees    int        `json:"worldwide_fees"`
+	TrailerURL       *string    `json:"trailer_url,omitempty"`
+	Year             int        `json:"year" binding:"required"`
+	Slogan           *string    `json:"slogan,omitempty"`
+	Duration         int        `json:"duration" binding:"required"`
+	Image1           *string    `json:"image1,omitempty"`
+	Image2           *string    `json:"image2,omitempty"`
+	Image3           *string    `json:"image3,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"html"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	FilmMediaStill    = "still"
+	FilmMediaPoster   = "poster"
+	FilmMediaBackdrop = "backdrop"
+	FilmMediaTrailer  = "trailer"
+)
+
+// FilmMedia кадр, постер, фон или трейлер фильма. У картинок URL это путь в статике,
+// у трейлера внешняя ссылка; размеры известны только у загруженных картинок
+type FilmMedia struct {
+	ID       uuid.UUID `json:"id" binding:"required"`
+	Type     string    `json:"type" binding:"required"`
+	URL      string    `json:"url" binding:"required"`
+	Position int       `json:"position" binding:"required"`
+	Width    *int      `json:"width,omitempty"`
+	Height   *int      `json:"height,omitempty"`
+	Caption  *string   `json:"caption,omitempty"`
+}
+
+func (fm *FilmMedia) Sanitize() {
+	fm.Type = html.EscapeString(fm.Type)
+	fm.URL = html.EscapeString(fm.URL)
+	if fm.Caption != nil {
+		sanitized := html.EscapeString(*fm.Caption)
+		fm.Caption = &sanitized
+	}
+}
+
+func IsFilmImageType(mediaType string) bool {
+	return mediaType == FilmMediaStill || mediaType == FilmMediaPoster || mediaType == FilmMediaBackdrop
+}
+
+// FilmMediaInput поля формы при загрузке картинки, сам файл идёт отдельной частью
+type FilmMediaInput struct {
+	Type    string  `json:"type" binding:"required"`
+	Caption *string `json:"caption,omitempty"`
+}
+
+type FilmTrailerInput struct {
+	URL     string  `json:"url" binding:"required"`
+	Caption *string `json:"caption,omitempty"`
+}
+
+// FilmMediaOrderInput все медиа фильма в новом порядке, каждое ровно один раз
+type FilmMediaOrderInput struct {
+	IDs []uuid.UUID `json:"ids" binding:"required"`
+}
+
+// SetMedia кладёт галерею в страницу фильма. Пока клиенты переходят на media, старые поля
+// заполняются из неё, а у фильмов без записей в film_media галерея собирается из старых полей
+func (fp *FilmPage) SetMedia(media []FilmMedia) {
+	if len(media) == 0 {
+		fp.Media = fp.legacyMedia()
+		return
+	}
+	fp.Media = media
+
+	var poster, backdrop, trailer bool
+	stills := make([]*string, 0, 3)
+	for i := range media {
+		url := media[i].URL
+		switch media[i].Type {
+		case FilmMediaPoster:
+			if !poster {
+				fp.Poster, poster = url, true
+			}
+		case FilmMediaBackdrop:
+			if !backdrop {
+				fp.Cover, backdrop = url, true
+			}
+		case FilmMediaTrailer:
+			if !trailer {
+				fp.TrailerURL, trailer = &url, true
+			}
+		case FilmMediaStill:
+			if len(stills) < cap(stills) {
+				stills = append(stills, &url)
+			}
+		}
+	}
+	if len(stills) > 0 {
+		stills = append(stills, nil, nil)
+		fp.Image1, fp.Image2, fp.Image3 = stills[0], stills[1], stills[2]
+	}
+}
+
+func (fp *FilmPage) legacyMedia() []FilmMedia {
+	media := []FilmMedia{}
+	add := func(mediaType string, url *string) {
+		if url == nil || *url == "" {
+			return
+		}
+		media = append(media, FilmMedia{Type: mediaType, URL: *url, Position: len(media)})
+	}
+	add(FilmMediaPoster, &fp.Poster)
+	add(FilmMediaBackdrop, &fp.Cover)
+	add(FilmMediaStill, fp.Image1)
+	add(FilmMediaStill, fp.Image2)
+	add(FilmMediaStill, fp.Image3)
+	add(FilmMediaTrailer, fp.TrailerURL)
+	return media
+}
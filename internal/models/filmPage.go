@@ -7,30 +7,31 @@ import (
 )
 
 type FilmPage struct {
-	ID               uuid.UUID `json:"id" binding:"required"`
-	Title            string    `json:"title" binding:"required"`
-	OriginalTitle    *string   `json:"original_title,omitempty"`
-	Cover            string    `json:"cover" binding:"required"`
-	Poster           string    `json:"poster" binding:"required"`
-	Genre            string    `json:"genre" binding:"required"`
-	ShortDescription string    `json:"short_description" binding:"required"`
-	Description      string    `json:"description" binding:"required"`
-	AgeCategory      string    `json:"age_category" binding:"required"`
-	Budget           int       `json:"budget" binding:"required"`
-	WorldwideFees    int       `json:"worldwide_fees" binding:"required"`
-	TrailerURL       *string   `json:"trailer_url" binding:"required"`
-	NumberOfRatings  int       `json:"number_of_ratings" binding:"required"`
-	Year             int       `json:"year" binding:"required"`
-	Rating           float64   `json:"rating" binding:"required"`
-	Country          string    `json:"country" binding:"required"`
-	Slogan           *string   `json:"slogan,omitempty"`
-	Duration         int       `json:"duration" binding:"required"`
-	Image1           *string   `json:"image1,omitempty"`
-	Image2           *string   `json:"image2,omitempty"`
-	Image3           *string   `json:"image3,omitempty"`
-	Actors           []Actor   `json:"actors" binding:"required"`
-	IsReviewed       bool      `json:"is_reviewed" binding:"required"`
-	UserRating       *int      `json:"user_rating,omitempty"`
+	ID               uuid.UUID   `json:"id" binding:"required"`
+	Title            string      `json:"title" binding:"required"`
+	OriginalTitle    *string     `json:"original_title,omitempty"`
+	Cover            string      `json:"cover" binding:"required"`
+	Poster           string      `json:"poster" binding:"required"`
+	Genre            string      `json:"genre" binding:"required"`
+	ShortDescription string      `json:"short_description" binding:"required"`
+	Description      string      `json:"description" binding:"required"`
+	AgeCategory      string      `json:"age_category" binding:"required"`
+	Budget           int         `json:"budget" binding:"required"`
+	WorldwideFees    int         `json:"worldwide_fees" binding:"required"`
+	TrailerURL       *string     `json:"trailer_url" binding:"required"`
+	NumberOfRatings  int         `json:"number_of_ratings" binding:"required"`
+	Year             int         `json:"year" binding:"required"`
+	Rating           float64     `json:"rating" binding:"required"`
+	Country          string      `json:"country" binding:"required"`
+	Slogan           *string     `json:"slogan,omitempty"`
+	Duration         int         `json:"duration" binding:"required"`
+	Image1           *string     `json:"image1,omitempty"`
+	Image2           *string     `json:"image2,omitempty"`
+	Image3           *string     `json:"image3,omitempty"`
+	Media            []FilmMedia `json:"media" binding:"required"`
+	CastAndCrew      []CrewGroup `json:"cast_and_crew" binding:"required"`
+	IsReviewed       bool        `json:"is_reviewed" binding:"required"`
+	UserRating       *int        `json:"user_rating,omitempty"`
 }
 
 func (fp *FilmPage) Sanitize() {
@@ -68,4 +69,10 @@ func (fp *FilmPage) Sanitize() {
 		sanitized := html.EscapeString(*fp.Image3)
 		fp.Image3 = &sanitized
 	}
+	for i := range fp.Media {
+		fp.Media[i].Sanitize()
+	}
+	for i := range fp.CastAndCrew {
+		fp.CastAndCrew[i].Sanitize()
+	}
 }
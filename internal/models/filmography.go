@@ -0,0 +1,88 @@
+package models
+
+import (
+	"html"
+	"sort"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type FilmographyFilm struct {
+	ID           uuid.UUID `json:"id" binding:"required"`
+	Cover        string    `json:"cover" binding:"required"`
+	Title        string    `json:"title" binding:"required"`
+	Year         int       `json:"year" binding:"required"`
+	Rating       float64   `json:"rating" binding:"required"`
+	RatingsCount int       `json:"ratings_count" binding:"required"`
+	Role         string    `json:"role" binding:"required"`
+	Character    *string   `json:"character,omitempty"`
+}
+
+func (ff *FilmographyFilm) Sanitize() {
+	ff.Cover = html.EscapeString(ff.Cover)
+	ff.Title = html.EscapeString(ff.Title)
+	if ff.Character != nil {
+		sanitized := html.EscapeString(*ff.Character)
+		ff.Character = &sanitized
+	}
+}
+
+type FilmographyGroup struct {
+	Role       string            `json:"role" binding:"required"`
+	FilmsCount int               `json:"films_count" binding:"required"`
+	Films      []FilmographyFilm `json:"films" binding:"required"`
+}
+
+func (fg *FilmographyGroup) Sanitize() {
+	for i := range fg.Films {
+		fg.Films[i].Sanitize()
+	}
+}
+
+// GroupFilmography раскладывает фильмы персоны по ролям в порядке CrewRoles
+func GroupFilmography(films []FilmographyFilm) []FilmographyGroup {
+	byRole := make(map[string][]FilmographyFilm)
+	for _, film := range films {
+		byRole[film.Role] = append(byRole[film.Role], film)
+	}
+
+	groups := make([]FilmographyGroup, 0, len(byRole))
+	for _, role := range CrewRoles {
+		if len(byRole[role]) == 0 {
+			continue
+		}
+		groups = append(groups, FilmographyGroup{
+			Role:       role,
+			FilmsCount: len(byRole[role]),
+			Films:      byRole[role],
+		})
+	}
+	return groups
+}
+
+// BestKnownFor выбирает до limit самых оцениваемых фильмов персоны без повторов
+func BestKnownFor(films []FilmographyFilm, limit int) []FilmographyFilm {
+	seen := make(map[uuid.UUID]struct{}, len(films))
+	best := make([]FilmographyFilm, 0, len(films))
+	for _, film := range films {
+		if film.RatingsCount == 0 {
+			continue
+		}
+		if _, ok := seen[film.ID]; ok {
+			continue
+		}
+		seen[film.ID] = struct{}{}
+		best = append(best, film)
+	}
+
+	sort.SliceStable(best, func(i, j int) bool {
+		if best[i].RatingsCount != best[j].RatingsCount {
+			return best[i].RatingsCount > best[j].RatingsCount
+		}
+		return best[i].Rating > best[j].Rating
+	})
+	if len(best) > limit {
+		best = best[:limit]
+	}
+	return best
+}
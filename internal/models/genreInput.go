@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type GenreInput struct {
+	Title       string     `json:"title" binding:"required"`
+	Description *string    `json:"description,omitempty"`
+	Icon        *string    `json:"icon,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
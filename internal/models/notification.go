@@ -0,0 +1,102 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	NotificationFilmReview  = "film_review"
+	NotificationRatingShift = "rating_shift"
+	NotificationSecurity    = "security"
+)
+
+const (
+	SecurityPasswordChanged = "password_changed"
+	SecurityPasswordReset   = "password_reset"
+	SecurityLoginChanged    = "login_changed"
+	SecurityEmailChanged    = "email_changed"
+	// второй фактор включают и выключают только редакторы и администраторы
+	SecurityTwoFactorEnabled  = "two_factor_enabled"
+	SecurityTwoFactorDisabled = "two_factor_disabled"
+)
+
+// NotificationEvent событие, из которого получаются уведомления
+type NotificationEvent interface {
+	NotificationType() string
+}
+
+// FilmReviewEvent новый отзыв на фильм, который оценивали другие пользователи
+type FilmReviewEvent struct {
+	FilmID      uuid.UUID
+	FilmTitle   string
+	ReviewID    uuid.UUID
+	AuthorID    uuid.UUID
+	AuthorLogin string
+}
+
+func (FilmReviewEvent) NotificationType() string { return NotificationFilmReview }
+
+// FilmRatingShiftEvent заметное изменение средней оценки фильма
+type FilmRatingShiftEvent struct {
+	FilmID    uuid.UUID
+	FilmTitle string
+	OldRating float64
+	NewRating float64
+	// AuthorID поставил оценку, сдвинувшую среднюю, ему уведомление не нужно
+	AuthorID uuid.UUID
+}
+
+func (FilmRatingShiftEvent) NotificationType() string { return NotificationRatingShift }
+
+// SecurityEvent изменение настроек безопасности аккаунта
+type SecurityEvent struct {
+	UserID uuid.UUID
+	Action string
+}
+
+func (SecurityEvent) NotificationType() string { return NotificationSecurity }
+
+// NotificationPayload данные уведомления, заполняются только нужные для его типа поля
+type NotificationPayload struct {
+	FilmID      *uuid.UUID `json:"film_id,omitempty"`
+	FilmTitle   string     `json:"film_title,omitempty"`
+	ReviewID    *uuid.UUID `json:"review_id,omitempty"`
+	AuthorID    *uuid.UUID `json:"author_id,omitempty"`
+	AuthorLogin string     `json:"author_login,omitempty"`
+	OldRating   *float64   `json:"old_rating,omitempty"`
+	NewRating   *float64   `json:"new_rating,omitempty"`
+	Action      string     `json:"action,omitempty"`
+}
+
+type Notification struct {
+	ID        uuid.UUID           `json:"id" binding:"required"`
+	UserID    uuid.UUID           `json:"-"`
+	Type      string              `json:"type" binding:"required"`
+	Payload   NotificationPayload `json:"payload" binding:"required"`
+	Read      bool                `json:"read" binding:"required"`
+	CreatedAt time.Time           `json:"created_at" binding:"required"`
+}
+
+func (n *Notification) Sanitize() {
+	n.Payload.FilmTitle = html.EscapeString(n.Payload.FilmTitle)
+	n.Payload.AuthorLogin = html.EscapeString(n.Payload.AuthorLogin)
+}
+
+type NotificationPage struct {
+	Items       []Notification `json:"items" binding:"required"`
+	NextCursor  string         `json:"next_cursor,omitempty"`
+	UnreadCount int            `json:"unread_count" binding:"required"`
+}
+
+func (np *NotificationPage) Sanitize() {
+	for i := range np.Items {
+		np.Items[i].Sanitize()
+	}
+}
+
+type UnreadCount struct {
+	UnreadCount int `json:"unread_count" binding:"required"`
+}
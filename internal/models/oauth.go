@@ -0,0 +1,47 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ExternalIdentity пользователь внешнего провайдера; Subject неизменный идентификатор у провайдера
+type ExternalIdentity struct {
+	Provider string
+	Subject  string
+	Email    string
+	Username string
+	// EmailVerified провайдер подтвердил, что почта принадлежит пользователю
+	EmailVerified bool
+}
+
+// OAuthState начатый вход через провайдера. Если LinkUserID задан, найденная учётка
+// привязывается к этому пользователю вместо входа
+type OAuthState struct {
+	StateHash    []byte
+	Provider     string
+	CodeVerifier string
+	LinkUserID   uuid.NullUUID
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+type LinkedIdentity struct {
+	Provider  string    `json:"provider" binding:"required"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at" binding:"required"`
+}
+
+func (li *LinkedIdentity) Sanitize() {
+	li.Email = html.EscapeString(li.Email)
+}
+
+type OAuthProviders struct {
+	Providers []string `json:"providers" binding:"required"`
+}
+
+type OAuthRedirect struct {
+	URL string `json:"url" binding:"required"`
+}
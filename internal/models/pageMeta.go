@@ -0,0 +1,59 @@
+package models
+
+// MetaTag у OpenGraph ключ в property, у Twitter в name
+type MetaTag struct {
+	Property string `json:"property,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Content  string `json:"content" binding:"required"`
+}
+
+// PageMeta метаданные страницы SPA для превью в соцсетях и поисковиков.
+// Значения не экранируются: клиент выставляет их через атрибуты и textContent,
+// а пререндер экранирует их шаблоном
+type PageMeta struct {
+	Title       string      `json:"title" binding:"required"`
+	Description string      `json:"description" binding:"required"`
+	Canonical   string      `json:"canonical" binding:"required"`
+	Image       string      `json:"image,omitempty"`
+	Tags        []MetaTag   `json:"tags" binding:"required"`
+	JSONLD      interface{} `json:"json_ld,omitempty" swaggertype:"object"`
+}
+
+type SchemaAggregateRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float64 `json:"ratingValue"`
+	RatingCount int     `json:"ratingCount"`
+	BestRating  int     `json:"bestRating"`
+	WorstRating int     `json:"worstRating"`
+}
+
+type SchemaPerson struct {
+	Context       string `json:"@context,omitempty"`
+	Type          string `json:"@type"`
+	Name          string `json:"name"`
+	AlternateName string `json:"alternateName,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Image         string `json:"image,omitempty"`
+	BirthDate     string `json:"birthDate,omitempty"`
+	DeathDate     string `json:"deathDate,omitempty"`
+	BirthPlace    string `json:"birthPlace,omitempty"`
+	Height        string `json:"height,omitempty"`
+}
+
+type SchemaMovie struct {
+	Context         string                 `json:"@context"`
+	Type            string                 `json:"@type"`
+	Name            string                 `json:"name"`
+	AlternateName   string                 `json:"alternateName,omitempty"`
+	URL             string                 `json:"url"`
+	Image           string                 `json:"image,omitempty"`
+	Description     string                 `json:"description,omitempty"`
+	DateCreated     string                 `json:"dateCreated,omitempty"`
+	Genre           string                 `json:"genre,omitempty"`
+	Duration        string                 `json:"duration,omitempty"`
+	ContentRating   string                 `json:"contentRating,omitempty"`
+	CountryOfOrigin string                 `json:"countryOfOrigin,omitempty"`
+	Director        []SchemaPerson         `json:"director,omitempty"`
+	Actor           []SchemaPerson         `json:"actor,omitempty"`
+	AggregateRating *SchemaAggregateRating `json:"aggregateRating,omitempty"`
+}
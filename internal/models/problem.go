@@ -0,0 +1,25 @@
+package models
+
+import "html"
+
+// Problem тело ошибки в формате RFC 7807 (application/problem+json).
+// Code стабилен и предназначен для клиентов, Title переводится на язык запроса
+type Problem struct {
+	Type      string       `json:"type" binding:"required"`
+	Title     string       `json:"title" binding:"required"`
+	Status    int          `json:"status" binding:"required"`
+	Code      string       `json:"code" binding:"required"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+func (p *Problem) Sanitize() {
+	p.Title = html.EscapeString(p.Title)
+	p.Instance = html.EscapeString(p.Instance)
+	p.RequestID = html.EscapeString(p.RequestID)
+	for i := range p.Errors {
+		p.Errors[i].Field = html.EscapeString(p.Errors[i].Field)
+		p.Errors[i].Message = html.EscapeString(p.Errors[i].Message)
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+import uuid "github.com/satori/go.uuid"
+
+type FilmRatingUpdate struct {
+	FilmID uuid.UUID `json:"film_id" binding:"required"`
+	Rating float64   `json:"rating" binding:"required"`
+}
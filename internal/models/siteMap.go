@@ -1,18 +1,48 @@
 package models
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const SitemapXmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapIndex корневой файл, который ссылается на дочерние файлы с адресами
+type SitemapIndex struct {
+	XMLName xml.Name      `xml:"sitemapindex"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	Sitemap []SitemapItem `xml:"sitemap"`
+}
+
+type SitemapItem struct {
+	Loc     string `xml:"loc"`
+	Lastmod string `xml:"lastmod,omitempty"`
+}
 
 type Urlset struct {
 	XMLName xml.Name  `xml:"urlset"`
-	Text    string    `xml:",chardata"`
 	Xmlns   string    `xml:"xmlns,attr"`
 	URL     []URLItem `xml:"url"`
 }
 
 type URLItem struct {
-	Text       string  `xml:",chardata"`
 	Loc        string  `xml:"loc"`
-	Lastmod    string  `xml:"lastmod"`
-	Changefreq string  `xml:"changefreq"`
-	Priority   float64 `xml:"priority"`
+	Lastmod    string  `xml:"lastmod,omitempty"`
+	Changefreq string  `xml:"changefreq,omitempty"`
+	Priority   float64 `xml:"priority,omitempty"`
+}
+
+// SitemapEntry страница каталога, попадающая в sitemap. Kind совпадает с разделом сайта: films, actors, genres
+type SitemapEntry struct {
+	Kind      string
+	ID        uuid.UUID
+	UpdatedAt *time.Time
+}
+
+// SitemapFile готовый к отдаче XML и время, когда он был собран
+type SitemapFile struct {
+	Content     []byte
+	GeneratedAt time.Time
 }
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	FeedItemRating = "rating"
+	FeedItemReview = "review"
+)
+
+type FollowUser struct {
+	ID         uuid.UUID `json:"id" binding:"required"`
+	Login      string    `json:"login" binding:"required"`
+	Avatar     string    `json:"avatar" binding:"required"`
+	FollowedAt time.Time `json:"followed_at" binding:"required"`
+}
+
+func (fu *FollowUser) Sanitize() {
+	fu.Login = html.EscapeString(fu.Login)
+	fu.Avatar = html.EscapeString(fu.Avatar)
+}
+
+type FeedItem struct {
+	ID         uuid.UUID `json:"id" binding:"required"`
+	Type       string    `json:"type" binding:"required"`
+	UserID     uuid.UUID `json:"user_id" binding:"required"`
+	UserLogin  string    `json:"user_login" binding:"required"`
+	UserAvatar string    `json:"user_avatar" binding:"required"`
+	FilmID     uuid.UUID `json:"film_id" binding:"required"`
+	FilmTitle  string    `json:"film_title" binding:"required"`
+	FilmCover  string    `json:"film_cover" binding:"required"`
+	Title      *string   `json:"title,omitempty"`
+	Text       *string   `json:"text,omitempty"`
+	Rating     *int      `json:"rating,omitempty"`
+	CreatedAt  time.Time `json:"created_at" binding:"required"`
+}
+
+func (fi *FeedItem) Sanitize() {
+	fi.UserLogin = html.EscapeString(fi.UserLogin)
+	fi.UserAvatar = html.EscapeString(fi.UserAvatar)
+	fi.FilmTitle = html.EscapeString(fi.FilmTitle)
+	fi.FilmCover = html.EscapeString(fi.FilmCover)
+	if fi.Title != nil {
+		sanitized := html.EscapeString(*fi.Title)
+		fi.Title = &sanitized
+	}
+	if fi.Text != nil {
+		sanitized := html.EscapeString(*fi.Text)
+		fi.Text = &sanitized
+	}
+}
+
+type FeedPage struct {
+	Items      []FeedItem `json:"items" binding:"required"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+func (fp *FeedPage) Sanitize() {
+	for i := range fp.Items {
+		fp.Items[i].Sanitize()
+	}
+}
+
+// CompatibilityFilm фильм, который оценили оба пользователя
+type CompatibilityFilm struct {
+	FilmID      uuid.UUID `json:"film_id" binding:"required"`
+	Title       string    `json:"title" binding:"required"`
+	Cover       string    `json:"cover" binding:"required"`
+	UserRating  int       `json:"user_rating" binding:"required"`
+	OtherRating int       `json:"other_rating" binding:"required"`
+}
+
+// Compatibility совпадение вкусов пользователя UserID с пользователем OtherUserID.
+// Score равен nil, если общих оценок слишком мало для корреляции
+type Compatibility struct {
+	UserID      uuid.UUID           `json:"user_id" binding:"required"`
+	OtherUserID uuid.UUID           `json:"other_user_id" binding:"required"`
+	Score       *float64            `json:"score"`
+	CommonFilms int                 `json:"common_films" binding:"required"`
+	Agree       []CompatibilityFilm `json:"agree" binding:"required"`
+	Disagree    []CompatibilityFilm `json:"disagree" binding:"required"`
+}
+
+func (c *Compatibility) Sanitize() {
+	for i := range c.Agree {
+		c.Agree[i].Title = html.EscapeString(c.Agree[i].Title)
+		c.Agree[i].Cover = html.EscapeString(c.Agree[i].Cover)
+	}
+	for i := range c.Disagree {
+		c.Disagree[i].Title = html.EscapeString(c.Disagree[i].Title)
+		c.Disagree[i].Cover = html.EscapeString(c.Disagree[i].Cover)
+	}
+}
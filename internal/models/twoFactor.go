@@ -0,0 +1,49 @@
+package models
+
+import (
+	"html"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// TOTP секрет второго фактора; пока Enabled ложно, он только выдан и ждёт подтверждения кодом
+type TOTP struct {
+	UserID       uuid.UUID
+	Secret       string
+	LastUsedStep int64
+	Enabled      bool
+}
+
+type TwoFactorSetup struct {
+	Secret          string `json:"secret" binding:"required"`
+	ProvisioningURI string `json:"provisioning_uri" binding:"required"`
+}
+
+// RecoveryCodes показываются один раз при включении, в базе хранятся только хэши
+type RecoveryCodes struct {
+	Codes []string `json:"codes" binding:"required"`
+}
+
+// TwoFactorChallenge ответ на вход по паролю, если включена двухфакторная аутентификация
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required" binding:"required"`
+	PendingToken      string `json:"pending_token" binding:"required"`
+}
+
+type TwoFactorSignInInput struct {
+	PendingToken string `json:"pending_token" binding:"required"`
+	// Code шестизначный код из приложения или один из кодов восстановления
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorDisableInput struct {
+	Password string `json:"password" binding:"required"`
+}
+
+func (tdi *TwoFactorDisableInput) Sanitize() {
+	tdi.Password = html.EscapeString(tdi.Password)
+}
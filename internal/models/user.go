@@ -12,12 +12,17 @@ type User struct {
 	Version      int       `json:"version" binding:"required"`
 	Login        string    `json:"login" binding:"required"`
 	PasswordHash []byte    `json:"-"`
-	Avatar       string    `json:"avatar" binding:"required"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	// PasswordSet false у тех, кто зарегистрировался через провайдера и ещё не задал пароль
+	PasswordSet bool       `json:"-"`
+	Avatar      string     `json:"avatar" binding:"required"`
+	Avatars     AvatarURLs `json:"avatars" binding:"required"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
+// Sanitize вызывается перед каждой отдачей пользователя, поэтому здесь же заполняются размеры аватара
 func (u *User) Sanitize() {
 	u.Login = html.EscapeString(u.Login)
 	u.Avatar = html.EscapeString(u.Avatar)
+	u.Avatars = NewAvatarURLs(u.Avatar)
 }
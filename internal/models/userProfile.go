@@ -0,0 +1,86 @@
+package models
+
+import (
+	"html"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// ProfilePrivacy какие разделы публичного профиля видны другим пользователям,
+// при FollowersOnly разделы видны только подписчикам
+type ProfilePrivacy struct {
+	ShowStats     bool `json:"show_stats"`
+	ShowTaste     bool `json:"show_taste"`
+	ShowReviews   bool `json:"show_reviews"`
+	FollowersOnly bool `json:"followers_only"`
+}
+
+type ProfileStats struct {
+	RatingsCount  int     `json:"ratings_count" binding:"required"`
+	ReviewsCount  int     `json:"reviews_count" binding:"required"`
+	AverageRating float64 `json:"average_rating" binding:"required"`
+}
+
+type ProfileGenre struct {
+	ID            uuid.UUID `json:"id" binding:"required"`
+	Title         string    `json:"title" binding:"required"`
+	RatingsCount  int       `json:"ratings_count" binding:"required"`
+	AverageRating float64   `json:"average_rating" binding:"required"`
+}
+
+type ProfileActor struct {
+	ID            uuid.UUID `json:"id" binding:"required"`
+	RussianName   string    `json:"russian_name" binding:"required"`
+	Photo         string    `json:"photo" binding:"required"`
+	RatingsCount  int       `json:"ratings_count" binding:"required"`
+	AverageRating float64   `json:"average_rating" binding:"required"`
+}
+
+type ProfileReview struct {
+	ID        uuid.UUID `json:"id" binding:"required"`
+	FilmID    uuid.UUID `json:"film_id" binding:"required"`
+	FilmTitle string    `json:"film_title" binding:"required"`
+	Title     string    `json:"title" binding:"required"`
+	Text      *string   `json:"text"`
+	Rating    *int      `json:"rating"`
+	CreatedAt time.Time `json:"created_at" binding:"required"`
+}
+
+// UserProfile скрытые настройками приватности разделы приходят как null
+type UserProfile struct {
+	ID              uuid.UUID       `json:"id" binding:"required"`
+	Login           string          `json:"login" binding:"required"`
+	Avatar          string          `json:"avatar" binding:"required"`
+	Avatars         AvatarURLs      `json:"avatars" binding:"required"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Privacy         ProfilePrivacy  `json:"privacy" binding:"required"`
+	FollowersCount  int             `json:"followers_count" binding:"required"`
+	FollowingCount  int             `json:"following_count" binding:"required"`
+	Stats           *ProfileStats   `json:"stats"`
+	FavouriteGenres []ProfileGenre  `json:"favourite_genres"`
+	FavouriteActors []ProfileActor  `json:"favourite_actors"`
+	LatestReviews   []ProfileReview `json:"latest_reviews"`
+}
+
+func (up *UserProfile) Sanitize() {
+	up.Login = html.EscapeString(up.Login)
+	up.Avatar = html.EscapeString(up.Avatar)
+	up.Avatars = NewAvatarURLs(up.Avatar)
+	for i := range up.FavouriteGenres {
+		up.FavouriteGenres[i].Title = html.EscapeString(up.FavouriteGenres[i].Title)
+	}
+	for i := range up.FavouriteActors {
+		up.FavouriteActors[i].RussianName = html.EscapeString(up.FavouriteActors[i].RussianName)
+		up.FavouriteActors[i].Photo = html.EscapeString(up.FavouriteActors[i].Photo)
+	}
+	for i := range up.LatestReviews {
+		review := &up.LatestReviews[i]
+		review.FilmTitle = html.EscapeString(review.FilmTitle)
+		review.Title = html.EscapeString(review.Title)
+		if review.Text != nil {
+			sanitized := html.EscapeString(*review.Text)
+			review.Text = &sanitized
+		}
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"kinopoisk/internal/pkg/actors"
 	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	uuid "github.com/satori/go.uuid"
@@ -26,9 +28,9 @@ func NewActorHandler(uc actors.ActorUsecase) *ActorHandler {
 // @Produce      json
 // @Param        id   path      string  true  "Actor ID"
 // @Success      200  {object}  models.ActorPage
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Failure      400 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
 // @Router       /actors/{id} [get]
 func (a *ActorHandler) GetActor(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -37,18 +39,13 @@ func (a *ActorHandler) GetActor(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
 	actor, err := a.uc.GetActor(r.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, actors.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	actor.Sanitize()
@@ -61,10 +58,12 @@ func (a *ActorHandler) GetActor(w http.ResponseWriter, r *http.Request) {
 // @Tags         actors
 // @Produce      json
 // @Param        id   path      string  true  "Actor ID"
-// @Success      200  {array}   models.MainPageFilm
-// @Failure      400
-// @Failure      404
-// @Failure      500
+// @Param        country_id  query  string  false  "Country ID"
+// @Param        sort  query  string  false  "Sort order"  Enums(year, rating, popularity)
+// @Success      200  {array}   models.ActorFilm
+// @Failure      400 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
 // @Router       /actors/{id}/films [get]
 func (a *ActorHandler) GetFilmsByActor(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -74,20 +73,23 @@ func (a *ActorHandler) GetFilmsByActor(w http.ResponseWriter, r *http.Request) {
 	neededActor, err := uuid.FromString(idStr)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
 	pager := helpers.GetPagerFromRequest(r)
+	filter, err := helpers.GetFilmFilterFromRequest(r)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	sort := r.URL.Query().Get("sort")
 
-	films, err := a.uc.GetFilmsByActor(r.Context(), neededActor, pager)
+	films, err := a.uc.GetFilmsByActor(r.Context(), neededActor, pager, filter, sort)
 	if err != nil {
-		switch {
-		case errors.Is(err, actors.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	for i := range films {
@@ -97,3 +99,88 @@ func (a *ActorHandler) GetFilmsByActor(w http.ResponseWriter, r *http.Request) {
 	helpers.WriteJSON(w, films)
 	log.LogHandlerInfo(logger, "success", http.StatusOK)
 }
+
+// GetCostars godoc
+// @Summary      Get frequent collaborators of actor
+// @Tags         actors
+// @Produce      json
+// @Param        id   path      string  true  "Actor ID"
+// @Param        count   query  int  false  "Number of costars"
+// @Param        offset  query  int  false  "Offset"
+// @Success      200  {array}   models.Costar
+// @Failure      400 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /actors/{id}/costars [get]
+func (a *ActorHandler) GetCostars(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	costars, err := a.uc.GetCostars(r.Context(), id, helpers.GetPagerFromRequest(r))
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	for i := range costars {
+		costars[i].Sanitize()
+	}
+
+	helpers.WriteJSON(w, costars)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetCostarPath godoc
+// @Summary      Get shortest costar path between two actors
+// @Tags         actors
+// @Produce      json
+// @Param        from  query  string  true  "Actor ID to start from"
+// @Param        to    query  string  true  "Actor ID to reach"
+// @Param        max_depth  query  int  false  "Maximum number of films in path (1-6)"
+// @Success      200  {object}  models.CostarPath
+// @Failure      400 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /actors/path [get]
+func (a *ActorHandler) GetCostarPath(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	query := r.URL.Query()
+
+	from, err := uuid.FromString(query.Get("from"))
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of first actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	to, err := uuid.FromString(query.Get("to"))
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of second actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	maxDepth := 0
+	if depthStr := query.Get("max_depth"); depthStr != "" {
+		maxDepth, err = strconv.Atoi(depthStr)
+		if err != nil {
+			log.LogHandlerError(logger, errors.New("invalid max depth"), http.StatusBadRequest)
+			problem.WriteStatus(w, r, http.StatusBadRequest)
+			return
+		}
+	}
+
+	path, err := a.uc.GetCostarPath(r.Context(), from, to, maxDepth)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	path.Sanitize()
+
+	helpers.WriteJSON(w, path)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
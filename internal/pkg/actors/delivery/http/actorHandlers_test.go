@@ -35,6 +35,7 @@ func TestGetActor(t *testing.T) {
 	actorID := uuid.NewV4()
 	actorIDStr := actorID.String()
 	originalName := "Leonardo DiCaprio"
+	character := "Джек Доусон"
 	birthDate := time.Date(1974, 11, 11, 0, 0, 0, 0, time.UTC)
 
 	expectedActor := models.ActorPage{
@@ -48,7 +49,15 @@ func TestGetActor(t *testing.T) {
 		ZodiacSign:    "Скорпион",
 		BirthPlace:    "Лос-Анджелес, США",
 		MaritalStatus: "Не женат",
-		FilmsNumber:   45,
+		Filmography: []models.FilmographyGroup{
+			{
+				Role:       models.CrewRoleActor,
+				FilmsCount: 1,
+				Films: []models.FilmographyFilm{
+					{ID: uuid.NewV4(), Cover: "/covers/titanic.jpg", Title: "Титаник", Year: 1997, Rating: 8.4, Role: models.CrewRoleActor, Character: &character},
+				},
+			},
+		},
 	}
 
 	tests := []struct {
@@ -188,7 +197,7 @@ func TestGetFilmsByActor(t *testing.T) {
 	actorID := uuid.NewV4()
 	actorIDStr := actorID.String()
 
-	expectedFilms := []models.MainPageFilm{
+	expectedFilms := []models.ActorFilm{
 		{
 			ID:     uuid.NewV4(),
 			Cover:  "/covers/titanic.jpg",
@@ -207,7 +216,7 @@ func TestGetFilmsByActor(t *testing.T) {
 		},
 	}
 
-	emptyFilms := []models.MainPageFilm{}
+	emptyFilms := []models.ActorFilm{}
 
 	tests := []struct {
 		name           string
@@ -216,7 +225,7 @@ func TestGetFilmsByActor(t *testing.T) {
 		mockSetup      func(mockUsecase *mocks.MockActorUsecase)
 		expectedStatus int
 		expectBody     bool
-		expectedFilms  []models.MainPageFilm
+		expectedFilms  []models.ActorFilm
 	}{
 		{
 			name:   "Success with films",
@@ -224,7 +233,7 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}).
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -237,7 +246,7 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}).
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, "").
 					Return(emptyFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -258,8 +267,8 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}).
-					Return([]models.MainPageFilm{}, actors.ErrorNotFound)
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, "").
+					Return([]models.ActorFilm{}, actors.ErrorNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
 			expectBody:     false,
@@ -270,8 +279,8 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}).
-					Return([]models.MainPageFilm{}, actors.ErrorInternalServerError)
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, "").
+					Return([]models.ActorFilm{}, actors.ErrorInternalServerError)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectBody:     false,
@@ -282,19 +291,44 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}).
-					Return([]models.MainPageFilm{}, errors.New("unknown error"))
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, "").
+					Return([]models.ActorFilm{}, errors.New("unknown error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectBody:     false,
 		},
+		{
+			name:   "Sort from query",
+			url:    "/actors/" + actorIDStr + "/films?count=10&offset=0&sort=popularity",
+			varsID: actorIDStr,
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, models.ActorFilmsSortPopularity).
+					Return(expectedFilms, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectBody:     true,
+			expectedFilms:  expectedFilms,
+		},
+		{
+			name:   "Unknown sort",
+			url:    "/actors/" + actorIDStr + "/films?count=10&offset=0&sort=created_at",
+			varsID: actorIDStr,
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}, "created_at").
+					Return([]models.ActorFilm{}, actors.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectBody:     false,
+		},
 		{
 			name:   "Success with default pager values",
 			url:    "/actors/" + actorIDStr + "/films",
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, gomock.Any()).
+					GetFilmsByActor(gomock.Any(), actorID, gomock.Any(), models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -307,7 +341,7 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 5, Offset: 10}).
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 5, Offset: 10}, models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -320,7 +354,7 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, gomock.Any()).
+					GetFilmsByActor(gomock.Any(), actorID, gomock.Any(), models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -333,7 +367,7 @@ func TestGetFilmsByActor(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 1000, Offset: 500}).
+					GetFilmsByActor(gomock.Any(), actorID, models.Pager{Count: 1000, Offset: 500}, models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -368,7 +402,7 @@ func TestGetFilmsByActor(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, rec.Code)
 
 			if tt.expectBody {
-				var decoded []models.MainPageFilm
+				var decoded []models.ActorFilm
 				err := json.Unmarshal(rec.Body.Bytes(), &decoded)
 				assert.NoError(t, err)
 				assert.Equal(t, len(tt.expectedFilms), len(decoded))
@@ -469,7 +503,7 @@ func TestGetFilmsByActor_EdgeCases(t *testing.T) {
 	actorID := uuid.NewV4()
 	actorIDStr := actorID.String()
 
-	expectedFilms := []models.MainPageFilm{
+	expectedFilms := []models.ActorFilm{
 		{
 			ID:     uuid.NewV4(),
 			Cover:  "/covers/test.jpg",
@@ -493,7 +527,7 @@ func TestGetFilmsByActor_EdgeCases(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, gomock.Any()).
+					GetFilmsByActor(gomock.Any(), actorID, gomock.Any(), models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -504,7 +538,7 @@ func TestGetFilmsByActor_EdgeCases(t *testing.T) {
 			varsID: actorIDStr,
 			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
 				mockUsecase.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, gomock.Any()).
+					GetFilmsByActor(gomock.Any(), actorID, gomock.Any(), models.FilmFilter{}, "").
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -543,3 +577,156 @@ func TestGetFilmsByActor_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCostars(t *testing.T) {
+	actorID := uuid.NewV4()
+	costars := []models.Costar{
+		{CostarActor: models.CostarActor{ID: uuid.NewV4(), RussianName: "Кейт Уинслет"}, SharedFilms: 2},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func(mockUsecase *mocks.MockActorUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  "/actors/" + actorID.String() + "/costars?count=5&offset=0",
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().
+					GetCostars(gomock.Any(), actorID, models.Pager{Count: 5, Offset: 0}).
+					Return(costars, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			url:            "/actors/not-a-uuid/costars",
+			mockSetup:      func(mockUsecase *mocks.MockActorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Actor not found",
+			url:  "/actors/" + actorID.String() + "/costars?count=5&offset=0",
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().
+					GetCostars(gomock.Any(), actorID, models.Pager{Count: 5, Offset: 0}).
+					Return([]models.Costar{}, actors.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockActorUsecase(ctrl)
+			handler := NewActorHandler(mockUsecase)
+			tt.mockSetup(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/actors/{id}/costars", handler.GetCostars)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var decoded []models.Costar
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+				assert.Equal(t, costars, decoded)
+			}
+		})
+	}
+}
+
+func TestGetCostarPath(t *testing.T) {
+	from := uuid.NewV4()
+	to := uuid.NewV4()
+	baseURL := "/actors/path?from=" + from.String() + "&to=" + to.String()
+	path := models.CostarPath{
+		Degrees: 1,
+		Actors:  []models.CostarActor{{ID: from}, {ID: to}},
+		Films:   []models.CostarFilm{{ID: uuid.NewV4(), Title: "Титаник"}},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func(mockUsecase *mocks.MockActorUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  baseURL,
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().GetCostarPath(gomock.Any(), from, to, 0).Return(path, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Max depth from query",
+			url:  baseURL + "&max_depth=2",
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().GetCostarPath(gomock.Any(), from, to, 2).Return(path, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing from",
+			url:            "/actors/path?to=" + to.String(),
+			mockSetup:      func(mockUsecase *mocks.MockActorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid to",
+			url:            "/actors/path?from=" + from.String() + "&to=abc",
+			mockSetup:      func(mockUsecase *mocks.MockActorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid max depth",
+			url:            baseURL + "&max_depth=deep",
+			mockSetup:      func(mockUsecase *mocks.MockActorUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Depth out of range",
+			url:  baseURL + "&max_depth=10",
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().GetCostarPath(gomock.Any(), from, to, 10).Return(models.CostarPath{}, actors.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "No path",
+			url:  baseURL,
+			mockSetup: func(mockUsecase *mocks.MockActorUsecase) {
+				mockUsecase.EXPECT().GetCostarPath(gomock.Any(), from, to, 0).Return(models.CostarPath{}, actors.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockActorUsecase(ctrl)
+			handler := NewActorHandler(mockUsecase)
+			tt.mockSetup(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			handler.GetCostarPath(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
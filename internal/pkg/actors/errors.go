@@ -4,5 +4,6 @@ import "errors"
 
 var (
 	ErrorNotFound            = errors.New("actor not found")
+	ErrorBadRequest          = errors.New("bad request")
 	ErrorInternalServerError = errors.New("internal server error")
 )
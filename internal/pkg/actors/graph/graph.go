@@ -0,0 +1,218 @@
+package graph
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"sort"
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type EdgeLoader interface {
+	GetCastEdges(ctx context.Context) ([]models.CastEdge, error)
+}
+
+// snapshot неизменяемый после построения, поэтому читатели работают с ним без блокировок
+type snapshot struct {
+	actors     map[uuid.UUID]models.CostarActor
+	films      map[uuid.UUID]models.CostarFilm
+	actorFilms map[uuid.UUID][]uuid.UUID
+	filmActors map[uuid.UUID][]uuid.UUID
+}
+
+// CostarGraph держит в памяти граф «актер — фильм — актер» и перестраивает его при изменениях каталога
+type CostarGraph struct {
+	loader  EdgeLoader
+	mu      sync.RWMutex
+	current *snapshot
+	refresh chan struct{}
+}
+
+func NewCostarGraph(loader EdgeLoader) *CostarGraph {
+	return &CostarGraph{
+		loader:  loader,
+		current: build(nil),
+		refresh: make(chan struct{}, 1),
+	}
+}
+
+func build(edges []models.CastEdge) *snapshot {
+	s := &snapshot{
+		actors:     make(map[uuid.UUID]models.CostarActor),
+		films:      make(map[uuid.UUID]models.CostarFilm),
+		actorFilms: make(map[uuid.UUID][]uuid.UUID),
+		filmActors: make(map[uuid.UUID][]uuid.UUID),
+	}
+	for _, edge := range edges {
+		s.actors[edge.ActorID] = models.CostarActor{ID: edge.ActorID, RussianName: edge.ActorName, Photo: edge.ActorPhoto}
+		s.films[edge.FilmID] = models.CostarFilm{ID: edge.FilmID, Title: edge.FilmTitle, Year: edge.FilmYear}
+		s.actorFilms[edge.ActorID] = append(s.actorFilms[edge.ActorID], edge.FilmID)
+		s.filmActors[edge.FilmID] = append(s.filmActors[edge.FilmID], edge.ActorID)
+	}
+
+	// фиксированный порядок соседей делает результат обхода воспроизводимым
+	for _, ids := range s.actorFilms {
+		sortIDs(ids)
+	}
+	for _, ids := range s.filmActors {
+		sortIDs(ids)
+	}
+	return s
+}
+
+func sortIDs(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+}
+
+func (g *CostarGraph) snapshot() *snapshot {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.current
+}
+
+// Refresh загружает связи из базы и атомарно подменяет граф
+func (g *CostarGraph) Refresh(ctx context.Context) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	edges, err := g.loader.GetCastEdges(ctx)
+	if err != nil {
+		logger.Error("failed to refresh costar graph: " + err.Error())
+		return err
+	}
+	next := build(edges)
+
+	g.mu.Lock()
+	g.current = next
+	g.mu.Unlock()
+
+	logger.Info("succesfully refreshed costar graph", slog.Int("actors", len(next.actors)), slog.Int("films", len(next.films)))
+	return nil
+}
+
+// CatalogueChanged не блокирует вызывающего: пачка изменений схлопывается в одно перестроение
+func (g *CostarGraph) CatalogueChanged() {
+	select {
+	case g.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// Run перестраивает граф по сигналам CatalogueChanged, пока не отменен ctx
+func (g *CostarGraph) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-g.refresh:
+			_ = g.Refresh(ctx)
+		}
+	}
+}
+
+// Costars возвращает коллег актера по убыванию числа общих фильмов; false, если актера нет в графе
+func (g *CostarGraph) Costars(actorID uuid.UUID) ([]models.Costar, bool) {
+	s := g.snapshot()
+	films, ok := s.actorFilms[actorID]
+	if !ok {
+		return nil, false
+	}
+
+	shared := make(map[uuid.UUID]int)
+	for _, filmID := range films {
+		for _, costarID := range s.filmActors[filmID] {
+			if costarID != actorID {
+				shared[costarID]++
+			}
+		}
+	}
+
+	costars := make([]models.Costar, 0, len(shared))
+	for costarID, count := range shared {
+		costars = append(costars, models.Costar{CostarActor: s.actors[costarID], SharedFilms: count})
+	}
+	sort.Slice(costars, func(i, j int) bool {
+		if costars[i].SharedFilms != costars[j].SharedFilms {
+			return costars[i].SharedFilms > costars[j].SharedFilms
+		}
+		if costars[i].RussianName != costars[j].RussianName {
+			return costars[i].RussianName < costars[j].RussianName
+		}
+		return costars[i].ID.String() < costars[j].ID.String()
+	})
+	return costars, true
+}
+
+type step struct {
+	prevActor uuid.UUID
+	film      uuid.UUID
+}
+
+// Path ищет кратчайшую цепочку через общие фильмы обходом в ширину не глубже maxDepth фильмов
+func (g *CostarGraph) Path(from, to uuid.UUID, maxDepth int) (models.CostarPath, bool) {
+	s := g.snapshot()
+	if _, ok := s.actors[from]; !ok {
+		return models.CostarPath{}, false
+	}
+	if _, ok := s.actors[to]; !ok {
+		return models.CostarPath{}, false
+	}
+
+	visitedFilms := make(map[uuid.UUID]struct{})
+	parents := map[uuid.UUID]step{from: {}}
+	frontier := []uuid.UUID{from}
+
+	for depth := 0; depth < maxDepth && len(frontier) > 0; depth++ {
+		if _, found := parents[to]; found {
+			break
+		}
+		var next []uuid.UUID
+		for _, actorID := range frontier {
+			for _, filmID := range s.actorFilms[actorID] {
+				if _, seen := visitedFilms[filmID]; seen {
+					continue
+				}
+				visitedFilms[filmID] = struct{}{}
+				for _, costarID := range s.filmActors[filmID] {
+					if _, seen := parents[costarID]; seen {
+						continue
+					}
+					parents[costarID] = step{prevActor: actorID, film: filmID}
+					next = append(next, costarID)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	if _, found := parents[to]; !found {
+		return models.CostarPath{}, false
+	}
+	return s.unwind(from, to, parents), true
+}
+
+func (s *snapshot) unwind(from, to uuid.UUID, parents map[uuid.UUID]step) models.CostarPath {
+	path := models.CostarPath{
+		Actors: []models.CostarActor{s.actors[to]},
+		Films:  []models.CostarFilm{},
+	}
+	for current := to; current != from; {
+		st := parents[current]
+		path.Films = append(path.Films, s.films[st.film])
+		path.Actors = append(path.Actors, s.actors[st.prevActor])
+		current = st.prevActor
+	}
+
+	for i, j := 0, len(path.Actors)-1; i < j; i, j = i+1, j-1 {
+		path.Actors[i], path.Actors[j] = path.Actors[j], path.Actors[i]
+	}
+	for i, j := 0, len(path.Films)-1; i < j; i, j = i+1, j-1 {
+		path.Films[i], path.Films[j] = path.Films[j], path.Films[i]
+	}
+	path.Degrees = len(path.Films)
+	return path
+}
@@ -0,0 +1,266 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+type stubLoader struct {
+	edges []models.CastEdge
+	err   error
+}
+
+func (l *stubLoader) GetCastEdges(ctx context.Context) ([]models.CastEdge, error) {
+	return l.edges, l.err
+}
+
+// Фиксированные идентификаторы, чтобы порядок обхода не зависел от запуска
+var (
+	depp    = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000a")
+	bloom   = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000b")
+	knight  = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000c")
+	mortens = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000d")
+	loner   = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000e")
+
+	pirates1 = uuid.FromStringOrNil("00000000-0000-0000-0000-0000000000f1")
+	pirates2 = uuid.FromStringOrNil("00000000-0000-0000-0000-0000000000f2")
+	lotr     = uuid.FromStringOrNil("00000000-0000-0000-0000-0000000000f3")
+	solo     = uuid.FromStringOrNil("00000000-0000-0000-0000-0000000000f4")
+)
+
+func edge(actorID uuid.UUID, name string, filmID uuid.UUID, title string) models.CastEdge {
+	return models.CastEdge{ActorID: actorID, ActorName: name, FilmID: filmID, FilmTitle: title, FilmYear: 2003}
+}
+
+// depp и bloom вместе в двух «Пиратах», knight только в первых, bloom и mortens во «Властелине колец»
+func testEdges() []models.CastEdge {
+	return []models.CastEdge{
+		edge(depp, "Джонни Депп", pirates1, "Пираты 1"),
+		edge(bloom, "Орландо Блум", pirates1, "Пираты 1"),
+		edge(knight, "Кира Найтли", pirates1, "Пираты 1"),
+		edge(depp, "Джонни Депп", pirates2, "Пираты 2"),
+		edge(bloom, "Орландо Блум", pirates2, "Пираты 2"),
+		edge(bloom, "Орландо Блум", lotr, "Властелин колец"),
+		edge(mortens, "Вигго Мортенсен", lotr, "Властелин колец"),
+		edge(loner, "Одиночка", solo, "Моноспектакль"),
+	}
+}
+
+func loadedGraph(t *testing.T) *CostarGraph {
+	g := NewCostarGraph(&stubLoader{edges: testEdges()})
+	assert.NoError(t, g.Refresh(testContext()))
+	return g
+}
+
+func TestCostars(t *testing.T) {
+	g := loadedGraph(t)
+
+	tests := []struct {
+		name      string
+		actorID   uuid.UUID
+		wantIDs   []uuid.UUID
+		wantCount []int
+		wantOK    bool
+	}{
+		{
+			name:      "Sorted by shared films",
+			actorID:   bloom,
+			wantIDs:   []uuid.UUID{depp, mortens, knight},
+			wantCount: []int{2, 1, 1},
+			wantOK:    true,
+		},
+		{
+			name:      "Actor without costars",
+			actorID:   loner,
+			wantIDs:   []uuid.UUID{},
+			wantCount: []int{},
+			wantOK:    true,
+		},
+		{
+			name:    "Unknown actor",
+			actorID: uuid.NewV4(),
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			costars, ok := g.Costars(tt.actorID)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			ids := make([]uuid.UUID, 0, len(costars))
+			counts := make([]int, 0, len(costars))
+			for _, costar := range costars {
+				ids = append(ids, costar.ID)
+				counts = append(counts, costar.SharedFilms)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantCount, counts)
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	g := loadedGraph(t)
+
+	tests := []struct {
+		name       string
+		from       uuid.UUID
+		to         uuid.UUID
+		maxDepth   int
+		wantOK     bool
+		wantActors []uuid.UUID
+		wantFilms  []uuid.UUID
+	}{
+		{
+			name:       "Direct costars",
+			from:       depp,
+			to:         knight,
+			maxDepth:   4,
+			wantOK:     true,
+			wantActors: []uuid.UUID{depp, knight},
+			wantFilms:  []uuid.UUID{pirates1},
+		},
+		{
+			name:       "Two degrees",
+			from:       knight,
+			to:         mortens,
+			maxDepth:   4,
+			wantOK:     true,
+			wantActors: []uuid.UUID{knight, bloom, mortens},
+			wantFilms:  []uuid.UUID{pirates1, lotr},
+		},
+		{
+			name:     "Depth limit",
+			from:     knight,
+			to:       mortens,
+			maxDepth: 1,
+			wantOK:   false,
+		},
+		{
+			name:       "Same actor",
+			from:       depp,
+			to:         depp,
+			maxDepth:   4,
+			wantOK:     true,
+			wantActors: []uuid.UUID{depp},
+			wantFilms:  []uuid.UUID{},
+		},
+		{
+			name:     "Disconnected",
+			from:     depp,
+			to:       loner,
+			maxDepth: 6,
+			wantOK:   false,
+		},
+		{
+			name:     "Unknown actor",
+			from:     depp,
+			to:       uuid.NewV4(),
+			maxDepth: 6,
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := g.Path(tt.from, tt.to, tt.maxDepth)
+			assert.Equal(t, tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			actorIDs := make([]uuid.UUID, 0, len(path.Actors))
+			for _, actor := range path.Actors {
+				actorIDs = append(actorIDs, actor.ID)
+			}
+			filmIDs := make([]uuid.UUID, 0, len(path.Films))
+			for _, film := range path.Films {
+				filmIDs = append(filmIDs, film.ID)
+			}
+			assert.Equal(t, tt.wantActors, actorIDs)
+			assert.Equal(t, tt.wantFilms, filmIDs)
+			assert.Equal(t, len(tt.wantFilms), path.Degrees)
+		})
+	}
+}
+
+func TestRefreshKeepsGraphOnError(t *testing.T) {
+	loader := &stubLoader{edges: testEdges()}
+	g := NewCostarGraph(loader)
+	assert.NoError(t, g.Refresh(testContext()))
+
+	loader.err = errors.New("db down")
+	assert.Error(t, g.Refresh(testContext()))
+
+	_, ok := g.Costars(depp)
+	assert.True(t, ok)
+}
+
+func TestConcurrentReadersDuringRefresh(t *testing.T) {
+	g := loadedGraph(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = g.Refresh(testContext())
+		}()
+		go func() {
+			defer wg.Done()
+			_, ok := g.Path(knight, mortens, 4)
+			assert.True(t, ok)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRunRefreshesOnCatalogueChange(t *testing.T) {
+	loader := &stubLoader{}
+	g := NewCostarGraph(loader)
+	assert.NoError(t, g.Refresh(testContext()))
+
+	_, ok := g.Costars(depp)
+	assert.False(t, ok)
+
+	loader.edges = testEdges()
+	g.CatalogueChanged()
+	g.CatalogueChanged()
+
+	ctx, cancel := context.WithCancel(testContext())
+	done := make(chan struct{})
+	go func() {
+		g.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		_, ok := g.Costars(depp)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+}
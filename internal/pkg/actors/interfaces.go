@@ -9,11 +9,19 @@ import (
 
 type ActorUsecase interface {
 	GetActor(ctx context.Context, id uuid.UUID) (models.ActorPage, error)
-	GetFilmsByActor(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error)
+	GetFilmsByActor(ctx context.Context, id uuid.UUID, pager models.Pager, filter models.FilmFilter, sort string) ([]models.ActorFilm, error)
+	GetCostars(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.Costar, error)
+	GetCostarPath(ctx context.Context, from, to uuid.UUID, maxDepth int) (models.CostarPath, error)
 }
 
 type ActorRepo interface {
 	GetActorByID(ctx context.Context, id uuid.UUID) (models.Actor, error)
-	GetActorFilmsCount(ctx context.Context, actorID uuid.UUID) (int, error)
-	GetFilmsByActor(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error)
+	GetActorFilmography(ctx context.Context, actorID uuid.UUID) ([]models.FilmographyFilm, error)
+	GetFilmsByActor(ctx context.Context, actorID uuid.UUID, limit, offset int, filter models.FilmFilter, sort string) ([]models.ActorFilm, error)
+	GetCastEdges(ctx context.Context) ([]models.CastEdge, error)
+}
+
+type CostarGraph interface {
+	Costars(actorID uuid.UUID) ([]models.Costar, bool)
+	Path(from, to uuid.UUID, maxDepth int) (models.CostarPath, bool)
 }
@@ -57,19 +57,49 @@ func (mr *MockActorUsecaseMockRecorder) GetActor(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockActorUsecase)(nil).GetActor), ctx, id)
 }
 
+// GetCostarPath mocks base method.
+func (m *MockActorUsecase) GetCostarPath(ctx context.Context, from, to uuid.UUID, maxDepth int) (models.CostarPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCostarPath", ctx, from, to, maxDepth)
+	ret0, _ := ret[0].(models.CostarPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCostarPath indicates an expected call of GetCostarPath.
+func (mr *MockActorUsecaseMockRecorder) GetCostarPath(ctx, from, to, maxDepth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCostarPath", reflect.TypeOf((*MockActorUsecase)(nil).GetCostarPath), ctx, from, to, maxDepth)
+}
+
+// GetCostars mocks base method.
+func (m *MockActorUsecase) GetCostars(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.Costar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCostars", ctx, id, pager)
+	ret0, _ := ret[0].([]models.Costar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCostars indicates an expected call of GetCostars.
+func (mr *MockActorUsecaseMockRecorder) GetCostars(ctx, id, pager any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCostars", reflect.TypeOf((*MockActorUsecase)(nil).GetCostars), ctx, id, pager)
+}
+
 // GetFilmsByActor mocks base method.
-func (m *MockActorUsecase) GetFilmsByActor(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error) {
+func (m *MockActorUsecase) GetFilmsByActor(ctx context.Context, id uuid.UUID, pager models.Pager, filter models.FilmFilter, sort string) ([]models.ActorFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsByActor", ctx, id, pager)
-	ret0, _ := ret[0].([]models.MainPageFilm)
+	ret := m.ctrl.Call(m, "GetFilmsByActor", ctx, id, pager, filter, sort)
+	ret0, _ := ret[0].([]models.ActorFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsByActor indicates an expected call of GetFilmsByActor.
-func (mr *MockActorUsecaseMockRecorder) GetFilmsByActor(ctx, id, pager any) *gomock.Call {
+func (mr *MockActorUsecaseMockRecorder) GetFilmsByActor(ctx, id, pager, filter, sort any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByActor", reflect.TypeOf((*MockActorUsecase)(nil).GetFilmsByActor), ctx, id, pager)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByActor", reflect.TypeOf((*MockActorUsecase)(nil).GetFilmsByActor), ctx, id, pager, filter, sort)
 }
 
 // MockActorRepo is a mock of ActorRepo interface.
@@ -111,32 +141,101 @@ func (mr *MockActorRepoMockRecorder) GetActorByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorByID", reflect.TypeOf((*MockActorRepo)(nil).GetActorByID), ctx, id)
 }
 
-// GetActorFilmsCount mocks base method.
-func (m *MockActorRepo) GetActorFilmsCount(ctx context.Context, actorID uuid.UUID) (int, error) {
+// GetActorFilmography mocks base method.
+func (m *MockActorRepo) GetActorFilmography(ctx context.Context, actorID uuid.UUID) ([]models.FilmographyFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActorFilmsCount", ctx, actorID)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetActorFilmography", ctx, actorID)
+	ret0, _ := ret[0].([]models.FilmographyFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetActorFilmsCount indicates an expected call of GetActorFilmsCount.
-func (mr *MockActorRepoMockRecorder) GetActorFilmsCount(ctx, actorID any) *gomock.Call {
+// GetActorFilmography indicates an expected call of GetActorFilmography.
+func (mr *MockActorRepoMockRecorder) GetActorFilmography(ctx, actorID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorFilmsCount", reflect.TypeOf((*MockActorRepo)(nil).GetActorFilmsCount), ctx, actorID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorFilmography", reflect.TypeOf((*MockActorRepo)(nil).GetActorFilmography), ctx, actorID)
+}
+
+// GetCastEdges mocks base method.
+func (m *MockActorRepo) GetCastEdges(ctx context.Context) ([]models.CastEdge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCastEdges", ctx)
+	ret0, _ := ret[0].([]models.CastEdge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCastEdges indicates an expected call of GetCastEdges.
+func (mr *MockActorRepoMockRecorder) GetCastEdges(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastEdges", reflect.TypeOf((*MockActorRepo)(nil).GetCastEdges), ctx)
 }
 
 // GetFilmsByActor mocks base method.
-func (m *MockActorRepo) GetFilmsByActor(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error) {
+func (m *MockActorRepo) GetFilmsByActor(ctx context.Context, actorID uuid.UUID, limit, offset int, filter models.FilmFilter, sort string) ([]models.ActorFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsByActor", ctx, actorID, limit, offset)
-	ret0, _ := ret[0].([]models.MainPageFilm)
+	ret := m.ctrl.Call(m, "GetFilmsByActor", ctx, actorID, limit, offset, filter, sort)
+	ret0, _ := ret[0].([]models.ActorFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsByActor indicates an expected call of GetFilmsByActor.
-func (mr *MockActorRepoMockRecorder) GetFilmsByActor(ctx, actorID, limit, offset any) *gomock.Call {
+func (mr *MockActorRepoMockRecorder) GetFilmsByActor(ctx, actorID, limit, offset, filter, sort any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByActor", reflect.TypeOf((*MockActorRepo)(nil).GetFilmsByActor), ctx, actorID, limit, offset, filter, sort)
+}
+
+// MockCostarGraph is a mock of CostarGraph interface.
+type MockCostarGraph struct {
+	ctrl     *gomock.Controller
+	recorder *MockCostarGraphMockRecorder
+	isgomock struct{}
+}
+
+// MockCostarGraphMockRecorder is the mock recorder for MockCostarGraph.
+type MockCostarGraphMockRecorder struct {
+	mock *MockCostarGraph
+}
+
+// NewMockCostarGraph creates a new mock instance.
+func NewMockCostarGraph(ctrl *gomock.Controller) *MockCostarGraph {
+	mock := &MockCostarGraph{ctrl: ctrl}
+	mock.recorder = &MockCostarGraphMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCostarGraph) EXPECT() *MockCostarGraphMockRecorder {
+	return m.recorder
+}
+
+// Costars mocks base method.
+func (m *MockCostarGraph) Costars(actorID uuid.UUID) ([]models.Costar, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Costars", actorID)
+	ret0, _ := ret[0].([]models.Costar)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Costars indicates an expected call of Costars.
+func (mr *MockCostarGraphMockRecorder) Costars(actorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Costars", reflect.TypeOf((*MockCostarGraph)(nil).Costars), actorID)
+}
+
+// Path mocks base method.
+func (m *MockCostarGraph) Path(from, to uuid.UUID, maxDepth int) (models.CostarPath, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Path", from, to, maxDepth)
+	ret0, _ := ret[0].(models.CostarPath)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Path indicates an expected call of Path.
+func (mr *MockCostarGraphMockRecorder) Path(from, to, maxDepth any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByActor", reflect.TypeOf((*MockActorRepo)(nil).GetFilmsByActor), ctx, actorID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockCostarGraph)(nil).Path), from, to, maxDepth)
 }
@@ -45,84 +45,100 @@ func (r *ActorRepository) GetActorByID(ctx context.Context, id uuid.UUID) (model
 	return actor, nil
 }
 
-func (r *ActorRepository) GetActorFilmsCount(ctx context.Context, actorID uuid.UUID) (int, error) {
+func (r *ActorRepository) GetActorFilmography(ctx context.Context, actorID uuid.UUID) ([]models.FilmographyFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
-	var count int
-	err := r.db.QueryRow(
-		ctx,
-		GetActorFilmsCount,
-		actorID,
-	).Scan(&count)
+
+	rows, err := r.db.Query(ctx, GetActorFilmography, actorID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			logger.Error("actor has no films: " + err.Error())
-			return 0, actors.ErrorNotFound
-		}
-		logger.Error("failed to scan films of actor: " + err.Error())
-		return 0, actors.ErrorInternalServerError
+		logger.Error("failed to get filmography of actor: " + err.Error())
+		return nil, actors.ErrorInternalServerError
 	}
+	defer rows.Close()
 
-	logger.Info("succesfully got number of films by actor from db")
-	return count, nil
-}
-
-func (r *ActorRepository) GetFilmAvgRating(ctx context.Context, filmID uuid.UUID) (float64, error) {
-	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
-	var avgRating float64
-	err := r.db.QueryRow(
-		ctx,
-		GetFilmAvgRating,
-		filmID,
-	).Scan(&avgRating)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			logger.Error("film is not found: " + err.Error())
-			return 0, actors.ErrorNotFound
+	var filmography []models.FilmographyFilm
+	for rows.Next() {
+		var film models.FilmographyFilm
+		if err := rows.Scan(
+			&film.ID, &film.Cover, &film.Title, &film.Year,
+			&film.Rating, &film.RatingsCount, &film.Role, &film.Character,
+		); err != nil {
+			logger.Error("failed to scan film of actor: " + err.Error())
+			continue
 		}
-		logger.Error("failed to scan rating of film: " + err.Error())
-		return 0, actors.ErrorInternalServerError
+		film.Rating, _ = strconv.ParseFloat(fmt.Sprintf("%.1f", film.Rating), 64)
+		filmography = append(filmography, film)
 	}
-	roundedRating, _ := strconv.ParseFloat(fmt.Sprintf("%.1f", avgRating), 64)
-	logger.Info("succesfully got rating of film from db")
-	return roundedRating, nil
+
+	logger.Info("succesfully got filmography of actor from db")
+	return filmography, nil
 }
 
-func (r *ActorRepository) GetFilmsByActor(ctx context.Context, actorID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error) {
+func (r *ActorRepository) GetFilmsByActor(ctx context.Context, actorID uuid.UUID, limit, offset int, filter models.FilmFilter, sort string) ([]models.ActorFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	rows, err := r.db.Query(ctx, GetFilmsByActor, actorID, limit, offset)
+	rows, err := r.db.Query(ctx, GetFilmsByActor, actorID, limit, offset, filter.CountryID, sort)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			logger.Error("actor has not films: " + err.Error())
-			return []models.MainPageFilm{}, actors.ErrorNotFound
+			return []models.ActorFilm{}, actors.ErrorNotFound
 		}
 		logger.Error("failed to query films of actor: " + err.Error())
-		return []models.MainPageFilm{}, actors.ErrorInternalServerError
+		return []models.ActorFilm{}, actors.ErrorInternalServerError
 	}
 	defer rows.Close()
 
-	var films []models.MainPageFilm
+	var films []models.ActorFilm
 	for rows.Next() {
-		var film models.MainPageFilm
+		var film models.ActorFilm
 		if err := rows.Scan(
 			&film.ID,
 			&film.Cover,
 			&film.Title,
 			&film.Year,
 			&film.Genre,
+			&film.Rating,
+			&film.RatingsCount,
+			&film.Role,
+			&film.Character,
+			&film.Description,
 		); err != nil {
 			logger.Error("failed to scan films: " + err.Error())
 			return nil, actors.ErrorInternalServerError
 		}
-
-		rating, err := r.GetFilmAvgRating(ctx, film.ID)
-		if err != nil {
-			logger.Error("failed to scan rating of film: " + err.Error())
-		}
-		film.Rating = rating
-
+		film.Rating, _ = strconv.ParseFloat(fmt.Sprintf("%.1f", film.Rating), 64)
 		films = append(films, film)
 	}
 	logger.Info("succesfully got films by actor from db")
 	return films, nil
 }
+
+func (r *ActorRepository) GetCastEdges(ctx context.Context) ([]models.CastEdge, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, GetCastEdges)
+	if err != nil {
+		logger.Error("failed to query cast edges: " + err.Error())
+		return nil, actors.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	var edges []models.CastEdge
+	for rows.Next() {
+		var edge models.CastEdge
+		if err := rows.Scan(
+			&edge.ActorID, &edge.ActorName, &edge.ActorPhoto,
+			&edge.FilmID, &edge.FilmTitle, &edge.FilmYear,
+		); err != nil {
+			logger.Error("failed to scan cast edge: " + err.Error())
+			return nil, actors.ErrorInternalServerError
+		}
+		edges = append(edges, edge)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to read cast edges: " + err.Error())
+		return nil, actors.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got cast edges from db")
+	return edges, nil
+}
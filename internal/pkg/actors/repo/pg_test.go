@@ -143,73 +143,51 @@ func TestGetActorByID(t *testing.T) {
 	}
 }
 
-func TestGetActorFilmsCount(t *testing.T) {
+func TestGetActorFilmography(t *testing.T) {
 	actorID := uuid.NewV4()
+	filmID1 := uuid.NewV4()
+	filmID2 := uuid.NewV4()
+	character := "Купер"
 
 	tests := []struct {
 		name       string
 		actorID    uuid.UUID
 		repoMocker func(*pgxpoolmock.MockPgxPool)
 		wantErr    bool
-		wantCount  int
+		wantFilms  []models.FilmographyFilm
 		errorType  error
 	}{
 		{
 			name:    "Success",
 			actorID: actorID,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				countRows := pgxpoolmock.NewRows([]string{"count"}).
-					AddRow(15).
+				rows := pgxpoolmock.NewRows([]string{
+					"id", "cover", "title", "year", "rating", "ratings_count", "role", "character",
+				}).
+					AddRow(filmID1, "/static/cover1.jpg", "Интерстеллар", 2014, 8.66, 120, models.CrewRoleActor, &character).
+					AddRow(filmID2, "/static/cover2.jpg", "Короткометражка", 2010, 0.0, 0, models.CrewRoleProducer, (*string)(nil)).
 					ToPgxRows()
-				countRows.Next()
 
 				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetActorFilmsCount, actorID).
-					Return(countRows)
+					Query(gomock.Any(), GetActorFilmography, actorID).
+					Return(rows, nil)
 			},
-			wantErr:   false,
-			wantCount: 15,
-		},
-		{
-			name:    "NoFilmsFound",
-			actorID: actorID,
-			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetActorFilmsCount, actorID).
-					Return(MockRow{err: pgx.ErrNoRows})
+			wantFilms: []models.FilmographyFilm{
+				{ID: filmID1, Cover: "/static/cover1.jpg", Title: "Интерстеллар", Year: 2014, Rating: 8.7, RatingsCount: 120, Role: models.CrewRoleActor, Character: &character},
+				{ID: filmID2, Cover: "/static/cover2.jpg", Title: "Короткометражка", Year: 2010, Rating: 0, Role: models.CrewRoleProducer},
 			},
-			wantErr:   true,
-			wantCount: 0,
-			errorType: actors.ErrorNotFound,
 		},
 		{
 			name:    "DatabaseError",
 			actorID: actorID,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetActorFilmsCount, actorID).
-					Return(MockRow{err: errors.New("database connection failed")})
+					Query(gomock.Any(), GetActorFilmography, actorID).
+					Return(nil, errors.New("database connection failed"))
 			},
 			wantErr:   true,
-			wantCount: 0,
 			errorType: actors.ErrorInternalServerError,
 		},
-		{
-			name:    "ZeroFilms",
-			actorID: actorID,
-			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				countRows := pgxpoolmock.NewRows([]string{"count"}).
-					AddRow(0).
-					ToPgxRows()
-				countRows.Next()
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetActorFilmsCount, actorID).
-					Return(countRows)
-			},
-			wantErr:   false,
-			wantCount: 0,
-		},
 	}
 
 	for _, tt := range tests {
@@ -221,7 +199,7 @@ func TestGetActorFilmsCount(t *testing.T) {
 			tt.repoMocker(mockPool)
 
 			repo := NewActorRepository(mockPool)
-			count, err := repo.GetActorFilmsCount(testContext(), tt.actorID)
+			films, err := repo.GetActorFilmography(testContext(), tt.actorID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -231,102 +209,7 @@ func TestGetActorFilmsCount(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.wantCount, count)
-			}
-		})
-	}
-}
-
-func TestGetFilmAvgRating(t *testing.T) {
-	filmID := uuid.NewV4()
-
-	tests := []struct {
-		name       string
-		filmID     uuid.UUID
-		repoMocker func(*pgxpoolmock.MockPgxPool)
-		wantErr    bool
-		wantRating float64
-	}{
-		{
-			name:   "Success",
-			filmID: filmID,
-			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				rows := pgxpoolmock.NewRows([]string{"avg"}).
-					AddRow(8.54321).
-					ToPgxRows()
-				rows.Next()
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID).
-					Return(rows)
-			},
-			wantErr:    false,
-			wantRating: 8.5,
-		},
-		{
-			name:   "ZeroRating",
-			filmID: filmID,
-			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				rows := pgxpoolmock.NewRows([]string{"avg"}).
-					AddRow(0.0).
-					ToPgxRows()
-				rows.Next()
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID).
-					Return(rows)
-			},
-			wantErr:    false,
-			wantRating: 0.0,
-		},
-		{
-			name:   "HighPrecisionRating",
-			filmID: filmID,
-			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				rows := pgxpoolmock.NewRows([]string{"avg"}).
-					AddRow(9.98765).
-					ToPgxRows()
-				rows.Next()
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID).
-					Return(rows)
-			},
-			wantErr:    false,
-			wantRating: 10.0,
-		},
-		{
-			name:   "NotFound",
-			filmID: filmID,
-			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID).
-					Return(MockRow{err: pgx.ErrNoRows})
-			},
-			wantErr:    true,
-			wantRating: 0.0,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
-			tt.repoMocker(mockPool)
-
-			repo := NewActorRepository(mockPool)
-			rating, err := repo.GetFilmAvgRating(testContext(), tt.filmID)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.name == "NotFound" {
-					assert.True(t, errors.Is(err, actors.ErrorNotFound))
-				}
-			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.wantRating, rating)
+				assert.Equal(t, tt.wantFilms, films)
 			}
 		})
 	}
@@ -336,55 +219,54 @@ func TestGetFilmsByActor(t *testing.T) {
 	actorID := uuid.NewV4()
 	filmID1 := uuid.NewV4()
 	filmID2 := uuid.NewV4()
+	countryID := uuid.NewV4()
+	character := "Форрест"
+	description := "Главная роль"
 
-	filmColumns := []string{"id", "cover", "title", "year", "genre"}
+	filmColumns := []string{
+		"id", "cover", "title", "year", "genre", "rating", "ratings_count", "role", "character", "description",
+	}
 
 	tests := []struct {
 		name       string
 		actorID    uuid.UUID
 		limit      int
 		offset     int
+		filter     models.FilmFilter
+		sort       string
 		repoMocker func(*pgxpoolmock.MockPgxPool)
 		wantErr    bool
-		wantFilms  []models.MainPageFilm
+		wantFilms  []models.ActorFilm
 	}{
 		{
 			name:    "Success",
 			actorID: actorID,
 			limit:   10,
 			offset:  0,
+			sort:    models.ActorFilmsSortYear,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				filmRows := pgxpoolmock.NewRows(filmColumns).
-					AddRow(filmID1, "film1.jpg", "Форрест Гамп", 1994, "Драма").
-					AddRow(filmID2, "film2.jpg", "Зеленая миля", 1999, "Драма").
+					AddRow(filmID1, "film1.jpg", "Форрест Гамп", 1994, "Драма", 8.84, 42, models.CrewRoleActor, &character, &description).
+					AddRow(filmID2, "film2.jpg", "Зеленая миля", 1999, "Драма", 0.0, 0, models.CrewRoleProducer, (*string)(nil), (*string)(nil)).
 					ToPgxRows()
 
-				ratingRows1 := pgxpoolmock.NewRows([]string{"avg"}).AddRow(8.8).ToPgxRows()
-				ratingRows1.Next()
-				ratingRows2 := pgxpoolmock.NewRows([]string{"avg"}).AddRow(8.6).ToPgxRows()
-				ratingRows2.Next()
-
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
+					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0, uuid.NullUUID{}, models.ActorFilmsSortYear).
 					Return(filmRows, nil)
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID1).
-					Return(ratingRows1)
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID2).
-					Return(ratingRows2)
 			},
 			wantErr: false,
-			wantFilms: []models.MainPageFilm{
+			wantFilms: []models.ActorFilm{
 				{
-					ID:     filmID1,
-					Cover:  "film1.jpg",
-					Title:  "Форрест Гамп",
-					Year:   1994,
-					Genre:  "Драма",
-					Rating: 8.8,
+					ID:           filmID1,
+					Cover:        "film1.jpg",
+					Title:        "Форрест Гамп",
+					Year:         1994,
+					Genre:        "Драма",
+					Rating:       8.8,
+					RatingsCount: 42,
+					Role:         models.CrewRoleActor,
+					Character:    &character,
+					Description:  &description,
 				},
 				{
 					ID:     filmID2,
@@ -392,7 +274,8 @@ func TestGetFilmsByActor(t *testing.T) {
 					Title:  "Зеленая миля",
 					Year:   1999,
 					Genre:  "Драма",
-					Rating: 8.6,
+					Rating: 0,
+					Role:   models.CrewRoleProducer,
 				},
 			},
 		},
@@ -401,24 +284,26 @@ func TestGetFilmsByActor(t *testing.T) {
 			actorID: actorID,
 			limit:   10,
 			offset:  0,
+			sort:    models.ActorFilmsSortYear,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				filmRows := pgxpoolmock.NewRows(filmColumns).ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
+					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0, uuid.NullUUID{}, models.ActorFilmsSortYear).
 					Return(filmRows, nil)
 			},
 			wantErr:   false,
-			wantFilms: []models.MainPageFilm{},
+			wantFilms: nil,
 		},
 		{
 			name:    "QueryError",
 			actorID: actorID,
 			limit:   10,
 			offset:  0,
+			sort:    models.ActorFilmsSortYear,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
+					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0, uuid.NullUUID{}, models.ActorFilmsSortYear).
 					Return(nil, assert.AnError)
 			},
 			wantErr:   true,
@@ -429,9 +314,10 @@ func TestGetFilmsByActor(t *testing.T) {
 			actorID: actorID,
 			limit:   10,
 			offset:  0,
+			sort:    models.ActorFilmsSortYear,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
+					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0, uuid.NullUUID{}, models.ActorFilmsSortYear).
 					Return(nil, pgx.ErrNoRows)
 			},
 			wantErr:   true,
@@ -442,110 +328,111 @@ func TestGetFilmsByActor(t *testing.T) {
 			actorID: actorID,
 			limit:   10,
 			offset:  0,
+			sort:    models.ActorFilmsSortYear,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				filmRows := pgxpoolmock.NewRows([]string{"id", "cover"}).
 					AddRow(filmID1, "film1.jpg").
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
+					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0, uuid.NullUUID{}, models.ActorFilmsSortYear).
 					Return(filmRows, nil)
 			},
 			wantErr:   true,
 			wantFilms: nil,
 		},
 		{
-			name:    "RatingQueryError",
+			name:    "SortAndFilterArePassed",
 			actorID: actorID,
-			limit:   10,
-			offset:  0,
+			limit:   5,
+			offset:  10,
+			filter:  models.FilmFilter{CountryID: uuid.NullUUID{UUID: countryID, Valid: true}},
+			sort:    models.ActorFilmsSortPopularity,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				filmRows := pgxpoolmock.NewRows(filmColumns).
-					AddRow(filmID1, "film1.jpg", "Форрест Гамп", 1994, "Драма").
+					AddRow(filmID1, "film1.jpg", "Форрест Гамп", 1994, "Драма", 8.8, 42, models.CrewRoleActor, &character, (*string)(nil)).
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
+					Query(gomock.Any(), GetFilmsByActor, actorID, 5, 10,
+						uuid.NullUUID{UUID: countryID, Valid: true}, models.ActorFilmsSortPopularity).
 					Return(filmRows, nil)
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID1).
-					Return(MockRow{err: pgx.ErrNoRows})
 			},
 			wantErr: false,
-			wantFilms: []models.MainPageFilm{
+			wantFilms: []models.ActorFilm{
 				{
-					ID:     filmID1,
-					Cover:  "film1.jpg",
-					Title:  "Форрест Гамп",
-					Year:   1994,
-					Genre:  "Драма",
-					Rating: 0.0,
+					ID:           filmID1,
+					Cover:        "film1.jpg",
+					Title:        "Форрест Гамп",
+					Year:         1994,
+					Genre:        "Драма",
+					Rating:       8.8,
+					RatingsCount: 42,
+					Role:         models.CrewRoleActor,
+					Character:    &character,
 				},
 			},
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewActorRepository(mockPool)
+			films, err := repo.GetFilmsByActor(testContext(), tt.actorID, tt.limit, tt.offset, tt.filter, tt.sort)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFilms, films)
+			}
+		})
+	}
+}
+
+func TestGetCastEdges(t *testing.T) {
+	actorID := uuid.NewV4()
+	filmID := uuid.NewV4()
+	columns := []string{"actor_id", "russian_name", "photo", "film_id", "title", "year"}
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantEdges  []models.CastEdge
+		wantErr    error
+	}{
 		{
-			name:    "RatingInternalError",
-			actorID: actorID,
-			limit:   10,
-			offset:  0,
+			name: "Success",
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				filmRows := pgxpoolmock.NewRows(filmColumns).
-					AddRow(filmID1, "film1.jpg", "Форрест Гамп", 1994, "Драма").
+				rows := pgxpoolmock.NewRows(columns).
+					AddRow(actorID, "Том Хэнкс", "/static/hanks.jpg", filmID, "Форрест Гамп", 1994).
 					ToPgxRows()
-
-				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 10, 0).
-					Return(filmRows, nil)
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID1).
-					Return(MockRow{err: assert.AnError})
+				mockPool.EXPECT().Query(gomock.Any(), GetCastEdges).Return(rows, nil)
 			},
-			wantErr: false,
-			wantFilms: []models.MainPageFilm{
-				{
-					ID:     filmID1,
-					Cover:  "film1.jpg",
-					Title:  "Форрест Гамп",
-					Year:   1994,
-					Genre:  "Драма",
-					Rating: 0.0,
-				},
+			wantEdges: []models.CastEdge{
+				{ActorID: actorID, ActorName: "Том Хэнкс", ActorPhoto: "/static/hanks.jpg", FilmID: filmID, FilmTitle: "Форрест Гамп", FilmYear: 1994},
 			},
 		},
 		{
-			name:    "DifferentLimitOffset",
-			actorID: actorID,
-			limit:   5,
-			offset:  10,
+			name: "Query error",
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				filmRows := pgxpoolmock.NewRows(filmColumns).
-					AddRow(filmID1, "film1.jpg", "Форрест Гамп", 1994, "Драма").
-					ToPgxRows()
-
-				ratingRows1 := pgxpoolmock.NewRows([]string{"avg"}).AddRow(8.8).ToPgxRows()
-				ratingRows1.Next()
-
-				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByActor, actorID, 5, 10).
-					Return(filmRows, nil)
-
-				mockPool.EXPECT().
-					QueryRow(gomock.Any(), GetFilmAvgRating, filmID1).
-					Return(ratingRows1)
+				mockPool.EXPECT().Query(gomock.Any(), GetCastEdges).Return(nil, errors.New("db error"))
 			},
-			wantErr: false,
-			wantFilms: []models.MainPageFilm{
-				{
-					ID:     filmID1,
-					Cover:  "film1.jpg",
-					Title:  "Форрест Гамп",
-					Year:   1994,
-					Genre:  "Драма",
-					Rating: 8.8,
-				},
+			wantErr: actors.ErrorInternalServerError,
+		},
+		{
+			name: "Scan error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"actor_id"}).AddRow(actorID).ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetCastEdges).Return(rows, nil)
 			},
+			wantErr: actors.ErrorInternalServerError,
 		},
 	}
 
@@ -558,24 +445,13 @@ func TestGetFilmsByActor(t *testing.T) {
 			tt.repoMocker(mockPool)
 
 			repo := NewActorRepository(mockPool)
-			films, err := repo.GetFilmsByActor(testContext(), tt.actorID, tt.limit, tt.offset)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-			} else {
-				assert.NoError(t, err)
-				assert.Len(t, films, len(tt.wantFilms))
-				if len(tt.wantFilms) > 0 {
-					for i := range tt.wantFilms {
-						assert.Equal(t, tt.wantFilms[i].ID, films[i].ID)
-						assert.Equal(t, tt.wantFilms[i].Title, films[i].Title)
-						assert.Equal(t, tt.wantFilms[i].Cover, films[i].Cover)
-						assert.Equal(t, tt.wantFilms[i].Year, films[i].Year)
-						assert.Equal(t, tt.wantFilms[i].Genre, films[i].Genre)
-						assert.Equal(t, tt.wantFilms[i].Rating, films[i].Rating)
-					}
-				}
+			edges, err := repo.GetCastEdges(testContext())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEdges, edges)
 		})
 	}
 }
@@ -5,11 +5,11 @@ import _ "embed"
 //go:embed sql/getActorByIDQuery.sql
 var GetActorByID string
 
-//go:embed sql/getActorFilmsCountQuery.sql
-var GetActorFilmsCount string
-
-//go:embed sql/getFilmAvgRatingQuery.sql
-var GetFilmAvgRating string
+//go:embed sql/getActorFilmographyQuery.sql
+var GetActorFilmography string
 
 //go:embed sql/getFilmsByActorQuery.sql
 var GetFilmsByActor string
+
+//go:embed sql/getCastEdgesQuery.sql
+var GetCastEdges string
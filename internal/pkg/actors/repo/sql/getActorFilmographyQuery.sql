@@ -0,0 +1,19 @@
+SELECT
+    f.id,
+    COALESCE(f.cover, ''),
+    f.title,
+    f.year,
+    COALESCE(fr.avg_rating, 0),
+    COALESCE(fr.ratings_count, 0),
+    aif.role,
+    aif."character"
+FROM actor_in_film aif
+JOIN film f ON f.id = aif.film_id
+LEFT JOIN (
+    SELECT film_id, AVG(rating) AS avg_rating, COUNT(*) AS ratings_count
+    FROM film_feedback
+    WHERE rating IS NOT NULL
+    GROUP BY film_id
+) fr ON fr.film_id = f.id
+WHERE aif.actor_id = $1
+ORDER BY f.year DESC, f.title
\ No newline at end of file
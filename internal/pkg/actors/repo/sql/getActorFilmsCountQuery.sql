@@ -1,3 +0,0 @@
-SELECT COUNT(1) 
-FROM actor_in_film 
-WHERE actor_id = $1
\ No newline at end of file
@@ -0,0 +1,11 @@
+SELECT
+    a.id,
+    a.russian_name,
+    COALESCE(a.photo, ''),
+    f.id,
+    f.title,
+    f.year
+FROM actor_in_film aif
+JOIN actor a ON a.id = aif.actor_id
+JOIN film f ON f.id = aif.film_id
+WHERE aif.role = 'actor'
\ No newline at end of file
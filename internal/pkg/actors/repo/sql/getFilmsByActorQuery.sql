@@ -1,12 +1,29 @@
-SELECT 
-    f.id, 
-    COALESCE(f.cover, ''), 
-    f.title, 
+SELECT
+    f.id,
+    COALESCE(f.cover, ''),
+    f.title,
     f.year,
-    g.title as genre
-FROM film f
-JOIN actor_in_film aif ON f.id = aif.film_id
+    g.title AS genre,
+    COALESCE(fr.avg_rating, 0),
+    COALESCE(fr.ratings_count, 0),
+    aif.role,
+    aif."character",
+    aif.description
+FROM actor_in_film aif
+JOIN film f ON f.id = aif.film_id
 JOIN genre g ON f.genre_id = g.id
+LEFT JOIN (
+    SELECT film_id, AVG(rating) AS avg_rating, COUNT(*) AS ratings_count
+    FROM film_feedback
+    WHERE rating IS NOT NULL
+    GROUP BY film_id
+) fr ON fr.film_id = f.id
 WHERE aif.actor_id = $1
-ORDER BY f.created_at DESC
+    AND ($4::uuid IS NULL OR f.country_id = $4::uuid)
+ORDER BY
+    CASE WHEN $5::text = 'rating' THEN COALESCE(fr.avg_rating, 0) END DESC,
+    CASE WHEN $5::text = 'popularity' THEN COALESCE(fr.ratings_count, 0) END DESC,
+    f.year DESC,
+    f.title,
+    aif.billing_order
 LIMIT $2 OFFSET $3
\ No newline at end of file
@@ -11,13 +11,23 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// сколько фильмов показывать в блоке «Лучшие фильмы»
+const bestKnownForLimit = 4
+
+const (
+	defaultPathDepth = 4
+	maxPathDepth     = 6
+)
+
 type ActorUsecase struct {
-	actorRepo actors.ActorRepo
+	actorRepo   actors.ActorRepo
+	costarGraph actors.CostarGraph
 }
 
-func NewActorUsecase(repo actors.ActorRepo) *ActorUsecase {
+func NewActorUsecase(repo actors.ActorRepo, costarGraph actors.CostarGraph) *ActorUsecase {
 	return &ActorUsecase{
-		actorRepo: repo,
+		actorRepo:   repo,
+		costarGraph: costarGraph,
 	}
 }
 
@@ -40,7 +50,7 @@ func (uc *ActorUsecase) GetActor(ctx context.Context, id uuid.UUID) (models.Acto
 		age--
 	}
 
-	filmsNumber, err := uc.actorRepo.GetActorFilmsCount(ctx, id)
+	filmography, err := uc.actorRepo.GetActorFilmography(ctx, id)
 	if err != nil {
 		return models.ActorPage{}, err
 	}
@@ -56,21 +66,68 @@ func (uc *ActorUsecase) GetActor(ctx context.Context, id uuid.UUID) (models.Acto
 		ZodiacSign:    actor.ZodiacSign,
 		BirthPlace:    actor.BirthPlace,
 		MaritalStatus: actor.MaritalStatus,
-		FilmsNumber:   filmsNumber,
+		Filmography:   models.GroupFilmography(filmography),
+		BestKnownFor:  models.BestKnownFor(filmography, bestKnownForLimit),
 	}
 	return result, nil
 }
 
-func (uc *ActorUsecase) GetFilmsByActor(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error) {
+func (uc *ActorUsecase) GetFilmsByActor(ctx context.Context, id uuid.UUID, pager models.Pager, filter models.FilmFilter, sort string) ([]models.ActorFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
-	films, err := uc.actorRepo.GetFilmsByActor(ctx, id, pager.Count, pager.Offset)
+	if sort == "" {
+		sort = models.ActorFilmsSortYear
+	}
+	if !models.IsActorFilmsSort(sort) {
+		logger.Error("unknown sort of actor films: " + sort)
+		return []models.ActorFilm{}, actors.ErrorBadRequest
+	}
+
+	films, err := uc.actorRepo.GetFilmsByActor(ctx, id, pager.Count, pager.Offset, filter, sort)
 	if err != nil {
-		return []models.MainPageFilm{}, err
+		return []models.ActorFilm{}, err
 	}
 
 	if len(films) == 0 {
 		logger.Error("actor has no films")
-		return []models.MainPageFilm{}, actors.ErrorNotFound
+		return []models.ActorFilm{}, actors.ErrorNotFound
 	}
 	return films, nil
 }
+
+func (uc *ActorUsecase) GetCostars(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.Costar, error) {
+	costars, ok := uc.costarGraph.Costars(id)
+	if !ok {
+		// актер без ролей в фильмах в граф не попадает, но существовать может
+		if _, err := uc.actorRepo.GetActorByID(ctx, id); err != nil {
+			return []models.Costar{}, err
+		}
+		return []models.Costar{}, nil
+	}
+
+	if pager.Offset >= len(costars) {
+		return []models.Costar{}, nil
+	}
+	end := pager.Offset + pager.Count
+	if end > len(costars) {
+		end = len(costars)
+	}
+	return costars[pager.Offset:end], nil
+}
+
+func (uc *ActorUsecase) GetCostarPath(ctx context.Context, from, to uuid.UUID, maxDepth int) (models.CostarPath, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if maxDepth == 0 {
+		maxDepth = defaultPathDepth
+	}
+	if maxDepth < 0 || maxDepth > maxPathDepth {
+		logger.Error("depth of costar path is out of range")
+		return models.CostarPath{}, actors.ErrorBadRequest
+	}
+
+	path, ok := uc.costarGraph.Path(from, to, maxDepth)
+	if !ok {
+		logger.Error("costar path is not found")
+		return models.CostarPath{}, actors.ErrorNotFound
+	}
+	return path, nil
+}
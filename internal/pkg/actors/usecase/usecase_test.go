@@ -31,12 +31,18 @@ func TestActorUsecase_GetActor(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockActorRepo(ctrl)
-	usecase := NewActorUsecase(mockRepo)
+	usecase := NewActorUsecase(mockRepo, nil)
 
 	actorID := uuid.NewV4()
 	birthDate := time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
 	deathDate := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
 	originalName := "Johnny Depp"
+	jackSparrow := "Джек Воробей"
+	filmography := []models.FilmographyFilm{
+		{ID: uuid.NewV4(), Title: "Пираты Карибского моря", Year: 2003, Rating: 8.1, RatingsCount: 300, Role: models.CrewRoleActor, Character: &jackSparrow},
+		{ID: uuid.NewV4(), Title: "Храбрец", Year: 1997, Rating: 6.3, Role: models.CrewRoleDirector},
+		{ID: uuid.NewV4(), Title: "Эдвард руки-ножницы", Year: 1990, Rating: 8.0, RatingsCount: 450, Role: models.CrewRoleActor},
+	}
 
 	tests := []struct {
 		name        string
@@ -64,8 +70,8 @@ func TestActorUsecase_GetActor(t *testing.T) {
 					GetActorByID(gomock.Any(), actorID).
 					Return(actor, nil)
 				mockRepo.EXPECT().
-					GetActorFilmsCount(gomock.Any(), actorID).
-					Return(25, nil)
+					GetActorFilmography(gomock.Any(), actorID).
+					Return(filmography, nil)
 			},
 			expected: models.ActorPage{
 				ID:            actorID,
@@ -78,7 +84,19 @@ func TestActorUsecase_GetActor(t *testing.T) {
 				ZodiacSign:    "Козерог",
 				BirthPlace:    "Овенсборо, Кентукки, США",
 				MaritalStatus: "Разведен",
-				FilmsNumber:   25,
+				Filmography: []models.FilmographyGroup{
+					{
+						Role:       models.CrewRoleDirector,
+						FilmsCount: 1,
+						Films:      []models.FilmographyFilm{filmography[1]},
+					},
+					{
+						Role:       models.CrewRoleActor,
+						FilmsCount: 2,
+						Films:      []models.FilmographyFilm{filmography[0], filmography[2]},
+					},
+				},
+				BestKnownFor: []models.FilmographyFilm{filmography[2], filmography[0]},
 			},
 			expectError: false,
 		},
@@ -102,8 +120,8 @@ func TestActorUsecase_GetActor(t *testing.T) {
 					GetActorByID(gomock.Any(), actorID).
 					Return(actor, nil)
 				mockRepo.EXPECT().
-					GetActorFilmsCount(gomock.Any(), actorID).
-					Return(15, nil)
+					GetActorFilmography(gomock.Any(), actorID).
+					Return(nil, nil)
 			},
 			expected: models.ActorPage{
 				ID:            actorID,
@@ -116,7 +134,8 @@ func TestActorUsecase_GetActor(t *testing.T) {
 				ZodiacSign:    "Телец",
 				BirthPlace:    "Австралия",
 				MaritalStatus: "Не женат",
-				FilmsNumber:   15,
+				Filmography:   []models.FilmographyGroup{},
+				BestKnownFor:  []models.FilmographyFilm{},
 			},
 			expectError: false,
 		},
@@ -143,7 +162,7 @@ func TestActorUsecase_GetActor(t *testing.T) {
 			errorType:   actors.ErrorInternalServerError,
 		},
 		{
-			name: "Error - no films count",
+			name: "Error - filmography failed",
 			setupMock: func() {
 				actor := models.Actor{
 					ID:            actorID,
@@ -161,8 +180,8 @@ func TestActorUsecase_GetActor(t *testing.T) {
 					GetActorByID(gomock.Any(), actorID).
 					Return(actor, nil)
 				mockRepo.EXPECT().
-					GetActorFilmsCount(gomock.Any(), actorID).
-					Return(0, actors.ErrorInternalServerError)
+					GetActorFilmography(gomock.Any(), actorID).
+					Return(nil, actors.ErrorInternalServerError)
 			},
 			expected:    models.ActorPage{},
 			expectError: true,
@@ -187,7 +206,8 @@ func TestActorUsecase_GetActor(t *testing.T) {
 				assert.Equal(t, tt.expected.RussianName, result.RussianName)
 				assert.Equal(t, tt.expected.Height, result.Height)
 				assert.Equal(t, tt.expected.Age, result.Age)
-				assert.Equal(t, tt.expected.FilmsNumber, result.FilmsNumber)
+				assert.Equal(t, tt.expected.Filmography, result.Filmography)
+				assert.Equal(t, tt.expected.BestKnownFor, result.BestKnownFor)
 			}
 		})
 	}
@@ -198,7 +218,7 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockActorRepo(ctrl)
-	usecase := NewActorUsecase(mockRepo)
+	usecase := NewActorUsecase(mockRepo, nil)
 
 	actorID := uuid.NewV4()
 	pager := models.Pager{
@@ -206,7 +226,7 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 		Offset: 0,
 	}
 
-	expectedFilms := []models.MainPageFilm{
+	expectedFilms := []models.ActorFilm{
 		{
 			ID:     uuid.NewV4(),
 			Cover:  "film1.jpg",
@@ -228,7 +248,7 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupMock   func()
-		expected    []models.MainPageFilm
+		expected    []models.ActorFilm
 		expectError bool
 		errorType   error
 	}{
@@ -236,7 +256,7 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 			name: "Success - with films",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset).
+					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, models.ActorFilmsSortYear).
 					Return(expectedFilms, nil)
 			},
 			expected:    expectedFilms,
@@ -246,10 +266,10 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 			name: "Error - repository error",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset).
+					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, models.ActorFilmsSortYear).
 					Return(nil, actors.ErrorInternalServerError)
 			},
-			expected:    []models.MainPageFilm{},
+			expected:    []models.ActorFilm{},
 			expectError: true,
 			errorType:   actors.ErrorInternalServerError,
 		},
@@ -257,10 +277,10 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 			name: "Error - actor not found",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset).
+					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, models.ActorFilmsSortYear).
 					Return(nil, actors.ErrorNotFound)
 			},
-			expected:    []models.MainPageFilm{},
+			expected:    []models.ActorFilm{},
 			expectError: true,
 			errorType:   actors.ErrorNotFound,
 		},
@@ -268,10 +288,10 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 			name: "Error - no films found",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset).
-					Return([]models.MainPageFilm{}, nil)
+					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, models.ActorFilmsSortYear).
+					Return([]models.ActorFilm{}, nil)
 			},
-			expected:    []models.MainPageFilm{},
+			expected:    []models.ActorFilm{},
 			expectError: true,
 			errorType:   actors.ErrorNotFound,
 		},
@@ -280,7 +300,7 @@ func TestActorUsecase_GetFilmsByActor(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
-			result, err := usecase.GetFilmsByActor(testContext(), actorID, pager)
+			result, err := usecase.GetFilmsByActor(testContext(), actorID, pager, models.FilmFilter{}, "")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -301,7 +321,7 @@ func TestActorUsecase_GetFilmsByActor_EmptyFilms(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockActorRepo(ctrl)
-	usecase := NewActorUsecase(mockRepo)
+	usecase := NewActorUsecase(mockRepo, nil)
 
 	actorID := uuid.NewV4()
 	pager := models.Pager{
@@ -310,10 +330,10 @@ func TestActorUsecase_GetFilmsByActor_EmptyFilms(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset).
-		Return([]models.MainPageFilm{}, nil)
+		GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, models.ActorFilmsSortYear).
+		Return([]models.ActorFilm{}, nil)
 
-	films, err := usecase.GetFilmsByActor(testContext(), actorID, pager)
+	films, err := usecase.GetFilmsByActor(testContext(), actorID, pager, models.FilmFilter{}, "")
 
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, actors.ErrorNotFound)
@@ -325,7 +345,7 @@ func TestActorUsecase_GetFilmsByActor_WithFilms(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockActorRepo(ctrl)
-	usecase := NewActorUsecase(mockRepo)
+	usecase := NewActorUsecase(mockRepo, nil)
 
 	actorID := uuid.NewV4()
 	pager := models.Pager{
@@ -333,7 +353,7 @@ func TestActorUsecase_GetFilmsByActor_WithFilms(t *testing.T) {
 		Offset: 0,
 	}
 
-	expectedFilms := []models.MainPageFilm{
+	expectedFilms := []models.ActorFilm{
 		{
 			ID:     uuid.NewV4(),
 			Cover:  "film1.jpg",
@@ -345,10 +365,10 @@ func TestActorUsecase_GetFilmsByActor_WithFilms(t *testing.T) {
 	}
 
 	mockRepo.EXPECT().
-		GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset).
+		GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, models.ActorFilmsSortYear).
 		Return(expectedFilms, nil)
 
-	films, err := usecase.GetFilmsByActor(testContext(), actorID, pager)
+	films, err := usecase.GetFilmsByActor(testContext(), actorID, pager, models.FilmFilter{}, "")
 
 	assert.NoError(t, err)
 	assert.Len(t, films, 1)
@@ -362,3 +382,188 @@ func calculateAge(birthDate, endDate time.Time) int {
 	}
 	return age
 }
+
+func TestActorUsecase_GetFilmsByActor_Sort(t *testing.T) {
+	actorID := uuid.NewV4()
+	pager := models.Pager{Count: 10, Offset: 0}
+	films := []models.ActorFilm{{ID: uuid.NewV4(), Title: "Пираты Карибского моря", RatingsCount: 300}}
+
+	tests := []struct {
+		name      string
+		sort      string
+		repoSort  string
+		callsRepo bool
+		errorType error
+	}{
+		{name: "Default is year", sort: "", repoSort: models.ActorFilmsSortYear, callsRepo: true},
+		{name: "By rating", sort: models.ActorFilmsSortRating, repoSort: models.ActorFilmsSortRating, callsRepo: true},
+		{name: "By popularity", sort: models.ActorFilmsSortPopularity, repoSort: models.ActorFilmsSortPopularity, callsRepo: true},
+		{name: "Unknown sort", sort: "created_at", errorType: actors.ErrorBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockActorRepo(ctrl)
+			usecase := NewActorUsecase(mockRepo, nil)
+			if tt.callsRepo {
+				mockRepo.EXPECT().
+					GetFilmsByActor(gomock.Any(), actorID, pager.Count, pager.Offset, models.FilmFilter{}, tt.repoSort).
+					Return(films, nil)
+			}
+
+			result, err := usecase.GetFilmsByActor(testContext(), actorID, pager, models.FilmFilter{}, tt.sort)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, films, result)
+		})
+	}
+}
+
+func TestActorUsecase_GetCostars(t *testing.T) {
+	actorID := uuid.NewV4()
+	costars := []models.Costar{
+		{CostarActor: models.CostarActor{ID: uuid.NewV4(), RussianName: "Орландо Блум"}, SharedFilms: 3},
+		{CostarActor: models.CostarActor{ID: uuid.NewV4(), RussianName: "Кира Найтли"}, SharedFilms: 2},
+		{CostarActor: models.CostarActor{ID: uuid.NewV4(), RussianName: "Джеффри Раш"}, SharedFilms: 1},
+	}
+
+	tests := []struct {
+		name      string
+		pager     models.Pager
+		setupMock func(mockRepo *mocks.MockActorRepo, mockGraph *mocks.MockCostarGraph)
+		expected  []models.Costar
+		errorType error
+	}{
+		{
+			name:  "First page",
+			pager: models.Pager{Count: 2, Offset: 0},
+			setupMock: func(mockRepo *mocks.MockActorRepo, mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Costars(actorID).Return(costars, true)
+			},
+			expected: costars[:2],
+		},
+		{
+			name:  "Offset past the end",
+			pager: models.Pager{Count: 2, Offset: 5},
+			setupMock: func(mockRepo *mocks.MockActorRepo, mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Costars(actorID).Return(costars, true)
+			},
+			expected: []models.Costar{},
+		},
+		{
+			name:  "Actor without films",
+			pager: models.Pager{Count: 10, Offset: 0},
+			setupMock: func(mockRepo *mocks.MockActorRepo, mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Costars(actorID).Return(nil, false)
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), actorID).Return(models.Actor{ID: actorID}, nil)
+			},
+			expected: []models.Costar{},
+		},
+		{
+			name:  "Unknown actor",
+			pager: models.Pager{Count: 10, Offset: 0},
+			setupMock: func(mockRepo *mocks.MockActorRepo, mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Costars(actorID).Return(nil, false)
+				mockRepo.EXPECT().GetActorByID(gomock.Any(), actorID).Return(models.Actor{}, actors.ErrorNotFound)
+			},
+			errorType: actors.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockActorRepo(ctrl)
+			mockGraph := mocks.NewMockCostarGraph(ctrl)
+			tt.setupMock(mockRepo, mockGraph)
+
+			usecase := NewActorUsecase(mockRepo, mockGraph)
+			result, err := usecase.GetCostars(testContext(), actorID, tt.pager)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestActorUsecase_GetCostarPath(t *testing.T) {
+	from := uuid.NewV4()
+	to := uuid.NewV4()
+	path := models.CostarPath{
+		Degrees: 1,
+		Actors:  []models.CostarActor{{ID: from}, {ID: to}},
+		Films:   []models.CostarFilm{{ID: uuid.NewV4(), Title: "Пираты Карибского моря"}},
+	}
+
+	tests := []struct {
+		name      string
+		maxDepth  int
+		setupMock func(mockGraph *mocks.MockCostarGraph)
+		errorType error
+	}{
+		{
+			name:     "Default depth",
+			maxDepth: 0,
+			setupMock: func(mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Path(from, to, defaultPathDepth).Return(path, true)
+			},
+		},
+		{
+			name:     "Custom depth",
+			maxDepth: maxPathDepth,
+			setupMock: func(mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Path(from, to, maxPathDepth).Return(path, true)
+			},
+		},
+		{
+			name:      "Depth too large",
+			maxDepth:  maxPathDepth + 1,
+			setupMock: func(mockGraph *mocks.MockCostarGraph) {},
+			errorType: actors.ErrorBadRequest,
+		},
+		{
+			name:      "Negative depth",
+			maxDepth:  -1,
+			setupMock: func(mockGraph *mocks.MockCostarGraph) {},
+			errorType: actors.ErrorBadRequest,
+		},
+		{
+			name:     "No path",
+			maxDepth: 2,
+			setupMock: func(mockGraph *mocks.MockCostarGraph) {
+				mockGraph.EXPECT().Path(from, to, 2).Return(models.CostarPath{}, false)
+			},
+			errorType: actors.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGraph := mocks.NewMockCostarGraph(ctrl)
+			tt.setupMock(mockGraph)
+
+			usecase := NewActorUsecase(mocks.NewMockActorRepo(ctrl), mockGraph)
+			result, err := usecase.GetCostarPath(testContext(), from, to, tt.maxDepth)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, path, result)
+		})
+	}
+}
@@ -0,0 +1,827 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+type AdminHandler struct {
+	uc admin.AdminUsecase
+}
+
+func NewAdminHandler(uc admin.AdminUsecase) *AdminHandler {
+	return &AdminHandler{uc: uc}
+}
+
+// Middleware пропускает дальше только редакторов и администраторов.
+// Должен стоять после auth middleware, который кладет пользователя в контекст
+func (a *AdminHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+		user, ok := r.Context().Value(auth.UserKey).(models.User)
+		if !ok {
+			log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
+			return
+		}
+
+		err := a.uc.CheckEditor(r.Context(), user.ID)
+		if err != nil {
+			problem.WriteError(w, r, logger, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func pathID(r *http.Request, name string) (uuid.UUID, error) {
+	return uuid.FromString(mux.Vars(r)[name])
+}
+
+func decodeInput(r *http.Request, dst interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(dst)
+	if err != nil {
+		return admin.NewValidationError(models.FieldError{Field: "body", Message: "invalid json"})
+	}
+	return nil
+}
+
+func updatedAtFromQuery(r *http.Request) (time.Time, error) {
+	value := r.URL.Query().Get("updated_at")
+	if value == "" {
+		return time.Time{}, admin.NewValidationError(models.FieldError{Field: "updated_at", Message: "is required"})
+	}
+	updatedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, admin.NewValidationError(models.FieldError{Field: "updated_at", Message: "must be RFC3339 timestamp"})
+	}
+	return updatedAt, nil
+}
+
+// CreateFilm godoc
+// @Summary Create film
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param input body models.FilmInput true "Film data"
+// @Success 200 {object} models.Film
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films [post]
+func (a *AdminHandler) CreateFilm(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.FilmInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	film, err := a.uc.CreateFilm(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	film.Sanitize()
+	helpers.WriteJSON(w, film)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UpdateFilm godoc
+// @Summary Update film
+// @Description updated_at must match the current value, otherwise 409 is returned
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Film ID"
+// @Param input body models.FilmInput true "Film data"
+// @Success 200 {object} models.Film
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id} [put]
+func (a *AdminHandler) UpdateFilm(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.FilmInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	film, err := a.uc.UpdateFilm(r.Context(), id, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	film.Sanitize()
+	helpers.WriteJSON(w, film)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// DeleteFilm godoc
+// @Summary Delete film
+// @Tags admin
+// @Param id path string true "Film ID"
+// @Param updated_at query string true "Current updated_at of the film (RFC3339)"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id} [delete]
+func (a *AdminHandler) DeleteFilm(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	updatedAt, err := updatedAtFromQuery(r)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	err = a.uc.DeleteFilm(r.Context(), id, updatedAt)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// CreateActor godoc
+// @Summary Create actor
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param input body models.ActorInput true "Actor data"
+// @Success 200 {object} models.Actor
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/actors [post]
+func (a *AdminHandler) CreateActor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.ActorInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	actor, err := a.uc.CreateActor(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	actor.Sanitize()
+	helpers.WriteJSON(w, actor)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UpdateActor godoc
+// @Summary Update actor
+// @Description updated_at must match the current value, otherwise 409 is returned
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Actor ID"
+// @Param input body models.ActorInput true "Actor data"
+// @Success 200 {object} models.Actor
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/actors/{id} [put]
+func (a *AdminHandler) UpdateActor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.ActorInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	actor, err := a.uc.UpdateActor(r.Context(), id, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	actor.Sanitize()
+	helpers.WriteJSON(w, actor)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// DeleteActor godoc
+// @Summary Delete actor
+// @Tags admin
+// @Param id path string true "Actor ID"
+// @Param updated_at query string true "Current updated_at of the actor (RFC3339)"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/actors/{id} [delete]
+func (a *AdminHandler) DeleteActor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	updatedAt, err := updatedAtFromQuery(r)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	err = a.uc.DeleteActor(r.Context(), id, updatedAt)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// CreateGenre godoc
+// @Summary Create genre
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param input body models.GenreInput true "Genre data"
+// @Success 200 {object} models.Genre
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/genres [post]
+func (a *AdminHandler) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.GenreInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	genre, err := a.uc.CreateGenre(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	genre.Sanitize()
+	helpers.WriteJSON(w, genre)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UpdateGenre godoc
+// @Summary Update genre
+// @Description updated_at must match the current value, otherwise 409 is returned
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Genre ID"
+// @Param input body models.GenreInput true "Genre data"
+// @Success 200 {object} models.Genre
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/genres/{id} [put]
+func (a *AdminHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of genre"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.GenreInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	genre, err := a.uc.UpdateGenre(r.Context(), id, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	genre.Sanitize()
+	helpers.WriteJSON(w, genre)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// DeleteGenre godoc
+// @Summary Delete genre
+// @Description Genres used by films cannot be deleted (409)
+// @Tags admin
+// @Param id path string true "Genre ID"
+// @Param updated_at query string true "Current updated_at of the genre (RFC3339)"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/genres/{id} [delete]
+func (a *AdminHandler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of genre"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	updatedAt, err := updatedAtFromQuery(r)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	err = a.uc.DeleteGenre(r.Context(), id, updatedAt)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// CreateCountry godoc
+// @Summary Create country
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param input body models.CountryInput true "Country data"
+// @Success 200 {object} models.Country
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/countries [post]
+func (a *AdminHandler) CreateCountry(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.CountryInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	country, err := a.uc.CreateCountry(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	country.Sanitize()
+	helpers.WriteJSON(w, country)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UpdateCountry godoc
+// @Summary Update country
+// @Description updated_at must match the current value, otherwise 409 is returned
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Country ID"
+// @Param input body models.CountryInput true "Country data"
+// @Success 200 {object} models.Country
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/countries/{id} [put]
+func (a *AdminHandler) UpdateCountry(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.CountryInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	country, err := a.uc.UpdateCountry(r.Context(), id, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	country.Sanitize()
+	helpers.WriteJSON(w, country)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// DeleteCountry godoc
+// @Summary Delete country
+// @Description Countries used by films cannot be deleted (409)
+// @Tags admin
+// @Param id path string true "Country ID"
+// @Param updated_at query string true "Current updated_at of the country (RFC3339)"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/countries/{id} [delete]
+func (a *AdminHandler) DeleteCountry(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	updatedAt, err := updatedAtFromQuery(r)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	err = a.uc.DeleteCountry(r.Context(), id, updatedAt)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// AddActorToFilm godoc
+// @Summary Link person to film in one of crew roles
+// @Description role defaults to actor; character is allowed only for actors
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Film ID"
+// @Param input body models.ActorInFilmInput true "Actor role data"
+// @Success 200 {object} models.ActorInFilm
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/actors [post]
+func (a *AdminHandler) AddActorToFilm(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.ActorInFilmInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	actorInFilm, err := a.uc.AddActorToFilm(r.Context(), filmID, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	actorInFilm.Sanitize()
+	helpers.WriteJSON(w, actorInFilm)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UpdateActorInFilm godoc
+// @Summary Update person role in film
+// @Description role in body selects the link; updated_at must match the current value, otherwise 409 is returned
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Film ID"
+// @Param actor_id path string true "Actor ID"
+// @Param input body models.ActorInFilmInput true "Actor role data"
+// @Success 200 {object} models.ActorInFilm
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/actors/{actor_id} [put]
+func (a *AdminHandler) UpdateActorInFilm(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	actorID, err := pathID(r, "actor_id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.ActorInFilmInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	req.ActorID = actorID
+
+	actorInFilm, err := a.uc.UpdateActorInFilm(r.Context(), filmID, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	actorInFilm.Sanitize()
+	helpers.WriteJSON(w, actorInFilm)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// RemoveActorFromFilm godoc
+// @Summary Unlink person from film
+// @Tags admin
+// @Param id path string true "Film ID"
+// @Param actor_id path string true "Actor ID"
+// @Param role query string false "Crew role" default(actor)
+// @Param updated_at query string true "Current updated_at of the link (RFC3339)"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/actors/{actor_id} [delete]
+func (a *AdminHandler) RemoveActorFromFilm(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	actorID, err := pathID(r, "actor_id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of actor"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	updatedAt, err := updatedAtFromQuery(r)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	err = a.uc.RemoveActorFromFilm(r.Context(), filmID, actorID, r.URL.Query().Get("role"), updatedAt)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UploadFilmMedia godoc
+// @Summary Upload film image
+// @Description The image is appended to the end of the film gallery and stored as is. Sides must be at most 10000 px
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Film ID"
+// @Param file formData file true "Image file (max 20MB, formats: jpg, png, webp)"
+// @Param type formData string true "Media type (still, poster, backdrop)"
+// @Param caption formData string false "Caption"
+// @Success 200 {object} models.FilmMedia
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/media [post]
+func (a *AdminHandler) UploadFilmMedia(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	// запас сверху на поля формы и границы multipart
+	const maxRequestBodySize = admin.MaxFilmImageBytes + 1024*1024
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	if err := r.ParseMultipartForm(maxRequestBodySize); err != nil {
+		if errors.As(err, new(*http.MaxBytesError)) {
+			log.LogHandlerError(logger, errors.New("file is too large"), http.StatusRequestEntityTooLarge)
+			problem.WriteStatus(w, r, http.StatusRequestEntityTooLarge)
+			return
+		}
+		log.LogHandlerError(logger, err, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	defer func() {
+		_ = r.MultipartForm.RemoveAll()
+	}()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		problem.WriteError(w, r, logger, admin.NewValidationError(models.FieldError{Field: "file", Message: "is required"}))
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("failed to read file"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	req := models.FilmMediaInput{Type: r.FormValue("type")}
+	if _, ok := r.MultipartForm.Value["caption"]; ok {
+		caption := r.FormValue("caption")
+		req.Caption = &caption
+	}
+
+	media, err := a.uc.UploadFilmMedia(r.Context(), filmID, req, data)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	media.Sanitize()
+	helpers.WriteJSON(w, media)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// AddFilmTrailer godoc
+// @Summary Add film trailer
+// @Description The trailer is appended to the end of the film gallery
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Film ID"
+// @Param input body models.FilmTrailerInput true "Trailer link (absolute http or https URL)"
+// @Success 200 {object} models.FilmMedia
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/media/trailer [post]
+func (a *AdminHandler) AddFilmTrailer(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.FilmTrailerInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	media, err := a.uc.AddFilmTrailer(r.Context(), filmID, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	media.Sanitize()
+	helpers.WriteJSON(w, media)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ReorderFilmMedia godoc
+// @Summary Reorder film media
+// @Description ids must list every media of the film exactly once, in the new order
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Film ID"
+// @Param input body models.FilmMediaOrderInput true "Media ids in the new order"
+// @Success 200 {array} models.FilmMedia
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/media/order [put]
+func (a *AdminHandler) ReorderFilmMedia(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	var req models.FilmMediaOrderInput
+	if err := decodeInput(r, &req); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	media, err := a.uc.ReorderFilmMedia(r.Context(), filmID, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	for i := range media {
+		media[i].Sanitize()
+	}
+	helpers.WriteJSON(w, media)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// DeleteFilmMedia godoc
+// @Summary Delete film media
+// @Description Uploaded image files are removed from the storage as well
+// @Tags admin
+// @Param id path string true "Film ID"
+// @Param media_id path string true "Media ID"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/films/{id}/media/{media_id} [delete]
+func (a *AdminHandler) DeleteFilmMedia(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := pathID(r, "id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	mediaID, err := pathID(r, "media_id")
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of media"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	err = a.uc.DeleteFilmMedia(r.Context(), filmID, mediaID)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
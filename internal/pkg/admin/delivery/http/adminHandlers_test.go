@@ -0,0 +1,477 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/admin/mocks"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAdminUsecase(ctrl)
+	handler := NewAdminHandler(mockUsecase)
+
+	user := models.User{ID: uuid.NewV4(), Login: "editor"}
+
+	tests := []struct {
+		name           string
+		withUser       bool
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:     "Editor passes",
+			withUser: true,
+			mockSetup: func() {
+				mockUsecase.EXPECT().CheckEditor(gomock.Any(), user.ID).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "Regular user forbidden",
+			withUser: true,
+			mockSetup: func() {
+				mockUsecase.EXPECT().CheckEditor(gomock.Any(), user.ID).Return(admin.ErrorForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:     "Editor without two factor forbidden",
+			withUser: true,
+			mockSetup: func() {
+				mockUsecase.EXPECT().CheckEditor(gomock.Any(), user.ID).Return(admin.ErrorTwoFactorRequired)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "No user in context",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:     "Internal error",
+			withUser: true,
+			mockSetup: func() {
+				mockUsecase.EXPECT().CheckEditor(gomock.Any(), user.ID).Return(errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			ctx := testContext()
+			if tt.withUser {
+				ctx = context.WithValue(ctx, auth.UserKey, user)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/admin/films", nil).WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			handler.Middleware(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestCreateFilm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAdminUsecase(ctrl)
+	handler := NewAdminHandler(mockUsecase)
+
+	input := models.FilmInput{Title: "Дюна", Year: 2021, Duration: 155}
+	film := models.Film{ID: uuid.NewV4(), Title: "Дюна", Year: 2021, Duration: 155}
+
+	tests := []struct {
+		name           string
+		body           []byte
+		mockSetup      func()
+		expectedStatus int
+		expectedFields []string
+	}{
+		{
+			name: "Success",
+			body: func() []byte { b, _ := json.Marshal(input); return b }(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateFilm(gomock.Any(), input).Return(film, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid json",
+			body:           []byte("{"),
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"body"},
+		},
+		{
+			name: "Validation error",
+			body: func() []byte { b, _ := json.Marshal(input); return b }(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateFilm(gomock.Any(), input).Return(models.Film{}, admin.NewValidationError(
+					models.FieldError{Field: "cover", Message: "is required"},
+					models.FieldError{Field: "genre_id", Message: "is required"},
+				))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []string{"cover", "genre_id"},
+		},
+		{
+			name: "Internal error",
+			body: func() []byte { b, _ := json.Marshal(input); return b }(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().CreateFilm(gomock.Any(), input).Return(models.Film{}, admin.ErrorInternalServerError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/films", bytes.NewReader(tt.body)).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			handler.CreateFilm(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedFields != nil {
+				var decoded models.Problem
+				err := json.Unmarshal(rec.Body.Bytes(), &decoded)
+				assert.NoError(t, err)
+				assert.Equal(t, "validation_failed", decoded.Code)
+				fields := make([]string, 0, len(decoded.Errors))
+				for _, fieldErr := range decoded.Errors {
+					fields = append(fields, fieldErr.Field)
+				}
+				assert.Equal(t, tt.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestUpdateGenre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAdminUsecase(ctrl)
+	handler := NewAdminHandler(mockUsecase)
+
+	genreID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	description, icon := "Драмы", "/icons/drama.png"
+	input := models.GenreInput{Title: "Драма", Description: &description, Icon: &icon, UpdatedAt: &updatedAt}
+	body, _ := json.Marshal(input)
+
+	tests := []struct {
+		name           string
+		varsID         string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			varsID: genreID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().
+					UpdateGenre(gomock.Any(), genreID, gomock.Any()).
+					Return(models.Genre{ID: genreID, Title: "Драма"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			varsID:         "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Stale version",
+			varsID: genreID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().
+					UpdateGenre(gomock.Any(), genreID, gomock.Any()).
+					Return(models.Genre{}, admin.ErrorConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "Not found",
+			varsID: genreID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().
+					UpdateGenre(gomock.Any(), genreID, gomock.Any()).
+					Return(models.Genre{}, admin.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/genres/"+tt.varsID, bytes.NewReader(body)).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/admin/genres/{id}", handler.UpdateGenre)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestRemoveActorFromFilm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAdminUsecase(ctrl)
+	handler := NewAdminHandler(mockUsecase)
+
+	filmID := uuid.NewV4()
+	actorID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 123000, time.UTC)
+	baseURL := "/admin/films/" + filmID.String() + "/actors/" + actorID.String()
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  baseURL + "?updated_at=" + updatedAt.Format(time.RFC3339Nano),
+			mockSetup: func() {
+				mockUsecase.EXPECT().RemoveActorFromFilm(gomock.Any(), filmID, actorID, "", updatedAt).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Crew role from query",
+			url:  baseURL + "?role=director&updated_at=" + updatedAt.Format(time.RFC3339Nano),
+			mockSetup: func() {
+				mockUsecase.EXPECT().RemoveActorFromFilm(gomock.Any(), filmID, actorID, models.CrewRoleDirector, updatedAt).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing updated_at",
+			url:            baseURL,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Malformed updated_at",
+			url:            baseURL + "?updated_at=yesterday",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Stale version",
+			url:  baseURL + "?updated_at=" + updatedAt.Format(time.RFC3339Nano),
+			mockSetup: func() {
+				mockUsecase.EXPECT().RemoveActorFromFilm(gomock.Any(), filmID, actorID, "", updatedAt).Return(admin.ErrorConflict)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodDelete, tt.url, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/admin/films/{id}/actors/{actor_id}", handler.RemoveActorFromFilm)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func multipartBody(t *testing.T, fields map[string]string, file []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if file != nil {
+		part, err := writer.CreateFormFile("file", "still.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = part.Write(file)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return body, writer.FormDataContentType()
+}
+
+func TestUploadFilmMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAdminUsecase(ctrl)
+	handler := NewAdminHandler(mockUsecase)
+
+	filmID := uuid.NewV4()
+	file := []byte("image bytes")
+	caption := "Кадр"
+
+	tests := []struct {
+		name           string
+		fields         map[string]string
+		file           []byte
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			fields: map[string]string{"type": models.FilmMediaStill, "caption": caption},
+			file:   file,
+			mockSetup: func() {
+				mockUsecase.EXPECT().UploadFilmMedia(gomock.Any(), filmID, models.FilmMediaInput{Type: models.FilmMediaStill, Caption: &caption}, file).
+					Return(models.FilmMedia{ID: uuid.NewV4(), Type: models.FilmMediaStill}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing file",
+			fields:         map[string]string{"type": models.FilmMediaStill},
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Film not found",
+			fields: map[string]string{"type": models.FilmMediaPoster},
+			file:   file,
+			mockSetup: func() {
+				mockUsecase.EXPECT().UploadFilmMedia(gomock.Any(), filmID, models.FilmMediaInput{Type: models.FilmMediaPoster}, file).
+					Return(models.FilmMedia{}, admin.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			body, contentType := multipartBody(t, tt.fields, tt.file)
+			req := httptest.NewRequest(http.MethodPost, "/admin/films/"+filmID.String()+"/media", body).WithContext(testContext())
+			req.Header.Set("Content-Type", contentType)
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/admin/films/{id}/media", handler.UploadFilmMedia)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestReorderFilmMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockAdminUsecase(ctrl)
+	handler := NewAdminHandler(mockUsecase)
+
+	filmID := uuid.NewV4()
+	ids := []uuid.UUID{uuid.NewV4(), uuid.NewV4()}
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: `{"ids":["` + ids[0].String() + `","` + ids[1].String() + `"]}`,
+			mockSetup: func() {
+				mockUsecase.EXPECT().ReorderFilmMedia(gomock.Any(), filmID, models.FilmMediaOrderInput{IDs: ids}).
+					Return([]models.FilmMedia{{ID: ids[0]}, {ID: ids[1], Position: 1}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid json",
+			body:           `{"ids":`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Incomplete list",
+			body: `{"ids":["` + ids[0].String() + `"]}`,
+			mockSetup: func() {
+				mockUsecase.EXPECT().ReorderFilmMedia(gomock.Any(), filmID, models.FilmMediaOrderInput{IDs: ids[:1]}).
+					Return(nil, admin.NewValidationError(models.FieldError{Field: "ids", Message: "must list every media of the film exactly once"}))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/films/"+filmID.String()+"/media/order", bytes.NewBufferString(tt.body)).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/admin/films/{id}/media/order", handler.ReorderFilmMedia)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
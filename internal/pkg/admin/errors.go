@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"kinopoisk/internal/models"
+)
+
+var (
+	ErrorBadRequest          = errors.New("bad request")
+	ErrorNotFound            = errors.New("not found")
+	ErrorConflict            = errors.New("entity was modified or is still referenced")
+	ErrorUnauthorized        = errors.New("user is unauthorized")
+	ErrorForbidden           = errors.New("user has no editor rights")
+	ErrorInternalServerError = errors.New("internal server error")
+
+	// ErrorTwoFactorRequired редактор без второго фактора не получает доступ к админке
+	ErrorTwoFactorRequired = fmt.Errorf("two factor is not enabled: %w", ErrorForbidden)
+)
+
+// ValidationError описывает ошибки валидации по полям входных данных
+type ValidationError struct {
+	Fields []models.FieldError
+}
+
+func NewValidationError(fields ...models.FieldError) *ValidationError {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	return "validation failed"
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrorBadRequest
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type AdminUsecase interface {
+	CheckEditor(ctx context.Context, userID uuid.UUID) error
+
+	CreateFilm(ctx context.Context, req models.FilmInput) (models.Film, error)
+	UpdateFilm(ctx context.Context, id uuid.UUID, req models.FilmInput) (models.Film, error)
+	DeleteFilm(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateActor(ctx context.Context, req models.ActorInput) (models.Actor, error)
+	UpdateActor(ctx context.Context, id uuid.UUID, req models.ActorInput) (models.Actor, error)
+	DeleteActor(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateGenre(ctx context.Context, req models.GenreInput) (models.Genre, error)
+	UpdateGenre(ctx context.Context, id uuid.UUID, req models.GenreInput) (models.Genre, error)
+	DeleteGenre(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateCountry(ctx context.Context, req models.CountryInput) (models.Country, error)
+	UpdateCountry(ctx context.Context, id uuid.UUID, req models.CountryInput) (models.Country, error)
+	DeleteCountry(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	AddActorToFilm(ctx context.Context, filmID uuid.UUID, req models.ActorInFilmInput) (models.ActorInFilm, error)
+	UpdateActorInFilm(ctx context.Context, filmID uuid.UUID, req models.ActorInFilmInput) (models.ActorInFilm, error)
+	RemoveActorFromFilm(ctx context.Context, filmID, actorID uuid.UUID, role string, updatedAt time.Time) error
+
+	UploadFilmMedia(ctx context.Context, filmID uuid.UUID, req models.FilmMediaInput, data []byte) (models.FilmMedia, error)
+	AddFilmTrailer(ctx context.Context, filmID uuid.UUID, req models.FilmTrailerInput) (models.FilmMedia, error)
+	ReorderFilmMedia(ctx context.Context, filmID uuid.UUID, req models.FilmMediaOrderInput) ([]models.FilmMedia, error)
+	DeleteFilmMedia(ctx context.Context, filmID, mediaID uuid.UUID) error
+}
+
+type AdminRepo interface {
+	GetUserAccess(ctx context.Context, userID uuid.UUID) (role string, twoFactor bool, err error)
+
+	CreateFilm(ctx context.Context, film models.Film) (models.Film, error)
+	UpdateFilm(ctx context.Context, film models.Film, updatedAt time.Time) (models.Film, error)
+	DeleteFilm(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateActor(ctx context.Context, actor models.Actor) (models.Actor, error)
+	UpdateActor(ctx context.Context, actor models.Actor, updatedAt time.Time) (models.Actor, error)
+	DeleteActor(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateGenre(ctx context.Context, genre models.Genre) (models.Genre, error)
+	UpdateGenre(ctx context.Context, genre models.Genre, updatedAt time.Time) (models.Genre, error)
+	DeleteGenre(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateCountry(ctx context.Context, country models.Country) (models.Country, error)
+	UpdateCountry(ctx context.Context, country models.Country, updatedAt time.Time) (models.Country, error)
+	DeleteCountry(ctx context.Context, id uuid.UUID, updatedAt time.Time) error
+
+	CreateActorInFilm(ctx context.Context, aif models.ActorInFilm) (models.ActorInFilm, error)
+	UpdateActorInFilm(ctx context.Context, aif models.ActorInFilm, updatedAt time.Time) (models.ActorInFilm, error)
+	DeleteActorInFilm(ctx context.Context, filmID, actorID uuid.UUID, role string, updatedAt time.Time) error
+
+	CreateFilmMedia(ctx context.Context, filmID uuid.UUID, media models.FilmMedia) (models.FilmMedia, error)
+	GetFilmMedia(ctx context.Context, filmID uuid.UUID) ([]models.FilmMedia, error)
+	ReorderFilmMedia(ctx context.Context, filmID uuid.UUID, ids []uuid.UUID) (int64, error)
+	DeleteFilmMedia(ctx context.Context, filmID, mediaID uuid.UUID) (models.FilmMedia, error)
+}
+
+// MediaStorage хранит загруженные картинки фильмов; путь возвращается в том виде, в каком он пишется в film_media.url
+type MediaStorage interface {
+	UploadFilmImage(ctx context.Context, filmID, mediaID uuid.UUID, contentType string, data []byte) (string, error)
+	DeleteFilmImage(ctx context.Context, imagePath string) error
+}
+
+// CatalogueObserver получает сигнал после каждого успешного изменения каталога
+type CatalogueObserver interface {
+	CatalogueChanged()
+}
@@ -0,0 +1,746 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/admin/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/admin/interfaces.go -destination=internal/pkg/admin/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/satori/go.uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminUsecase is a mock of AdminUsecase interface.
+type MockAdminUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminUsecaseMockRecorder is the mock recorder for MockAdminUsecase.
+type MockAdminUsecaseMockRecorder struct {
+	mock *MockAdminUsecase
+}
+
+// NewMockAdminUsecase creates a new mock instance.
+func NewMockAdminUsecase(ctrl *gomock.Controller) *MockAdminUsecase {
+	mock := &MockAdminUsecase{ctrl: ctrl}
+	mock.recorder = &MockAdminUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminUsecase) EXPECT() *MockAdminUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddActorToFilm mocks base method.
+func (m *MockAdminUsecase) AddActorToFilm(ctx context.Context, filmID uuid.UUID, req models.ActorInFilmInput) (models.ActorInFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddActorToFilm", ctx, filmID, req)
+	ret0, _ := ret[0].(models.ActorInFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddActorToFilm indicates an expected call of AddActorToFilm.
+func (mr *MockAdminUsecaseMockRecorder) AddActorToFilm(ctx, filmID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActorToFilm", reflect.TypeOf((*MockAdminUsecase)(nil).AddActorToFilm), ctx, filmID, req)
+}
+
+// AddFilmTrailer mocks base method.
+func (m *MockAdminUsecase) AddFilmTrailer(ctx context.Context, filmID uuid.UUID, req models.FilmTrailerInput) (models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmTrailer", ctx, filmID, req)
+	ret0, _ := ret[0].(models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFilmTrailer indicates an expected call of AddFilmTrailer.
+func (mr *MockAdminUsecaseMockRecorder) AddFilmTrailer(ctx, filmID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmTrailer", reflect.TypeOf((*MockAdminUsecase)(nil).AddFilmTrailer), ctx, filmID, req)
+}
+
+// CheckEditor mocks base method.
+func (m *MockAdminUsecase) CheckEditor(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckEditor", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckEditor indicates an expected call of CheckEditor.
+func (mr *MockAdminUsecaseMockRecorder) CheckEditor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEditor", reflect.TypeOf((*MockAdminUsecase)(nil).CheckEditor), ctx, userID)
+}
+
+// CreateActor mocks base method.
+func (m *MockAdminUsecase) CreateActor(ctx context.Context, req models.ActorInput) (models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActor", ctx, req)
+	ret0, _ := ret[0].(models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActor indicates an expected call of CreateActor.
+func (mr *MockAdminUsecaseMockRecorder) CreateActor(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockAdminUsecase)(nil).CreateActor), ctx, req)
+}
+
+// CreateCountry mocks base method.
+func (m *MockAdminUsecase) CreateCountry(ctx context.Context, req models.CountryInput) (models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCountry", ctx, req)
+	ret0, _ := ret[0].(models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCountry indicates an expected call of CreateCountry.
+func (mr *MockAdminUsecaseMockRecorder) CreateCountry(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCountry", reflect.TypeOf((*MockAdminUsecase)(nil).CreateCountry), ctx, req)
+}
+
+// CreateFilm mocks base method.
+func (m *MockAdminUsecase) CreateFilm(ctx context.Context, req models.FilmInput) (models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilm", ctx, req)
+	ret0, _ := ret[0].(models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFilm indicates an expected call of CreateFilm.
+func (mr *MockAdminUsecaseMockRecorder) CreateFilm(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockAdminUsecase)(nil).CreateFilm), ctx, req)
+}
+
+// CreateGenre mocks base method.
+func (m *MockAdminUsecase) CreateGenre(ctx context.Context, req models.GenreInput) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, req)
+	ret0, _ := ret[0].(models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockAdminUsecaseMockRecorder) CreateGenre(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockAdminUsecase)(nil).CreateGenre), ctx, req)
+}
+
+// DeleteActor mocks base method.
+func (m *MockAdminUsecase) DeleteActor(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActor indicates an expected call of DeleteActor.
+func (mr *MockAdminUsecaseMockRecorder) DeleteActor(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockAdminUsecase)(nil).DeleteActor), ctx, id, updatedAt)
+}
+
+// DeleteCountry mocks base method.
+func (m *MockAdminUsecase) DeleteCountry(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCountry", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCountry indicates an expected call of DeleteCountry.
+func (mr *MockAdminUsecaseMockRecorder) DeleteCountry(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCountry", reflect.TypeOf((*MockAdminUsecase)(nil).DeleteCountry), ctx, id, updatedAt)
+}
+
+// DeleteFilm mocks base method.
+func (m *MockAdminUsecase) DeleteFilm(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilm", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilm indicates an expected call of DeleteFilm.
+func (mr *MockAdminUsecaseMockRecorder) DeleteFilm(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockAdminUsecase)(nil).DeleteFilm), ctx, id, updatedAt)
+}
+
+// DeleteFilmMedia mocks base method.
+func (m *MockAdminUsecase) DeleteFilmMedia(ctx context.Context, filmID, mediaID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmMedia", ctx, filmID, mediaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmMedia indicates an expected call of DeleteFilmMedia.
+func (mr *MockAdminUsecaseMockRecorder) DeleteFilmMedia(ctx, filmID, mediaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmMedia", reflect.TypeOf((*MockAdminUsecase)(nil).DeleteFilmMedia), ctx, filmID, mediaID)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockAdminUsecase) DeleteGenre(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockAdminUsecaseMockRecorder) DeleteGenre(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockAdminUsecase)(nil).DeleteGenre), ctx, id, updatedAt)
+}
+
+// RemoveActorFromFilm mocks base method.
+func (m *MockAdminUsecase) RemoveActorFromFilm(ctx context.Context, filmID, actorID uuid.UUID, role string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveActorFromFilm", ctx, filmID, actorID, role, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveActorFromFilm indicates an expected call of RemoveActorFromFilm.
+func (mr *MockAdminUsecaseMockRecorder) RemoveActorFromFilm(ctx, filmID, actorID, role, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActorFromFilm", reflect.TypeOf((*MockAdminUsecase)(nil).RemoveActorFromFilm), ctx, filmID, actorID, role, updatedAt)
+}
+
+// ReorderFilmMedia mocks base method.
+func (m *MockAdminUsecase) ReorderFilmMedia(ctx context.Context, filmID uuid.UUID, req models.FilmMediaOrderInput) ([]models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderFilmMedia", ctx, filmID, req)
+	ret0, _ := ret[0].([]models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderFilmMedia indicates an expected call of ReorderFilmMedia.
+func (mr *MockAdminUsecaseMockRecorder) ReorderFilmMedia(ctx, filmID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderFilmMedia", reflect.TypeOf((*MockAdminUsecase)(nil).ReorderFilmMedia), ctx, filmID, req)
+}
+
+// UpdateActor mocks base method.
+func (m *MockAdminUsecase) UpdateActor(ctx context.Context, id uuid.UUID, req models.ActorInput) (models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActor", ctx, id, req)
+	ret0, _ := ret[0].(models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActor indicates an expected call of UpdateActor.
+func (mr *MockAdminUsecaseMockRecorder) UpdateActor(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockAdminUsecase)(nil).UpdateActor), ctx, id, req)
+}
+
+// UpdateActorInFilm mocks base method.
+func (m *MockAdminUsecase) UpdateActorInFilm(ctx context.Context, filmID uuid.UUID, req models.ActorInFilmInput) (models.ActorInFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActorInFilm", ctx, filmID, req)
+	ret0, _ := ret[0].(models.ActorInFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActorInFilm indicates an expected call of UpdateActorInFilm.
+func (mr *MockAdminUsecaseMockRecorder) UpdateActorInFilm(ctx, filmID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorInFilm", reflect.TypeOf((*MockAdminUsecase)(nil).UpdateActorInFilm), ctx, filmID, req)
+}
+
+// UpdateCountry mocks base method.
+func (m *MockAdminUsecase) UpdateCountry(ctx context.Context, id uuid.UUID, req models.CountryInput) (models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCountry", ctx, id, req)
+	ret0, _ := ret[0].(models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCountry indicates an expected call of UpdateCountry.
+func (mr *MockAdminUsecaseMockRecorder) UpdateCountry(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCountry", reflect.TypeOf((*MockAdminUsecase)(nil).UpdateCountry), ctx, id, req)
+}
+
+// UpdateFilm mocks base method.
+func (m *MockAdminUsecase) UpdateFilm(ctx context.Context, id uuid.UUID, req models.FilmInput) (models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilm", ctx, id, req)
+	ret0, _ := ret[0].(models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFilm indicates an expected call of UpdateFilm.
+func (mr *MockAdminUsecaseMockRecorder) UpdateFilm(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockAdminUsecase)(nil).UpdateFilm), ctx, id, req)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockAdminUsecase) UpdateGenre(ctx context.Context, id uuid.UUID, req models.GenreInput) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, id, req)
+	ret0, _ := ret[0].(models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockAdminUsecaseMockRecorder) UpdateGenre(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockAdminUsecase)(nil).UpdateGenre), ctx, id, req)
+}
+
+// UploadFilmMedia mocks base method.
+func (m *MockAdminUsecase) UploadFilmMedia(ctx context.Context, filmID uuid.UUID, req models.FilmMediaInput, data []byte) (models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFilmMedia", ctx, filmID, req, data)
+	ret0, _ := ret[0].(models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFilmMedia indicates an expected call of UploadFilmMedia.
+func (mr *MockAdminUsecaseMockRecorder) UploadFilmMedia(ctx, filmID, req, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFilmMedia", reflect.TypeOf((*MockAdminUsecase)(nil).UploadFilmMedia), ctx, filmID, req, data)
+}
+
+// MockAdminRepo is a mock of AdminRepo interface.
+type MockAdminRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminRepoMockRecorder is the mock recorder for MockAdminRepo.
+type MockAdminRepoMockRecorder struct {
+	mock *MockAdminRepo
+}
+
+// NewMockAdminRepo creates a new mock instance.
+func NewMockAdminRepo(ctrl *gomock.Controller) *MockAdminRepo {
+	mock := &MockAdminRepo{ctrl: ctrl}
+	mock.recorder = &MockAdminRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminRepo) EXPECT() *MockAdminRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateActor mocks base method.
+func (m *MockAdminRepo) CreateActor(ctx context.Context, actor models.Actor) (models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActor", ctx, actor)
+	ret0, _ := ret[0].(models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActor indicates an expected call of CreateActor.
+func (mr *MockAdminRepoMockRecorder) CreateActor(ctx, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActor", reflect.TypeOf((*MockAdminRepo)(nil).CreateActor), ctx, actor)
+}
+
+// CreateActorInFilm mocks base method.
+func (m *MockAdminRepo) CreateActorInFilm(ctx context.Context, aif models.ActorInFilm) (models.ActorInFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActorInFilm", ctx, aif)
+	ret0, _ := ret[0].(models.ActorInFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActorInFilm indicates an expected call of CreateActorInFilm.
+func (mr *MockAdminRepoMockRecorder) CreateActorInFilm(ctx, aif any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActorInFilm", reflect.TypeOf((*MockAdminRepo)(nil).CreateActorInFilm), ctx, aif)
+}
+
+// CreateCountry mocks base method.
+func (m *MockAdminRepo) CreateCountry(ctx context.Context, country models.Country) (models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCountry", ctx, country)
+	ret0, _ := ret[0].(models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCountry indicates an expected call of CreateCountry.
+func (mr *MockAdminRepoMockRecorder) CreateCountry(ctx, country any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCountry", reflect.TypeOf((*MockAdminRepo)(nil).CreateCountry), ctx, country)
+}
+
+// CreateFilm mocks base method.
+func (m *MockAdminRepo) CreateFilm(ctx context.Context, film models.Film) (models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilm", ctx, film)
+	ret0, _ := ret[0].(models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFilm indicates an expected call of CreateFilm.
+func (mr *MockAdminRepoMockRecorder) CreateFilm(ctx, film any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilm", reflect.TypeOf((*MockAdminRepo)(nil).CreateFilm), ctx, film)
+}
+
+// CreateFilmMedia mocks base method.
+func (m *MockAdminRepo) CreateFilmMedia(ctx context.Context, filmID uuid.UUID, media models.FilmMedia) (models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFilmMedia", ctx, filmID, media)
+	ret0, _ := ret[0].(models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFilmMedia indicates an expected call of CreateFilmMedia.
+func (mr *MockAdminRepoMockRecorder) CreateFilmMedia(ctx, filmID, media any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFilmMedia", reflect.TypeOf((*MockAdminRepo)(nil).CreateFilmMedia), ctx, filmID, media)
+}
+
+// CreateGenre mocks base method.
+func (m *MockAdminRepo) CreateGenre(ctx context.Context, genre models.Genre) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGenre", ctx, genre)
+	ret0, _ := ret[0].(models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGenre indicates an expected call of CreateGenre.
+func (mr *MockAdminRepoMockRecorder) CreateGenre(ctx, genre any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGenre", reflect.TypeOf((*MockAdminRepo)(nil).CreateGenre), ctx, genre)
+}
+
+// DeleteActor mocks base method.
+func (m *MockAdminRepo) DeleteActor(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActor", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActor indicates an expected call of DeleteActor.
+func (mr *MockAdminRepoMockRecorder) DeleteActor(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockAdminRepo)(nil).DeleteActor), ctx, id, updatedAt)
+}
+
+// DeleteActorInFilm mocks base method.
+func (m *MockAdminRepo) DeleteActorInFilm(ctx context.Context, filmID, actorID uuid.UUID, role string, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActorInFilm", ctx, filmID, actorID, role, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActorInFilm indicates an expected call of DeleteActorInFilm.
+func (mr *MockAdminRepoMockRecorder) DeleteActorInFilm(ctx, filmID, actorID, role, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActorInFilm", reflect.TypeOf((*MockAdminRepo)(nil).DeleteActorInFilm), ctx, filmID, actorID, role, updatedAt)
+}
+
+// DeleteCountry mocks base method.
+func (m *MockAdminRepo) DeleteCountry(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCountry", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCountry indicates an expected call of DeleteCountry.
+func (mr *MockAdminRepoMockRecorder) DeleteCountry(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCountry", reflect.TypeOf((*MockAdminRepo)(nil).DeleteCountry), ctx, id, updatedAt)
+}
+
+// DeleteFilm mocks base method.
+func (m *MockAdminRepo) DeleteFilm(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilm", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilm indicates an expected call of DeleteFilm.
+func (mr *MockAdminRepoMockRecorder) DeleteFilm(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockAdminRepo)(nil).DeleteFilm), ctx, id, updatedAt)
+}
+
+// DeleteFilmMedia mocks base method.
+func (m *MockAdminRepo) DeleteFilmMedia(ctx context.Context, filmID, mediaID uuid.UUID) (models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmMedia", ctx, filmID, mediaID)
+	ret0, _ := ret[0].(models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFilmMedia indicates an expected call of DeleteFilmMedia.
+func (mr *MockAdminRepoMockRecorder) DeleteFilmMedia(ctx, filmID, mediaID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmMedia", reflect.TypeOf((*MockAdminRepo)(nil).DeleteFilmMedia), ctx, filmID, mediaID)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockAdminRepo) DeleteGenre(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", ctx, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockAdminRepoMockRecorder) DeleteGenre(ctx, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockAdminRepo)(nil).DeleteGenre), ctx, id, updatedAt)
+}
+
+// GetFilmMedia mocks base method.
+func (m *MockAdminRepo) GetFilmMedia(ctx context.Context, filmID uuid.UUID) ([]models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmMedia", ctx, filmID)
+	ret0, _ := ret[0].([]models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmMedia indicates an expected call of GetFilmMedia.
+func (mr *MockAdminRepoMockRecorder) GetFilmMedia(ctx, filmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmMedia", reflect.TypeOf((*MockAdminRepo)(nil).GetFilmMedia), ctx, filmID)
+}
+
+// GetUserAccess mocks base method.
+func (m *MockAdminRepo) GetUserAccess(ctx context.Context, userID uuid.UUID) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAccess", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserAccess indicates an expected call of GetUserAccess.
+func (mr *MockAdminRepoMockRecorder) GetUserAccess(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAccess", reflect.TypeOf((*MockAdminRepo)(nil).GetUserAccess), ctx, userID)
+}
+
+// ReorderFilmMedia mocks base method.
+func (m *MockAdminRepo) ReorderFilmMedia(ctx context.Context, filmID uuid.UUID, ids []uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderFilmMedia", ctx, filmID, ids)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderFilmMedia indicates an expected call of ReorderFilmMedia.
+func (mr *MockAdminRepoMockRecorder) ReorderFilmMedia(ctx, filmID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderFilmMedia", reflect.TypeOf((*MockAdminRepo)(nil).ReorderFilmMedia), ctx, filmID, ids)
+}
+
+// UpdateActor mocks base method.
+func (m *MockAdminRepo) UpdateActor(ctx context.Context, actor models.Actor, updatedAt time.Time) (models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActor", ctx, actor, updatedAt)
+	ret0, _ := ret[0].(models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActor indicates an expected call of UpdateActor.
+func (mr *MockAdminRepoMockRecorder) UpdateActor(ctx, actor, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActor", reflect.TypeOf((*MockAdminRepo)(nil).UpdateActor), ctx, actor, updatedAt)
+}
+
+// UpdateActorInFilm mocks base method.
+func (m *MockAdminRepo) UpdateActorInFilm(ctx context.Context, aif models.ActorInFilm, updatedAt time.Time) (models.ActorInFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActorInFilm", ctx, aif, updatedAt)
+	ret0, _ := ret[0].(models.ActorInFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActorInFilm indicates an expected call of UpdateActorInFilm.
+func (mr *MockAdminRepoMockRecorder) UpdateActorInFilm(ctx, aif, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActorInFilm", reflect.TypeOf((*MockAdminRepo)(nil).UpdateActorInFilm), ctx, aif, updatedAt)
+}
+
+// UpdateCountry mocks base method.
+func (m *MockAdminRepo) UpdateCountry(ctx context.Context, country models.Country, updatedAt time.Time) (models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCountry", ctx, country, updatedAt)
+	ret0, _ := ret[0].(models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCountry indicates an expected call of UpdateCountry.
+func (mr *MockAdminRepoMockRecorder) UpdateCountry(ctx, country, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCountry", reflect.TypeOf((*MockAdminRepo)(nil).UpdateCountry), ctx, country, updatedAt)
+}
+
+// UpdateFilm mocks base method.
+func (m *MockAdminRepo) UpdateFilm(ctx context.Context, film models.Film, updatedAt time.Time) (models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilm", ctx, film, updatedAt)
+	ret0, _ := ret[0].(models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFilm indicates an expected call of UpdateFilm.
+func (mr *MockAdminRepoMockRecorder) UpdateFilm(ctx, film, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFilm", reflect.TypeOf((*MockAdminRepo)(nil).UpdateFilm), ctx, film, updatedAt)
+}
+
+// UpdateGenre mocks base method.
+func (m *MockAdminRepo) UpdateGenre(ctx context.Context, genre models.Genre, updatedAt time.Time) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", ctx, genre, updatedAt)
+	ret0, _ := ret[0].(models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockAdminRepoMockRecorder) UpdateGenre(ctx, genre, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockAdminRepo)(nil).UpdateGenre), ctx, genre, updatedAt)
+}
+
+// MockMediaStorage is a mock of MediaStorage interface.
+type MockMediaStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaStorageMockRecorder
+	isgomock struct{}
+}
+
+// MockMediaStorageMockRecorder is the mock recorder for MockMediaStorage.
+type MockMediaStorageMockRecorder struct {
+	mock *MockMediaStorage
+}
+
+// NewMockMediaStorage creates a new mock instance.
+func NewMockMediaStorage(ctrl *gomock.Controller) *MockMediaStorage {
+	mock := &MockMediaStorage{ctrl: ctrl}
+	mock.recorder = &MockMediaStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaStorage) EXPECT() *MockMediaStorageMockRecorder {
+	return m.recorder
+}
+
+// DeleteFilmImage mocks base method.
+func (m *MockMediaStorage) DeleteFilmImage(ctx context.Context, imagePath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmImage", ctx, imagePath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmImage indicates an expected call of DeleteFilmImage.
+func (mr *MockMediaStorageMockRecorder) DeleteFilmImage(ctx, imagePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmImage", reflect.TypeOf((*MockMediaStorage)(nil).DeleteFilmImage), ctx, imagePath)
+}
+
+// UploadFilmImage mocks base method.
+func (m *MockMediaStorage) UploadFilmImage(ctx context.Context, filmID, mediaID uuid.UUID, contentType string, data []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFilmImage", ctx, filmID, mediaID, contentType, data)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFilmImage indicates an expected call of UploadFilmImage.
+func (mr *MockMediaStorageMockRecorder) UploadFilmImage(ctx, filmID, mediaID, contentType, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFilmImage", reflect.TypeOf((*MockMediaStorage)(nil).UploadFilmImage), ctx, filmID, mediaID, contentType, data)
+}
+
+// MockCatalogueObserver is a mock of CatalogueObserver interface.
+type MockCatalogueObserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogueObserverMockRecorder
+	isgomock struct{}
+}
+
+// MockCatalogueObserverMockRecorder is the mock recorder for MockCatalogueObserver.
+type MockCatalogueObserverMockRecorder struct {
+	mock *MockCatalogueObserver
+}
+
+// NewMockCatalogueObserver creates a new mock instance.
+func NewMockCatalogueObserver(ctrl *gomock.Controller) *MockCatalogueObserver {
+	mock := &MockCatalogueObserver{ctrl: ctrl}
+	mock.recorder = &MockCatalogueObserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogueObserver) EXPECT() *MockCatalogueObserverMockRecorder {
+	return m.recorder
+}
+
+// CatalogueChanged mocks base method.
+func (m *MockCatalogueObserver) CatalogueChanged() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CatalogueChanged")
+}
+
+// CatalogueChanged indicates an expected call of CatalogueChanged.
+func (mr *MockCatalogueObserverMockRecorder) CatalogueChanged() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CatalogueChanged", reflect.TypeOf((*MockCatalogueObserver)(nil).CatalogueChanged))
+}
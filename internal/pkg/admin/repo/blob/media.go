@@ -0,0 +1,64 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/storage"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"path"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// mediaRoot картинки фильмов лежат в статике рядом со старыми films/ и gallery/
+const mediaRoot = "static"
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type MediaRepository struct {
+	bucket storage.Bucket
+}
+
+func NewMediaRepository(bucket storage.Bucket) *MediaRepository {
+	return &MediaRepository{bucket: bucket}
+}
+
+// UploadFilmImage кладёт файл под уникальным ключом films/<film>/<media>, поэтому кэшировать его можно навсегда
+func (r *MediaRepository) UploadFilmImage(ctx context.Context, filmID, mediaID uuid.UUID, contentType string, data []byte) (string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return "", admin.ErrorBadRequest
+	}
+
+	imagePath := path.Join("films", filmID.String(), mediaID.String()+extension)
+	err := r.bucket.Put(ctx, path.Join(mediaRoot, imagePath), bytes.NewReader(data), storage.PutOptions{
+		ContentType:  contentType,
+		CacheControl: storage.DefaultCacheControl,
+		Public:       true,
+	})
+	if err != nil {
+		logger.Error("failed to upload film image", "error", err)
+		return "", admin.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully uploaded film image", "image_path", imagePath)
+	return imagePath, nil
+}
+
+func (r *MediaRepository) DeleteFilmImage(ctx context.Context, imagePath string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	err := r.bucket.Delete(ctx, path.Join(mediaRoot, imagePath))
+	if err != nil && !errors.Is(err, storage.ErrorNotFound) {
+		logger.Error("failed to delete film image", "error", err)
+		return admin.ErrorInternalServerError
+	}
+	return nil
+}
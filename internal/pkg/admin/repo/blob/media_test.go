@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/storage"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestMediaRepository(t *testing.T) {
+	ctx := testContext()
+	bucket := storage.NewDiskBucket(t.TempDir())
+	repo := NewMediaRepository(bucket)
+	filmID, mediaID := uuid.NewV4(), uuid.NewV4()
+
+	imagePath, err := repo.UploadFilmImage(ctx, filmID, mediaID, "image/webp", []byte("webp"))
+	assert.NoError(t, err)
+	assert.Equal(t, "films/"+filmID.String()+"/"+mediaID.String()+".webp", imagePath)
+
+	info, err := bucket.Stat(ctx, "static/"+imagePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/webp", info.ContentType)
+
+	assert.NoError(t, repo.DeleteFilmImage(ctx, imagePath))
+	_, err = bucket.Stat(ctx, "static/"+imagePath)
+	assert.ErrorIs(t, err, storage.ErrorNotFound)
+	// повторное удаление не ошибка
+	assert.NoError(t, repo.DeleteFilmImage(ctx, imagePath))
+
+	_, err = repo.UploadFilmImage(ctx, filmID, mediaID, "image/gif", []byte("gif"))
+	assert.ErrorIs(t, err, admin.ErrorBadRequest)
+}
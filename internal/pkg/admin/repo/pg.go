@@ -0,0 +1,476 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+	checkViolation      = "23514"
+)
+
+// поля, на которые ссылаются ограничения из createDB.sql
+var constraintFields = map[string]string{
+	"film_genre_fk":                      "genre_id",
+	"film_country_fk":                    "country_id",
+	"actor_in_film_actor_fk":             "actor_id",
+	"actor_in_film_film_fk":              "film_id",
+	"actor_in_film_unique":               "actor_id",
+	"actor_in_film_character_role_check": "character",
+	"genre_title_unique":                 "title",
+	"country_name_unique":                "name",
+	"film_media_film_fk":                 "film_id",
+}
+
+type AdminRepository struct {
+	db pgxtype.Querier
+}
+
+func NewAdminRepository(db pgxtype.Querier) *AdminRepository {
+	return &AdminRepository{db: db}
+}
+
+func constraintField(constraint, table string) string {
+	if field, ok := constraintFields[constraint]; ok {
+		return field
+	}
+	field := strings.TrimPrefix(constraint, table+"_")
+	return strings.TrimSuffix(field, "_check")
+}
+
+// mapWriteError переводит ошибки postgres при записи в ошибки домена
+func mapWriteError(logger *slog.Logger, err error, table string) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		logger.Error("failed to write " + table + ": " + err.Error())
+		return admin.ErrorInternalServerError
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation:
+		logger.Error(table + " already exists: " + pgErr.Message)
+		return admin.NewValidationError(models.FieldError{
+			Field:   constraintField(pgErr.ConstraintName, table),
+			Message: "already exists",
+		})
+	case foreignKeyViolation:
+		logger.Error(table + " references missing entity: " + pgErr.Message)
+		return admin.NewValidationError(models.FieldError{
+			Field:   constraintField(pgErr.ConstraintName, table),
+			Message: "references a missing entity",
+		})
+	case checkViolation:
+		logger.Error(table + " violates check constraint: " + pgErr.Message)
+		return admin.NewValidationError(models.FieldError{
+			Field:   constraintField(pgErr.ConstraintName, table),
+			Message: "has invalid value",
+		})
+	}
+
+	logger.Error("failed to write " + table + ": " + err.Error())
+	return admin.ErrorInternalServerError
+}
+
+// mapDeleteError отличает удаление сущности, на которую еще ссылаются, от прочих ошибок
+func mapDeleteError(logger *slog.Logger, err error, table string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		logger.Error(table + " is still referenced: " + pgErr.Message)
+		return admin.ErrorConflict
+	}
+	logger.Error("failed to delete " + table + ": " + err.Error())
+	return admin.ErrorInternalServerError
+}
+
+// staleOrMissing вызывается, когда запись не обновилась: либо ее нет, либо updated_at устарел
+func (r *AdminRepository) staleOrMissing(ctx context.Context, logger *slog.Logger, query string, args ...interface{}) error {
+	var exists bool
+	err := r.db.QueryRow(ctx, query, args...).Scan(&exists)
+	if err != nil {
+		logger.Error("failed to check existence: " + err.Error())
+		return admin.ErrorInternalServerError
+	}
+	if !exists {
+		logger.Error("entity is not found")
+		return admin.ErrorNotFound
+	}
+	logger.Error("entity was modified by someone else")
+	return admin.ErrorConflict
+}
+
+// GetUserAccess возвращает роль пользователя и включён ли у него второй фактор
+func (r *AdminRepository) GetUserAccess(ctx context.Context, userID uuid.UUID) (string, bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var role string
+	var twoFactor bool
+	err := r.db.QueryRow(ctx, GetUserAccessQuery, userID).Scan(&role, &twoFactor)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return "", false, admin.ErrorUnauthorized
+		}
+		logger.Error("failed to scan role: " + err.Error())
+		return "", false, admin.ErrorInternalServerError
+	}
+	logger.Info("succesfully got access of user from db")
+	return role, twoFactor, nil
+}
+
+func scanFilm(row pgx.Row) (models.Film, error) {
+	var film models.Film
+	err := row.Scan(
+		&film.ID, &film.Title, &film.OriginalTitle, &film.Cover, &film.Poster,
+		&film.ShortDescription, &film.Description, &film.AgeCategory, &film.Budget,
+		&film.WorldwideFees, &film.TrailerURL, &film.Year, &film.CountryID,
+		&film.GenreID, &film.Slogan, &film.Duration, &film.Image1, &film.Image2,
+		&film.Image3, &film.CreatedAt, &film.UpdatedAt,
+	)
+	return film, err
+}
+
+func (r *AdminRepository) CreateFilm(ctx context.Context, film models.Film) (models.Film, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	created, err := scanFilm(r.db.QueryRow(
+		ctx,
+		CreateFilmQuery,
+		film.ID, film.Title, film.OriginalTitle, film.Cover, film.Poster,
+		film.ShortDescription, film.Description, film.AgeCategory, film.Budget,
+		film.WorldwideFees, film.TrailerURL, film.Year, film.CountryID,
+		film.GenreID, film.Slogan, film.Duration, film.Image1, film.Image2, film.Image3,
+	))
+	if err != nil {
+		return models.Film{}, mapWriteError(logger, err, "film")
+	}
+	logger.Info("succesfully created film")
+	return created, nil
+}
+
+func (r *AdminRepository) UpdateFilm(ctx context.Context, film models.Film, updatedAt time.Time) (models.Film, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	updated, err := scanFilm(r.db.QueryRow(
+		ctx,
+		UpdateFilmQuery,
+		film.ID, film.Title, film.OriginalTitle, film.Cover, film.Poster,
+		film.ShortDescription, film.Description, film.AgeCategory, film.Budget,
+		film.WorldwideFees, film.TrailerURL, film.Year, film.CountryID,
+		film.GenreID, film.Slogan, film.Duration, film.Image1, film.Image2, film.Image3,
+		updatedAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Film{}, r.staleOrMissing(ctx, logger, CheckFilmExistsQuery, film.ID)
+		}
+		return models.Film{}, mapWriteError(logger, err, "film")
+	}
+	logger.Info("succesfully updated film")
+	return updated, nil
+}
+
+func (r *AdminRepository) DeleteFilm(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, DeleteFilmQuery, id, updatedAt)
+	if err != nil {
+		return mapDeleteError(logger, err, "film")
+	}
+	if tag.RowsAffected() == 0 {
+		return r.staleOrMissing(ctx, logger, CheckFilmExistsQuery, id)
+	}
+	logger.Info("succesfully deleted film")
+	return nil
+}
+
+func scanActor(row pgx.Row) (models.Actor, error) {
+	var actor models.Actor
+	err := row.Scan(
+		&actor.ID, &actor.RussianName, &actor.OriginalName, &actor.Photo, &actor.Height,
+		&actor.BirthDate, &actor.DeathDate, &actor.ZodiacSign, &actor.BirthPlace, &actor.MaritalStatus,
+		&actor.CreatedAt, &actor.UpdatedAt,
+	)
+	return actor, err
+}
+
+func (r *AdminRepository) CreateActor(ctx context.Context, actor models.Actor) (models.Actor, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	created, err := scanActor(r.db.QueryRow(
+		ctx,
+		CreateActorQuery,
+		actor.ID, actor.RussianName, actor.OriginalName, actor.Photo, actor.Height,
+		actor.BirthDate, actor.DeathDate, actor.ZodiacSign, actor.BirthPlace, actor.MaritalStatus,
+	))
+	if err != nil {
+		return models.Actor{}, mapWriteError(logger, err, "actor")
+	}
+	logger.Info("succesfully created actor")
+	return created, nil
+}
+
+func (r *AdminRepository) UpdateActor(ctx context.Context, actor models.Actor, updatedAt time.Time) (models.Actor, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	updated, err := scanActor(r.db.QueryRow(
+		ctx,
+		UpdateActorQuery,
+		actor.ID, actor.RussianName, actor.OriginalName, actor.Photo, actor.Height,
+		actor.BirthDate, actor.DeathDate, actor.ZodiacSign, actor.BirthPlace, actor.MaritalStatus,
+		updatedAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Actor{}, r.staleOrMissing(ctx, logger, CheckActorExistsQuery, actor.ID)
+		}
+		return models.Actor{}, mapWriteError(logger, err, "actor")
+	}
+	logger.Info("succesfully updated actor")
+	return updated, nil
+}
+
+func (r *AdminRepository) DeleteActor(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, DeleteActorQuery, id, updatedAt)
+	if err != nil {
+		return mapDeleteError(logger, err, "actor")
+	}
+	if tag.RowsAffected() == 0 {
+		return r.staleOrMissing(ctx, logger, CheckActorExistsQuery, id)
+	}
+	logger.Info("succesfully deleted actor")
+	return nil
+}
+
+func scanGenre(row pgx.Row) (models.Genre, error) {
+	var genre models.Genre
+	err := row.Scan(
+		&genre.ID, &genre.Title, &genre.Description, &genre.Icon,
+		&genre.CreatedAt, &genre.UpdatedAt,
+	)
+	return genre, err
+}
+
+func (r *AdminRepository) CreateGenre(ctx context.Context, genre models.Genre) (models.Genre, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	created, err := scanGenre(r.db.QueryRow(
+		ctx,
+		CreateGenreQuery,
+		genre.ID, genre.Title, genre.Description, genre.Icon,
+	))
+	if err != nil {
+		return models.Genre{}, mapWriteError(logger, err, "genre")
+	}
+	logger.Info("succesfully created genre")
+	return created, nil
+}
+
+func (r *AdminRepository) UpdateGenre(ctx context.Context, genre models.Genre, updatedAt time.Time) (models.Genre, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	updated, err := scanGenre(r.db.QueryRow(
+		ctx,
+		UpdateGenreQuery,
+		genre.ID, genre.Title, genre.Description, genre.Icon, updatedAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Genre{}, r.staleOrMissing(ctx, logger, CheckGenreExistsQuery, genre.ID)
+		}
+		return models.Genre{}, mapWriteError(logger, err, "genre")
+	}
+	logger.Info("succesfully updated genre")
+	return updated, nil
+}
+
+func (r *AdminRepository) DeleteGenre(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, DeleteGenreQuery, id, updatedAt)
+	if err != nil {
+		return mapDeleteError(logger, err, "genre")
+	}
+	if tag.RowsAffected() == 0 {
+		return r.staleOrMissing(ctx, logger, CheckGenreExistsQuery, id)
+	}
+	logger.Info("succesfully deleted genre")
+	return nil
+}
+
+func scanCountry(row pgx.Row) (models.Country, error) {
+	var country models.Country
+	err := row.Scan(&country.ID, &country.Name, &country.CreatedAt, &country.UpdatedAt)
+	return country, err
+}
+
+func (r *AdminRepository) CreateCountry(ctx context.Context, country models.Country) (models.Country, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	created, err := scanCountry(r.db.QueryRow(ctx, CreateCountryQuery, country.ID, country.Name))
+	if err != nil {
+		return models.Country{}, mapWriteError(logger, err, "country")
+	}
+	logger.Info("succesfully created country")
+	return created, nil
+}
+
+func (r *AdminRepository) UpdateCountry(ctx context.Context, country models.Country, updatedAt time.Time) (models.Country, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	updated, err := scanCountry(r.db.QueryRow(ctx, UpdateCountryQuery, country.ID, country.Name, updatedAt))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Country{}, r.staleOrMissing(ctx, logger, CheckCountryExistsQuery, country.ID)
+		}
+		return models.Country{}, mapWriteError(logger, err, "country")
+	}
+	logger.Info("succesfully updated country")
+	return updated, nil
+}
+
+func (r *AdminRepository) DeleteCountry(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, DeleteCountryQuery, id, updatedAt)
+	if err != nil {
+		return mapDeleteError(logger, err, "country")
+	}
+	if tag.RowsAffected() == 0 {
+		return r.staleOrMissing(ctx, logger, CheckCountryExistsQuery, id)
+	}
+	logger.Info("succesfully deleted country")
+	return nil
+}
+
+func scanActorInFilm(row pgx.Row) (models.ActorInFilm, error) {
+	var aif models.ActorInFilm
+	err := row.Scan(
+		&aif.ID, &aif.ActorID, &aif.FilmID, &aif.Role, &aif.Character, &aif.Description,
+		&aif.BillingOrder, &aif.CreatedAt, &aif.UpdatedAt,
+	)
+	return aif, err
+}
+
+func (r *AdminRepository) CreateActorInFilm(ctx context.Context, aif models.ActorInFilm) (models.ActorInFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	created, err := scanActorInFilm(r.db.QueryRow(
+		ctx,
+		CreateActorInFilmQuery,
+		aif.ID, aif.ActorID, aif.FilmID, aif.Role, aif.Character, aif.Description, aif.BillingOrder,
+	))
+	if err != nil {
+		return models.ActorInFilm{}, mapWriteError(logger, err, "actor_in_film")
+	}
+	logger.Info("succesfully linked actor to film")
+	return created, nil
+}
+
+func (r *AdminRepository) UpdateActorInFilm(ctx context.Context, aif models.ActorInFilm, updatedAt time.Time) (models.ActorInFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	updated, err := scanActorInFilm(r.db.QueryRow(
+		ctx,
+		UpdateActorInFilmQuery,
+		aif.FilmID, aif.ActorID, aif.Role, aif.Character, aif.Description, aif.BillingOrder, updatedAt,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.ActorInFilm{}, r.staleOrMissing(ctx, logger, CheckActorInFilmExistsQuery, aif.FilmID, aif.ActorID, aif.Role)
+		}
+		return models.ActorInFilm{}, mapWriteError(logger, err, "actor_in_film")
+	}
+	logger.Info("succesfully updated actor in film")
+	return updated, nil
+}
+
+func (r *AdminRepository) DeleteActorInFilm(ctx context.Context, filmID, actorID uuid.UUID, role string, updatedAt time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, DeleteActorInFilmQuery, filmID, actorID, role, updatedAt)
+	if err != nil {
+		return mapDeleteError(logger, err, "actor_in_film")
+	}
+	if tag.RowsAffected() == 0 {
+		return r.staleOrMissing(ctx, logger, CheckActorInFilmExistsQuery, filmID, actorID, role)
+	}
+	logger.Info("succesfully removed actor from film")
+	return nil
+}
+
+func scanFilmMedia(row pgx.Row) (models.FilmMedia, error) {
+	var media models.FilmMedia
+	err := row.Scan(&media.ID, &media.Type, &media.URL, &media.Position, &media.Width, &media.Height, &media.Caption)
+	return media, err
+}
+
+// CreateFilmMedia добавляет медиа в конец галереи фильма
+func (r *AdminRepository) CreateFilmMedia(ctx context.Context, filmID uuid.UUID, media models.FilmMedia) (models.FilmMedia, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	created, err := scanFilmMedia(r.db.QueryRow(
+		ctx,
+		CreateFilmMediaQuery,
+		media.ID, filmID, media.Type, media.URL, media.Width, media.Height, media.Caption,
+	))
+	if err != nil {
+		return models.FilmMedia{}, mapWriteError(logger, err, "film_media")
+	}
+	logger.Info("succesfully created film media")
+	return created, nil
+}
+
+func (r *AdminRepository) GetFilmMedia(ctx context.Context, filmID uuid.UUID) ([]models.FilmMedia, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	rows, err := r.db.Query(ctx, GetFilmMediaQuery, filmID)
+	if err != nil {
+		logger.Error("failed to get film media: " + err.Error())
+		return nil, admin.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	media := []models.FilmMedia{}
+	for rows.Next() {
+		item, err := scanFilmMedia(rows)
+		if err != nil {
+			logger.Error("failed to scan film media: " + err.Error())
+			return nil, admin.ErrorInternalServerError
+		}
+		media = append(media, item)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to get film media: " + err.Error())
+		return nil, admin.ErrorInternalServerError
+	}
+	logger.Info("succesfully got film media from db")
+	return media, nil
+}
+
+// ReorderFilmMedia меняет порядок, только если ids это ровно все медиа фильма; иначе ничего не обновляется и возвращается 0
+func (r *AdminRepository) ReorderFilmMedia(ctx context.Context, filmID uuid.UUID, ids []uuid.UUID) (int64, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	idStrings := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idStrings = append(idStrings, id.String())
+	}
+	tag, err := r.db.Exec(ctx, ReorderFilmMediaQuery, filmID, idStrings)
+	if err != nil {
+		logger.Error("failed to reorder film media: " + err.Error())
+		return 0, admin.ErrorInternalServerError
+	}
+	logger.Info("succesfully reordered film media")
+	return tag.RowsAffected(), nil
+}
+
+func (r *AdminRepository) DeleteFilmMedia(ctx context.Context, filmID, mediaID uuid.UUID) (models.FilmMedia, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	deleted, err := scanFilmMedia(r.db.QueryRow(ctx, DeleteFilmMediaQuery, filmID, mediaID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("film media is not found")
+			return models.FilmMedia{}, admin.ErrorNotFound
+		}
+		logger.Error("failed to delete film media: " + err.Error())
+		return models.FilmMedia{}, admin.ErrorInternalServerError
+	}
+	logger.Info("succesfully deleted film media")
+	return deleted, nil
+}
@@ -0,0 +1,390 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+type MockRow struct {
+	err error
+}
+
+func (m MockRow) Scan(dest ...interface{}) error {
+	return m.err
+}
+
+func existsRow(exists bool) pgx.Row {
+	rows := pgxpoolmock.NewRows([]string{"exists"}).AddRow(exists).ToPgxRows()
+	rows.Next()
+	return rows
+}
+
+func TestGetUserAccess(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantRole   string
+		want2FA    bool
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"role", "two_factor"}).AddRow(models.RoleEditor, true).ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserAccessQuery, userID).Return(rows)
+			},
+			wantRole: models.RoleEditor,
+			want2FA:  true,
+		},
+		{
+			name: "User not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserAccessQuery, userID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: admin.ErrorUnauthorized,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserAccessQuery, userID).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: admin.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAdminRepository(mockPool)
+			role, twoFactor, err := repo.GetUserAccess(testContext(), userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRole, role)
+				assert.Equal(t, tt.want2FA, twoFactor)
+			}
+		})
+	}
+}
+
+func TestCreateCountry(t *testing.T) {
+	country := models.Country{ID: uuid.NewV4(), Name: "Франция"}
+	createdAt := time.Now()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+		wantField  string
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(country.ID, country.Name, createdAt, createdAt).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), CreateCountryQuery, country.ID, country.Name).Return(rows)
+			},
+		},
+		{
+			name: "Duplicate name",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), CreateCountryQuery, country.ID, country.Name).
+					Return(MockRow{err: &pgconn.PgError{Code: uniqueViolation, ConstraintName: "country_name_unique"}})
+			},
+			wantErr:   admin.ErrorBadRequest,
+			wantField: "name",
+		},
+		{
+			name: "Check violation",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), CreateCountryQuery, country.ID, country.Name).
+					Return(MockRow{err: &pgconn.PgError{Code: checkViolation, ConstraintName: "country_name_check"}})
+			},
+			wantErr:   admin.ErrorBadRequest,
+			wantField: "name",
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), CreateCountryQuery, country.ID, country.Name).
+					Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: admin.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAdminRepository(mockPool)
+			created, err := repo.CreateCountry(testContext(), country)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantField != "" {
+					var validationErr *admin.ValidationError
+					assert.True(t, errors.As(err, &validationErr))
+					assert.Equal(t, tt.wantField, validationErr.Fields[0].Field)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, country.Name, created.Name)
+		})
+	}
+}
+
+func TestUpdateGenre(t *testing.T) {
+	genre := models.Genre{ID: uuid.NewV4(), Title: "Драма", Description: "Драмы", Icon: "/icons/drama.png"}
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{
+					"id", "title", "description", "icon", "created_at", "updated_at",
+				}).
+					AddRow(genre.ID, genre.Title, genre.Description, genre.Icon, updatedAt, time.Now()).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), UpdateGenreQuery, genre.ID, genre.Title, genre.Description, genre.Icon, updatedAt).
+					Return(rows)
+			},
+		},
+		{
+			name: "Stale updated_at",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), UpdateGenreQuery, genre.ID, genre.Title, genre.Description, genre.Icon, updatedAt).
+					Return(MockRow{err: pgx.ErrNoRows})
+				mockPool.EXPECT().QueryRow(gomock.Any(), CheckGenreExistsQuery, genre.ID).Return(existsRow(true))
+			},
+			wantErr: admin.ErrorConflict,
+		},
+		{
+			name: "Genre not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), UpdateGenreQuery, genre.ID, genre.Title, genre.Description, genre.Icon, updatedAt).
+					Return(MockRow{err: pgx.ErrNoRows})
+				mockPool.EXPECT().QueryRow(gomock.Any(), CheckGenreExistsQuery, genre.ID).Return(existsRow(false))
+			},
+			wantErr: admin.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAdminRepository(mockPool)
+			updated, err := repo.UpdateGenre(testContext(), genre, updatedAt)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, genre.Title, updated.Title)
+		})
+	}
+}
+
+func TestDeleteFilm(t *testing.T) {
+	filmID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), DeleteFilmQuery, filmID, updatedAt).Return(pgconn.CommandTag("DELETE 1"), nil)
+			},
+		},
+		{
+			name: "Stale updated_at",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), DeleteFilmQuery, filmID, updatedAt).Return(pgconn.CommandTag("DELETE 0"), nil)
+				mockPool.EXPECT().QueryRow(gomock.Any(), CheckFilmExistsQuery, filmID).Return(existsRow(true))
+			},
+			wantErr: admin.ErrorConflict,
+		},
+		{
+			name: "Film is still referenced",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), DeleteFilmQuery, filmID, updatedAt).
+					Return(nil, &pgconn.PgError{Code: foreignKeyViolation})
+			},
+			wantErr: admin.ErrorConflict,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), DeleteFilmQuery, filmID, updatedAt).Return(nil, errors.New("db error"))
+			},
+			wantErr: admin.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAdminRepository(mockPool)
+			err := repo.DeleteFilm(testContext(), filmID, updatedAt)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReorderFilmMedia(t *testing.T) {
+	filmID := uuid.NewV4()
+	first, second := uuid.NewV4(), uuid.NewV4()
+	ids := []string{second.String(), first.String()}
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantUpdated int64
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), ReorderFilmMediaQuery, filmID, ids).Return(pgconn.CommandTag("UPDATE 2"), nil)
+			},
+			wantUpdated: 2,
+		},
+		{
+			name: "List does not match the gallery",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), ReorderFilmMediaQuery, filmID, ids).Return(pgconn.CommandTag("UPDATE 0"), nil)
+			},
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), ReorderFilmMediaQuery, filmID, ids).Return(nil, errors.New("db error"))
+			},
+			wantErr: admin.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAdminRepository(mockPool)
+			updated, err := repo.ReorderFilmMedia(testContext(), filmID, []uuid.UUID{second, first})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUpdated, updated)
+		})
+	}
+}
+
+func TestDeleteFilmMedia(t *testing.T) {
+	filmID, mediaID := uuid.NewV4(), uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), DeleteFilmMediaQuery, filmID, mediaID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: admin.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), DeleteFilmMediaQuery, filmID, mediaID).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: admin.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAdminRepository(mockPool)
+			_, err := repo.DeleteFilmMedia(testContext(), filmID, mediaID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
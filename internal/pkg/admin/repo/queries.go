@@ -0,0 +1,78 @@
+package repo
+
+import _ "embed"
+
+//go:embed sql/getUserAccessQuery.sql
+var GetUserAccessQuery string
+
+//go:embed sql/createFilmQuery.sql
+var CreateFilmQuery string
+
+//go:embed sql/updateFilmQuery.sql
+var UpdateFilmQuery string
+
+//go:embed sql/deleteFilmQuery.sql
+var DeleteFilmQuery string
+
+//go:embed sql/checkFilmExistsQuery.sql
+var CheckFilmExistsQuery string
+
+//go:embed sql/createActorQuery.sql
+var CreateActorQuery string
+
+//go:embed sql/updateActorQuery.sql
+var UpdateActorQuery string
+
+//go:embed sql/deleteActorQuery.sql
+var DeleteActorQuery string
+
+//go:embed sql/checkActorExistsQuery.sql
+var CheckActorExistsQuery string
+
+//go:embed sql/createGenreQuery.sql
+var CreateGenreQuery string
+
+//go:embed sql/updateGenreQuery.sql
+var UpdateGenreQuery string
+
+//go:embed sql/deleteGenreQuery.sql
+var DeleteGenreQuery string
+
+//go:embed sql/checkGenreExistsQuery.sql
+var CheckGenreExistsQuery string
+
+//go:embed sql/createCountryQuery.sql
+var CreateCountryQuery string
+
+//go:embed sql/updateCountryQuery.sql
+var UpdateCountryQuery string
+
+//go:embed sql/deleteCountryQuery.sql
+var DeleteCountryQuery string
+
+//go:embed sql/checkCountryExistsQuery.sql
+var CheckCountryExistsQuery string
+
+//go:embed sql/createActorInFilmQuery.sql
+var CreateActorInFilmQuery string
+
+//go:embed sql/updateActorInFilmQuery.sql
+var UpdateActorInFilmQuery string
+
+//go:embed sql/deleteActorInFilmQuery.sql
+var DeleteActorInFilmQuery string
+
+//go:embed sql/checkActorInFilmExistsQuery.sql
+var CheckActorInFilmExistsQuery string
+
+//go:embed sql/createFilmMediaQuery.sql
+var CreateFilmMediaQuery string
+
+//go:embed sql/getFilmMediaQuery.sql
+var GetFilmMediaQuery string
+
+//go:embed sql/reorderFilmMediaQuery.sql
+var ReorderFilmMediaQuery string
+
+//go:embed sql/deleteFilmMediaQuery.sql
+var DeleteFilmMediaQuery string
@@ -0,0 +1 @@
+SELECT EXISTS(SELECT 1 FROM actor_in_film WHERE film_id = $1 AND actor_id = $2 AND role = $3)
\ No newline at end of file
@@ -0,0 +1,3 @@
+INSERT INTO actor_in_film (id, actor_id, film_id, role, "character", description, billing_order) 
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, actor_id, film_id, role, "character", description, billing_order, created_at, updated_at
\ No newline at end of file
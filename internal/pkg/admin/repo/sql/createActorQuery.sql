@@ -0,0 +1,9 @@
+INSERT INTO actor (
+    id, russian_name, original_name, photo, height,
+    birth_date, death_date, zodiac_sign, birth_place, marital_status
+)
+VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''))
+RETURNING 
+    id, russian_name, original_name, COALESCE(photo, ''), height,
+    birth_date, death_date, COALESCE(zodiac_sign, ''), COALESCE(birth_place, ''), COALESCE(marital_status, ''),
+    created_at, updated_at
\ No newline at end of file
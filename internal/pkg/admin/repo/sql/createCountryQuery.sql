@@ -0,0 +1,3 @@
+INSERT INTO country (id, name) 
+VALUES ($1, $2)
+RETURNING id, name, created_at, updated_at
\ No newline at end of file
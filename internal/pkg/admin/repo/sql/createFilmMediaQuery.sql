@@ -0,0 +1,3 @@
+INSERT INTO film_media (id, film_id, type, url, "position", width, height, caption)
+VALUES ($1, $2, $3, $4, (SELECT COALESCE(max("position") + 1, 0) FROM film_media WHERE film_id = $2), $5, $6, $7)
+RETURNING id, type, url, "position", width, height, caption
\ No newline at end of file
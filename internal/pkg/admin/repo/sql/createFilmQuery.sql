@@ -0,0 +1,13 @@
+INSERT INTO film (
+    id, title, original_title, cover, poster,
+    short_description, description, age_category, budget,
+    worldwide_fees, trailer_url, year, country_id,
+    genre_id, slogan, duration, image1, image2, image3
+)
+VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+RETURNING 
+    id, title, original_title, COALESCE(cover, ''), COALESCE(poster, ''),
+    COALESCE(short_description, ''), COALESCE(description, ''), COALESCE(age_category, ''), budget,
+    worldwide_fees, trailer_url, year, country_id,
+    genre_id, slogan, duration, image1, image2,
+    image3, created_at, updated_at
\ No newline at end of file
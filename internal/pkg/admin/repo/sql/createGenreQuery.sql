@@ -0,0 +1,3 @@
+INSERT INTO genre (id, title, description, icon) 
+VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''))
+RETURNING id, title, COALESCE(description, ''), COALESCE(icon, ''), created_at, updated_at
\ No newline at end of file
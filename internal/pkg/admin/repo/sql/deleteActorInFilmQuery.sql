@@ -0,0 +1,2 @@
+DELETE FROM actor_in_film 
+WHERE film_id = $1 AND actor_id = $2 AND role = $3 AND updated_at = $4
\ No newline at end of file
@@ -0,0 +1,3 @@
+DELETE FROM film_media
+WHERE film_id = $1 AND id = $2
+RETURNING id, type, url, "position", width, height, caption
\ No newline at end of file
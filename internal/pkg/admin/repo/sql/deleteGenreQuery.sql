@@ -0,0 +1,2 @@
+DELETE FROM genre 
+WHERE id = $1 AND updated_at = $2
\ No newline at end of file
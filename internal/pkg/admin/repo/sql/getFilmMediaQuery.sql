@@ -0,0 +1,4 @@
+SELECT id, type, url, "position", width, height, caption
+FROM film_media
+WHERE film_id = $1
+ORDER BY "position", created_at
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT u.role, EXISTS (
+    SELECT 1 FROM user_totp t WHERE t.user_id = u.id AND t.enabled_at IS NOT NULL
+)
+FROM user_table u
+WHERE u.id = $1
\ No newline at end of file
@@ -0,0 +1,10 @@
+WITH valid AS (
+    SELECT count(*) = cardinality($2::uuid[])
+        AND count(*) FILTER (WHERE id = ANY($2::uuid[])) = count(*) AS ok
+    FROM film_media
+    WHERE film_id = $1
+)
+UPDATE film_media m
+SET "position" = o.ord - 1
+FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord), valid
+WHERE m.id = o.id AND m.film_id = $1 AND valid.ok
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE actor_in_film 
+SET "character" = $4, description = $5, billing_order = $6
+WHERE film_id = $1 AND actor_id = $2 AND role = $3 AND updated_at = $7
+RETURNING id, actor_id, film_id, role, "character", description, billing_order, created_at, updated_at
\ No newline at end of file
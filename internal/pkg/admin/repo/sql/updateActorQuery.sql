@@ -0,0 +1,8 @@
+UPDATE actor 
+SET russian_name = $2, original_name = $3, photo = NULLIF($4, ''), height = $5,
+    birth_date = $6, death_date = $7, zodiac_sign = NULLIF($8, ''), birth_place = NULLIF($9, ''), marital_status = NULLIF($10, '')
+WHERE id = $1 AND updated_at = $11
+RETURNING 
+    id, russian_name, original_name, COALESCE(photo, ''), height,
+    birth_date, death_date, COALESCE(zodiac_sign, ''), COALESCE(birth_place, ''), COALESCE(marital_status, ''),
+    created_at, updated_at
\ No newline at end of file
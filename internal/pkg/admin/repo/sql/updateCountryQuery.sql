@@ -0,0 +1,4 @@
+UPDATE country 
+SET name = $2
+WHERE id = $1 AND updated_at = $3
+RETURNING id, name, created_at, updated_at
\ No newline at end of file
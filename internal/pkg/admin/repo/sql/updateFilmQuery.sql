@@ -0,0 +1,12 @@
+UPDATE film 
+SET title = $2, original_title = $3, cover = NULLIF($4, ''), poster = NULLIF($5, ''),
+    short_description = NULLIF($6, ''), description = NULLIF($7, ''), age_category = NULLIF($8, ''), budget = $9,
+    worldwide_fees = $10, trailer_url = $11, year = $12, country_id = $13,
+    genre_id = $14, slogan = $15, duration = $16, image1 = $17, image2 = $18, image3 = $19
+WHERE id = $1 AND updated_at = $20
+RETURNING 
+    id, title, original_title, COALESCE(cover, ''), COALESCE(poster, ''),
+    COALESCE(short_description, ''), COALESCE(description, ''), COALESCE(age_category, ''), budget,
+    worldwide_fees, trailer_url, year, country_id,
+    genre_id, slogan, duration, image1, image2,
+    image3, created_at, updated_at
\ No newline at end of file
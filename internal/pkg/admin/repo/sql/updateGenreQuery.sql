@@ -0,0 +1,4 @@
+UPDATE genre 
+SET title = $2, description = NULLIF($3, ''), icon = NULLIF($4, '')
+WHERE id = $1 AND updated_at = $5
+RETURNING id, title, COALESCE(description, ''), COALESCE(icon, ''), created_at, updated_at
\ No newline at end of file
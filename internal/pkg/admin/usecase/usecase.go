@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type AdminUsecase struct {
+	adminRepo admin.AdminRepo
+	media     admin.MediaStorage
+	observers []admin.CatalogueObserver
+}
+
+func NewAdminUsecase(repo admin.AdminRepo, media admin.MediaStorage, observers ...admin.CatalogueObserver) *AdminUsecase {
+	return &AdminUsecase{adminRepo: repo, media: media, observers: observers}
+}
+
+func (uc *AdminUsecase) catalogueChanged(err error) {
+	if err != nil {
+		return
+	}
+	for _, observer := range uc.observers {
+		observer.CatalogueChanged()
+	}
+}
+
+func (uc *AdminUsecase) CheckEditor(ctx context.Context, userID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	role, twoFactor, err := uc.adminRepo.GetUserAccess(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if role != models.RoleEditor && role != models.RoleAdmin {
+		logger.Error("user has no editor rights")
+		return admin.ErrorForbidden
+	}
+	if !twoFactor {
+		logger.Error("editor has no two factor enabled")
+		return admin.ErrorTwoFactorRequired
+	}
+	return nil
+}
+
+// valueOrEmpty разворачивает необязательное поле; пустая строка
+// превращается в NULL уже в запросе
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func filmFromInput(id uuid.UUID, req models.FilmInput) models.Film {
+	return models.Film{
+		ID:               id,
+		Title:            req.Title,
+		OriginalTitle:    req.OriginalTitle,
+		Cover:            valueOrEmpty(req.Cover),
+		Poster:           valueOrEmpty(req.Poster),
+		GenreID:          req.GenreID,
+		ShortDescription: valueOrEmpty(req.ShortDescription),
+		Description:      valueOrEmpty(req.Description),
+		AgeCategory:      valueOrEmpty(req.AgeCategory),
+		Budget:           req.Budget,
+		WorldwideFees:    req.WorldwideFees,
+		TrailerURL:       req.TrailerURL,
+		Year:             req.Year,
+		CountryID:        req.CountryID,
+		Slogan:           req.Slogan,
+		Duration:         req.Duration,
+		Image1:           req.Image1,
+		Image2:           req.Image2,
+		Image3:           req.Image3,
+	}
+}
+
+func (uc *AdminUsecase) CreateFilm(ctx context.Context, req models.FilmInput) (models.Film, error) {
+	if err := admin.ValidateFilm(req, time.Now()); err != nil {
+		return models.Film{}, err
+	}
+	result, err := uc.adminRepo.CreateFilm(ctx, filmFromInput(uuid.NewV4(), req))
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) UpdateFilm(ctx context.Context, id uuid.UUID, req models.FilmInput) (models.Film, error) {
+	if err := admin.WithUpdatedAt(admin.ValidateFilm(req, time.Now()), req.UpdatedAt); err != nil {
+		return models.Film{}, err
+	}
+	result, err := uc.adminRepo.UpdateFilm(ctx, filmFromInput(id, req), *req.UpdatedAt)
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) DeleteFilm(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	if err := admin.WithUpdatedAt(nil, &updatedAt); err != nil {
+		return err
+	}
+	err := uc.adminRepo.DeleteFilm(ctx, id, updatedAt)
+	uc.catalogueChanged(err)
+	return err
+}
+
+func actorFromInput(id uuid.UUID, req models.ActorInput) models.Actor {
+	return models.Actor{
+		ID:            id,
+		RussianName:   req.RussianName,
+		OriginalName:  req.OriginalName,
+		Photo:         valueOrEmpty(req.Photo),
+		Height:        req.Height,
+		BirthDate:     req.BirthDate,
+		DeathDate:     req.DeathDate,
+		ZodiacSign:    valueOrEmpty(req.ZodiacSign),
+		BirthPlace:    valueOrEmpty(req.BirthPlace),
+		MaritalStatus: valueOrEmpty(req.MaritalStatus),
+	}
+}
+
+func (uc *AdminUsecase) CreateActor(ctx context.Context, req models.ActorInput) (models.Actor, error) {
+	if err := admin.ValidateActor(req, time.Now()); err != nil {
+		return models.Actor{}, err
+	}
+	result, err := uc.adminRepo.CreateActor(ctx, actorFromInput(uuid.NewV4(), req))
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) UpdateActor(ctx context.Context, id uuid.UUID, req models.ActorInput) (models.Actor, error) {
+	if err := admin.WithUpdatedAt(admin.ValidateActor(req, time.Now()), req.UpdatedAt); err != nil {
+		return models.Actor{}, err
+	}
+	result, err := uc.adminRepo.UpdateActor(ctx, actorFromInput(id, req), *req.UpdatedAt)
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) DeleteActor(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	if err := admin.WithUpdatedAt(nil, &updatedAt); err != nil {
+		return err
+	}
+	err := uc.adminRepo.DeleteActor(ctx, id, updatedAt)
+	uc.catalogueChanged(err)
+	return err
+}
+
+func (uc *AdminUsecase) CreateGenre(ctx context.Context, req models.GenreInput) (models.Genre, error) {
+	if err := admin.ValidateGenre(req); err != nil {
+		return models.Genre{}, err
+	}
+	result, err := uc.adminRepo.CreateGenre(ctx, models.Genre{
+		ID:          uuid.NewV4(),
+		Title:       req.Title,
+		Description: valueOrEmpty(req.Description),
+		Icon:        valueOrEmpty(req.Icon),
+	})
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) UpdateGenre(ctx context.Context, id uuid.UUID, req models.GenreInput) (models.Genre, error) {
+	if err := admin.WithUpdatedAt(admin.ValidateGenre(req), req.UpdatedAt); err != nil {
+		return models.Genre{}, err
+	}
+	result, err := uc.adminRepo.UpdateGenre(ctx, models.Genre{
+		ID:          id,
+		Title:       req.Title,
+		Description: valueOrEmpty(req.Description),
+		Icon:        valueOrEmpty(req.Icon),
+	}, *req.UpdatedAt)
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) DeleteGenre(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	if err := admin.WithUpdatedAt(nil, &updatedAt); err != nil {
+		return err
+	}
+	err := uc.adminRepo.DeleteGenre(ctx, id, updatedAt)
+	uc.catalogueChanged(err)
+	return err
+}
+
+func (uc *AdminUsecase) CreateCountry(ctx context.Context, req models.CountryInput) (models.Country, error) {
+	if err := admin.ValidateCountry(req); err != nil {
+		return models.Country{}, err
+	}
+	result, err := uc.adminRepo.CreateCountry(ctx, models.Country{ID: uuid.NewV4(), Name: req.Name})
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) UpdateCountry(ctx context.Context, id uuid.UUID, req models.CountryInput) (models.Country, error) {
+	if err := admin.WithUpdatedAt(admin.ValidateCountry(req), req.UpdatedAt); err != nil {
+		return models.Country{}, err
+	}
+	result, err := uc.adminRepo.UpdateCountry(ctx, models.Country{ID: id, Name: req.Name}, *req.UpdatedAt)
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) DeleteCountry(ctx context.Context, id uuid.UUID, updatedAt time.Time) error {
+	if err := admin.WithUpdatedAt(nil, &updatedAt); err != nil {
+		return err
+	}
+	err := uc.adminRepo.DeleteCountry(ctx, id, updatedAt)
+	uc.catalogueChanged(err)
+	return err
+}
+
+// roleOrDefault сохраняет совместимость со старыми клиентами, которые не передают роль
+func roleOrDefault(role string) string {
+	if role == "" {
+		return models.CrewRoleActor
+	}
+	return role
+}
+
+func (uc *AdminUsecase) AddActorToFilm(ctx context.Context, filmID uuid.UUID, req models.ActorInFilmInput) (models.ActorInFilm, error) {
+	req.Role = roleOrDefault(req.Role)
+	if err := admin.ValidateActorInFilm(req); err != nil {
+		return models.ActorInFilm{}, err
+	}
+	result, err := uc.adminRepo.CreateActorInFilm(ctx, models.ActorInFilm{
+		ID:           uuid.NewV4(),
+		ActorID:      req.ActorID,
+		FilmID:       filmID,
+		Role:         req.Role,
+		Character:    req.Character,
+		Description:  req.Description,
+		BillingOrder: req.BillingOrder,
+	})
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) UpdateActorInFilm(ctx context.Context, filmID uuid.UUID, req models.ActorInFilmInput) (models.ActorInFilm, error) {
+	req.Role = roleOrDefault(req.Role)
+	if err := admin.WithUpdatedAt(admin.ValidateActorInFilm(req), req.UpdatedAt); err != nil {
+		return models.ActorInFilm{}, err
+	}
+	result, err := uc.adminRepo.UpdateActorInFilm(ctx, models.ActorInFilm{
+		ActorID:      req.ActorID,
+		FilmID:       filmID,
+		Role:         req.Role,
+		Character:    req.Character,
+		Description:  req.Description,
+		BillingOrder: req.BillingOrder,
+	}, *req.UpdatedAt)
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+func (uc *AdminUsecase) RemoveActorFromFilm(ctx context.Context, filmID, actorID uuid.UUID, role string, updatedAt time.Time) error {
+	role = roleOrDefault(role)
+	if !models.IsCrewRole(role) {
+		return admin.NewValidationError(models.FieldError{Field: "role", Message: "is unknown"})
+	}
+	if err := admin.WithUpdatedAt(nil, &updatedAt); err != nil {
+		return err
+	}
+	err := uc.adminRepo.DeleteActorInFilm(ctx, filmID, actorID, role, updatedAt)
+	uc.catalogueChanged(err)
+	return err
+}
+
+// UploadFilmMedia проверяет картинку по заголовку и добавляет её в конец галереи фильма.
+// Файл хранится как есть: постеры и кадры не пережимаются, чтобы не терять качество
+func (uc *AdminUsecase) UploadFilmMedia(ctx context.Context, filmID uuid.UUID, req models.FilmMediaInput, data []byte) (models.FilmMedia, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if err := admin.ValidateFilmMedia(req); err != nil {
+		return models.FilmMedia{}, err
+	}
+	config, contentType, err := admin.ValidateFilmImage(data)
+	if err != nil {
+		return models.FilmMedia{}, err
+	}
+
+	mediaID := uuid.NewV4()
+	imagePath, err := uc.media.UploadFilmImage(ctx, filmID, mediaID, contentType, data)
+	if err != nil {
+		return models.FilmMedia{}, err
+	}
+
+	result, err := uc.adminRepo.CreateFilmMedia(ctx, filmID, models.FilmMedia{
+		ID:      mediaID,
+		Type:    req.Type,
+		URL:     imagePath,
+		Width:   &config.Width,
+		Height:  &config.Height,
+		Caption: req.Caption,
+	})
+	if err != nil {
+		if err := uc.media.DeleteFilmImage(ctx, imagePath); err != nil {
+			logger.Warn("failed to delete unused film image", "error", err)
+		}
+		return models.FilmMedia{}, err
+	}
+	uc.catalogueChanged(err)
+	return result, nil
+}
+
+func (uc *AdminUsecase) AddFilmTrailer(ctx context.Context, filmID uuid.UUID, req models.FilmTrailerInput) (models.FilmMedia, error) {
+	if err := admin.ValidateFilmTrailer(req); err != nil {
+		return models.FilmMedia{}, err
+	}
+	result, err := uc.adminRepo.CreateFilmMedia(ctx, filmID, models.FilmMedia{
+		ID:      uuid.NewV4(),
+		Type:    models.FilmMediaTrailer,
+		URL:     req.URL,
+		Caption: req.Caption,
+	})
+	uc.catalogueChanged(err)
+	return result, err
+}
+
+// ReorderFilmMedia принимает только полный список медиа фильма, чтобы два редактора
+// не перемешали порядок, глядя на разные версии галереи
+func (uc *AdminUsecase) ReorderFilmMedia(ctx context.Context, filmID uuid.UUID, req models.FilmMediaOrderInput) ([]models.FilmMedia, error) {
+	if err := admin.ValidateFilmMediaOrder(req); err != nil {
+		return nil, err
+	}
+	updated, err := uc.adminRepo.ReorderFilmMedia(ctx, filmID, req.IDs)
+	if err != nil {
+		return nil, err
+	}
+	if updated != int64(len(req.IDs)) {
+		return nil, admin.NewValidationError(models.FieldError{Field: "ids", Message: "must list every media of the film exactly once"})
+	}
+	uc.catalogueChanged(nil)
+	return uc.adminRepo.GetFilmMedia(ctx, filmID)
+}
+
+func (uc *AdminUsecase) DeleteFilmMedia(ctx context.Context, filmID, mediaID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	deleted, err := uc.adminRepo.DeleteFilmMedia(ctx, filmID, mediaID)
+	if err != nil {
+		return err
+	}
+	// у трейлеров и картинок, перенесённых из старых полей, своих файлов нет
+	if models.IsFilmImageType(deleted.Type) && deleted.Width != nil {
+		if err := uc.media.DeleteFilmImage(ctx, deleted.URL); err != nil {
+			logger.Warn("failed to delete film image", "error", err)
+		}
+	}
+	uc.catalogueChanged(nil)
+	return nil
+}
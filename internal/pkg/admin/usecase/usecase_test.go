@@ -0,0 +1,786 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/admin/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func validFilmInput() models.FilmInput {
+	return models.FilmInput{
+		Title:            "Интерстеллар",
+		Cover:            stringPtr("films/interstellar/cover.jpg"),
+		Poster:           stringPtr("films/interstellar/poster.jpg"),
+		GenreID:          uuid.NewV4(),
+		CountryID:        uuid.NewV4(),
+		ShortDescription: stringPtr("Путешествие сквозь червоточину"),
+		Description:      stringPtr("Команда исследователей отправляется к далеким мирам"),
+		AgeCategory:      stringPtr("12+"),
+		Budget:           165000000,
+		WorldwideFees:    677000000,
+		Year:             2014,
+		Duration:         169,
+	}
+}
+
+func fieldNames(err error) []string {
+	var validationErr *admin.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	names := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		names = append(names, field.Field)
+	}
+	return names
+}
+
+func TestAdminUsecase_CheckEditor(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name      string
+		role      string
+		twoFactor bool
+		repoErr   error
+		expectErr error
+	}{
+		{name: "Editor", role: models.RoleEditor, twoFactor: true},
+		{name: "Admin", role: models.RoleAdmin, twoFactor: true},
+		{name: "Editor without two factor", role: models.RoleEditor, expectErr: admin.ErrorTwoFactorRequired},
+		{name: "Admin without two factor", role: models.RoleAdmin, expectErr: admin.ErrorTwoFactorRequired},
+		{name: "Regular user", role: models.RoleUser, twoFactor: true, expectErr: admin.ErrorForbidden},
+		{name: "Unknown user", repoErr: admin.ErrorUnauthorized, expectErr: admin.ErrorUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			mockRepo.EXPECT().GetUserAccess(gomock.Any(), userID).Return(tt.role, tt.twoFactor, tt.repoErr)
+
+			uc := NewAdminUsecase(mockRepo, nil)
+			err := uc.CheckEditor(testContext(), userID)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_CreateFilm(t *testing.T) {
+	tooLong := strings.Repeat("а", 101)
+	empty := ""
+
+	tests := []struct {
+		name         string
+		input        func() models.FilmInput
+		setupMock    func(mockRepo *mocks.MockAdminRepo)
+		expectFields []string
+		expectErr    error
+	}{
+		{
+			name:  "Success",
+			input: validFilmInput,
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().
+					CreateFilm(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, film models.Film) (models.Film, error) {
+						film.CreatedAt = time.Now()
+						film.UpdatedAt = film.CreatedAt
+						return film, nil
+					})
+			},
+		},
+		{
+			name: "Field errors mirror db constraints",
+			input: func() models.FilmInput {
+				req := validFilmInput()
+				req.Title = tooLong
+				req.OriginalTitle = &empty
+				req.AgeCategory = stringPtr("18++++")
+				req.Year = 1894
+				req.Duration = 0
+				req.Budget = -1
+				req.GenreID = uuid.Nil
+				return req
+			},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectFields: []string{"title", "original_title", "genre_id", "age_category", "budget", "year", "duration"},
+			expectErr:    admin.ErrorBadRequest,
+		},
+		{
+			name: "Nullable fields may be omitted",
+			input: func() models.FilmInput {
+				req := validFilmInput()
+				req.Cover = nil
+				req.Poster = nil
+				req.ShortDescription = nil
+				req.Description = nil
+				req.AgeCategory = nil
+				return req
+			},
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().
+					CreateFilm(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, film models.Film) (models.Film, error) {
+						assert.Empty(t, film.Cover)
+						assert.Empty(t, film.Description)
+						return film, nil
+					})
+			},
+		},
+		{
+			name: "Nullable fields must not be empty when given",
+			input: func() models.FilmInput {
+				req := validFilmInput()
+				req.Cover = &empty
+				req.Description = &empty
+				return req
+			},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectFields: []string{"cover", "description"},
+			expectErr:    admin.ErrorBadRequest,
+		},
+		{
+			name: "Year too far in future",
+			input: func() models.FilmInput {
+				req := validFilmInput()
+				req.Year = time.Now().Year() + admin.MaxFutureYears + 1
+				return req
+			},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectFields: []string{"year"},
+			expectErr:    admin.ErrorBadRequest,
+		},
+		{
+			name:  "Repo error",
+			input: validFilmInput,
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().
+					CreateFilm(gomock.Any(), gomock.Any()).
+					Return(models.Film{}, admin.ErrorInternalServerError)
+			},
+			expectErr: admin.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewAdminUsecase(mockRepo, nil)
+			req := tt.input()
+			film, err := uc.CreateFilm(testContext(), req)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				if tt.expectFields != nil {
+					assert.Equal(t, tt.expectFields, fieldNames(err))
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, film.ID)
+			assert.Equal(t, req.Title, film.Title)
+		})
+	}
+}
+
+func TestAdminUsecase_UpdateFilm(t *testing.T) {
+	filmID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Missing updated_at is reported with other fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := NewAdminUsecase(mocks.NewMockAdminRepo(ctrl), nil)
+		req := validFilmInput()
+		req.Title = ""
+
+		_, err := uc.UpdateFilm(testContext(), filmID, req)
+		assert.ErrorIs(t, err, admin.ErrorBadRequest)
+		assert.Equal(t, []string{"title", "updated_at"}, fieldNames(err))
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockAdminRepo(ctrl)
+		mockRepo.EXPECT().
+			UpdateFilm(gomock.Any(), gomock.Any(), updatedAt).
+			DoAndReturn(func(_ context.Context, film models.Film, _ time.Time) (models.Film, error) {
+				assert.Equal(t, filmID, film.ID)
+				return models.Film{}, admin.ErrorConflict
+			})
+
+		uc := NewAdminUsecase(mockRepo, nil)
+		req := validFilmInput()
+		req.UpdatedAt = &updatedAt
+
+		_, err := uc.UpdateFilm(testContext(), filmID, req)
+		assert.ErrorIs(t, err, admin.ErrorConflict)
+	})
+}
+
+func TestAdminUsecase_CreateGenre(t *testing.T) {
+	empty := ""
+
+	t.Run("Description and icon may be omitted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockAdminRepo(ctrl)
+		mockRepo.EXPECT().
+			CreateGenre(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, genre models.Genre) (models.Genre, error) {
+				assert.Empty(t, genre.Description)
+				assert.Empty(t, genre.Icon)
+				return genre, nil
+			})
+
+		uc := NewAdminUsecase(mockRepo, nil)
+		_, err := uc.CreateGenre(testContext(), models.GenreInput{Title: "Драма"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Given fields must not be empty", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := NewAdminUsecase(mocks.NewMockAdminRepo(ctrl), nil)
+		_, err := uc.CreateGenre(testContext(), models.GenreInput{Title: "Драма", Description: &empty, Icon: &empty})
+		assert.ErrorIs(t, err, admin.ErrorBadRequest)
+		assert.Equal(t, []string{"description", "icon"}, fieldNames(err))
+	})
+}
+
+func TestAdminUsecase_DeleteGenre(t *testing.T) {
+	genreID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		updatedAt time.Time
+		setupMock func(mockRepo *mocks.MockAdminRepo)
+		expectErr error
+	}{
+		{
+			name:      "Success",
+			updatedAt: updatedAt,
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().DeleteGenre(gomock.Any(), genreID, updatedAt).Return(nil)
+			},
+		},
+		{
+			name:      "Zero version",
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {},
+			expectErr: admin.ErrorBadRequest,
+		},
+		{
+			name:      "Genre is still used",
+			updatedAt: updatedAt,
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().DeleteGenre(gomock.Any(), genreID, updatedAt).Return(admin.ErrorConflict)
+			},
+			expectErr: admin.ErrorConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewAdminUsecase(mockRepo, nil)
+			err := uc.DeleteGenre(testContext(), genreID, tt.updatedAt)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_CreateActor(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewAdminUsecase(mocks.NewMockAdminRepo(ctrl), nil)
+	_, err := uc.CreateActor(testContext(), models.ActorInput{
+		RussianName:   "Киану Ривз",
+		Photo:         stringPtr("actors/keanu.jpg"),
+		Height:        301,
+		BirthDate:     future,
+		ZodiacSign:    stringPtr("Дева"),
+		BirthPlace:    stringPtr("Бейрут, Ливан"),
+		MaritalStatus: stringPtr("Не женат"),
+	})
+	assert.ErrorIs(t, err, admin.ErrorBadRequest)
+	assert.Equal(t, []string{"height", "birth_date"}, fieldNames(err))
+}
+
+func TestAdminUsecase_AddActorToFilm(t *testing.T) {
+	filmID := uuid.NewV4()
+	actorID := uuid.NewV4()
+	blank := "   "
+	character := "Нео"
+	description := "Главный герой"
+
+	tests := []struct {
+		name         string
+		input        models.ActorInFilmInput
+		setupMock    func(mockRepo *mocks.MockAdminRepo)
+		expectFields []string
+		expectRole   string
+	}{
+		{
+			name:         "Blank character",
+			input:        models.ActorInFilmInput{ActorID: actorID, Character: &blank, Description: &description},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectFields: []string{"character"},
+		},
+		{
+			name:  "Actor without character",
+			input: models.ActorInFilmInput{ActorID: actorID, Role: models.CrewRoleActor},
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().
+					CreateActorInFilm(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, aif models.ActorInFilm) (models.ActorInFilm, error) {
+						assert.Nil(t, aif.Character)
+						return aif, nil
+					})
+			},
+			expectRole: models.CrewRoleActor,
+		},
+		{
+			name:         "Character for director",
+			input:        models.ActorInFilmInput{ActorID: actorID, Role: models.CrewRoleDirector, Character: &character},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectFields: []string{"character"},
+		},
+		{
+			name:         "Unknown role and negative billing order",
+			input:        models.ActorInFilmInput{ActorID: actorID, Role: "stuntman", BillingOrder: -1},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectFields: []string{"role", "billing_order"},
+		},
+		{
+			name:  "Role defaults to actor",
+			input: models.ActorInFilmInput{ActorID: actorID, Character: &character, Description: &description},
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().
+					CreateActorInFilm(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, aif models.ActorInFilm) (models.ActorInFilm, error) {
+						assert.Equal(t, filmID, aif.FilmID)
+						assert.Equal(t, actorID, aif.ActorID)
+						return aif, nil
+					})
+			},
+			expectRole: models.CrewRoleActor,
+		},
+		{
+			name:  "Director with billing order",
+			input: models.ActorInFilmInput{ActorID: actorID, Role: models.CrewRoleDirector, BillingOrder: 1},
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().
+					CreateActorInFilm(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, aif models.ActorInFilm) (models.ActorInFilm, error) {
+						assert.Nil(t, aif.Character)
+						assert.Equal(t, 1, aif.BillingOrder)
+						return aif, nil
+					})
+			},
+			expectRole: models.CrewRoleDirector,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewAdminUsecase(mockRepo, nil)
+			aif, err := uc.AddActorToFilm(testContext(), filmID, tt.input)
+			if tt.expectFields != nil {
+				assert.ErrorIs(t, err, admin.ErrorBadRequest)
+				assert.Equal(t, tt.expectFields, fieldNames(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectRole, aif.Role)
+		})
+	}
+}
+
+func TestAdminUsecase_RemoveActorFromFilm(t *testing.T) {
+	filmID := uuid.NewV4()
+	actorID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Unknown role", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		uc := NewAdminUsecase(mocks.NewMockAdminRepo(ctrl), nil)
+		err := uc.RemoveActorFromFilm(testContext(), filmID, actorID, "stuntman", updatedAt)
+		assert.Equal(t, []string{"role"}, fieldNames(err))
+	})
+
+	t.Run("Empty role means actor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockAdminRepo(ctrl)
+		mockRepo.EXPECT().DeleteActorInFilm(gomock.Any(), filmID, actorID, models.CrewRoleActor, updatedAt).Return(nil)
+
+		uc := NewAdminUsecase(mockRepo, nil)
+		assert.NoError(t, uc.RemoveActorFromFilm(testContext(), filmID, actorID, "", updatedAt))
+	})
+}
+
+func TestAdminUsecase_NotifiesCatalogueObservers(t *testing.T) {
+	genreID := uuid.NewV4()
+	updatedAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(mockRepo *mocks.MockAdminRepo, observer *mocks.MockCatalogueObserver)
+	}{
+		{
+			name: "Notified after change",
+			setupMock: func(mockRepo *mocks.MockAdminRepo, observer *mocks.MockCatalogueObserver) {
+				mockRepo.EXPECT().DeleteGenre(gomock.Any(), genreID, updatedAt).Return(nil)
+				observer.EXPECT().CatalogueChanged().Times(1)
+			},
+		},
+		{
+			name: "Silent on failed change",
+			setupMock: func(mockRepo *mocks.MockAdminRepo, observer *mocks.MockCatalogueObserver) {
+				mockRepo.EXPECT().DeleteGenre(gomock.Any(), genreID, updatedAt).Return(admin.ErrorConflict)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			observer := mocks.NewMockCatalogueObserver(ctrl)
+			tt.setupMock(mockRepo, observer)
+
+			uc := NewAdminUsecase(mockRepo, nil, observer)
+			_ = uc.DeleteGenre(testContext(), genreID, updatedAt)
+		})
+	}
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAdminUsecase_UploadFilmMedia(t *testing.T) {
+	filmID := uuid.NewV4()
+	data := pngImage(t, 4, 3)
+
+	tests := []struct {
+		name         string
+		input        models.FilmMediaInput
+		data         []byte
+		setupMock    func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage)
+		expectErr    error
+		expectFields []string
+	}{
+		{
+			name:  "Success",
+			input: models.FilmMediaInput{Type: models.FilmMediaStill},
+			data:  data,
+			setupMock: func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {
+				mockMedia.EXPECT().UploadFilmImage(gomock.Any(), filmID, gomock.Any(), "image/png", data).Return("films/x/y.png", nil)
+				mockRepo.EXPECT().CreateFilmMedia(gomock.Any(), filmID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, media models.FilmMedia) (models.FilmMedia, error) {
+						assert.Equal(t, "films/x/y.png", media.URL)
+						assert.Equal(t, 4, *media.Width)
+						assert.Equal(t, 3, *media.Height)
+						media.Position = 2
+						return media, nil
+					})
+			},
+		},
+		{
+			name:         "Unknown type",
+			input:        models.FilmMediaInput{Type: models.FilmMediaTrailer},
+			data:         data,
+			setupMock:    func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {},
+			expectErr:    admin.ErrorBadRequest,
+			expectFields: []string{"type"},
+		},
+		{
+			name:         "Not an image",
+			input:        models.FilmMediaInput{Type: models.FilmMediaPoster},
+			data:         []byte("GIF89a not really"),
+			setupMock:    func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {},
+			expectErr:    admin.ErrorBadRequest,
+			expectFields: []string{"file"},
+		},
+		{
+			name:  "Insert fails - uploaded file is removed",
+			input: models.FilmMediaInput{Type: models.FilmMediaBackdrop},
+			data:  data,
+			setupMock: func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {
+				mockMedia.EXPECT().UploadFilmImage(gomock.Any(), filmID, gomock.Any(), "image/png", data).Return("films/x/y.png", nil)
+				mockRepo.EXPECT().CreateFilmMedia(gomock.Any(), filmID, gomock.Any()).Return(models.FilmMedia{}, admin.ErrorNotFound)
+				mockMedia.EXPECT().DeleteFilmImage(gomock.Any(), "films/x/y.png").Return(nil)
+			},
+			expectErr: admin.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			mockMedia := mocks.NewMockMediaStorage(ctrl)
+			tt.setupMock(mockRepo, mockMedia)
+
+			uc := NewAdminUsecase(mockRepo, mockMedia)
+			media, err := uc.UploadFilmMedia(testContext(), filmID, tt.input, tt.data)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				if tt.expectFields != nil {
+					assert.Equal(t, tt.expectFields, fieldNames(err))
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 2, media.Position)
+		})
+	}
+}
+
+func TestAdminUsecase_AddFilmTrailer(t *testing.T) {
+	filmID := uuid.NewV4()
+
+	tests := []struct {
+		name      string
+		url       string
+		setupMock func(mockRepo *mocks.MockAdminRepo)
+		expectErr error
+	}{
+		{
+			name: "Success",
+			url:  "https://www.youtube.com/watch?v=zSWdZVtXT7E",
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().CreateFilmMedia(gomock.Any(), filmID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, media models.FilmMedia) (models.FilmMedia, error) {
+						assert.Equal(t, models.FilmMediaTrailer, media.Type)
+						assert.Nil(t, media.Width)
+						return media, nil
+					})
+			},
+		},
+		{
+			name:      "Script link",
+			url:       "javascript:alert(1)",
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {},
+			expectErr: admin.ErrorBadRequest,
+		},
+		{
+			name:      "Relative link",
+			url:       "/trailers/1.mp4",
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {},
+			expectErr: admin.ErrorBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewAdminUsecase(mockRepo, nil)
+			_, err := uc.AddFilmTrailer(testContext(), filmID, models.FilmTrailerInput{URL: tt.url})
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminUsecase_ReorderFilmMedia(t *testing.T) {
+	filmID := uuid.NewV4()
+	first, second := uuid.NewV4(), uuid.NewV4()
+
+	tests := []struct {
+		name         string
+		ids          []uuid.UUID
+		setupMock    func(mockRepo *mocks.MockAdminRepo)
+		expectErr    error
+		expectFields []string
+	}{
+		{
+			name: "Success",
+			ids:  []uuid.UUID{second, first},
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().ReorderFilmMedia(gomock.Any(), filmID, []uuid.UUID{second, first}).Return(int64(2), nil)
+				mockRepo.EXPECT().GetFilmMedia(gomock.Any(), filmID).Return([]models.FilmMedia{{ID: second}, {ID: first, Position: 1}}, nil)
+			},
+		},
+		{
+			name:         "Duplicates",
+			ids:          []uuid.UUID{first, first},
+			setupMock:    func(mockRepo *mocks.MockAdminRepo) {},
+			expectErr:    admin.ErrorBadRequest,
+			expectFields: []string{"ids"},
+		},
+		{
+			name: "Incomplete list",
+			ids:  []uuid.UUID{first},
+			setupMock: func(mockRepo *mocks.MockAdminRepo) {
+				mockRepo.EXPECT().ReorderFilmMedia(gomock.Any(), filmID, []uuid.UUID{first}).Return(int64(0), nil)
+			},
+			expectErr:    admin.ErrorBadRequest,
+			expectFields: []string{"ids"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewAdminUsecase(mockRepo, nil)
+			media, err := uc.ReorderFilmMedia(testContext(), filmID, models.FilmMediaOrderInput{IDs: tt.ids})
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				assert.Equal(t, tt.expectFields, fieldNames(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, media, 2)
+		})
+	}
+}
+
+func TestAdminUsecase_DeleteFilmMedia(t *testing.T) {
+	filmID, mediaID := uuid.NewV4(), uuid.NewV4()
+	width := 1920
+
+	tests := []struct {
+		name      string
+		setupMock func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage)
+		expectErr error
+	}{
+		{
+			name: "Uploaded image",
+			setupMock: func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {
+				mockRepo.EXPECT().DeleteFilmMedia(gomock.Any(), filmID, mediaID).
+					Return(models.FilmMedia{Type: models.FilmMediaStill, URL: "films/a/b.jpg", Width: &width, Height: &width}, nil)
+				mockMedia.EXPECT().DeleteFilmImage(gomock.Any(), "films/a/b.jpg").Return(nil)
+			},
+		},
+		{
+			name: "Legacy image keeps the file",
+			setupMock: func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {
+				mockRepo.EXPECT().DeleteFilmMedia(gomock.Any(), filmID, mediaID).
+					Return(models.FilmMedia{Type: models.FilmMediaPoster, URL: "films/old/poster.jpg"}, nil)
+			},
+		},
+		{
+			name: "Trailer",
+			setupMock: func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {
+				mockRepo.EXPECT().DeleteFilmMedia(gomock.Any(), filmID, mediaID).
+					Return(models.FilmMedia{Type: models.FilmMediaTrailer, URL: "https://example.com/t"}, nil)
+			},
+		},
+		{
+			name: "Not found",
+			setupMock: func(mockRepo *mocks.MockAdminRepo, mockMedia *mocks.MockMediaStorage) {
+				mockRepo.EXPECT().DeleteFilmMedia(gomock.Any(), filmID, mediaID).Return(models.FilmMedia{}, admin.ErrorNotFound)
+			},
+			expectErr: admin.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockAdminRepo(ctrl)
+			mockMedia := mocks.NewMockMediaStorage(ctrl)
+			tt.setupMock(mockRepo, mockMedia)
+
+			uc := NewAdminUsecase(mockRepo, mockMedia)
+			err := uc.DeleteFilmMedia(testContext(), filmID, mediaID)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"kinopoisk/internal/models"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	// форматы, которые принимаются на загрузку в галерею
+	_ "image/jpeg"
+	_ "image/png"
+
+	uuid "github.com/satori/go.uuid"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	MinFilmYear      = 1895
+	MaxFutureYears   = 5
+	MaxActorHeight   = 300
+	MaxCountryLength = 100
+	// MaxFilmImageBytes и MaxFilmImageSide ограничивают загрузку в галерею фильма;
+	// размеры проверяются по заголовку, сама картинка не декодируется
+	MaxFilmImageBytes = 20 * 1024 * 1024
+	MaxFilmImageSide  = 10000
+)
+
+var filmImageTypes = map[string]string{"jpeg": "image/jpeg", "png": "image/png", "webp": "image/webp"}
+
+type fieldChecker struct {
+	errors []models.FieldError
+}
+
+func (c *fieldChecker) add(field, message string) {
+	c.errors = append(c.errors, models.FieldError{Field: field, Message: message})
+}
+
+func (c *fieldChecker) requiredText(field, value string, maxLen int) {
+	length := utf8.RuneCountInString(value)
+	if length == 0 {
+		c.add(field, "is required")
+		return
+	}
+	if length > maxLen {
+		c.add(field, fmt.Sprintf("must be at most %d characters", maxLen))
+	}
+}
+
+func (c *fieldChecker) optionalText(field string, value *string, maxLen int) {
+	if value == nil {
+		return
+	}
+	length := utf8.RuneCountInString(*value)
+	if length == 0 {
+		c.add(field, "must not be empty")
+		return
+	}
+	if length > maxLen {
+		c.add(field, fmt.Sprintf("must be at most %d characters", maxLen))
+	}
+}
+
+func (c *fieldChecker) trimmedText(field, value string, maxLen int) {
+	if strings.TrimSpace(value) == "" {
+		c.add(field, "is required")
+		return
+	}
+	if utf8.RuneCountInString(value) > maxLen {
+		c.add(field, fmt.Sprintf("must be at most %d characters", maxLen))
+	}
+}
+
+func (c *fieldChecker) requiredID(field string, value uuid.UUID) {
+	if value == uuid.Nil {
+		c.add(field, "is required")
+	}
+}
+
+func (c *fieldChecker) notInFuture(field string, value time.Time, now time.Time) {
+	if value.After(now) {
+		c.add(field, "must not be in the future")
+	}
+}
+
+func (c *fieldChecker) err() error {
+	if len(c.errors) == 0 {
+		return nil
+	}
+	return NewValidationError(c.errors...)
+}
+
+func ValidateFilm(req models.FilmInput, now time.Time) error {
+	var c fieldChecker
+	c.requiredText("title", req.Title, 100)
+	c.optionalText("original_title", req.OriginalTitle, 100)
+	c.optionalText("cover", req.Cover, 100)
+	c.optionalText("poster", req.Poster, 100)
+	c.requiredID("genre_id", req.GenreID)
+	c.requiredID("country_id", req.CountryID)
+	c.optionalText("short_description", req.ShortDescription, 500)
+	c.optionalText("description", req.Description, 5000)
+	c.optionalText("age_category", req.AgeCategory, 5)
+	if req.Budget < 0 {
+		c.add("budget", "must not be negative")
+	}
+	if req.WorldwideFees < 0 {
+		c.add("worldwide_fees", "must not be negative")
+	}
+	c.optionalText("trailer_url", req.TrailerURL, 200)
+	maxYear := now.Year() + MaxFutureYears
+	if req.Year < MinFilmYear || req.Year > maxYear {
+		c.add("year", fmt.Sprintf("must be between %d and %d", MinFilmYear, maxYear))
+	}
+	c.optionalText("slogan", req.Slogan, 200)
+	if req.Duration <= 0 {
+		c.add("duration", "must be positive")
+	}
+	c.optionalText("image1", req.Image1, 100)
+	c.optionalText("image2", req.Image2, 100)
+	c.optionalText("image3", req.Image3, 100)
+	return c.err()
+}
+
+func ValidateActor(req models.ActorInput, now time.Time) error {
+	var c fieldChecker
+	c.requiredText("russian_name", req.RussianName, 100)
+	c.optionalText("original_name", req.OriginalName, 100)
+	c.optionalText("photo", req.Photo, 100)
+	if req.Height <= 0 || req.Height > MaxActorHeight {
+		c.add("height", fmt.Sprintf("must be between 1 and %d", MaxActorHeight))
+	}
+	if req.BirthDate.IsZero() {
+		c.add("birth_date", "is required")
+	} else {
+		c.notInFuture("birth_date", req.BirthDate, now)
+	}
+	if req.DeathDate != nil {
+		c.notInFuture("death_date", *req.DeathDate, now)
+		if !req.BirthDate.IsZero() && req.DeathDate.Before(req.BirthDate) {
+			c.add("death_date", "must not be before birth_date")
+		}
+	}
+	c.optionalText("zodiac_sign", req.ZodiacSign, 20)
+	c.optionalText("birth_place", req.BirthPlace, 200)
+	c.optionalText("marital_status", req.MaritalStatus, 50)
+	return c.err()
+}
+
+func ValidateGenre(req models.GenreInput) error {
+	var c fieldChecker
+	c.requiredText("title", req.Title, 40)
+	c.optionalText("description", req.Description, 500)
+	c.optionalText("icon", req.Icon, 100)
+	return c.err()
+}
+
+func ValidateCountry(req models.CountryInput) error {
+	var c fieldChecker
+	c.requiredText("name", req.Name, MaxCountryLength)
+	return c.err()
+}
+
+func ValidateActorInFilm(req models.ActorInFilmInput) error {
+	var c fieldChecker
+	c.requiredID("actor_id", req.ActorID)
+	if !models.IsCrewRole(req.Role) {
+		c.add("role", "must be one of: "+strings.Join(models.CrewRoles, ", "))
+	}
+	switch {
+	case req.Character == nil:
+	case req.Role == models.CrewRoleActor:
+		c.trimmedText("character", *req.Character, 200)
+	default:
+		c.add("character", "is allowed only for actors")
+	}
+	if req.Description != nil {
+		c.trimmedText("description", *req.Description, 1000)
+	}
+	if req.BillingOrder < 0 {
+		c.add("billing_order", "must not be negative")
+	}
+	return c.err()
+}
+
+func ValidateFilmMedia(req models.FilmMediaInput) error {
+	var c fieldChecker
+	if !models.IsFilmImageType(req.Type) {
+		c.add("type", "must be one of: still, poster, backdrop")
+	}
+	c.optionalText("caption", req.Caption, 500)
+	return c.err()
+}
+
+// ValidateFilmTrailer принимает только абсолютные http(s) ссылки, чтобы в плеер не попал javascript:
+func ValidateFilmTrailer(req models.FilmTrailerInput) error {
+	var c fieldChecker
+	c.requiredText("url", req.URL, 500)
+	if parsed, err := url.Parse(req.URL); req.URL != "" && (err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "") {
+		c.add("url", "must be an absolute http or https link")
+	}
+	c.optionalText("caption", req.Caption, 500)
+	return c.err()
+}
+
+// ValidateFilmImage возвращает размеры картинки и её content type, определённый по содержимому, а не по имени файла
+func ValidateFilmImage(data []byte) (image.Config, string, error) {
+	var c fieldChecker
+	if len(data) == 0 {
+		c.add("file", "is required")
+		return image.Config{}, "", c.err()
+	}
+	if len(data) > MaxFilmImageBytes {
+		c.add("file", fmt.Sprintf("must be at most %d bytes", MaxFilmImageBytes))
+		return image.Config{}, "", c.err()
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	contentType, ok := filmImageTypes[format]
+	if err != nil || !ok {
+		c.add("file", "must be a jpeg, png or webp image")
+		return image.Config{}, "", c.err()
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > MaxFilmImageSide || config.Height > MaxFilmImageSide {
+		c.add("file", fmt.Sprintf("sides must be between 1 and %d pixels", MaxFilmImageSide))
+		return image.Config{}, "", c.err()
+	}
+	return config, contentType, nil
+}
+
+func ValidateFilmMediaOrder(req models.FilmMediaOrderInput) error {
+	var c fieldChecker
+	if len(req.IDs) == 0 {
+		c.add("ids", "is required")
+	}
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if seen[id] {
+			c.add("ids", "must not contain duplicates")
+			break
+		}
+		seen[id] = true
+	}
+	return c.err()
+}
+
+// WithUpdatedAt дополняет результат валидации проверкой версии записи,
+// без которой обновление и удаление невозможны
+func WithUpdatedAt(validationErr error, updatedAt *time.Time) error {
+	var c fieldChecker
+	if ve, ok := validationErr.(*ValidationError); ok {
+		c.errors = append(c.errors, ve.Fields...)
+	} else if validationErr != nil {
+		return validationErr
+	}
+	if updatedAt == nil || updatedAt.IsZero() {
+		c.add("updated_at", "is required")
+	}
+	return c.err()
+}
@@ -7,10 +7,12 @@ import (
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/auth"
 	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
@@ -25,7 +27,9 @@ type AuthHandler struct {
 	JWTSecret      string
 	CookieSecure   bool
 	CookieSamesite http.SameSite
-	uc             auth.AuthUsecase
+	// AppBaseURL адрес фронтенда, куда возвращаем браузер после входа через провайдера
+	AppBaseURL string
+	uc         auth.AuthUsecase
 }
 
 func NewAuthHandler(uc auth.AuthUsecase) *AuthHandler {
@@ -45,10 +49,37 @@ func NewAuthHandler(uc auth.AuthUsecase) *AuthHandler {
 		JWTSecret:      os.Getenv("JWT_SECRET"),
 		CookieSecure:   secure,
 		CookieSamesite: samesite,
+		AppBaseURL:     strings.TrimSuffix(os.Getenv("APP_BASE_URL"), "/"),
 		uc:             uc,
 	}
 }
 
+// setSessionCookies выставляет JWT и CSRF куки и возвращает CSRF-токен для заголовка
+func (a *AuthHandler) setSessionCookies(w http.ResponseWriter, token string) string {
+	csrfToken := uuid.NewV4().String()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    csrfToken,
+		HttpOnly: false,
+		Secure:   a.CookieSecure,
+		SameSite: a.CookieSamesite,
+		Expires:  time.Now().Add(12 * time.Hour),
+		Path:     "/",
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		HttpOnly: true,
+		Secure:   a.CookieSecure,
+		SameSite: a.CookieSamesite,
+		Expires:  time.Now().Add(12 * time.Hour),
+		Path:     "/",
+	})
+	return csrfToken
+}
+
 // SignupUser godoc
 // @Summary User registration
 // @Description Register a new user account
@@ -57,9 +88,9 @@ func NewAuthHandler(uc auth.AuthUsecase) *AuthHandler {
 // @Produce json
 // @Param input body models.SignUpInput true "User registration data"
 // @Success 200 {object} models.User
-// @Failure 400
-// @Failure 409
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /auth/signup [post]
 func (a *AuthHandler) SignupUser(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -68,45 +99,18 @@ func (a *AuthHandler) SignupUser(w http.ResponseWriter, r *http.Request) {
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid input"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	req.Sanitize()
 
 	user, token, err := a.uc.SignUpUser(r.Context(), req)
 	if err != nil {
-		switch {
-		case errors.Is(err, auth.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		case errors.Is(err, auth.ErrorConflict):
-			helpers.WriteError(w, http.StatusConflict)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 
-	csrfToken := uuid.NewV4().String()
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     CSRFCookieName,
-		Value:    csrfToken,
-		HttpOnly: false,
-		Secure:   a.CookieSecure,
-		SameSite: a.CookieSamesite,
-		Expires:  time.Now().Add(12 * time.Hour),
-		Path:     "/",
-	})
-
-	http.SetCookie(w, &http.Cookie{
-		Name:     CookieName,
-		Value:    token,
-		HttpOnly: true,
-		Secure:   a.CookieSecure,
-		SameSite: a.CookieSamesite,
-		Expires:  time.Now().Add(12 * time.Hour),
-		Path:     "/",
-	})
+	csrfToken := a.setSessionCookies(w, token)
 	user.Sanitize()
 
 	w.Header().Set("X-CSRF-Token", csrfToken)
@@ -116,15 +120,16 @@ func (a *AuthHandler) SignupUser(w http.ResponseWriter, r *http.Request) {
 
 // SignInUser godoc
 // @Summary User login
-// @Description Authenticate user
+// @Description Authenticate user. If two-factor authentication is enabled, responds with 202 and a pending token for /auth/signin/2fa instead of setting the session cookie
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param input body models.SignInInput true "User data"
 // @Success 200 {object} models.User
-// @Failure 400
-// @Failure 401
-// @Failure 500
+// @Success 202 {object} models.TwoFactorChallenge
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /auth/signin [post]
 func (a *AuthHandler) SignInUser(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -133,43 +138,62 @@ func (a *AuthHandler) SignInUser(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid input"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	req.Sanitize()
 
 	user, token, err := a.uc.SignInUser(r.Context(), req)
+	if errors.Is(err, auth.ErrorTwoFactorRequired) {
+		helpers.WriteJSONWithStatus(w, http.StatusAccepted, models.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			PendingToken:      token,
+		})
+		log.LogHandlerInfo(logger, "two factor required", http.StatusAccepted)
+		return
+	}
 	if err != nil {
-		switch {
-		case errors.Is(err, auth.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 
-	csrfToken := uuid.NewV4().String()
+	csrfToken := a.setSessionCookies(w, token)
+	user.Sanitize()
+	w.Header().Set("X-CSRF-Token", csrfToken)
+	helpers.WriteJSON(w, user)
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     CSRFCookieName,
-		Value:    csrfToken,
-		HttpOnly: false,
-		Secure:   a.CookieSecure,
-		SameSite: a.CookieSamesite,
-		Expires:  time.Now().Add(12 * time.Hour),
-		Path:     "/",
-	})
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     CookieName,
-		Value:    token,
-		HttpOnly: true,
-		Secure:   a.CookieSecure,
-		SameSite: a.CookieSamesite,
-		Expires:  time.Now().Add(12 * time.Hour),
-		Path:     "/",
-	})
+// VerifyTwoFactor godoc
+// @Summary Second step of login
+// @Description Completes login with the pending token from /auth/signin and a TOTP code or one of the recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorSignInInput true "Pending token and code"
+// @Success 200 {object} models.User
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/signin/2fa [post]
+func (a *AuthHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.TwoFactorSignInInput
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid input"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	user, token, err := a.uc.VerifyTwoFactor(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	csrfToken := a.setSessionCookies(w, token)
 	user.Sanitize()
 	w.Header().Set("X-CSRF-Token", csrfToken)
 	helpers.WriteJSON(w, user)
@@ -177,13 +201,105 @@ func (a *AuthHandler) SignInUser(w http.ResponseWriter, r *http.Request) {
 	log.LogHandlerInfo(logger, "success", http.StatusOK)
 }
 
+// SetupTwoFactor godoc
+// @Summary Start two-factor enrolment
+// @Description Generates a new TOTP secret and provisioning URI for a QR code. Available for editors and admins. 2FA is not enabled until confirmed via /auth/2fa/enable
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.TwoFactorSetup
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/2fa/setup [post]
+func (a *AuthHandler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	setup, err := a.uc.SetupTwoFactor(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	helpers.WriteJSON(w, setup)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// EnableTwoFactor godoc
+// @Summary Confirm two-factor enrolment
+// @Description Enables 2FA after checking a code from the authenticator app. Other sessions are logged out and the current one gets new cookies. Returns one-time recovery codes, they are shown only once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body models.TwoFactorCodeInput true "TOTP code"
+// @Success 200 {object} models.RecoveryCodes
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/2fa/enable [post]
+func (a *AuthHandler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.TwoFactorCodeInput
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid input"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	codes, token, err := a.uc.EnableTwoFactor(r.Context(), req.Code)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	csrfToken := a.setSessionCookies(w, token)
+	w.Header().Set("X-CSRF-Token", csrfToken)
+	helpers.WriteJSON(w, codes)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Turns 2FA off and removes recovery codes. Requires the current password unless the account has none yet
+// @Tags auth
+// @Accept json
+// @Param input body models.TwoFactorDisableInput true "Current password"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/2fa/disable [post]
+func (a *AuthHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	var req models.TwoFactorDisableInput
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid input"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
+
+	err = a.uc.DisableTwoFactor(r.Context(), req.Password)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
 func (a *AuthHandler) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 		csrfCookie, err := r.Cookie(CSRFCookieName)
 		if err != nil {
 			log.LogHandlerError(logger, errors.New("invalid csrf token"), http.StatusUnauthorized)
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 			return
 		}
 		var csrfToken string
@@ -197,13 +313,13 @@ func (a *AuthHandler) Middleware(next http.Handler) http.Handler {
 				csrfToken = tokenFromForm
 			} else {
 				log.LogHandlerError(logger, errors.New("csrf-token is empty"), http.StatusUnauthorized)
-				helpers.WriteError(w, http.StatusUnauthorized)
+				problem.WriteStatus(w, r, http.StatusUnauthorized)
 				return
 			}
 		}
 		if csrfCookie.Value != csrfToken {
 			log.LogHandlerError(logger, errors.New("invalid csrf-token"), http.StatusUnauthorized)
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 			return
 		}
 
@@ -215,7 +331,7 @@ func (a *AuthHandler) Middleware(next http.Handler) http.Handler {
 
 		user, err := a.uc.ValidateAndGetUser(r.Context(), token)
 		if err != nil {
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 			return
 		}
 		user.Sanitize()
@@ -232,19 +348,14 @@ func (a *AuthHandler) Middleware(next http.Handler) http.Handler {
 // @Tags auth
 // @Produce json
 // @Success 200 {object} models.User
-// @Failure 401
-// @Failure 500
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /auth/check [get]
 func (a *AuthHandler) CheckAuth(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 	user, err := a.uc.CheckAuth(r.Context())
 	if err != nil {
-		switch {
-		case errors.Is(err, auth.ErrorUnauthorized):
-			helpers.WriteError(w, http.StatusUnauthorized)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	user.Sanitize()
@@ -258,17 +369,17 @@ func (a *AuthHandler) CheckAuth(w http.ResponseWriter, r *http.Request) {
 // @Tags auth
 // @Produce json
 // @Success 200 {object} map[string]string
-// @Failure 401
-// @Failure 500
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /auth/logout [post]
 func (a *AuthHandler) LogOutUser(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 	err := a.uc.LogOutUser(r.Context())
 	if err != nil {
 		if errors.Is(err, auth.ErrorUnauthorized) {
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 		} else {
-			helpers.WriteError(w, http.StatusInternalServerError)
+			problem.WriteStatus(w, r, http.StatusInternalServerError)
 		}
 		return
 	}
@@ -3,6 +3,7 @@ package authHandlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -193,6 +194,198 @@ func TestSignInUser(t *testing.T) {
 	}
 }
 
+func TestSignInUserTwoFactorRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+	mockUsecase.EXPECT().SignInUser(gomock.Any(), gomock.Any()).Return(models.User{}, "pending", auth.ErrorTwoFactorRequired)
+
+	r := httptest.NewRequest("POST", "/auth/signin", bytes.NewBufferString(`{"login":"editor","password":"Pass123"}`)).WithContext(testContext())
+	w := httptest.NewRecorder()
+
+	NewAuthHandler(mockUsecase).SignInUser(w, r)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	// сессия не выдаётся, пока не введён второй фактор
+	assert.Empty(t, w.Result().Cookies())
+	var challenge models.TwoFactorChallenge
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &challenge))
+	assert.Equal(t, models.TwoFactorChallenge{TwoFactorRequired: true, PendingToken: "pending"}, challenge)
+}
+
+func TestVerifyTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		ucErr          error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			requestBody:    `{"pending_token":"pending","code":"123456"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"pending_token":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Wrong code",
+			requestBody:    `{"pending_token":"pending","code":"123456"}`,
+			ucErr:          auth.ErrorBadRequest,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Expired pending token",
+			requestBody:    `{"pending_token":"pending","code":"123456"}`,
+			ucErr:          auth.ErrorUnauthorized,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Internal server error",
+			requestBody:    `{"pending_token":"pending","code":"123456"}`,
+			ucErr:          errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+			if tt.name != "Invalid JSON" {
+				token := "jwt_token"
+				if tt.ucErr != nil {
+					token = ""
+				}
+				mockUsecase.EXPECT().VerifyTwoFactor(gomock.Any(), models.TwoFactorSignInInput{
+					PendingToken: "pending",
+					Code:         "123456",
+				}).Return(models.User{ID: uuid.NewV4(), Login: "editor"}, token, tt.ucErr)
+			}
+
+			r := httptest.NewRequest("POST", "/auth/signin/2fa", bytes.NewBufferString(tt.requestBody)).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			NewAuthHandler(mockUsecase).VerifyTwoFactor(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Len(t, w.Result().Cookies(), 2)
+				assert.NotEmpty(t, w.Header().Get("X-CSRF-Token"))
+			}
+		})
+	}
+}
+
+func TestSetupTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		ucErr          error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "Unauthorized", ucErr: auth.ErrorUnauthorized, expectedStatus: http.StatusUnauthorized},
+		{name: "Regular user", ucErr: auth.ErrorForbidden, expectedStatus: http.StatusForbidden},
+		{name: "Already enabled", ucErr: auth.ErrorConflict, expectedStatus: http.StatusConflict},
+		{name: "Internal server error", ucErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+			mockUsecase.EXPECT().SetupTwoFactor(gomock.Any()).Return(models.TwoFactorSetup{Secret: "SECRET", ProvisioningURI: "otpauth://totp/x"}, tt.ucErr)
+
+			r := httptest.NewRequest("POST", "/auth/2fa/setup", nil).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			NewAuthHandler(mockUsecase).SetupTwoFactor(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		ucErr          error
+		expectedStatus int
+	}{
+		{name: "Success", requestBody: `{"code":"123456"}`, expectedStatus: http.StatusOK},
+		{name: "Invalid JSON", requestBody: `{"code":`, expectedStatus: http.StatusBadRequest},
+		{name: "Wrong code", requestBody: `{"code":"123456"}`, ucErr: auth.ErrorBadRequest, expectedStatus: http.StatusBadRequest},
+		{name: "Already enabled", requestBody: `{"code":"123456"}`, ucErr: auth.ErrorConflict, expectedStatus: http.StatusConflict},
+		{name: "Internal server error", requestBody: `{"code":"123456"}`, ucErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+			if tt.name != "Invalid JSON" {
+				mockUsecase.EXPECT().EnableTwoFactor(gomock.Any(), "123456").Return(models.RecoveryCodes{Codes: []string{"abcde-fghij"}}, "jwt", tt.ucErr)
+			}
+
+			r := httptest.NewRequest("POST", "/auth/2fa/enable", bytes.NewBufferString(tt.requestBody)).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			NewAuthHandler(mockUsecase).EnableTwoFactor(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Len(t, w.Result().Cookies(), 2)
+				assert.NotEmpty(t, w.Header().Get("X-CSRF-Token"))
+			}
+		})
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		ucErr          error
+		expectedStatus int
+	}{
+		{name: "Success", requestBody: `{"password":"Pass<123"}`, expectedStatus: http.StatusOK},
+		{name: "Invalid JSON", requestBody: `{"password":`, expectedStatus: http.StatusBadRequest},
+		{name: "Wrong password", requestBody: `{"password":"Pass<123"}`, ucErr: auth.ErrorBadRequest, expectedStatus: http.StatusBadRequest},
+		{name: "Not enabled", requestBody: `{"password":"Pass<123"}`, ucErr: auth.ErrorNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Internal server error", requestBody: `{"password":"Pass<123"}`, ucErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+			if tt.name != "Invalid JSON" {
+				// пароль экранируется так же, как при входе
+				mockUsecase.EXPECT().DisableTwoFactor(gomock.Any(), "Pass&lt;123").Return(tt.ucErr)
+			}
+
+			r := httptest.NewRequest("POST", "/auth/2fa/disable", bytes.NewBufferString(tt.requestBody)).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			NewAuthHandler(mockUsecase).DisableTwoFactor(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestCheckAuth(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,214 @@
+package authHandlers
+
+import (
+	"errors"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// OAuthCookieName закрепляет state за браузером, который начал вход, чтобы чужой колбэк не сработал
+	OAuthCookieName = "DDFilmsOAuth"
+	oauthCookiePath = "/api/auth/oauth"
+	oauthCookieTTL  = 10 * time.Minute
+)
+
+func (a *AuthHandler) setOAuthCookie(w http.ResponseWriter, state string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     OAuthCookieName,
+		Value:    state,
+		HttpOnly: true,
+		Secure:   a.CookieSecure,
+		// колбэк приходит переходом с сайта провайдера, Strict куку бы не отправил
+		SameSite: http.SameSiteLaxMode,
+		Expires:  time.Now().Add(ttl),
+		Path:     oauthCookiePath,
+	})
+}
+
+// redirectToApp возвращает браузер на фронтенд; ошибки передаются кодом в oauth_error
+func (a *AuthHandler) redirectToApp(w http.ResponseWriter, r *http.Request, path string) {
+	http.Redirect(w, r, a.AppBaseURL+path, http.StatusFound)
+}
+
+// OAuthProviders godoc
+// @Summary Social login providers
+// @Description Lists configured OAuth2/OpenID Connect providers
+// @Tags auth
+// @Produce json
+// @Success 200 {object} models.OAuthProviders
+// @Router /auth/oauth/providers [get]
+func (a *AuthHandler) OAuthProviders(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	helpers.WriteJSON(w, models.OAuthProviders{Providers: a.uc.OAuthProviders()})
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// StartOAuth godoc
+// @Summary Sign in with a provider
+// @Description Redirects to the provider (authorization code flow with PKCE). A user signing in for the first time is registered with a generated login
+// @Tags auth
+// @Param provider path string true "Provider" Enums(vk, yandex, google)
+// @Success 302
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/oauth/{provider}/login [get]
+func (a *AuthHandler) StartOAuth(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	redirectURL, state, err := a.uc.StartOAuth(r.Context(), mux.Vars(r)["provider"], false)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	a.setOAuthCookie(w, state, oauthCookieTTL)
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+	log.LogHandlerInfo(logger, "success", http.StatusFound)
+}
+
+// LinkOAuth godoc
+// @Summary Link a provider to the current account
+// @Description Returns the provider URL to open. After the callback the provider account is linked to the current user
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider" Enums(vk, yandex, google)
+// @Success 200 {object} models.OAuthRedirect
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/oauth/{provider}/link [post]
+func (a *AuthHandler) LinkOAuth(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	redirectURL, state, err := a.uc.StartOAuth(r.Context(), mux.Vars(r)["provider"], true)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	a.setOAuthCookie(w, state, oauthCookieTTL)
+	helpers.WriteJSON(w, models.OAuthRedirect{URL: redirectURL})
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// OAuthCallback godoc
+// @Summary Provider callback
+// @Description Finishes sign in or linking and redirects to the app: "/" after sign in, "/settings?linked={provider}" after linking, "/signin/2fa#pending_token=..." if two-factor authentication is enabled, "/signin?oauth_error={code}" on failure
+// @Tags auth
+// @Param provider path string true "Provider" Enums(vk, yandex, google)
+// @Param code query string false "Authorization code"
+// @Param state query string false "State"
+// @Success 302
+// @Router /auth/oauth/{provider}/callback [get]
+func (a *AuthHandler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	provider := mux.Vars(r)["provider"]
+	query := r.URL.Query()
+
+	cookie, err := r.Cookie(OAuthCookieName)
+	a.setOAuthCookie(w, "", -oauthCookieTTL)
+	if query.Get("error") != "" {
+		log.LogHandlerError(logger, errors.New("provider returned "+query.Get("error")), http.StatusFound)
+		a.redirectToApp(w, r, "/signin?oauth_error=denied")
+		return
+	}
+	if err != nil || cookie.Value == "" || cookie.Value != query.Get("state") {
+		log.LogHandlerError(logger, errors.New("state does not match cookie"), http.StatusFound)
+		a.redirectToApp(w, r, "/signin?oauth_error=unauthorized")
+		return
+	}
+
+	user, token, err := a.uc.CompleteOAuth(r.Context(), provider, query)
+	if errors.Is(err, auth.ErrorTwoFactorRequired) {
+		// во фрагменте токен не уходит на сервер и не попадает в логи
+		a.redirectToApp(w, r, "/signin/2fa#pending_token="+url.QueryEscape(token))
+		log.LogHandlerInfo(logger, "two factor required", http.StatusFound)
+		return
+	}
+	if err != nil {
+		code := "internal"
+		switch {
+		case errors.Is(err, auth.ErrorBadRequest), errors.Is(err, auth.ErrorNotFound):
+			code = "bad_request"
+		case errors.Is(err, auth.ErrorUnauthorized):
+			code = "unauthorized"
+		case errors.Is(err, auth.ErrorConflict):
+			code = "conflict"
+		case errors.Is(err, auth.ErrorBadGateway):
+			code = "provider"
+		}
+		log.LogHandlerError(logger, err, http.StatusFound)
+		a.redirectToApp(w, r, "/signin?oauth_error="+code)
+		return
+	}
+
+	if token == "" {
+		a.redirectToApp(w, r, "/settings?linked="+url.QueryEscape(provider))
+		log.LogHandlerInfo(logger, "identity linked", http.StatusFound)
+		return
+	}
+
+	a.setSessionCookies(w, token)
+	a.redirectToApp(w, r, "/")
+	logger.Info("signed in with " + provider + " as " + user.Login)
+	log.LogHandlerInfo(logger, "success", http.StatusFound)
+}
+
+// GetIdentities godoc
+// @Summary Linked providers
+// @Description Lists provider accounts linked to the current user
+// @Tags auth
+// @Produce json
+// @Success 200 {array} models.LinkedIdentity
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/oauth/identities [get]
+func (a *AuthHandler) GetIdentities(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	identities, err := a.uc.GetIdentities(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	for i := range identities {
+		identities[i].Sanitize()
+	}
+	helpers.WriteJSON(w, identities)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UnlinkIdentity godoc
+// @Summary Unlink a provider
+// @Description Removes the link to the provider account. The last sign in method of a user without a password cannot be removed
+// @Tags auth
+// @Param provider path string true "Provider" Enums(vk, yandex, google)
+// @Success 200
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /auth/oauth/{provider} [delete]
+func (a *AuthHandler) UnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	err := a.uc.UnlinkIdentity(r.Context(), mux.Vars(r)["provider"])
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
@@ -0,0 +1,238 @@
+package authHandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/auth/mocks"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestStartOAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+	handler := NewAuthHandler(mockUsecase)
+
+	mockUsecase.EXPECT().StartOAuth(gomock.Any(), "google", false).Return("https://idp/authorize?state=state", "state", nil)
+	r := httptest.NewRequest(http.MethodGet, "/auth/oauth/google/login", nil).WithContext(testContext())
+	r = mux.SetURLVars(r, map[string]string{"provider": "google"})
+	w := httptest.NewRecorder()
+
+	handler.StartOAuth(w, r)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://idp/authorize?state=state", w.Header().Get("Location"))
+	cookie := findCookie(w.Result().Cookies(), OAuthCookieName)
+	if assert.NotNil(t, cookie) {
+		assert.Equal(t, "state", cookie.Value)
+		assert.True(t, cookie.HttpOnly)
+	}
+
+	mockUsecase.EXPECT().StartOAuth(gomock.Any(), "facebook", false).Return("", "", auth.ErrorNotFound)
+	r = mux.SetURLVars(r, map[string]string{"provider": "facebook"})
+	w = httptest.NewRecorder()
+
+	handler.StartOAuth(w, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestLinkOAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+	mockUsecase.EXPECT().StartOAuth(gomock.Any(), "vk", true).Return("https://idp/authorize", "state", nil)
+	r := httptest.NewRequest(http.MethodPost, "/auth/oauth/vk/link", nil).WithContext(testContext())
+	r = mux.SetURLVars(r, map[string]string{"provider": "vk"})
+	w := httptest.NewRecorder()
+
+	NewAuthHandler(mockUsecase).LinkOAuth(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"url":"https://idp/authorize"}`, w.Body.String())
+	assert.NotNil(t, findCookie(w.Result().Cookies(), OAuthCookieName))
+}
+
+func TestOAuthCallback(t *testing.T) {
+	tests := []struct {
+		name             string
+		url              string
+		cookie           string
+		mockSetup        func(*mocks.MockAuthUsecase)
+		expectedLocation string
+		expectSession    bool
+	}{
+		{
+			name:   "Sign in",
+			url:    "/auth/oauth/google/callback?code=code&state=state",
+			cookie: "state",
+			mockSetup: func(m *mocks.MockAuthUsecase) {
+				m.EXPECT().CompleteOAuth(gomock.Any(), "google", gomock.Any()).Return(models.User{ID: uuid.NewV4(), Login: "user12345"}, "jwt_token", nil)
+			},
+			expectedLocation: "https://ddfilms.ru/",
+			expectSession:    true,
+		},
+		{
+			name:   "Linked",
+			url:    "/auth/oauth/google/callback?code=code&state=state",
+			cookie: "state",
+			mockSetup: func(m *mocks.MockAuthUsecase) {
+				m.EXPECT().CompleteOAuth(gomock.Any(), "google", gomock.Any()).Return(models.User{ID: uuid.NewV4()}, "", nil)
+			},
+			expectedLocation: "https://ddfilms.ru/settings?linked=google",
+		},
+		{
+			name:   "Two factor required",
+			url:    "/auth/oauth/google/callback?code=code&state=state",
+			cookie: "state",
+			mockSetup: func(m *mocks.MockAuthUsecase) {
+				m.EXPECT().CompleteOAuth(gomock.Any(), "google", gomock.Any()).Return(models.User{}, "pending", auth.ErrorTwoFactorRequired)
+			},
+			expectedLocation: "https://ddfilms.ru/signin/2fa#pending_token=pending",
+		},
+		{
+			name:             "State from another browser",
+			url:              "/auth/oauth/google/callback?code=code&state=state",
+			cookie:           "other",
+			mockSetup:        func(m *mocks.MockAuthUsecase) {},
+			expectedLocation: "https://ddfilms.ru/signin?oauth_error=unauthorized",
+		},
+		{
+			name:             "No state cookie",
+			url:              "/auth/oauth/google/callback?code=code&state=state",
+			mockSetup:        func(m *mocks.MockAuthUsecase) {},
+			expectedLocation: "https://ddfilms.ru/signin?oauth_error=unauthorized",
+		},
+		{
+			name:             "User denied access",
+			url:              "/auth/oauth/google/callback?error=access_denied&state=state",
+			cookie:           "state",
+			mockSetup:        func(m *mocks.MockAuthUsecase) {},
+			expectedLocation: "https://ddfilms.ru/signin?oauth_error=denied",
+		},
+		{
+			name:   "Already linked to another user",
+			url:    "/auth/oauth/google/callback?code=code&state=state",
+			cookie: "state",
+			mockSetup: func(m *mocks.MockAuthUsecase) {
+				m.EXPECT().CompleteOAuth(gomock.Any(), "google", gomock.Any()).Return(models.User{}, "", auth.ErrorConflict)
+			},
+			expectedLocation: "https://ddfilms.ru/signin?oauth_error=conflict",
+		},
+		{
+			name:   "Provider failed",
+			url:    "/auth/oauth/google/callback?code=code&state=state",
+			cookie: "state",
+			mockSetup: func(m *mocks.MockAuthUsecase) {
+				m.EXPECT().CompleteOAuth(gomock.Any(), "google", gomock.Any()).Return(models.User{}, "", auth.ErrorBadGateway)
+			},
+			expectedLocation: "https://ddfilms.ru/signin?oauth_error=provider",
+		},
+		{
+			name:   "Internal error",
+			url:    "/auth/oauth/google/callback?code=code&state=state",
+			cookie: "state",
+			mockSetup: func(m *mocks.MockAuthUsecase) {
+				m.EXPECT().CompleteOAuth(gomock.Any(), "google", gomock.Any()).Return(models.User{}, "", errors.New("database error"))
+			},
+			expectedLocation: "https://ddfilms.ru/signin?oauth_error=internal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+
+			handler := NewAuthHandler(mockUsecase)
+			handler.AppBaseURL = "https://ddfilms.ru"
+
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			r = mux.SetURLVars(r, map[string]string{"provider": "google"})
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: OAuthCookieName, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			handler.OAuthCallback(w, r)
+
+			assert.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			// state одноразовый, кука стирается при любом исходе
+			stateCookie := findCookie(w.Result().Cookies(), OAuthCookieName)
+			if assert.NotNil(t, stateCookie) {
+				assert.Empty(t, stateCookie.Value)
+			}
+			assert.Equal(t, tt.expectSession, findCookie(w.Result().Cookies(), CookieName) != nil)
+		})
+	}
+}
+
+func TestUnlinkIdentity(t *testing.T) {
+	tests := []struct {
+		name           string
+		ucErr          error
+		expectedStatus int
+	}{
+		{name: "Success", expectedStatus: http.StatusOK},
+		{name: "Not linked", ucErr: auth.ErrorNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Last sign in method", ucErr: auth.ErrorConflict, expectedStatus: http.StatusConflict},
+		{name: "Internal server error", ucErr: errors.New("database error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+			mockUsecase.EXPECT().UnlinkIdentity(gomock.Any(), "vk").Return(tt.ucErr)
+
+			r := httptest.NewRequest(http.MethodDelete, "/auth/oauth/vk", nil).WithContext(testContext())
+			r = mux.SetURLVars(r, map[string]string{"provider": "vk"})
+			w := httptest.NewRecorder()
+
+			NewAuthHandler(mockUsecase).UnlinkIdentity(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestGetIdentities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUsecase := mocks.NewMockAuthUsecase(ctrl)
+
+	mockUsecase.EXPECT().GetIdentities(gomock.Any()).Return([]models.LinkedIdentity{{Provider: "vk", Email: "<b>@x.ru"}}, nil)
+	r := httptest.NewRequest(http.MethodGet, "/auth/oauth/identities", nil).WithContext(testContext())
+	w := httptest.NewRecorder()
+
+	NewAuthHandler(mockUsecase).GetIdentities(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var identities []models.LinkedIdentity
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &identities))
+	assert.Equal(t, "&lt;b&gt;@x.ru", identities[0].Email)
+}
@@ -1,10 +1,24 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrorBadRequest          = errors.New("wrong login or password")
 	ErrorConflict            = errors.New("user already exists")
 	ErrorUnauthorized        = errors.New("user is unauthorized")
 	ErrorInternalServerError = errors.New("internal server error")
+	ErrorForbidden           = errors.New("forbidden")
+	ErrorNotFound            = errors.New("not found")
+	ErrorTwoFactorRequired   = errors.New("second factor is required")
+	ErrorBadGateway          = errors.New("identity provider failed")
+
+	// уточнения общих ошибок получают в ответе свой код, а errors.Is с общей ошибкой продолжает работать
+	ErrorInvalidLogin     = fmt.Errorf("invalid login: %w", ErrorBadRequest)
+	ErrorWeakPassword     = fmt.Errorf("password does not satisfy the policy: %w", ErrorBadRequest)
+	ErrorWrongCredentials = fmt.Errorf("wrong login or password: %w", ErrorBadRequest)
+	ErrorWrongPassword    = fmt.Errorf("wrong password: %w", ErrorBadRequest)
+	ErrorLoginTaken       = fmt.Errorf("login is taken: %w", ErrorConflict)
 )
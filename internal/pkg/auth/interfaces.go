@@ -3,19 +3,40 @@ package auth
 import (
 	"context"
 	"kinopoisk/internal/models"
+	"net/url"
 
 	"github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
 )
 
 type AuthUsecase interface {
-	GenerateToken(id uuid.UUID, login string) (string, error)
+	GenerateToken(id uuid.UUID, login string, version int) (string, error)
 	ParseToken(token string) (*jwt.Token, error)
 	SignUpUser(ctx context.Context, req models.SignUpInput) (models.User, string, error)
+	// SignInUser при включённом втором факторе возвращает ErrorTwoFactorRequired и вместо JWT
+	// короткоживущий токен, который вместе с кодом передаётся в VerifyTwoFactor
 	SignInUser(ctx context.Context, req models.SignInInput) (models.User, string, error)
 	CheckAuth(ctx context.Context) (models.User, error)
 	LogOutUser(ctx context.Context) error
 	ValidateAndGetUser(ctx context.Context, token string) (models.User, error)
+	VerifyTwoFactor(ctx context.Context, req models.TwoFactorSignInInput) (models.User, string, error)
+	SetupTwoFactor(ctx context.Context) (models.TwoFactorSetup, error)
+	EnableTwoFactor(ctx context.Context, code string) (models.RecoveryCodes, string, error)
+	DisableTwoFactor(ctx context.Context, password string) error
+	OAuthProviders() []string
+	// StartOAuth возвращает адрес провайдера и state, который нужно закрепить за браузером.
+	// При link найденная учётка привяжется к текущему пользователю
+	StartOAuth(ctx context.Context, provider string, link bool) (string, string, error)
+	// CompleteOAuth обрабатывает колбэк провайдера. Как и SignInUser, может вернуть ErrorTwoFactorRequired;
+	// при привязке возвращает пустой токен
+	CompleteOAuth(ctx context.Context, provider string, callback url.Values) (models.User, string, error)
+	GetIdentities(ctx context.Context) ([]models.LinkedIdentity, error)
+	UnlinkIdentity(ctx context.Context, provider string) error
+}
+
+type OAuthProvider interface {
+	AuthCodeURL(state, codeChallenge string) string
+	Exchange(ctx context.Context, callback url.Values, codeVerifier string) (models.ExternalIdentity, error)
 }
 
 type AuthRepo interface {
@@ -23,5 +44,25 @@ type AuthRepo interface {
 	CreateUser(ctx context.Context, user models.User) error
 	CheckUserLogin(ctx context.Context, login string) (models.User, error)
 	IncrementUserVersion(ctx context.Context, userID uuid.UUID) error
+	RehashPassword(ctx context.Context, userID uuid.UUID, oldHash, newHash []byte) error
+	CancelUserDeletion(ctx context.Context, userID uuid.UUID) (bool, error)
 	GetUserByLogin(ctx context.Context, login string) (models.User, error)
+	GetUserByID(ctx context.Context, userID uuid.UUID) (models.User, error)
+	GetUserRole(ctx context.Context, userID uuid.UUID) (string, error)
+	GetTOTP(ctx context.Context, userID uuid.UUID) (models.TOTP, error)
+	SaveTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes [][]byte) error
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) error
+	DeleteTOTP(ctx context.Context, userID uuid.UUID) error
+	CreatePendingSignIn(ctx context.Context, token models.UserToken) error
+	AttemptPendingSignIn(ctx context.Context, tokenHash []byte, maxAttempts int) (uuid.UUID, error)
+	CompletePendingSignIn(ctx context.Context, tokenHash []byte) error
+	CreateOAuthState(ctx context.Context, state models.OAuthState) error
+	ConsumeOAuthState(ctx context.Context, stateHash []byte, provider string) (models.OAuthState, error)
+	GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error)
+	CreateOAuthUser(ctx context.Context, user models.User, identity models.ExternalIdentity) error
+	LinkIdentity(ctx context.Context, userID uuid.UUID, identity models.ExternalIdentity) error
+	GetIdentities(ctx context.Context, userID uuid.UUID) ([]models.LinkedIdentity, error)
+	UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error
 }
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	models "kinopoisk/internal/models"
+	url "net/url"
 	reflect "reflect"
 
 	jwt "github.com/golang-jwt/jwt"
@@ -58,19 +59,80 @@ func (mr *MockAuthUsecaseMockRecorder) CheckAuth(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckAuth", reflect.TypeOf((*MockAuthUsecase)(nil).CheckAuth), ctx)
 }
 
+// CompleteOAuth mocks base method.
+func (m *MockAuthUsecase) CompleteOAuth(ctx context.Context, provider string, callback url.Values) (models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOAuth", ctx, provider, callback)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompleteOAuth indicates an expected call of CompleteOAuth.
+func (mr *MockAuthUsecaseMockRecorder) CompleteOAuth(ctx, provider, callback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOAuth", reflect.TypeOf((*MockAuthUsecase)(nil).CompleteOAuth), ctx, provider, callback)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockAuthUsecase) DisableTwoFactor(ctx context.Context, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", ctx, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockAuthUsecaseMockRecorder) DisableTwoFactor(ctx, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockAuthUsecase)(nil).DisableTwoFactor), ctx, password)
+}
+
+// EnableTwoFactor mocks base method.
+func (m *MockAuthUsecase) EnableTwoFactor(ctx context.Context, code string) (models.RecoveryCodes, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, code)
+	ret0, _ := ret[0].(models.RecoveryCodes)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockAuthUsecaseMockRecorder) EnableTwoFactor(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockAuthUsecase)(nil).EnableTwoFactor), ctx, code)
+}
+
 // GenerateToken mocks base method.
-func (m *MockAuthUsecase) GenerateToken(id uuid.UUID, login string) (string, error) {
+func (m *MockAuthUsecase) GenerateToken(id uuid.UUID, login string, version int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", id, login)
+	ret := m.ctrl.Call(m, "GenerateToken", id, login, version)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockAuthUsecaseMockRecorder) GenerateToken(id, login any) *gomock.Call {
+func (mr *MockAuthUsecaseMockRecorder) GenerateToken(id, login, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthUsecase)(nil).GenerateToken), id, login, version)
+}
+
+// GetIdentities mocks base method.
+func (m *MockAuthUsecase) GetIdentities(ctx context.Context) ([]models.LinkedIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentities", ctx)
+	ret0, _ := ret[0].([]models.LinkedIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentities indicates an expected call of GetIdentities.
+func (mr *MockAuthUsecaseMockRecorder) GetIdentities(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthUsecase)(nil).GenerateToken), id, login)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentities", reflect.TypeOf((*MockAuthUsecase)(nil).GetIdentities), ctx)
 }
 
 // LogOutUser mocks base method.
@@ -87,6 +149,20 @@ func (mr *MockAuthUsecaseMockRecorder) LogOutUser(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogOutUser", reflect.TypeOf((*MockAuthUsecase)(nil).LogOutUser), ctx)
 }
 
+// OAuthProviders mocks base method.
+func (m *MockAuthUsecase) OAuthProviders() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OAuthProviders")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OAuthProviders indicates an expected call of OAuthProviders.
+func (mr *MockAuthUsecaseMockRecorder) OAuthProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OAuthProviders", reflect.TypeOf((*MockAuthUsecase)(nil).OAuthProviders))
+}
+
 // ParseToken mocks base method.
 func (m *MockAuthUsecase) ParseToken(token string) (*jwt.Token, error) {
 	m.ctrl.T.Helper()
@@ -102,6 +178,21 @@ func (mr *MockAuthUsecaseMockRecorder) ParseToken(token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthUsecase)(nil).ParseToken), token)
 }
 
+// SetupTwoFactor mocks base method.
+func (m *MockAuthUsecase) SetupTwoFactor(ctx context.Context) (models.TwoFactorSetup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetupTwoFactor", ctx)
+	ret0, _ := ret[0].(models.TwoFactorSetup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetupTwoFactor indicates an expected call of SetupTwoFactor.
+func (mr *MockAuthUsecaseMockRecorder) SetupTwoFactor(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetupTwoFactor", reflect.TypeOf((*MockAuthUsecase)(nil).SetupTwoFactor), ctx)
+}
+
 // SignInUser mocks base method.
 func (m *MockAuthUsecase) SignInUser(ctx context.Context, req models.SignInInput) (models.User, string, error) {
 	m.ctrl.T.Helper()
@@ -134,6 +225,36 @@ func (mr *MockAuthUsecaseMockRecorder) SignUpUser(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUpUser", reflect.TypeOf((*MockAuthUsecase)(nil).SignUpUser), ctx, req)
 }
 
+// StartOAuth mocks base method.
+func (m *MockAuthUsecase) StartOAuth(ctx context.Context, provider string, link bool) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOAuth", ctx, provider, link)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StartOAuth indicates an expected call of StartOAuth.
+func (mr *MockAuthUsecaseMockRecorder) StartOAuth(ctx, provider, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOAuth", reflect.TypeOf((*MockAuthUsecase)(nil).StartOAuth), ctx, provider, link)
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockAuthUsecase) UnlinkIdentity(ctx context.Context, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", ctx, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockAuthUsecaseMockRecorder) UnlinkIdentity(ctx, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockAuthUsecase)(nil).UnlinkIdentity), ctx, provider)
+}
+
 // ValidateAndGetUser mocks base method.
 func (m *MockAuthUsecase) ValidateAndGetUser(ctx context.Context, token string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -149,6 +270,75 @@ func (mr *MockAuthUsecaseMockRecorder) ValidateAndGetUser(ctx, token any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAndGetUser", reflect.TypeOf((*MockAuthUsecase)(nil).ValidateAndGetUser), ctx, token)
 }
 
+// VerifyTwoFactor mocks base method.
+func (m *MockAuthUsecase) VerifyTwoFactor(ctx context.Context, req models.TwoFactorSignInInput) (models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactor", ctx, req)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyTwoFactor indicates an expected call of VerifyTwoFactor.
+func (mr *MockAuthUsecaseMockRecorder) VerifyTwoFactor(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactor", reflect.TypeOf((*MockAuthUsecase)(nil).VerifyTwoFactor), ctx, req)
+}
+
+// MockOAuthProvider is a mock of OAuthProvider interface.
+type MockOAuthProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockOAuthProviderMockRecorder is the mock recorder for MockOAuthProvider.
+type MockOAuthProviderMockRecorder struct {
+	mock *MockOAuthProvider
+}
+
+// NewMockOAuthProvider creates a new mock instance.
+func NewMockOAuthProvider(ctrl *gomock.Controller) *MockOAuthProvider {
+	mock := &MockOAuthProvider{ctrl: ctrl}
+	mock.recorder = &MockOAuthProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthProvider) EXPECT() *MockOAuthProviderMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockOAuthProvider) AuthCodeURL(state, codeChallenge string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", state, codeChallenge)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockOAuthProviderMockRecorder) AuthCodeURL(state, codeChallenge any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockOAuthProvider)(nil).AuthCodeURL), state, codeChallenge)
+}
+
+// Exchange mocks base method.
+func (m *MockOAuthProvider) Exchange(ctx context.Context, callback url.Values, codeVerifier string) (models.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, callback, codeVerifier)
+	ret0, _ := ret[0].(models.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockOAuthProviderMockRecorder) Exchange(ctx, callback, codeVerifier any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockOAuthProvider)(nil).Exchange), ctx, callback, codeVerifier)
+}
+
 // MockAuthRepo is a mock of AuthRepo interface.
 type MockAuthRepo struct {
 	ctrl     *gomock.Controller
@@ -173,6 +363,36 @@ func (m *MockAuthRepo) EXPECT() *MockAuthRepoMockRecorder {
 	return m.recorder
 }
 
+// AttemptPendingSignIn mocks base method.
+func (m *MockAuthRepo) AttemptPendingSignIn(ctx context.Context, tokenHash []byte, maxAttempts int) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttemptPendingSignIn", ctx, tokenHash, maxAttempts)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttemptPendingSignIn indicates an expected call of AttemptPendingSignIn.
+func (mr *MockAuthRepoMockRecorder) AttemptPendingSignIn(ctx, tokenHash, maxAttempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttemptPendingSignIn", reflect.TypeOf((*MockAuthRepo)(nil).AttemptPendingSignIn), ctx, tokenHash, maxAttempts)
+}
+
+// CancelUserDeletion mocks base method.
+func (m *MockAuthRepo) CancelUserDeletion(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelUserDeletion", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelUserDeletion indicates an expected call of CancelUserDeletion.
+func (mr *MockAuthRepoMockRecorder) CancelUserDeletion(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelUserDeletion", reflect.TypeOf((*MockAuthRepo)(nil).CancelUserDeletion), ctx, userID)
+}
+
 // CheckUserExists mocks base method.
 func (m *MockAuthRepo) CheckUserExists(ctx context.Context, login string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +423,77 @@ func (mr *MockAuthRepoMockRecorder) CheckUserLogin(ctx, login any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserLogin", reflect.TypeOf((*MockAuthRepo)(nil).CheckUserLogin), ctx, login)
 }
 
+// CompletePendingSignIn mocks base method.
+func (m *MockAuthRepo) CompletePendingSignIn(ctx context.Context, tokenHash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePendingSignIn", ctx, tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompletePendingSignIn indicates an expected call of CompletePendingSignIn.
+func (mr *MockAuthRepoMockRecorder) CompletePendingSignIn(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePendingSignIn", reflect.TypeOf((*MockAuthRepo)(nil).CompletePendingSignIn), ctx, tokenHash)
+}
+
+// ConsumeOAuthState mocks base method.
+func (m *MockAuthRepo) ConsumeOAuthState(ctx context.Context, stateHash []byte, provider string) (models.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOAuthState", ctx, stateHash, provider)
+	ret0, _ := ret[0].(models.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOAuthState indicates an expected call of ConsumeOAuthState.
+func (mr *MockAuthRepoMockRecorder) ConsumeOAuthState(ctx, stateHash, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOAuthState", reflect.TypeOf((*MockAuthRepo)(nil).ConsumeOAuthState), ctx, stateHash, provider)
+}
+
+// CreateOAuthState mocks base method.
+func (m *MockAuthRepo) CreateOAuthState(ctx context.Context, state models.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthState", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthState indicates an expected call of CreateOAuthState.
+func (mr *MockAuthRepoMockRecorder) CreateOAuthState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthState", reflect.TypeOf((*MockAuthRepo)(nil).CreateOAuthState), ctx, state)
+}
+
+// CreateOAuthUser mocks base method.
+func (m *MockAuthRepo) CreateOAuthUser(ctx context.Context, user models.User, identity models.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthUser", ctx, user, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthUser indicates an expected call of CreateOAuthUser.
+func (mr *MockAuthRepoMockRecorder) CreateOAuthUser(ctx, user, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthUser", reflect.TypeOf((*MockAuthRepo)(nil).CreateOAuthUser), ctx, user, identity)
+}
+
+// CreatePendingSignIn mocks base method.
+func (m *MockAuthRepo) CreatePendingSignIn(ctx context.Context, token models.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingSignIn", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePendingSignIn indicates an expected call of CreatePendingSignIn.
+func (mr *MockAuthRepoMockRecorder) CreatePendingSignIn(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingSignIn", reflect.TypeOf((*MockAuthRepo)(nil).CreatePendingSignIn), ctx, token)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthRepo) CreateUser(ctx context.Context, user models.User) error {
 	m.ctrl.T.Helper()
@@ -217,6 +508,94 @@ func (mr *MockAuthRepoMockRecorder) CreateUser(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthRepo)(nil).CreateUser), ctx, user)
 }
 
+// DeleteTOTP mocks base method.
+func (m *MockAuthRepo) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockAuthRepoMockRecorder) DeleteTOTP(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockAuthRepo)(nil).DeleteTOTP), ctx, userID)
+}
+
+// EnableTOTP mocks base method.
+func (m *MockAuthRepo) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", ctx, userID, step, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MockAuthRepoMockRecorder) EnableTOTP(ctx, userID, step, recoveryCodeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MockAuthRepo)(nil).EnableTOTP), ctx, userID, step, recoveryCodeHashes)
+}
+
+// GetIdentities mocks base method.
+func (m *MockAuthRepo) GetIdentities(ctx context.Context, userID uuid.UUID) ([]models.LinkedIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentities", ctx, userID)
+	ret0, _ := ret[0].([]models.LinkedIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentities indicates an expected call of GetIdentities.
+func (mr *MockAuthRepoMockRecorder) GetIdentities(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentities", reflect.TypeOf((*MockAuthRepo)(nil).GetIdentities), ctx, userID)
+}
+
+// GetTOTP mocks base method.
+func (m *MockAuthRepo) GetTOTP(ctx context.Context, userID uuid.UUID) (models.TOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTP", ctx, userID)
+	ret0, _ := ret[0].(models.TOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTP indicates an expected call of GetTOTP.
+func (mr *MockAuthRepoMockRecorder) GetTOTP(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTP", reflect.TypeOf((*MockAuthRepo)(nil).GetTOTP), ctx, userID)
+}
+
+// GetUserByID mocks base method.
+func (m *MockAuthRepo) GetUserByID(ctx context.Context, userID uuid.UUID) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockAuthRepoMockRecorder) GetUserByID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockAuthRepo)(nil).GetUserByID), ctx, userID)
+}
+
+// GetUserByIdentity mocks base method.
+func (m *MockAuthRepo) GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByIdentity indicates an expected call of GetUserByIdentity.
+func (mr *MockAuthRepoMockRecorder) GetUserByIdentity(ctx, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByIdentity", reflect.TypeOf((*MockAuthRepo)(nil).GetUserByIdentity), ctx, provider, subject)
+}
+
 // GetUserByLogin mocks base method.
 func (m *MockAuthRepo) GetUserByLogin(ctx context.Context, login string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -232,6 +611,21 @@ func (mr *MockAuthRepoMockRecorder) GetUserByLogin(ctx, login any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockAuthRepo)(nil).GetUserByLogin), ctx, login)
 }
 
+// GetUserRole mocks base method.
+func (m *MockAuthRepo) GetUserRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRole", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRole indicates an expected call of GetUserRole.
+func (mr *MockAuthRepoMockRecorder) GetUserRole(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRole", reflect.TypeOf((*MockAuthRepo)(nil).GetUserRole), ctx, userID)
+}
+
 // IncrementUserVersion mocks base method.
 func (m *MockAuthRepo) IncrementUserVersion(ctx context.Context, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -245,3 +639,87 @@ func (mr *MockAuthRepoMockRecorder) IncrementUserVersion(ctx, userID any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementUserVersion", reflect.TypeOf((*MockAuthRepo)(nil).IncrementUserVersion), ctx, userID)
 }
+
+// LinkIdentity mocks base method.
+func (m *MockAuthRepo) LinkIdentity(ctx context.Context, userID uuid.UUID, identity models.ExternalIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", ctx, userID, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockAuthRepoMockRecorder) LinkIdentity(ctx, userID, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockAuthRepo)(nil).LinkIdentity), ctx, userID, identity)
+}
+
+// RehashPassword mocks base method.
+func (m *MockAuthRepo) RehashPassword(ctx context.Context, userID uuid.UUID, oldHash, newHash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehashPassword", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RehashPassword indicates an expected call of RehashPassword.
+func (mr *MockAuthRepoMockRecorder) RehashPassword(ctx, userID, oldHash, newHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashPassword", reflect.TypeOf((*MockAuthRepo)(nil).RehashPassword), ctx, userID, oldHash, newHash)
+}
+
+// SaveTOTPSecret mocks base method.
+func (m *MockAuthRepo) SaveTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTPSecret", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTPSecret indicates an expected call of SaveTOTPSecret.
+func (mr *MockAuthRepoMockRecorder) SaveTOTPSecret(ctx, userID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPSecret", reflect.TypeOf((*MockAuthRepo)(nil).SaveTOTPSecret), ctx, userID, secret)
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockAuthRepo) UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", ctx, userID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockAuthRepoMockRecorder) UnlinkIdentity(ctx, userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockAuthRepo)(nil).UnlinkIdentity), ctx, userID, provider)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockAuthRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockAuthRepoMockRecorder) UseRecoveryCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockAuthRepo)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockAuthRepo) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockAuthRepoMockRecorder) UseTOTPStep(ctx, userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockAuthRepo)(nil).UseTOTPStep), ctx, userID, step)
+}
@@ -0,0 +1,73 @@
+package oidc
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	ProviderVK     = "vk"
+	ProviderYandex = "yandex"
+	ProviderGoogle = "google"
+)
+
+// Defaults настройки известных провайдеров; адреса можно переопределить через окружение,
+// например чтобы прогнать вход против локального mock IdP
+var Defaults = []Config{
+	{
+		Name:           ProviderVK,
+		AuthURL:        "https://id.vk.com/authorize",
+		TokenURL:       "https://id.vk.com/oauth2/auth",
+		UserInfoURL:    "https://id.vk.com/oauth2/user_info",
+		Scopes:         []string{"email"},
+		UserInfo:       UserInfoForm,
+		Claims:         Claims{Root: "user", Subject: "user_id", Email: "email"},
+		CallbackParams: []string{"device_id", "state"},
+	},
+	{
+		Name:        ProviderYandex,
+		AuthURL:     "https://oauth.yandex.ru/authorize",
+		TokenURL:    "https://oauth.yandex.ru/token",
+		UserInfoURL: "https://login.yandex.ru/info?format=json",
+		Scopes:      []string{"login:info", "login:email"},
+		UserInfo:    UserInfoOAuth,
+		Claims:      Claims{Subject: "id", Email: "default_email", Username: "login"},
+	},
+	{
+		Name:        ProviderGoogle,
+		AuthURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+		TokenURL:    "https://oauth2.googleapis.com/token",
+		UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+		Scopes:      []string{"openid", "email"},
+		UserInfo:    UserInfoBearer,
+		Claims:      Claims{Subject: "sub", Email: "email", EmailVerified: "email_verified"},
+	},
+}
+
+// ConfigsFromEnv возвращает провайдеров, для которых задан OIDC_<NAME>_CLIENT_ID.
+// Колбэк по умолчанию {baseURL}/api/auth/oauth/{name}/callback
+func ConfigsFromEnv(baseURL string) []Config {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	configs := make([]Config, 0, len(Defaults))
+	for _, cfg := range Defaults {
+		prefix := "OIDC_" + strings.ToUpper(cfg.Name) + "_"
+		cfg.ClientID = os.Getenv(prefix + "CLIENT_ID")
+		if cfg.ClientID == "" {
+			continue
+		}
+		cfg.ClientSecret = os.Getenv(prefix + "CLIENT_SECRET")
+		cfg.AuthURL = envOrDefault(prefix+"AUTH_URL", cfg.AuthURL)
+		cfg.TokenURL = envOrDefault(prefix+"TOKEN_URL", cfg.TokenURL)
+		cfg.UserInfoURL = envOrDefault(prefix+"USERINFO_URL", cfg.UserInfoURL)
+		cfg.RedirectURL = envOrDefault(prefix+"REDIRECT_URL", baseURL+"/api/auth/oauth/"+cfg.Name+"/callback")
+		configs = append(configs, cfg)
+	}
+	return configs
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
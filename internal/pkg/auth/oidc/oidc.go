@@ -0,0 +1,225 @@
+// Package oidc реализует вход через внешних провайдеров по authorization code с PKCE (RFC 7636).
+// Личность берётся из userinfo по access token, полученному напрямую от провайдера
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"kinopoisk/internal/models"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// UserInfoBearer стандартный OIDC: GET с заголовком Authorization: Bearer
+	UserInfoBearer = "bearer"
+	// UserInfoOAuth Яндекс ждёт заголовок Authorization: OAuth
+	UserInfoOAuth = "oauth"
+	// UserInfoForm VK ID принимает токен POST-формой вместе с client_id
+	UserInfoForm = "form"
+
+	maxResponseSize = 1 << 20
+)
+
+var (
+	ErrExchange = errors.New("failed to exchange authorization code")
+	ErrUserInfo = errors.New("failed to get user info")
+)
+
+// Claims где искать поля в ответе userinfo. Root - вложенный объект, если поля лежат не в корне
+type Claims struct {
+	Root     string
+	Subject  string
+	Email    string
+	Username string
+	// EmailVerified флаг подтверждения почты; пусто, если провайдер отдаёт только подтверждённые адреса
+	EmailVerified string
+}
+
+type Config struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	RedirectURL  string
+	Scopes       []string
+	UserInfo     string
+	Claims       Claims
+	// CallbackParams параметры колбэка, которые провайдер требует вернуть при обмене кода
+	CallbackParams []string
+}
+
+type Provider struct {
+	cfg    Config
+	client *http.Client
+}
+
+func NewProvider(cfg Config, client *http.Client) *Provider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Provider{cfg: cfg, client: client}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+func (p *Provider) AuthCodeURL(state, codeChallenge string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.cfg.ClientID)
+	query.Set("redirect_uri", p.cfg.RedirectURL)
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	if len(p.cfg.Scopes) > 0 {
+		query.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	}
+
+	separator := "?"
+	if strings.Contains(p.cfg.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.cfg.AuthURL + separator + query.Encode()
+}
+
+// Exchange меняет код из колбэка на access token и по нему получает пользователя провайдера
+func (p *Provider) Exchange(ctx context.Context, callback url.Values, codeVerifier string) (models.ExternalIdentity, error) {
+	accessToken, err := p.exchangeCode(ctx, callback, codeVerifier)
+	if err != nil {
+		return models.ExternalIdentity{}, err
+	}
+
+	claims, err := p.userInfo(ctx, accessToken)
+	if err != nil {
+		return models.ExternalIdentity{}, err
+	}
+
+	if p.cfg.Claims.Root != "" {
+		nested, ok := claims[p.cfg.Claims.Root].(map[string]any)
+		if !ok {
+			return models.ExternalIdentity{}, fmt.Errorf("%w: no %q object", ErrUserInfo, p.cfg.Claims.Root)
+		}
+		claims = nested
+	}
+
+	identity := models.ExternalIdentity{
+		Provider: p.cfg.Name,
+		Subject:  claimString(claims, p.cfg.Claims.Subject),
+		Email:    claimString(claims, p.cfg.Claims.Email),
+		Username: claimString(claims, p.cfg.Claims.Username),
+	}
+	identity.EmailVerified = identity.Email != "" &&
+		(p.cfg.Claims.EmailVerified == "" || claims[p.cfg.Claims.EmailVerified] == true)
+	if identity.Subject == "" {
+		return models.ExternalIdentity{}, fmt.Errorf("%w: empty subject", ErrUserInfo)
+	}
+	return identity, nil
+}
+
+func (p *Provider) exchangeCode(ctx context.Context, callback url.Values, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", callback.Get("code"))
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+	for _, param := range p.cfg.CallbackParams {
+		form.Set(param, callback.Get(param))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}
+	if err := p.doJSON(req, &token); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	if token.Error != "" || token.AccessToken == "" {
+		return "", fmt.Errorf("%w: %s", ErrExchange, token.Error)
+	}
+	return token.AccessToken, nil
+}
+
+func (p *Provider) userInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	var req *http.Request
+	var err error
+	switch p.cfg.UserInfo {
+	case UserInfoForm:
+		form := url.Values{}
+		form.Set("client_id", p.cfg.ClientID)
+		form.Set("access_token", accessToken)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.UserInfoURL, strings.NewReader(form.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	case UserInfoOAuth:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+		if err == nil {
+			req.Header.Set("Authorization", "OAuth "+accessToken)
+		}
+	default:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+		if err == nil {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserInfo, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var claims map[string]any
+	if err := p.doJSON(req, &claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUserInfo, err)
+	}
+	return claims, nil
+}
+
+func (p *Provider) doJSON(req *http.Request, dest any) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize))
+	decoder.UseNumber()
+	return decoder.Decode(dest)
+}
+
+// claimString читает строковое поле; числовые идентификаторы (VK) приводятся к строке
+func claimString(claims map[string]any, name string) string {
+	if name == "" {
+		return ""
+	}
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,148 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"kinopoisk/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockIdP минимальный провайдер: выдаёт токен только при верном code_verifier
+func mockIdP(t *testing.T, verifier string, userInfo any) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "client", r.PostForm.Get("client_id"))
+		assert.Equal(t, "https://app/callback", r.PostForm.Get("redirect_uri"))
+		if r.PostForm.Get("code") != "code" || r.PostForm.Get("code_verifier") != verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(userInfo)
+	})
+	mux.HandleFunc("/form", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if r.Method != http.MethodPost || r.PostForm.Get("access_token") != "access" || r.PostForm.Get("client_id") != "client" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(userInfo)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testConfig(server *httptest.Server) Config {
+	return Config{
+		Name:        "mock",
+		ClientID:    "client",
+		AuthURL:     server.URL + "/authorize",
+		TokenURL:    server.URL + "/token",
+		UserInfoURL: server.URL + "/userinfo",
+		RedirectURL: "https://app/callback",
+		Scopes:      []string{"openid", "email"},
+		Claims:      Claims{Subject: "sub", Email: "email", Username: "preferred_username", EmailVerified: "email_verified"},
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	provider := NewProvider(Config{
+		ClientID:    "client",
+		AuthURL:     "https://idp/authorize?prompt=login",
+		RedirectURL: "https://app/callback",
+		Scopes:      []string{"openid", "email"},
+	}, nil)
+
+	parsed, err := url.Parse(provider.AuthCodeURL("state", Challenge("verifier")))
+	assert.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "login", query.Get("prompt"))
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, Challenge("verifier"), query.Get("code_challenge"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+}
+
+func TestChallenge(t *testing.T) {
+	// пример из приложения B RFC 7636
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestExchange(t *testing.T) {
+	server := mockIdP(t, "verifier", map[string]any{"sub": "42", "email": "user@example.com", "email_verified": true, "preferred_username": "user"})
+	provider := NewProvider(testConfig(server), server.Client())
+
+	identity, err := provider.Exchange(context.Background(), url.Values{"code": {"code"}}, "verifier")
+	assert.NoError(t, err)
+	assert.Equal(t, models.ExternalIdentity{Provider: "mock", Subject: "42", Email: "user@example.com", Username: "user", EmailVerified: true}, identity)
+
+	_, err = provider.Exchange(context.Background(), url.Values{"code": {"code"}}, "stolen")
+	assert.True(t, errors.Is(err, ErrExchange))
+}
+
+func TestExchangeNestedClaims(t *testing.T) {
+	// VK ID отдаёт пользователя во вложенном объекте и числовой идентификатор
+	server := mockIdP(t, "verifier", map[string]any{"user": map[string]any{"user_id": 1234567890123, "email": "vk@example.com"}})
+	cfg := testConfig(server)
+	cfg.UserInfoURL = server.URL + "/form"
+	cfg.UserInfo = UserInfoForm
+	cfg.Claims = Claims{Root: "user", Subject: "user_id", Email: "email"}
+	provider := NewProvider(cfg, server.Client())
+
+	identity, err := provider.Exchange(context.Background(), url.Values{"code": {"code"}}, "verifier")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890123", identity.Subject)
+	assert.Equal(t, "vk@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+}
+
+func TestExchangeUnverifiedEmail(t *testing.T) {
+	server := mockIdP(t, "verifier", map[string]any{"sub": "42", "email": "user@example.com", "email_verified": false})
+	provider := NewProvider(testConfig(server), server.Client())
+
+	identity, err := provider.Exchange(context.Background(), url.Values{"code": {"code"}}, "verifier")
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", identity.Email)
+	assert.False(t, identity.EmailVerified)
+}
+
+func TestExchangeWithoutSubject(t *testing.T) {
+	server := mockIdP(t, "verifier", map[string]any{"email": "user@example.com"})
+	provider := NewProvider(testConfig(server), server.Client())
+
+	_, err := provider.Exchange(context.Background(), url.Values{"code": {"code"}}, "verifier")
+	assert.True(t, errors.Is(err, ErrUserInfo))
+}
+
+func TestConfigsFromEnv(t *testing.T) {
+	t.Setenv("OIDC_GOOGLE_CLIENT_ID", "google-client")
+	t.Setenv("OIDC_GOOGLE_AUTH_URL", "http://localhost:8081/authorize")
+	t.Setenv("OIDC_VK_CLIENT_ID", "")
+	t.Setenv("OIDC_YANDEX_CLIENT_ID", "")
+
+	configs := ConfigsFromEnv("https://ddfilms.ru/")
+	assert.Len(t, configs, 1)
+	assert.Equal(t, ProviderGoogle, configs[0].Name)
+	assert.Equal(t, "google-client", configs[0].ClientID)
+	assert.Equal(t, "http://localhost:8081/authorize", configs[0].AuthURL)
+	assert.Equal(t, "https://oauth2.googleapis.com/token", configs[0].TokenURL)
+	assert.Equal(t, "https://ddfilms.ru/api/auth/oauth/google/callback", configs[0].RedirectURL)
+}
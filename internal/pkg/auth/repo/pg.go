@@ -8,11 +8,14 @@ import (
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
 
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype/pgxtype"
 	"github.com/jackc/pgx/v4"
 	uuid "github.com/satori/go.uuid"
 )
 
+const uniqueViolation = "23505"
+
 type AuthRepository struct {
 	db pgxtype.Querier
 }
@@ -62,6 +65,7 @@ func (r *AuthRepository) CheckUserLogin(ctx context.Context, login string) (mode
 		&user.Version,
 		&user.Login,
 		&user.PasswordHash,
+		&user.PasswordSet,
 		&user.Avatar,
 		&user.CreatedAt,
 		&user.UpdatedAt)
@@ -92,6 +96,35 @@ func (r *AuthRepository) IncrementUserVersion(ctx context.Context, userID uuid.U
 	return nil
 }
 
+// RehashPassword подменяет хэш, только если пароль не успели сменить после входа; версию не трогает,
+// чтобы не разлогинивать пользователя
+func (r *AuthRepository) RehashPassword(ctx context.Context, userID uuid.UUID, oldHash, newHash []byte) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, RehashPasswordQuery, userID, oldHash, newHash)
+	if err != nil {
+		logger.Error("failed to rehash password: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("password was changed concurrently")
+		return auth.ErrorConflict
+	}
+	logger.Info("succesfully rehashed password in db")
+	return nil
+}
+
+// CancelUserDeletion возвращает true, если удаление аккаунта было запланировано и теперь отменено
+func (r *AuthRepository) CancelUserDeletion(ctx context.Context, userID uuid.UUID) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, CancelUserDeletionQuery, userID)
+	if err != nil {
+		logger.Error("failed to cancel deletion: " + err.Error())
+		return false, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully checked scheduled deletion")
+	return tag.RowsAffected() > 0, nil
+}
+
 func (r *AuthRepository) GetUserByLogin(ctx context.Context, login string) (models.User, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 	var user models.User
@@ -101,7 +134,7 @@ func (r *AuthRepository) GetUserByLogin(ctx context.Context, login string) (mode
 		login,
 	).Scan(
 		&user.ID, &user.Version, &user.Login,
-		&user.PasswordHash, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
+		&user.PasswordHash, &user.PasswordSet, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -114,3 +147,324 @@ func (r *AuthRepository) GetUserByLogin(ctx context.Context, login string) (mode
 	logger.Info("succesfully got user by login from db")
 	return user, nil
 }
+
+func (r *AuthRepository) GetUserByID(ctx context.Context, userID uuid.UUID) (models.User, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var user models.User
+	err := r.db.QueryRow(ctx, GetUserByIDQuery, userID).Scan(
+		&user.ID, &user.Version, &user.Login,
+		&user.PasswordHash, &user.PasswordSet, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return models.User{}, auth.ErrorUnauthorized
+		}
+		logger.Error("failed to scan user: " + err.Error())
+		return models.User{}, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully got user by id from db")
+	return user, nil
+}
+
+func (r *AuthRepository) GetUserRole(ctx context.Context, userID uuid.UUID) (string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var role string
+	err := r.db.QueryRow(ctx, GetUserRoleQuery, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return "", auth.ErrorUnauthorized
+		}
+		logger.Error("failed to scan role: " + err.Error())
+		return "", auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully got role of user from db")
+	return role, nil
+}
+
+func (r *AuthRepository) GetTOTP(ctx context.Context, userID uuid.UUID) (models.TOTP, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var totp models.TOTP
+	err := r.db.QueryRow(ctx, GetTOTPQuery, userID).Scan(&totp.UserID, &totp.Secret, &totp.LastUsedStep, &totp.Enabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Info("two factor is not set up")
+			return models.TOTP{}, auth.ErrorNotFound
+		}
+		logger.Error("failed to scan totp: " + err.Error())
+		return models.TOTP{}, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully got totp of user from db")
+	return totp, nil
+}
+
+// SaveTOTPSecret выдаёт новый секрет, пока второй фактор не включён; включённый не перезаписывается
+func (r *AuthRepository) SaveTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, SaveTOTPSecretQuery, userID, secret)
+	if err != nil {
+		logger.Error("failed to save totp secret: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("two factor is already enabled")
+		return auth.ErrorConflict
+	}
+	logger.Info("succesfully saved totp secret in db")
+	return nil
+}
+
+// EnableTOTP включает второй фактор и одним запросом заменяет коды восстановления
+func (r *AuthRepository) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes [][]byte) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, EnableTOTPQuery, userID, step, recoveryCodeHashes)
+	if err != nil {
+		logger.Error("failed to enable totp: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("two factor is already enabled or not set up")
+		return auth.ErrorConflict
+	}
+	logger.Info("succesfully enabled totp in db")
+	return nil
+}
+
+// UseTOTPStep запоминает шаг принятого кода; код того же или более раннего шага повторно не пройдёт
+func (r *AuthRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, UseTOTPStepQuery, userID, step)
+	if err != nil {
+		logger.Error("failed to use totp step: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("totp code was already used")
+		return auth.ErrorBadRequest
+	}
+	logger.Info("succesfully used totp step in db")
+	return nil
+}
+
+func (r *AuthRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, UseRecoveryCodeQuery, userID, codeHash)
+	if err != nil {
+		logger.Error("failed to use recovery code: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("recovery code not exists or already used")
+		return auth.ErrorBadRequest
+	}
+	logger.Info("succesfully used recovery code in db")
+	return nil
+}
+
+func (r *AuthRepository) DeleteTOTP(ctx context.Context, userID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, DeleteTOTPQuery, userID)
+	if err != nil {
+		logger.Error("failed to delete totp: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("two factor is not set up")
+		return auth.ErrorNotFound
+	}
+	logger.Info("succesfully deleted totp from db")
+	return nil
+}
+
+// CreatePendingSignIn сохраняет хэш токена, выданного после верного пароля; прежний токен пользователя отзывается
+func (r *AuthRepository) CreatePendingSignIn(ctx context.Context, token models.UserToken) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := r.db.Exec(
+		ctx,
+		CreatePendingSignInQuery,
+		token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt,
+	)
+	if err != nil {
+		logger.Error("failed to create pending sign in: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully created pending sign in")
+	return nil
+}
+
+// AttemptPendingSignIn расходует одну попытку ввода кода и возвращает пользователя токена
+func (r *AuthRepository) AttemptPendingSignIn(ctx context.Context, tokenHash []byte, maxAttempts int) (uuid.UUID, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var userID uuid.UUID
+	err := r.db.QueryRow(ctx, AttemptPendingSignInQuery, tokenHash, maxAttempts).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("pending sign in not exists, expired or out of attempts")
+			return uuid.Nil, auth.ErrorUnauthorized
+		}
+		logger.Error("failed to attempt pending sign in: " + err.Error())
+		return uuid.Nil, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully attempted pending sign in")
+	return userID, nil
+}
+
+func (r *AuthRepository) CompletePendingSignIn(ctx context.Context, tokenHash []byte) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, CompletePendingSignInQuery, tokenHash)
+	if err != nil {
+		logger.Error("failed to complete pending sign in: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("pending sign in was already completed")
+		return auth.ErrorUnauthorized
+	}
+	logger.Info("succesfully completed pending sign in")
+	return nil
+}
+
+// CreateOAuthState сохраняет начатый вход через провайдера и заодно чистит просроченные
+func (r *AuthRepository) CreateOAuthState(ctx context.Context, state models.OAuthState) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := r.db.Exec(
+		ctx,
+		CreateOAuthStateQuery,
+		state.StateHash, state.Provider, state.CodeVerifier, state.LinkUserID, state.ExpiresAt, state.CreatedAt,
+	)
+	if err != nil {
+		logger.Error("failed to create oauth state: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully created oauth state")
+	return nil
+}
+
+// ConsumeOAuthState удаляет state при чтении, поэтому колбэк с ним проходит только один раз
+func (r *AuthRepository) ConsumeOAuthState(ctx context.Context, stateHash []byte, provider string) (models.OAuthState, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	state := models.OAuthState{StateHash: stateHash, Provider: provider}
+	err := r.db.QueryRow(ctx, ConsumeOAuthStateQuery, stateHash, provider).Scan(&state.CodeVerifier, &state.LinkUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("oauth state not exists or expired")
+			return models.OAuthState{}, auth.ErrorUnauthorized
+		}
+		logger.Error("failed to consume oauth state: " + err.Error())
+		return models.OAuthState{}, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully consumed oauth state")
+	return state, nil
+}
+
+func (r *AuthRepository) GetUserByIdentity(ctx context.Context, provider, subject string) (models.User, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var user models.User
+	err := r.db.QueryRow(ctx, GetUserByIdentityQuery, provider, subject).Scan(
+		&user.ID,
+		&user.Version,
+		&user.Login,
+		&user.PasswordHash,
+		&user.PasswordSet,
+		&user.Avatar,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Info("identity is not linked")
+			return models.User{}, auth.ErrorNotFound
+		}
+		logger.Error("failed to scan user: " + err.Error())
+		return models.User{}, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully got user by identity from db")
+	return user, nil
+}
+
+// CreateOAuthUser создаёт пользователя без пароля вместе с привязкой; занятый логин даёт ErrorConflict.
+// Подтверждённая провайдером почта становится почтой аккаунта, если её ещё никто не подтвердил
+func (r *AuthRepository) CreateOAuthUser(ctx context.Context, user models.User, identity models.ExternalIdentity) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := r.db.Exec(
+		ctx,
+		CreateOAuthUserQuery,
+		user.ID, user.Login, user.PasswordHash, user.CreatedAt, user.UpdatedAt,
+		identity.Provider, identity.Subject, identity.Email, identity.EmailVerified,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			logger.Error("login or identity already taken: " + pgErr.ConstraintName)
+			return auth.ErrorConflict
+		}
+		logger.Error("failed to create oauth user: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully created oauth user")
+	return nil
+}
+
+func (r *AuthRepository) LinkIdentity(ctx context.Context, userID uuid.UUID, identity models.ExternalIdentity) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := r.db.Exec(ctx, LinkIdentityQuery, userID, identity.Provider, identity.Subject, identity.Email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			logger.Error("identity already linked: " + pgErr.ConstraintName)
+			return auth.ErrorConflict
+		}
+		logger.Error("failed to link identity: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully linked identity")
+	return nil
+}
+
+func (r *AuthRepository) GetIdentities(ctx context.Context, userID uuid.UUID) ([]models.LinkedIdentity, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	rows, err := r.db.Query(ctx, GetIdentitiesQuery, userID)
+	if err != nil {
+		logger.Error("failed to get identities: " + err.Error())
+		return nil, auth.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	identities := make([]models.LinkedIdentity, 0)
+	for rows.Next() {
+		var identity models.LinkedIdentity
+		if err := rows.Scan(&identity.Provider, &identity.Email, &identity.CreatedAt); err != nil {
+			logger.Error("failed to scan identity: " + err.Error())
+			return nil, auth.ErrorInternalServerError
+		}
+		identities = append(identities, identity)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to iterate identities: " + err.Error())
+		return nil, auth.ErrorInternalServerError
+	}
+	logger.Info("succesfully got identities from db")
+	return identities, nil
+}
+
+// UnlinkIdentity не даёт отвязать последний способ входа у пользователя без пароля
+func (r *AuthRepository) UnlinkIdentity(ctx context.Context, userID uuid.UUID, provider string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var found, deleted bool
+	err := r.db.QueryRow(ctx, UnlinkIdentityQuery, userID, provider).Scan(&found, &deleted)
+	if err != nil {
+		logger.Error("failed to unlink identity: " + err.Error())
+		return auth.ErrorInternalServerError
+	}
+	if !found {
+		logger.Error("identity is not linked")
+		return auth.ErrorNotFound
+	}
+	if !deleted {
+		logger.Error("identity is the last sign in method")
+		return auth.ErrorConflict
+	}
+	logger.Info("succesfully unlinked identity")
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
 	"kinopoisk/internal/pkg/middleware/logger"
 	"log/slog"
 	"os"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
@@ -205,6 +207,67 @@ func TestIncrementUserVersion(t *testing.T) {
 	}
 }
 
+func TestRehashPassword(t *testing.T) {
+	userID := uuid.NewV4()
+	oldHash := []byte("old")
+	newHash := []byte("new")
+
+	tests := []struct {
+		name    string
+		tag     pgconn.CommandTag
+		dbErr   error
+		wantErr error
+	}{
+		{name: "Success", tag: pgconn.CommandTag("UPDATE 1")},
+		{name: "Error_PasswordChanged", tag: pgconn.CommandTag("UPDATE 0"), wantErr: auth.ErrorConflict},
+		{name: "Error_DatabaseError", dbErr: errors.New("database error"), wantErr: auth.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), RehashPasswordQuery, userID, oldHash, newHash).Return(tt.tag, tt.dbErr)
+
+			repo := NewAuthRepository(mockPool)
+			assert.ErrorIs(t, repo.RehashPassword(testContext(), userID, oldHash, newHash), tt.wantErr)
+		})
+	}
+}
+
+func TestCancelUserDeletion(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name          string
+		tag           pgconn.CommandTag
+		dbErr         error
+		wantCancelled bool
+		wantErr       error
+	}{
+		{name: "Success_Cancelled", tag: pgconn.CommandTag("DELETE 1"), wantCancelled: true},
+		{name: "Success_NotScheduled", tag: pgconn.CommandTag("DELETE 0")},
+		{name: "Error_DatabaseError", dbErr: errors.New("database error"), wantErr: auth.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), CancelUserDeletionQuery, userID).Return(tt.tag, tt.dbErr)
+
+			repo := NewAuthRepository(mockPool)
+			cancelled, err := repo.CancelUserDeletion(testContext(), userID)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCancelled, cancelled)
+		})
+	}
+}
+
 func TestCheckUserLogin(t *testing.T) {
 	userID := uuid.NewV4()
 	login := "testuser"
@@ -233,8 +296,8 @@ func TestCheckUserLogin(t *testing.T) {
 			name:  "Success",
 			login: login,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				rows := pgxpoolmock.NewRows([]string{"id", "version", "login", "password_hash", "avatar", "created_at", "updated_at"}).
-					AddRow(userID, 1, login, []byte("hash"), avatar, createdAt, updatedAt). // Убрать & перед avatar
+				rows := pgxpoolmock.NewRows([]string{"id", "version", "login", "password_hash", "password_set", "avatar", "created_at", "updated_at"}).
+					AddRow(userID, 1, login, []byte("hash"), true, avatar, createdAt, updatedAt). // Убрать & перед avatar
 					ToPgxRows()
 				rows.Next()
 				mockPool.EXPECT().
@@ -309,8 +372,8 @@ func TestGetUserByLogin(t *testing.T) {
 			name:  "Success",
 			login: login,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
-				rows := pgxpoolmock.NewRows([]string{"id", "version", "login", "password_hash", "avatar", "created_at", "updated_at"}).
-					AddRow(userID, 1, login, []byte("hash"), avatar, createdAt, updatedAt).
+				rows := pgxpoolmock.NewRows([]string{"id", "version", "login", "password_hash", "password_set", "avatar", "created_at", "updated_at"}).
+					AddRow(userID, 1, login, []byte("hash"), true, avatar, createdAt, updatedAt).
 					ToPgxRows()
 				rows.Next()
 				mockPool.EXPECT().
@@ -356,3 +419,301 @@ func TestGetUserByLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTOTP(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		want       models.TOTP
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"user_id", "secret", "last_used_step", "enabled"}).
+					AddRow(userID, "SECRET", int64(42), true).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetTOTPQuery, userID).Return(rows)
+			},
+			want: models.TOTP{UserID: userID, Secret: "SECRET", LastUsedStep: 42, Enabled: true},
+		},
+		{
+			name: "Error_NotSetUp",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetTOTPQuery, userID).Return(errorRow{err: pgx.ErrNoRows})
+			},
+			wantErr: auth.ErrorNotFound,
+		},
+		{
+			name: "Error_DatabaseError",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetTOTPQuery, userID).Return(errorRow{err: errors.New("database error")})
+			},
+			wantErr: auth.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAuthRepository(mockPool)
+			got, err := repo.GetTOTP(testContext(), userID)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSaveTOTPSecret(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name    string
+		tag     pgconn.CommandTag
+		dbErr   error
+		wantErr error
+	}{
+		{name: "Success", tag: pgconn.CommandTag("INSERT 0 1")},
+		{name: "Error_AlreadyEnabled", tag: pgconn.CommandTag("INSERT 0 0"), wantErr: auth.ErrorConflict},
+		{name: "Error_DatabaseError", dbErr: errors.New("database error"), wantErr: auth.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), SaveTOTPSecretQuery, userID, "SECRET").Return(tt.tag, tt.dbErr)
+
+			repo := NewAuthRepository(mockPool)
+			assert.ErrorIs(t, repo.SaveTOTPSecret(testContext(), userID, "SECRET"), tt.wantErr)
+		})
+	}
+}
+
+func TestUseTOTPStep(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name    string
+		tag     pgconn.CommandTag
+		dbErr   error
+		wantErr error
+	}{
+		{name: "Success", tag: pgconn.CommandTag("UPDATE 1")},
+		{name: "Error_Replayed", tag: pgconn.CommandTag("UPDATE 0"), wantErr: auth.ErrorBadRequest},
+		{name: "Error_DatabaseError", dbErr: errors.New("database error"), wantErr: auth.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), UseTOTPStepQuery, userID, int64(42)).Return(tt.tag, tt.dbErr)
+
+			repo := NewAuthRepository(mockPool)
+			assert.ErrorIs(t, repo.UseTOTPStep(testContext(), userID, 42), tt.wantErr)
+		})
+	}
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	userID := uuid.NewV4()
+	codeHash := []byte("hash")
+
+	tests := []struct {
+		name    string
+		tag     pgconn.CommandTag
+		dbErr   error
+		wantErr error
+	}{
+		{name: "Success", tag: pgconn.CommandTag("UPDATE 1")},
+		{name: "Error_AlreadyUsed", tag: pgconn.CommandTag("UPDATE 0"), wantErr: auth.ErrorBadRequest},
+		{name: "Error_DatabaseError", dbErr: errors.New("database error"), wantErr: auth.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), UseRecoveryCodeQuery, userID, codeHash).Return(tt.tag, tt.dbErr)
+
+			repo := NewAuthRepository(mockPool)
+			assert.ErrorIs(t, repo.UseRecoveryCode(testContext(), userID, codeHash), tt.wantErr)
+		})
+	}
+}
+
+func TestAttemptPendingSignIn(t *testing.T) {
+	userID := uuid.NewV4()
+	tokenHash := []byte("hash")
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		want       uuid.UUID
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"user_id"}).AddRow(userID).ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), AttemptPendingSignInQuery, tokenHash, 5).Return(rows)
+			},
+			want: userID,
+		},
+		{
+			name: "Error_ExpiredOrOutOfAttempts",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), AttemptPendingSignInQuery, tokenHash, 5).Return(errorRow{err: pgx.ErrNoRows})
+			},
+			want:    uuid.Nil,
+			wantErr: auth.ErrorUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAuthRepository(mockPool)
+			got, err := repo.AttemptPendingSignIn(testContext(), tokenHash, 5)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConsumeOAuthState(t *testing.T) {
+	stateHash := []byte("hash")
+	linkUserID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		want       models.OAuthState
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"code_verifier", "link_user_id"}).
+					AddRow("verifier", uuid.NullUUID{UUID: linkUserID, Valid: true}).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), ConsumeOAuthStateQuery, stateHash, "vk").Return(rows)
+			},
+			want: models.OAuthState{
+				StateHash:    stateHash,
+				Provider:     "vk",
+				CodeVerifier: "verifier",
+				LinkUserID:   uuid.NullUUID{UUID: linkUserID, Valid: true},
+			},
+		},
+		{
+			name: "Error_UsedOrExpired",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), ConsumeOAuthStateQuery, stateHash, "vk").Return(errorRow{err: pgx.ErrNoRows})
+			},
+			wantErr: auth.ErrorUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewAuthRepository(mockPool)
+			got, err := repo.ConsumeOAuthState(testContext(), stateHash, "vk")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateOAuthUser(t *testing.T) {
+	user := models.User{ID: uuid.NewV4(), Login: "ivan.petro", PasswordHash: []byte("hash"), CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	identity := models.ExternalIdentity{Provider: "yandex", Subject: "42", Email: "ivan@yandex.ru"}
+
+	tests := []struct {
+		name    string
+		dbErr   error
+		wantErr error
+	}{
+		{name: "Success"},
+		{name: "Error_LoginTaken", dbErr: &pgconn.PgError{Code: "23505", ConstraintName: "user_login_unique"}, wantErr: auth.ErrorConflict},
+		{name: "Error_DatabaseError", dbErr: errors.New("database error"), wantErr: auth.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().
+				Exec(gomock.Any(), CreateOAuthUserQuery,
+					user.ID, user.Login, user.PasswordHash, user.CreatedAt, user.UpdatedAt,
+					identity.Provider, identity.Subject, identity.Email, identity.EmailVerified).
+				Return(pgconn.CommandTag("INSERT 0 1"), tt.dbErr)
+
+			repo := NewAuthRepository(mockPool)
+			assert.ErrorIs(t, repo.CreateOAuthUser(testContext(), user, identity), tt.wantErr)
+		})
+	}
+}
+
+func TestUnlinkIdentity(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name    string
+		found   bool
+		deleted bool
+		wantErr error
+	}{
+		{name: "Success", found: true, deleted: true},
+		{name: "Error_NotLinked", wantErr: auth.ErrorNotFound},
+		{name: "Error_LastSignInMethod", found: true, wantErr: auth.ErrorConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			rows := pgxpoolmock.NewRows([]string{"found", "deleted"}).AddRow(tt.found, tt.deleted).ToPgxRows()
+			rows.Next()
+			mockPool.EXPECT().QueryRow(gomock.Any(), UnlinkIdentityQuery, userID, "vk").Return(rows)
+
+			repo := NewAuthRepository(mockPool)
+			assert.ErrorIs(t, repo.UnlinkIdentity(testContext(), userID, "vk"), tt.wantErr)
+		})
+	}
+}
@@ -14,5 +14,65 @@ var CheckUserLoginQuery string
 //go:embed sql/incrementUserVersionQuery.sql
 var IncrementUserVersionQuery string
 
+//go:embed sql/rehashPasswordQuery.sql
+var RehashPasswordQuery string
+
 //go:embed sql/getUserByLoginQuery.sql
 var GetUserByLoginQuery string
+
+//go:embed sql/getUserByIDQuery.sql
+var GetUserByIDQuery string
+
+//go:embed sql/getUserRoleQuery.sql
+var GetUserRoleQuery string
+
+//go:embed sql/getTOTPQuery.sql
+var GetTOTPQuery string
+
+//go:embed sql/saveTOTPSecretQuery.sql
+var SaveTOTPSecretQuery string
+
+//go:embed sql/enableTOTPQuery.sql
+var EnableTOTPQuery string
+
+//go:embed sql/useTOTPStepQuery.sql
+var UseTOTPStepQuery string
+
+//go:embed sql/useRecoveryCodeQuery.sql
+var UseRecoveryCodeQuery string
+
+//go:embed sql/deleteTOTPQuery.sql
+var DeleteTOTPQuery string
+
+//go:embed sql/createPendingSignInQuery.sql
+var CreatePendingSignInQuery string
+
+//go:embed sql/attemptPendingSignInQuery.sql
+var AttemptPendingSignInQuery string
+
+//go:embed sql/completePendingSignInQuery.sql
+var CompletePendingSignInQuery string
+
+//go:embed sql/createOAuthStateQuery.sql
+var CreateOAuthStateQuery string
+
+//go:embed sql/consumeOAuthStateQuery.sql
+var ConsumeOAuthStateQuery string
+
+//go:embed sql/getUserByIdentityQuery.sql
+var GetUserByIdentityQuery string
+
+//go:embed sql/createOAuthUserQuery.sql
+var CreateOAuthUserQuery string
+
+//go:embed sql/linkIdentityQuery.sql
+var LinkIdentityQuery string
+
+//go:embed sql/getIdentitiesQuery.sql
+var GetIdentitiesQuery string
+
+//go:embed sql/unlinkIdentityQuery.sql
+var UnlinkIdentityQuery string
+
+//go:embed sql/cancelUserDeletionQuery.sql
+var CancelUserDeletionQuery string
@@ -0,0 +1,5 @@
+UPDATE user_token 
+SET attempts = attempts + 1 
+WHERE token_hash = $1 AND purpose = 'two_factor' AND used_at IS NULL 
+    AND expires_at > CURRENT_TIMESTAMP AND attempts < $2 
+RETURNING user_id
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM user_deletion 
+WHERE user_id = $1
\ No newline at end of file
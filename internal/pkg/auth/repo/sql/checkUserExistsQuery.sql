@@ -1 +1,2 @@
-SELECT EXISTS(SELECT 1 FROM user_table WHERE login = $1)
\ No newline at end of file
+SELECT EXISTS(SELECT 1 FROM user_table WHERE login = $1) 
+  OR EXISTS(SELECT 1 FROM user_login_history WHERE login = $1 AND reserved_until > CURRENT_TIMESTAMP)
\ No newline at end of file
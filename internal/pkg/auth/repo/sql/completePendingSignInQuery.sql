@@ -0,0 +1,3 @@
+UPDATE user_token 
+SET used_at = CURRENT_TIMESTAMP 
+WHERE token_hash = $1 AND purpose = 'two_factor' AND used_at IS NULL
\ No newline at end of file
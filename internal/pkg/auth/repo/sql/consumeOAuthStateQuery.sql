@@ -0,0 +1,3 @@
+DELETE FROM oauth_state 
+WHERE state_hash = $1 AND provider = $2 AND expires_at > CURRENT_TIMESTAMP 
+RETURNING code_verifier, link_user_id
\ No newline at end of file
@@ -0,0 +1,6 @@
+WITH expired AS (
+    DELETE FROM oauth_state 
+    WHERE expires_at < CURRENT_TIMESTAMP
+)
+INSERT INTO oauth_state (state_hash, provider, code_verifier, link_user_id, expires_at, created_at) 
+VALUES ($1, $2, $3, $4, $5, $6)
\ No newline at end of file
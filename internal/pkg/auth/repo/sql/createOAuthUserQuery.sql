@@ -0,0 +1,12 @@
+WITH verified_email AS (
+    SELECT $8::text AS email 
+    WHERE $9 AND $8 <> '' 
+      AND NOT EXISTS (SELECT 1 FROM user_table WHERE email_verified AND lower(email) = lower($8))
+), new_user AS (
+    INSERT INTO user_table (id, login, password_hash, password_set, email, email_verified, created_at, updated_at) 
+    VALUES ($1, $2, $3, false, (SELECT email FROM verified_email), EXISTS (SELECT 1 FROM verified_email), $4, $5) 
+    RETURNING id
+)
+INSERT INTO user_identity (user_id, provider, subject, email) 
+SELECT id, $6, $7, NULLIF($8, '') 
+FROM new_user
\ No newline at end of file
@@ -0,0 +1,6 @@
+WITH revoked AS (
+    DELETE FROM user_token 
+    WHERE user_id = $2 AND purpose = 'two_factor' AND used_at IS NULL
+)
+INSERT INTO user_token (id, user_id, purpose, token_hash, expires_at, created_at) 
+VALUES ($1, $2, 'two_factor', $3, $4, $5)
\ No newline at end of file
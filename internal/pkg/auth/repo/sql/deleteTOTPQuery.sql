@@ -0,0 +1,6 @@
+WITH codes AS (
+    DELETE FROM user_recovery_code 
+    WHERE user_id = $1
+)
+DELETE FROM user_totp 
+WHERE user_id = $1
\ No newline at end of file
@@ -0,0 +1,12 @@
+WITH enabled AS (
+    UPDATE user_totp 
+    SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2 
+    WHERE user_id = $1 AND enabled_at IS NULL 
+    RETURNING user_id
+), cleared AS (
+    DELETE FROM user_recovery_code 
+    WHERE user_id IN (SELECT user_id FROM enabled)
+)
+INSERT INTO user_recovery_code (user_id, code_hash) 
+SELECT enabled.user_id, code_hash 
+FROM enabled, unnest($3::bytea[]) AS code_hash
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT provider, COALESCE(email, ''), created_at 
+FROM user_identity 
+WHERE user_id = $1 
+ORDER BY created_at
\ No newline at end of file
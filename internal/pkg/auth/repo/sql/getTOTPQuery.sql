@@ -0,0 +1,3 @@
+SELECT user_id, secret, last_used_step, enabled_at IS NOT NULL 
+FROM user_totp 
+WHERE user_id = $1
\ No newline at end of file
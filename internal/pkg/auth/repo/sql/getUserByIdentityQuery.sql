@@ -0,0 +1,4 @@
+SELECT u.id, u.version, u.login, u.password_hash, u.password_set, u.avatar, u.created_at, u.updated_at 
+FROM user_identity i 
+JOIN user_table u ON u.id = i.user_id 
+WHERE i.provider = $1 AND i.subject = $2
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT role 
+FROM user_table 
+WHERE id = $1
\ No newline at end of file
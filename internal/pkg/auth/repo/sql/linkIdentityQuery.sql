@@ -0,0 +1,2 @@
+INSERT INTO user_identity (user_id, provider, subject, email) 
+VALUES ($1, $2, $3, NULLIF($4, ''))
\ No newline at end of file
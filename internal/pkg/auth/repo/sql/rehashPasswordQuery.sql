@@ -0,0 +1,3 @@
+UPDATE user_table 
+SET password_hash = $3 
+WHERE id = $1 AND password_hash = $2
\ No newline at end of file
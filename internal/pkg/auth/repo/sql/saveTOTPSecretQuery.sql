@@ -0,0 +1,5 @@
+INSERT INTO user_totp (user_id, secret) 
+VALUES ($1, $2) 
+ON CONFLICT (user_id) DO UPDATE 
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = CURRENT_TIMESTAMP 
+WHERE user_totp.enabled_at IS NULL
\ No newline at end of file
@@ -0,0 +1,14 @@
+WITH target AS (
+    SELECT id 
+    FROM user_identity 
+    WHERE user_id = $1 AND provider = $2
+), deleted AS (
+    DELETE FROM user_identity 
+    WHERE id IN (SELECT id FROM target) 
+        AND (
+            EXISTS (SELECT 1 FROM user_table WHERE id = $1 AND password_set) 
+            OR (SELECT count(*) FROM user_identity WHERE user_id = $1) > 1
+        ) 
+    RETURNING id
+)
+SELECT EXISTS (SELECT 1 FROM target), EXISTS (SELECT 1 FROM deleted)
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE user_recovery_code 
+SET used_at = CURRENT_TIMESTAMP 
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE user_totp 
+SET last_used_step = $2 
+WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_used_step < $2
\ No newline at end of file
@@ -1,58 +1,79 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"html"
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/auth/oidc"
+	"kinopoisk/internal/pkg/auth/totp"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/password"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
-	"golang.org/x/crypto/argon2"
 )
 
-func HashPass(plainPassword string) []byte {
-	salt := make([]byte, 8)
-	_, err := rand.Read(salt)
-	if err != nil {
-		return []byte{}
-	}
-	hashedPass := argon2.IDKey([]byte(plainPassword), []byte(salt), 1, 64*1024, 4, 32)
-	return append(salt, hashedPass...)
-}
-
-func CheckPass(passHash []byte, plainPassword string) bool {
-	//salt := passHash[:8] - раньше было так
-	salt := make([]byte, 8)
-	copy(salt, passHash[:8])
-	userHash := argon2.IDKey([]byte(plainPassword), salt, 1, 64*1024, 4, 32)
-	userHashedPassword := append(salt, userHash...)
-	return bytes.Equal(userHashedPassword, passHash)
-}
+const (
+	totpIssuer = "DDFilms"
+	// pendingSignInTTL сколько живёт токен между вводом пароля и кода
+	pendingSignInTTL = 5 * time.Minute
+	// pendingSignInAttempts после стольких неверных кодов нужно снова вводить пароль
+	pendingSignInAttempts = 5
+	recoveryCodesCount    = 10
+
+	// oauthStateTTL сколько ждём возврата от провайдера
+	oauthStateTTL = 10 * time.Minute
+	// oauthLoginAttempts сколько логинов пробуем, если сгенерированный уже занят
+	oauthLoginAttempts = 5
+	oauthLoginBaseLen  = 10
+	oauthMinLoginLen   = 6
+	defaultAvatar      = "avatars/default.png"
+)
 
 type AuthUsecase struct {
-	secret   string
-	authRepo auth.AuthRepo
+	secret    string
+	authRepo  auth.AuthRepo
+	providers map[string]auth.OAuthProvider
+	passwords *password.Policy
+	notifier  notifications.Producer
 }
 
-func NewAuthUsecase(repo auth.AuthRepo) *AuthUsecase {
+func NewAuthUsecase(repo auth.AuthRepo, providers map[string]auth.OAuthProvider, passwords *password.Policy, notifier notifications.Producer) *AuthUsecase {
 	return &AuthUsecase{
-		authRepo: repo,
-		secret:   os.Getenv("JWT_SECRET"),
+		authRepo:  repo,
+		secret:    os.Getenv("JWT_SECRET"),
+		providers: providers,
+		passwords: passwords,
+		notifier:  notifier,
+	}
+}
+
+func (uc *AuthUsecase) notify(ctx context.Context, event models.NotificationEvent) {
+	if uc.notifier != nil {
+		uc.notifier.Notify(ctx, event)
 	}
 }
 
-func (uc *AuthUsecase) GenerateToken(id uuid.UUID, login string) (string, error) {
+func (uc *AuthUsecase) GenerateToken(id uuid.UUID, login string, version int) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":    id,
-		"login": login,
-		"exp":   time.Now().Add(time.Hour * 24).Unix(),
+		"id":      id,
+		"login":   login,
+		"version": version,
+		"exp":     time.Now().Add(time.Hour * 24).Unix(),
 	})
 	return token.SignedString([]byte(uc.secret))
 }
@@ -69,10 +90,17 @@ func (uc *AuthUsecase) ParseToken(token string) (*jwt.Token, error) {
 func (uc *AuthUsecase) SignUpUser(ctx context.Context, req models.SignUpInput) (models.User, string, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	msg, dataIsValid := auth.Validaton(req.Login, req.Password)
+	msg, dataIsValid := auth.ValidateLogin(req.Login)
 	if !dataIsValid {
 		logger.Error(msg)
-		return models.User{}, "", auth.ErrorBadRequest
+		return models.User{}, "", auth.ErrorInvalidLogin
+	}
+
+	// пароль приходит уже экранированным, длину считаем по тому, что ввёл пользователь
+	msg, dataIsValid = uc.passwords.Validate(req.Login, html.UnescapeString(req.Password))
+	if !dataIsValid {
+		logger.Error(msg)
+		return models.User{}, "", auth.ErrorWeakPassword
 	}
 
 	exists, err := uc.authRepo.CheckUserExists(ctx, req.Login)
@@ -81,13 +109,16 @@ func (uc *AuthUsecase) SignUpUser(ctx context.Context, req models.SignUpInput) (
 	}
 	if exists {
 		logger.Error("user already exists")
-		return models.User{}, "", auth.ErrorConflict
+		return models.User{}, "", auth.ErrorLoginTaken
 	}
 
-	passwordHash := HashPass(req.Password)
+	passwordHash, err := password.Hash(req.Password)
+	if err != nil {
+		logger.Error("failed to hash password: " + err.Error())
+		return models.User{}, "", auth.ErrorInternalServerError
+	}
 
 	id := uuid.NewV4()
-	defaultAvatar := "avatars/default.png"
 
 	user := models.User{
 		ID:           id,
@@ -104,7 +135,7 @@ func (uc *AuthUsecase) SignUpUser(ctx context.Context, req models.SignUpInput) (
 		return models.User{}, "", err
 	}
 
-	token, err := uc.GenerateToken(id, req.Login)
+	token, err := uc.GenerateToken(id, req.Login, user.Version)
 	if err != nil {
 		logger.Error("cannot generate token")
 		return models.User{}, "", auth.ErrorInternalServerError
@@ -118,21 +149,82 @@ func (uc *AuthUsecase) SignInUser(ctx context.Context, req models.SignInInput) (
 
 	neededUser, err := uc.authRepo.CheckUserLogin(ctx, req.Login)
 	if err != nil {
+		// неизвестный логин не отличается от неверного пароля
+		if errors.Is(err, auth.ErrorBadRequest) {
+			return models.User{}, "", auth.ErrorWrongCredentials
+		}
 		return models.User{}, "", err
 	}
 
-	if !CheckPass(neededUser.PasswordHash, req.Password) {
+	ok, needsRehash := password.Verify(neededUser.PasswordHash, req.Password)
+	if !ok {
 		logger.Error("wrong password")
-		return models.User{}, "", auth.ErrorBadRequest
+		return models.User{}, "", auth.ErrorWrongCredentials
+	}
+	if needsRehash {
+		uc.rehashPassword(ctx, neededUser, req.Password)
+	}
+
+	return uc.startSession(ctx, neededUser)
+}
+
+// rehashPassword пересчитывает хэш старого формата или с устаревшими параметрами.
+// Ошибка не мешает входу: попробуем при следующем
+func (uc *AuthUsecase) rehashPassword(ctx context.Context, user models.User, plainPassword string) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	newHash, err := password.Hash(plainPassword)
+	if err != nil {
+		logger.Error("failed to hash password: " + err.Error())
+		return
+	}
+	if err = uc.authRepo.RehashPassword(ctx, user.ID, user.PasswordHash, newHash); err != nil {
+		logger.Error("failed to rehash password: " + err.Error())
+		return
+	}
+	logger.Info("password hash upgraded")
+}
+
+// startSession выдаёт JWT или, если включён второй фактор, токен для VerifyTwoFactor
+func (uc *AuthUsecase) startSession(ctx context.Context, user models.User) (models.User, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	secondFactor, err := uc.authRepo.GetTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, auth.ErrorNotFound) {
+		return models.User{}, "", err
+	}
+	if err == nil && secondFactor.Enabled {
+		pendingToken, err := uc.issuePendingSignIn(ctx, user.ID)
+		if err != nil {
+			return models.User{}, "", err
+		}
+		return models.User{}, pendingToken, auth.ErrorTwoFactorRequired
+	}
+
+	if err = uc.restoreAccount(ctx, user.ID); err != nil {
+		return models.User{}, "", err
 	}
 
-	token, err := uc.GenerateToken(neededUser.ID, req.Login)
+	token, err := uc.GenerateToken(user.ID, user.Login, user.Version)
 	if err != nil {
 		logger.Error("cannot generate token")
 		return models.User{}, "", auth.ErrorInternalServerError
 	}
 
-	return neededUser, token, nil
+	return user, token, nil
+}
+
+// restoreAccount вход в течение срока ожидания отменяет запрошенное удаление аккаунта
+func (uc *AuthUsecase) restoreAccount(ctx context.Context, userID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	cancelled, err := uc.authRepo.CancelUserDeletion(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if cancelled {
+		logger.Info("account deletion cancelled by sign in")
+	}
+	return nil
 }
 
 func (uc *AuthUsecase) CheckAuth(ctx context.Context) (models.User, error) {
@@ -199,5 +291,441 @@ func (uc *AuthUsecase) ValidateAndGetUser(ctx context.Context, token string) (mo
 		return models.User{}, err
 	}
 
+	// логин после смены освобождается, поэтому токен сверяется ещё и по id
+	if id, _ := claims["id"].(string); id != user.ID.String() {
+		logger.Error("login belongs to another user")
+		return models.User{}, auth.ErrorUnauthorized
+	}
+
+	// версия растёт при выходе и смене пароля или логина, старые токены после этого не принимаются
+	if version, ok := claims["version"].(float64); !ok || int(version) != user.Version {
+		logger.Error("stale token version")
+		return models.User{}, auth.ErrorUnauthorized
+	}
+
 	return user, nil
 }
+
+func (uc *AuthUsecase) issuePendingSignIn(ctx context.Context, userID uuid.UUID) (string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		logger.Error("failed to generate pending token: " + err.Error())
+		return "", auth.ErrorInternalServerError
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now().UTC()
+	err := uc.authRepo.CreatePendingSignIn(ctx, models.UserToken{
+		ID:        uuid.NewV4(),
+		UserID:    userID,
+		Purpose:   models.TokenTwoFactor,
+		TokenHash: hashSecret(token),
+		ExpiresAt: now.Add(pendingSignInTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// VerifyTwoFactor завершает вход: принимает код из приложения или один из кодов восстановления
+func (uc *AuthUsecase) VerifyTwoFactor(ctx context.Context, req models.TwoFactorSignInInput) (models.User, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if req.PendingToken == "" {
+		logger.Error("empty pending token")
+		return models.User{}, "", auth.ErrorUnauthorized
+	}
+
+	pendingHash := hashSecret(req.PendingToken)
+	userID, err := uc.authRepo.AttemptPendingSignIn(ctx, pendingHash, pendingSignInAttempts)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	secondFactor, err := uc.authRepo.GetTOTP(ctx, userID)
+	if err != nil {
+		if errors.Is(err, auth.ErrorNotFound) {
+			return models.User{}, "", auth.ErrorUnauthorized
+		}
+		return models.User{}, "", err
+	}
+
+	code := strings.TrimSpace(req.Code)
+	if step, ok := totp.Validate(secondFactor.Secret, code, time.Now()); ok {
+		err = uc.authRepo.UseTOTPStep(ctx, userID, step)
+	} else if len(code) == totp.Digits {
+		logger.Error("wrong totp code")
+		err = auth.ErrorBadRequest
+	} else {
+		err = uc.authRepo.UseRecoveryCode(ctx, userID, hashSecret(normalizeRecoveryCode(code)))
+	}
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	err = uc.authRepo.CompletePendingSignIn(ctx, pendingHash)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	user, err := uc.authRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	if err = uc.restoreAccount(ctx, user.ID); err != nil {
+		return models.User{}, "", err
+	}
+
+	token, err := uc.GenerateToken(user.ID, user.Login, user.Version)
+	if err != nil {
+		logger.Error("cannot generate token")
+		return models.User{}, "", auth.ErrorInternalServerError
+	}
+	return user, token, nil
+}
+
+// SetupTwoFactor выдаёт новый секрет; второй фактор включится только после подтверждения кодом
+func (uc *AuthUsecase) SetupTwoFactor(ctx context.Context) (models.TwoFactorSetup, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("no such user in context")
+		return models.TwoFactorSetup{}, auth.ErrorUnauthorized
+	}
+
+	role, err := uc.authRepo.GetUserRole(ctx, user.ID)
+	if err != nil {
+		return models.TwoFactorSetup{}, err
+	}
+	if role != models.RoleEditor && role != models.RoleAdmin {
+		logger.Error("two factor is available only for editors and admins")
+		return models.TwoFactorSetup{}, auth.ErrorForbidden
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		logger.Error("failed to generate totp secret: " + err.Error())
+		return models.TwoFactorSetup{}, auth.ErrorInternalServerError
+	}
+
+	err = uc.authRepo.SaveTOTPSecret(ctx, user.ID, secret)
+	if err != nil {
+		return models.TwoFactorSetup{}, err
+	}
+
+	return models.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, totpIssuer, user.Login),
+	}, nil
+}
+
+func (uc *AuthUsecase) EnableTwoFactor(ctx context.Context, code string) (models.RecoveryCodes, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("no such user in context")
+		return models.RecoveryCodes{}, "", auth.ErrorUnauthorized
+	}
+
+	secondFactor, err := uc.authRepo.GetTOTP(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, auth.ErrorNotFound) {
+			return models.RecoveryCodes{}, "", auth.ErrorBadRequest
+		}
+		return models.RecoveryCodes{}, "", err
+	}
+	if secondFactor.Enabled {
+		logger.Error("two factor is already enabled")
+		return models.RecoveryCodes{}, "", auth.ErrorConflict
+	}
+
+	step, ok := totp.Validate(secondFactor.Secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		logger.Error("wrong totp code")
+		return models.RecoveryCodes{}, "", auth.ErrorBadRequest
+	}
+
+	codes, hashes, err := generateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		logger.Error("failed to generate recovery codes: " + err.Error())
+		return models.RecoveryCodes{}, "", auth.ErrorInternalServerError
+	}
+
+	err = uc.authRepo.EnableTOTP(ctx, user.ID, step, hashes)
+	if err != nil {
+		return models.RecoveryCodes{}, "", err
+	}
+
+	// сессии, открытые без второго фактора, больше не действуют; вызывающему выдаём новую
+	err = uc.authRepo.IncrementUserVersion(ctx, user.ID)
+	if err != nil {
+		return models.RecoveryCodes{}, "", err
+	}
+	uc.notify(ctx, models.SecurityEvent{UserID: user.ID, Action: models.SecurityTwoFactorEnabled})
+
+	token, err := uc.GenerateToken(user.ID, user.Login, user.Version+1)
+	if err != nil {
+		return models.RecoveryCodes{}, "", err
+	}
+
+	return models.RecoveryCodes{Codes: codes}, token, nil
+}
+
+func (uc *AuthUsecase) DisableTwoFactor(ctx context.Context, plainPassword string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("no such user in context")
+		return auth.ErrorUnauthorized
+	}
+
+	// без своего пароля (вход только через провайдера) сверять нечего
+	if user.PasswordSet {
+		if ok, _ := password.Verify(user.PasswordHash, plainPassword); !ok {
+			logger.Error("wrong password")
+			return auth.ErrorWrongPassword
+		}
+	}
+
+	err := uc.authRepo.DeleteTOTP(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	uc.notify(ctx, models.SecurityEvent{UserID: user.ID, Action: models.SecurityTwoFactorDisabled})
+	return nil
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCodes возвращает коды вида abcde-fghij и их хэши для базы
+func generateRecoveryCodes(count int) ([]string, [][]byte, error) {
+	codes := make([]string, 0, count)
+	hashes := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(raw))[:10]
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashes = append(hashes, hashSecret(code))
+	}
+	return codes, hashes, nil
+}
+
+// normalizeRecoveryCode прощает регистр, пробелы и дефис при вводе
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+func hashSecret(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+func (uc *AuthUsecase) OAuthProviders() []string {
+	names := make([]string, 0, len(uc.providers))
+	for name := range uc.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (uc *AuthUsecase) StartOAuth(ctx context.Context, providerName string, link bool) (string, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		logger.Error("unknown provider " + providerName)
+		return "", "", auth.ErrorNotFound
+	}
+
+	var linkUserID uuid.NullUUID
+	if link {
+		user, ok := ctx.Value(auth.UserKey).(models.User)
+		if !ok {
+			logger.Error("no such user in context")
+			return "", "", auth.ErrorUnauthorized
+		}
+		linkUserID = uuid.NullUUID{UUID: user.ID, Valid: true}
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		logger.Error("failed to generate state: " + err.Error())
+		return "", "", auth.ErrorInternalServerError
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		logger.Error("failed to generate code verifier: " + err.Error())
+		return "", "", auth.ErrorInternalServerError
+	}
+
+	now := time.Now().UTC()
+	err = uc.authRepo.CreateOAuthState(ctx, models.OAuthState{
+		StateHash:    hashSecret(state),
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		LinkUserID:   linkUserID,
+		ExpiresAt:    now.Add(oauthStateTTL),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return provider.AuthCodeURL(state, oidc.Challenge(verifier)), state, nil
+}
+
+func (uc *AuthUsecase) CompleteOAuth(ctx context.Context, providerName string, callback url.Values) (models.User, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		logger.Error("unknown provider " + providerName)
+		return models.User{}, "", auth.ErrorNotFound
+	}
+	if callback.Get("code") == "" || callback.Get("state") == "" {
+		logger.Error("no code or state in callback")
+		return models.User{}, "", auth.ErrorBadRequest
+	}
+
+	state, err := uc.authRepo.ConsumeOAuthState(ctx, hashSecret(callback.Get("state")), providerName)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	identity, err := provider.Exchange(ctx, callback, state.CodeVerifier)
+	if err != nil {
+		logger.Error("provider exchange failed: " + err.Error())
+		return models.User{}, "", auth.ErrorBadGateway
+	}
+	identity.Provider = providerName
+
+	if state.LinkUserID.Valid {
+		err = uc.authRepo.LinkIdentity(ctx, state.LinkUserID.UUID, identity)
+		if err != nil {
+			return models.User{}, "", err
+		}
+		return models.User{ID: state.LinkUserID.UUID}, "", nil
+	}
+
+	user, err := uc.authRepo.GetUserByIdentity(ctx, providerName, identity.Subject)
+	if errors.Is(err, auth.ErrorNotFound) {
+		user, err = uc.createOAuthUser(ctx, identity)
+	}
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	return uc.startSession(ctx, user)
+}
+
+// createOAuthUser регистрирует пользователя при первом входе через провайдера. Пароль случайный
+// и никому не известен: задать свой можно в настройках или через восстановление на почту от провайдера
+func (uc *AuthUsecase) createOAuthUser(ctx context.Context, identity models.ExternalIdentity) (models.User, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	randomPassword, err := oidc.RandomString()
+	if err != nil {
+		logger.Error("failed to generate password: " + err.Error())
+		return models.User{}, auth.ErrorInternalServerError
+	}
+	passwordHash, err := password.Hash(randomPassword)
+	if err != nil {
+		logger.Error("failed to hash password: " + err.Error())
+		return models.User{}, auth.ErrorInternalServerError
+	}
+
+	base := loginBase(identity)
+	for attempt := 0; attempt < oauthLoginAttempts; attempt++ {
+		login := base
+		if attempt > 0 || len(base) < oauthMinLoginLen {
+			suffix, err := randomDigits(5)
+			if err != nil {
+				logger.Error("failed to generate login: " + err.Error())
+				return models.User{}, auth.ErrorInternalServerError
+			}
+			login = base + suffix
+		}
+
+		now := time.Now().UTC()
+		user := models.User{
+			ID:           uuid.NewV4(),
+			Login:        login,
+			PasswordHash: passwordHash,
+			Avatar:       defaultAvatar,
+			Version:      1,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		err = uc.authRepo.CreateOAuthUser(ctx, user, identity)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, auth.ErrorConflict) {
+			return models.User{}, err
+		}
+	}
+
+	logger.Error("failed to pick a free login for " + base)
+	return models.User{}, auth.ErrorConflict
+}
+
+// loginBase берёт логин у провайдера или начало почты, оставляя только допустимые символы
+func loginBase(identity models.ExternalIdentity) string {
+	source := identity.Username
+	if source == "" {
+		source, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	var base strings.Builder
+	for _, char := range strings.ToLower(source) {
+		if base.Len() == oauthLoginBaseLen {
+			break
+		}
+		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '_' || char == '-' || char == '.' {
+			base.WriteRune(char)
+		}
+	}
+	if base.Len() == 0 {
+		return identity.Provider
+	}
+	return base.String()
+}
+
+func randomDigits(count int) (string, error) {
+	raw := make([]byte, count)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	for i := range raw {
+		raw[i] = '0' + raw[i]%10
+	}
+	return string(raw), nil
+}
+
+func (uc *AuthUsecase) GetIdentities(ctx context.Context) ([]models.LinkedIdentity, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("no such user in context")
+		return nil, auth.ErrorUnauthorized
+	}
+	return uc.authRepo.GetIdentities(ctx, user.ID)
+}
+
+func (uc *AuthUsecase) UnlinkIdentity(ctx context.Context, provider string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("no such user in context")
+		return auth.ErrorUnauthorized
+	}
+	return uc.authRepo.UnlinkIdentity(ctx, user.ID, provider)
+}
@@ -4,19 +4,26 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/auth"
 	"kinopoisk/internal/pkg/auth/mocks"
+	"kinopoisk/internal/pkg/auth/oidc"
+	"kinopoisk/internal/pkg/auth/totp"
 	"kinopoisk/internal/pkg/middleware/logger"
+	notificationMocks "kinopoisk/internal/pkg/notifications/mocks"
+	"kinopoisk/internal/pkg/password"
 
 	"github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
+	"golang.org/x/crypto/argon2"
 )
 
 func testLogger() *slog.Logger {
@@ -28,77 +35,9 @@ func testContext() context.Context {
 	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
 }
 
-func TestHashPass(t *testing.T) {
-	tests := []struct {
-		name     string
-		password string
-	}{
-		{
-			name:     "Success",
-			password: "testpassword123",
-		},
-		{
-			name:     "Empty password",
-			password: "",
-		},
-		{
-			name:     "Long password",
-			password: "verylongpasswordwithspecialchars!@#$%^&*()",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			hash := HashPass(tt.password)
-			assert.NotEmpty(t, hash)
-			assert.GreaterOrEqual(t, len(hash), 40) // salt (8) + hash (32)
-		})
-	}
-}
-
-func TestCheckPass(t *testing.T) {
-	password := "testpassword123"
-	correctHash := HashPass(password)
-	wrongHash := HashPass("wrongpassword")
-
-	tests := []struct {
-		name     string
-		hash     []byte
-		password string
-		expected bool
-	}{
-		{
-			name:     "Correct password",
-			hash:     correctHash,
-			password: password,
-			expected: true,
-		},
-		{
-			name:     "Wrong password",
-			hash:     correctHash,
-			password: "wrongpassword",
-			expected: false,
-		},
-		{
-			name:     "Different hash",
-			hash:     wrongHash,
-			password: password,
-			expected: false,
-		},
-		{
-			name:     "Empty password",
-			hash:     correctHash,
-			password: "",
-			expected: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := CheckPass(tt.hash, tt.password)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
+func testHash(plain string) []byte {
+	hash, _ := password.Hash(plain)
+	return hash
 }
 
 func TestNewAuthUsecase(t *testing.T) {
@@ -108,13 +47,13 @@ func TestNewAuthUsecase(t *testing.T) {
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
 
 	t.Run("Success creation", func(t *testing.T) {
-		usecase := NewAuthUsecase(mockRepo)
+		usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 		assert.NotNil(t, usecase)
 		assert.Equal(t, mockRepo, usecase.authRepo)
 	})
 
 	t.Run("Creation with nil repo", func(t *testing.T) {
-		usecase := NewAuthUsecase(nil)
+		usecase := NewAuthUsecase(nil, nil, nil, nil)
 		assert.NotNil(t, usecase)
 		assert.Nil(t, usecase.authRepo)
 	})
@@ -125,13 +64,13 @@ func TestAuthUsecase_GenerateToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	userID := uuid.NewV4()
 	login := "testuser"
 
 	t.Run("Success", func(t *testing.T) {
-		token, err := usecase.GenerateToken(userID, login)
+		token, err := usecase.GenerateToken(userID, login, 3)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, token)
 
@@ -144,10 +83,11 @@ func TestAuthUsecase_GenerateToken(t *testing.T) {
 		assert.True(t, ok)
 		assert.Equal(t, login, claims["login"])
 		assert.Equal(t, userID.String(), claims["id"])
+		assert.Equal(t, float64(3), claims["version"])
 	})
 
 	t.Run("Empty login", func(t *testing.T) {
-		token, err := usecase.GenerateToken(userID, "")
+		token, err := usecase.GenerateToken(userID, "", 1)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, token)
 	})
@@ -158,11 +98,11 @@ func TestAuthUsecase_ParseToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	userID := uuid.NewV4()
 	login := "testuser"
-	validToken, _ := usecase.GenerateToken(userID, login)
+	validToken, _ := usecase.GenerateToken(userID, login, 1)
 
 	tests := []struct {
 		name        string
@@ -207,7 +147,7 @@ func TestAuthUsecase_SignUpUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	login := "testuser"
 	password := "testpass123"
@@ -247,7 +187,7 @@ func TestAuthUsecase_SignUpUser(t *testing.T) {
 				Password: password,
 			},
 			expectError: true,
-			errorType:   auth.ErrorConflict,
+			errorType:   auth.ErrorLoginTaken,
 		},
 		{
 			name: "Error - CheckUserExists fails",
@@ -286,7 +226,7 @@ func TestAuthUsecase_SignUpUser(t *testing.T) {
 				Password: password,
 			},
 			expectError: true,
-			errorType:   auth.ErrorBadRequest,
+			errorType:   auth.ErrorInvalidLogin,
 		},
 		{
 			name:      "Error - invalid password",
@@ -296,7 +236,17 @@ func TestAuthUsecase_SignUpUser(t *testing.T) {
 				Password: "pass",
 			},
 			expectError: true,
-			errorType:   auth.ErrorBadRequest,
+			errorType:   auth.ErrorWeakPassword,
+		},
+		{
+			name:      "Error - password equals login",
+			setupMock: func() {},
+			req: models.SignUpInput{
+				Login:    login,
+				Password: login,
+			},
+			expectError: true,
+			errorType:   auth.ErrorWeakPassword,
 		},
 	}
 
@@ -328,7 +278,7 @@ func TestAuthUsecase_SignInUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	userID := uuid.NewV4()
 	login := "testuser"
@@ -337,7 +287,7 @@ func TestAuthUsecase_SignInUser(t *testing.T) {
 	existingUser := models.User{
 		ID:           userID,
 		Login:        login,
-		PasswordHash: HashPass(password),
+		PasswordHash: testHash(password),
 		Version:      1,
 	}
 
@@ -354,6 +304,31 @@ func TestAuthUsecase_SignInUser(t *testing.T) {
 				mockRepo.EXPECT().
 					CheckUserLogin(gomock.Any(), login).
 					Return(existingUser, nil)
+				mockRepo.EXPECT().
+					GetTOTP(gomock.Any(), userID).
+					Return(models.TOTP{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().
+					CancelUserDeletion(gomock.Any(), userID).
+					Return(false, nil)
+			},
+			req: models.SignInInput{
+				Login:    login,
+				Password: password,
+			},
+			expectError: false,
+		},
+		{
+			name: "Success - scheduled deletion cancelled",
+			setupMock: func() {
+				mockRepo.EXPECT().
+					CheckUserLogin(gomock.Any(), login).
+					Return(existingUser, nil)
+				mockRepo.EXPECT().
+					GetTOTP(gomock.Any(), userID).
+					Return(models.TOTP{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().
+					CancelUserDeletion(gomock.Any(), userID).
+					Return(true, nil)
 			},
 			req: models.SignInInput{
 				Login:    login,
@@ -361,6 +336,26 @@ func TestAuthUsecase_SignInUser(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Error - cannot cancel deletion",
+			setupMock: func() {
+				mockRepo.EXPECT().
+					CheckUserLogin(gomock.Any(), login).
+					Return(existingUser, nil)
+				mockRepo.EXPECT().
+					GetTOTP(gomock.Any(), userID).
+					Return(models.TOTP{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().
+					CancelUserDeletion(gomock.Any(), userID).
+					Return(false, auth.ErrorInternalServerError)
+			},
+			req: models.SignInInput{
+				Login:    login,
+				Password: password,
+			},
+			expectError: true,
+			errorType:   auth.ErrorInternalServerError,
+		},
 		{
 			name: "Error - repository error",
 			setupMock: func() {
@@ -386,7 +381,21 @@ func TestAuthUsecase_SignInUser(t *testing.T) {
 				Password: "wrongpass",
 			},
 			expectError: true,
-			errorType:   auth.ErrorBadRequest,
+			errorType:   auth.ErrorWrongCredentials,
+		},
+		{
+			name: "Error - unknown login",
+			setupMock: func() {
+				mockRepo.EXPECT().
+					CheckUserLogin(gomock.Any(), login).
+					Return(models.User{}, auth.ErrorBadRequest)
+			},
+			req: models.SignInInput{
+				Login:    login,
+				Password: password,
+			},
+			expectError: true,
+			errorType:   auth.ErrorWrongCredentials,
 		},
 		{
 			name: "Error - empty password",
@@ -424,12 +433,356 @@ func TestAuthUsecase_SignInUser(t *testing.T) {
 	}
 }
 
+func TestAuthUsecase_SignInUserRehash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
+
+	salt := []byte("saltsalt")
+	legacyHash := append(salt, argon2.IDKey([]byte("testpass123"), salt, 1, 64*1024, 4, 32)...)
+	user := models.User{ID: uuid.NewV4(), Login: "testuser", PasswordHash: legacyHash}
+	req := models.SignInInput{Login: "testuser", Password: "testpass123"}
+
+	t.Run("Legacy hash is upgraded", func(t *testing.T) {
+		mockRepo.EXPECT().CheckUserLogin(gomock.Any(), "testuser").Return(user, nil)
+		mockRepo.EXPECT().RehashPassword(gomock.Any(), user.ID, legacyHash, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, _ []byte, newHash []byte) error {
+				ok, needsRehash := password.Verify(newHash, "testpass123")
+				assert.True(t, ok)
+				assert.False(t, needsRehash)
+				return nil
+			})
+		mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{}, auth.ErrorNotFound)
+		mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), user.ID).Return(false, nil)
+
+		_, token, err := usecase.SignInUser(testContext(), req)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+	})
+
+	t.Run("Failed upgrade does not block sign in", func(t *testing.T) {
+		mockRepo.EXPECT().CheckUserLogin(gomock.Any(), "testuser").Return(user, nil)
+		mockRepo.EXPECT().RehashPassword(gomock.Any(), user.ID, legacyHash, gomock.Any()).Return(auth.ErrorConflict)
+		mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{}, auth.ErrorNotFound)
+		mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), user.ID).Return(false, nil)
+
+		_, token, err := usecase.SignInUser(testContext(), req)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+	})
+}
+
+func TestAuthUsecase_SignInUserTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
+
+	user := models.User{ID: uuid.NewV4(), Login: "editor", PasswordHash: testHash("testpass123")}
+	mockRepo.EXPECT().CheckUserLogin(gomock.Any(), user.Login).Return(user, nil)
+	mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{UserID: user.ID, Enabled: true}, nil)
+
+	var saved models.UserToken
+	mockRepo.EXPECT().CreatePendingSignIn(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, token models.UserToken) error {
+			saved = token
+			return nil
+		})
+
+	result, pendingToken, err := usecase.SignInUser(testContext(), models.SignInInput{Login: user.Login, Password: "testpass123"})
+	assert.ErrorIs(t, err, auth.ErrorTwoFactorRequired)
+	assert.Empty(t, result.ID)
+	assert.NotEmpty(t, pendingToken)
+	// в базе лежит только хэш токена, и он не подходит как JWT
+	assert.Equal(t, hashSecret(pendingToken), saved.TokenHash)
+	assert.Equal(t, models.TokenTwoFactor, saved.Purpose)
+	assert.Equal(t, user.ID, saved.UserID)
+	_, err = usecase.ParseToken(pendingToken)
+	assert.Error(t, err)
+}
+
+func TestAuthUsecase_VerifyTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	step := totp.Step(time.Now())
+	code, err := totp.Code(secret, step)
+	assert.NoError(t, err)
+	wrongCode, err := totp.Code(secret, step+10)
+	assert.NoError(t, err)
+
+	user := models.User{ID: uuid.NewV4(), Login: "editor"}
+	secondFactor := models.TOTP{UserID: user.ID, Secret: secret, Enabled: true}
+	pendingHash := hashSecret("pending")
+
+	tests := []struct {
+		name      string
+		req       models.TwoFactorSignInInput
+		setupMock func()
+		errorType error
+	}{
+		{
+			name: "Success - totp code",
+			req:  models.TwoFactorSignInInput{PendingToken: "pending", Code: code},
+			setupMock: func() {
+				mockRepo.EXPECT().AttemptPendingSignIn(gomock.Any(), pendingHash, pendingSignInAttempts).Return(user.ID, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(secondFactor, nil)
+				mockRepo.EXPECT().UseTOTPStep(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CompletePendingSignIn(gomock.Any(), pendingHash).Return(nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), user.ID).Return(false, nil)
+			},
+		},
+		{
+			name: "Success - recovery code",
+			req:  models.TwoFactorSignInInput{PendingToken: "pending", Code: " ABCDE-fghij "},
+			setupMock: func() {
+				mockRepo.EXPECT().AttemptPendingSignIn(gomock.Any(), pendingHash, pendingSignInAttempts).Return(user.ID, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(secondFactor, nil)
+				mockRepo.EXPECT().UseRecoveryCode(gomock.Any(), user.ID, hashSecret("abcdefghij")).Return(nil)
+				mockRepo.EXPECT().CompletePendingSignIn(gomock.Any(), pendingHash).Return(nil)
+				mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+				mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), user.ID).Return(false, nil)
+			},
+		},
+		{
+			name:      "Error - empty pending token",
+			req:       models.TwoFactorSignInInput{Code: code},
+			setupMock: func() {},
+			errorType: auth.ErrorUnauthorized,
+		},
+		{
+			name: "Error - expired pending token",
+			req:  models.TwoFactorSignInInput{PendingToken: "pending", Code: code},
+			setupMock: func() {
+				mockRepo.EXPECT().AttemptPendingSignIn(gomock.Any(), pendingHash, pendingSignInAttempts).Return(uuid.Nil, auth.ErrorUnauthorized)
+			},
+			errorType: auth.ErrorUnauthorized,
+		},
+		{
+			name: "Error - wrong totp code",
+			req:  models.TwoFactorSignInInput{PendingToken: "pending", Code: wrongCode},
+			setupMock: func() {
+				mockRepo.EXPECT().AttemptPendingSignIn(gomock.Any(), pendingHash, pendingSignInAttempts).Return(user.ID, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(secondFactor, nil)
+			},
+			errorType: auth.ErrorBadRequest,
+		},
+		{
+			name: "Error - replayed totp step",
+			req:  models.TwoFactorSignInInput{PendingToken: "pending", Code: code},
+			setupMock: func() {
+				mockRepo.EXPECT().AttemptPendingSignIn(gomock.Any(), pendingHash, pendingSignInAttempts).Return(user.ID, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(secondFactor, nil)
+				mockRepo.EXPECT().UseTOTPStep(gomock.Any(), user.ID, gomock.Any()).Return(auth.ErrorBadRequest)
+			},
+			errorType: auth.ErrorBadRequest,
+		},
+		{
+			name: "Error - two factor disabled meanwhile",
+			req:  models.TwoFactorSignInInput{PendingToken: "pending", Code: code},
+			setupMock: func() {
+				mockRepo.EXPECT().AttemptPendingSignIn(gomock.Any(), pendingHash, pendingSignInAttempts).Return(user.ID, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{}, auth.ErrorNotFound)
+			},
+			errorType: auth.ErrorUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			result, token, err := usecase.VerifyTwoFactor(testContext(), tt.req)
+
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, token)
+				assert.Equal(t, user.ID, result.ID)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_SetupTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
+
+	user := models.User{ID: uuid.NewV4(), Login: "editor"}
+	ctx := context.WithValue(testContext(), auth.UserKey, user)
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		setupMock func()
+		errorType error
+	}{
+		{
+			name: "Success",
+			ctx:  ctx,
+			setupMock: func() {
+				mockRepo.EXPECT().GetUserRole(gomock.Any(), user.ID).Return(models.RoleEditor, nil)
+				mockRepo.EXPECT().SaveTOTPSecret(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:      "Error - no user in context",
+			ctx:       testContext(),
+			setupMock: func() {},
+			errorType: auth.ErrorUnauthorized,
+		},
+		{
+			name: "Error - regular user",
+			ctx:  ctx,
+			setupMock: func() {
+				mockRepo.EXPECT().GetUserRole(gomock.Any(), user.ID).Return(models.RoleUser, nil)
+			},
+			errorType: auth.ErrorForbidden,
+		},
+		{
+			name: "Error - already enabled",
+			ctx:  ctx,
+			setupMock: func() {
+				mockRepo.EXPECT().GetUserRole(gomock.Any(), user.ID).Return(models.RoleAdmin, nil)
+				mockRepo.EXPECT().SaveTOTPSecret(gomock.Any(), user.ID, gomock.Any()).Return(auth.ErrorConflict)
+			},
+			errorType: auth.ErrorConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			setup, err := usecase.SetupTwoFactor(tt.ctx)
+
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, setup.Secret)
+				assert.Contains(t, setup.ProvisioningURI, "otpauth://totp/DDFilms:editor?")
+				assert.Contains(t, setup.ProvisioningURI, "secret="+setup.Secret)
+			}
+		})
+	}
+}
+
+func TestAuthUsecase_EnableTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	mockNotifier := notificationMocks.NewMockProducer(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, mockNotifier)
+
+	user := models.User{ID: uuid.NewV4(), Login: "editor", Version: 2}
+	ctx := context.WithValue(testContext(), auth.UserKey, user)
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+
+	t.Run("Success", func(t *testing.T) {
+		var hashes [][]byte
+		mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{UserID: user.ID, Secret: secret}, nil)
+		mockRepo.EXPECT().EnableTOTP(gomock.Any(), user.ID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, _ int64, codeHashes [][]byte) error {
+				hashes = codeHashes
+				return nil
+			})
+		mockRepo.EXPECT().IncrementUserVersion(gomock.Any(), user.ID).Return(nil)
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: user.ID, Action: models.SecurityTwoFactorEnabled})
+
+		codes, token, err := usecase.EnableTwoFactor(ctx, code)
+		assert.NoError(t, err)
+		parsedToken, err := usecase.ParseToken(token)
+		assert.NoError(t, err)
+		claims, _ := parsedToken.Claims.(jwt.MapClaims)
+		assert.Equal(t, float64(3), claims["version"])
+		assert.Len(t, codes.Codes, recoveryCodesCount)
+		assert.Len(t, hashes, recoveryCodesCount)
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes.Codes[0])
+		assert.Equal(t, hashSecret(normalizeRecoveryCode(codes.Codes[0])), hashes[0])
+	})
+
+	t.Run("Error - setup not started", func(t *testing.T) {
+		mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{}, auth.ErrorNotFound)
+		_, _, err := usecase.EnableTwoFactor(ctx, code)
+		assert.ErrorIs(t, err, auth.ErrorBadRequest)
+	})
+
+	t.Run("Error - already enabled", func(t *testing.T) {
+		mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{UserID: user.ID, Secret: secret, Enabled: true}, nil)
+		_, _, err := usecase.EnableTwoFactor(ctx, code)
+		assert.ErrorIs(t, err, auth.ErrorConflict)
+	})
+
+	t.Run("Error - wrong code", func(t *testing.T) {
+		mockRepo.EXPECT().GetTOTP(gomock.Any(), user.ID).Return(models.TOTP{UserID: user.ID, Secret: secret}, nil)
+		_, _, err := usecase.EnableTwoFactor(ctx, "000000x")
+		assert.ErrorIs(t, err, auth.ErrorBadRequest)
+	})
+}
+
+func TestAuthUsecase_DisableTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	mockNotifier := notificationMocks.NewMockProducer(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, mockNotifier)
+
+	user := models.User{ID: uuid.NewV4(), Login: "editor", PasswordHash: testHash("testpass123"), PasswordSet: true}
+	ctx := context.WithValue(testContext(), auth.UserKey, user)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteTOTP(gomock.Any(), user.ID).Return(nil)
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: user.ID, Action: models.SecurityTwoFactorDisabled})
+		assert.NoError(t, usecase.DisableTwoFactor(ctx, "testpass123"))
+	})
+
+	t.Run("Error - repo fails, no event", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteTOTP(gomock.Any(), user.ID).Return(auth.ErrorInternalServerError)
+		assert.ErrorIs(t, usecase.DisableTwoFactor(ctx, "testpass123"), auth.ErrorInternalServerError)
+	})
+
+	t.Run("Error - wrong password", func(t *testing.T) {
+		assert.ErrorIs(t, usecase.DisableTwoFactor(ctx, "wrongpass"), auth.ErrorBadRequest)
+	})
+
+	t.Run("Without own password", func(t *testing.T) {
+		oauthUser := user
+		oauthUser.PasswordSet = false
+		mockRepo.EXPECT().DeleteTOTP(gomock.Any(), user.ID).Return(nil)
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: user.ID, Action: models.SecurityTwoFactorDisabled})
+		assert.NoError(t, usecase.DisableTwoFactor(context.WithValue(testContext(), auth.UserKey, oauthUser), ""))
+	})
+
+	t.Run("Error - no user in context", func(t *testing.T) {
+		assert.ErrorIs(t, usecase.DisableTwoFactor(testContext(), "testpass123"), auth.ErrorUnauthorized)
+	})
+}
+
 func TestAuthUsecase_CheckAuth(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	userID := uuid.NewV4()
 	user := models.User{
@@ -479,7 +832,7 @@ func TestAuthUsecase_LogOutUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	userID := uuid.NewV4()
 	user := models.User{
@@ -546,16 +899,17 @@ func TestAuthUsecase_ValidateAndGetUser(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockAuthRepo(ctrl)
-	usecase := NewAuthUsecase(mockRepo)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
 
 	userID := uuid.NewV4()
 	login := "testuser"
 	user := models.User{
-		ID:    userID,
-		Login: login,
+		ID:      userID,
+		Login:   login,
+		Version: 2,
 	}
 
-	validToken, _ := usecase.GenerateToken(userID, login)
+	validToken, _ := usecase.GenerateToken(userID, login, user.Version)
 
 	// Create expired token
 	expiredToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
@@ -581,6 +935,26 @@ func TestAuthUsecase_ValidateAndGetUser(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:  "Error - login now belongs to another user",
+			token: validToken,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetUserByLogin(gomock.Any(), login).
+					Return(models.User{ID: uuid.NewV4(), Login: login}, nil)
+			},
+			expectError: true,
+		},
+		{
+			name:  "Error - user logged out or changed password after token was issued",
+			token: validToken,
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetUserByLogin(gomock.Any(), login).
+					Return(models.User{ID: userID, Login: login, Version: user.Version + 1}, nil)
+			},
+			expectError: true,
+		},
 		{
 			name:        "Error - empty token",
 			token:       "",
@@ -617,49 +991,280 @@ func TestAuthUsecase_ValidateAndGetUser(t *testing.T) {
 	}
 }
 
-func TestValidateFunctions(t *testing.T) {
+func TestValidateLogin(t *testing.T) {
 	tests := []struct {
 		name          string
 		login         string
-		password      string
 		expectedValid bool
 	}{
 		{
-			name:          "Valid credentials",
+			name:          "Valid login",
 			login:         "user123",
-			password:      "pass123",
 			expectedValid: true,
 		},
 		{
-			name:          "Invalid login length",
+			name:          "Too short",
 			login:         "usr",
-			password:      "pass123",
 			expectedValid: false,
 		},
 		{
-			name:          "Invalid password length",
-			login:         "user123",
-			password:      "pass",
+			name:          "Too long",
+			login:         "user1234567890123",
 			expectedValid: false,
 		},
 		{
-			name:          "Both invalid",
-			login:         "usr",
-			password:      "pass",
+			name:          "Invalid characters",
+			login:         "пользователь",
 			expectedValid: false,
 		},
 		{
-			name:          "Empty credentials",
+			name:          "Empty login",
 			login:         "",
-			password:      "",
 			expectedValid: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, dataIsValid := auth.Validaton(tt.login, tt.password)
+			_, dataIsValid := auth.ValidateLogin(tt.login)
 			assert.Equal(t, tt.expectedValid, dataIsValid)
 		})
 	}
 }
+
+func TestAuthUsecase_StartOAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	mockProvider := mocks.NewMockOAuthProvider(ctrl)
+	usecase := NewAuthUsecase(mockRepo, map[string]auth.OAuthProvider{"google": mockProvider, "vk": mockProvider}, nil, nil)
+	assert.Equal(t, []string{"google", "vk"}, usecase.OAuthProviders())
+
+	user := models.User{ID: uuid.NewV4(), Login: "testuser"}
+
+	t.Run("Sign in", func(t *testing.T) {
+		var saved models.OAuthState
+		mockRepo.EXPECT().CreateOAuthState(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, state models.OAuthState) error {
+				saved = state
+				return nil
+			})
+		mockProvider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(state, challenge string) string {
+				return "https://idp/authorize?state=" + state + "&code_challenge=" + challenge
+			})
+
+		redirectURL, state, err := usecase.StartOAuth(testContext(), "google", false)
+		assert.NoError(t, err)
+		assert.Equal(t, hashSecret(state), saved.StateHash)
+		assert.Equal(t, "google", saved.Provider)
+		assert.False(t, saved.LinkUserID.Valid)
+		// в адрес уходит только challenge, verifier остаётся на сервере
+		assert.Contains(t, redirectURL, "code_challenge="+oidc.Challenge(saved.CodeVerifier))
+		assert.NotContains(t, redirectURL, saved.CodeVerifier)
+	})
+
+	t.Run("Link", func(t *testing.T) {
+		mockRepo.EXPECT().CreateOAuthState(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, state models.OAuthState) error {
+				assert.Equal(t, uuid.NullUUID{UUID: user.ID, Valid: true}, state.LinkUserID)
+				return nil
+			})
+		mockProvider.EXPECT().AuthCodeURL(gomock.Any(), gomock.Any()).Return("https://idp/authorize")
+
+		_, _, err := usecase.StartOAuth(context.WithValue(testContext(), auth.UserKey, user), "vk", true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error - link without user", func(t *testing.T) {
+		_, _, err := usecase.StartOAuth(testContext(), "vk", true)
+		assert.ErrorIs(t, err, auth.ErrorUnauthorized)
+	})
+
+	t.Run("Error - unknown provider", func(t *testing.T) {
+		_, _, err := usecase.StartOAuth(testContext(), "facebook", false)
+		assert.ErrorIs(t, err, auth.ErrorNotFound)
+	})
+}
+
+func TestAuthUsecase_CompleteOAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	mockProvider := mocks.NewMockOAuthProvider(ctrl)
+	usecase := NewAuthUsecase(mockRepo, map[string]auth.OAuthProvider{"yandex": mockProvider}, nil, nil)
+
+	callback := url.Values{"code": {"code"}, "state": {"state"}}
+	stateHash := hashSecret("state")
+	identity := models.ExternalIdentity{Subject: "42", Email: "Ivan.Petrov@yandex.ru", Username: "ivan.petrov"}
+	existing := models.User{ID: uuid.NewV4(), Login: "existing"}
+	linkUserID := uuid.NewV4()
+
+	consume := func(state models.OAuthState) {
+		mockRepo.EXPECT().ConsumeOAuthState(gomock.Any(), stateHash, "yandex").Return(state, nil)
+	}
+	exchange := func() {
+		mockProvider.EXPECT().Exchange(gomock.Any(), callback, "verifier").Return(identity, nil)
+	}
+
+	tests := []struct {
+		name      string
+		callback  url.Values
+		setupMock func()
+		wantLogin string
+		wantToken bool
+		errorType error
+	}{
+		{
+			name:     "Sign in linked user",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier"})
+				exchange()
+				mockRepo.EXPECT().GetUserByIdentity(gomock.Any(), "yandex", "42").Return(existing, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), existing.ID).Return(models.TOTP{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), existing.ID).Return(false, nil)
+			},
+			wantLogin: "existing",
+			wantToken: true,
+		},
+		{
+			name:     "First sign in creates user",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier"})
+				exchange()
+				mockRepo.EXPECT().GetUserByIdentity(gomock.Any(), "yandex", "42").Return(models.User{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().CreateOAuthUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, user models.User, linked models.ExternalIdentity) error {
+						assert.Equal(t, "ivan.petro", user.Login)
+						assert.True(t, strings.HasPrefix(string(user.PasswordHash), "$argon2id$"))
+						assert.Equal(t, "yandex", linked.Provider)
+						return nil
+					})
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), gomock.Any()).Return(models.TOTP{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantLogin: "ivan.petro",
+			wantToken: true,
+		},
+		{
+			name:     "Taken login gets a suffix",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier"})
+				exchange()
+				mockRepo.EXPECT().GetUserByIdentity(gomock.Any(), "yandex", "42").Return(models.User{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().CreateOAuthUser(gomock.Any(), gomock.Any(), gomock.Any()).Return(auth.ErrorConflict)
+				mockRepo.EXPECT().CreateOAuthUser(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, user models.User, _ models.ExternalIdentity) error {
+						assert.Regexp(t, `^ivan\.petro[0-9]{5}$`, user.Login)
+						return nil
+					})
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), gomock.Any()).Return(models.TOTP{}, auth.ErrorNotFound)
+				mockRepo.EXPECT().CancelUserDeletion(gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+			wantToken: true,
+		},
+		{
+			name:     "Two factor still required",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier"})
+				exchange()
+				mockRepo.EXPECT().GetUserByIdentity(gomock.Any(), "yandex", "42").Return(existing, nil)
+				mockRepo.EXPECT().GetTOTP(gomock.Any(), existing.ID).Return(models.TOTP{Enabled: true}, nil)
+				mockRepo.EXPECT().CreatePendingSignIn(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			errorType: auth.ErrorTwoFactorRequired,
+		},
+		{
+			name:     "Link to current user",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier", LinkUserID: uuid.NullUUID{UUID: linkUserID, Valid: true}})
+				exchange()
+				mockRepo.EXPECT().LinkIdentity(gomock.Any(), linkUserID, models.ExternalIdentity{
+					Provider: "yandex", Subject: "42", Email: "Ivan.Petrov@yandex.ru", Username: "ivan.petrov",
+				}).Return(nil)
+			},
+		},
+		{
+			name:     "Error - identity linked to another user",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier", LinkUserID: uuid.NullUUID{UUID: linkUserID, Valid: true}})
+				exchange()
+				mockRepo.EXPECT().LinkIdentity(gomock.Any(), linkUserID, gomock.Any()).Return(auth.ErrorConflict)
+			},
+			errorType: auth.ErrorConflict,
+		},
+		{
+			name:      "Error - no code",
+			callback:  url.Values{"state": {"state"}},
+			setupMock: func() {},
+			errorType: auth.ErrorBadRequest,
+		},
+		{
+			name:     "Error - unknown or used state",
+			callback: callback,
+			setupMock: func() {
+				mockRepo.EXPECT().ConsumeOAuthState(gomock.Any(), stateHash, "yandex").Return(models.OAuthState{}, auth.ErrorUnauthorized)
+			},
+			errorType: auth.ErrorUnauthorized,
+		},
+		{
+			name:     "Error - provider failed",
+			callback: callback,
+			setupMock: func() {
+				consume(models.OAuthState{CodeVerifier: "verifier"})
+				mockProvider.EXPECT().Exchange(gomock.Any(), callback, "verifier").Return(models.ExternalIdentity{}, oidc.ErrExchange)
+			},
+			errorType: auth.ErrorBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+			user, token, err := usecase.CompleteOAuth(testContext(), "yandex", tt.callback)
+
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantToken, token != "")
+			if tt.wantLogin != "" {
+				assert.Equal(t, tt.wantLogin, user.Login)
+			}
+		})
+	}
+}
+
+func TestLoginBase(t *testing.T) {
+	assert.Equal(t, "ivan_petro", loginBase(models.ExternalIdentity{Username: "Ivan_Petrov_1990"}))
+	assert.Equal(t, "user", loginBase(models.ExternalIdentity{Email: "user@example.com"}))
+	// кириллица и пробелы отбрасываются, остаётся имя провайдера
+	assert.Equal(t, "vk", loginBase(models.ExternalIdentity{Provider: "vk", Username: "Иван Петров"}))
+}
+
+func TestAuthUsecase_UnlinkIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockAuthRepo(ctrl)
+	usecase := NewAuthUsecase(mockRepo, nil, nil, nil)
+	user := models.User{ID: uuid.NewV4()}
+
+	mockRepo.EXPECT().UnlinkIdentity(gomock.Any(), user.ID, "vk").Return(auth.ErrorConflict)
+	assert.ErrorIs(t, usecase.UnlinkIdentity(context.WithValue(testContext(), auth.UserKey, user), "vk"), auth.ErrorConflict)
+	assert.ErrorIs(t, usecase.UnlinkIdentity(testContext(), "vk"), auth.ErrorUnauthorized)
+
+	mockRepo.EXPECT().GetIdentities(gomock.Any(), user.ID).Return([]models.LinkedIdentity{{Provider: "vk"}}, nil)
+	identities, err := usecase.GetIdentities(context.WithValue(testContext(), auth.UserKey, user))
+	assert.NoError(t, err)
+	assert.Len(t, identities, 1)
+}
@@ -0,0 +1,99 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"kinopoisk/internal/models"
+
+	// форматы, которые принимаются на загрузку
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	// MaxBytes больше этого файл не читается вовсе
+	MaxBytes = 10 * 1024 * 1024
+	// MaxSide и MaxPixels проверяются по заголовку до декодирования, чтобы маленький
+	// файл с огромными размерами не съел память
+	MaxSide   = 8192
+	MaxPixels = 40_000_000
+	// MinSide меньше самого маленького аватара картинку пришлось бы растягивать
+	MinSide = 64
+
+	jpegQuality = 85
+)
+
+var (
+	ErrTooLarge          = errors.New("image is too large")
+	ErrTooSmall          = errors.New("image is too small")
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrInvalidImage      = errors.New("invalid image")
+)
+
+var allowedFormats = map[string]bool{"jpeg": true, "png": true, "webp": true}
+
+// Process декодирует картинку, обрезает её до квадрата по центру и нарезает
+// все размеры из models.AvatarSizes. Результат перекодируется в JPEG, поэтому
+// EXIF, GPS и прочие метаданные исходника в него не попадают; поворот из EXIF
+// при этом применяется, чтобы снимки с телефона не лежали на боку
+func Process(data []byte) (map[int][]byte, error) {
+	if len(data) > MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, ErrInvalidImage
+	}
+	if !allowedFormats[format] {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width > MaxSide || config.Height > MaxSide || config.Width*config.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+	if config.Width < MinSide || config.Height < MinSide {
+		return nil, ErrTooSmall
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(data)
+	}
+
+	crop := centerSquare(src.Bounds())
+	result := make(map[int][]byte, len(models.AvatarSizes))
+	for _, size := range models.AvatarSizes {
+		dst := image.NewRGBA(image.Rect(0, 0, size, size))
+		// прозрачность PNG и WebP ложится на белый фон, в JPEG альфы нет
+		xdraw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, xdraw.Src)
+		xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, xdraw.Over, nil)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, orient(dst, orientation), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		result[size] = buf.Bytes()
+	}
+	return result, nil
+}
+
+func centerSquare(bounds image.Rectangle) image.Rectangle {
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
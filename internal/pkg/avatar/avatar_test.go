@@ -0,0 +1,158 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"kinopoisk/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// quadrants левая верхняя четверть красная, остальное синее
+func quadrants(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 && y < height/2 {
+				img.SetRGBA(x, y, red)
+			} else {
+				img.SetRGBA(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// withOrientation вставляет после SOI сегмент APP1 с EXIF, где есть только Orientation
+func withOrientation(jpegData []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00*\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifTagOrientation)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, jpegMarkerAPP1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	result := append([]byte{}, jpegData[:2]...)
+	result = append(result, app1...)
+	return append(result, jpegData[2:]...)
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xC000 && g < 0x4000 && b < 0x4000
+}
+
+func TestProcess(t *testing.T) {
+	result, err := Process(encodePNG(t, quadrants(1200, 800)))
+	assert.NoError(t, err)
+	assert.Len(t, result, len(models.AvatarSizes))
+
+	for _, size := range models.AvatarSizes {
+		img, format, err := image.Decode(bytes.NewReader(result[size]))
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+		// из 1200x800 вырезается квадрат 800x800 со сдвигом 200, красного в нём четверть со стороной 400
+		assert.True(t, isRed(img.At(size/8, size/8)), "size %d", size)
+		assert.False(t, isRed(img.At(size*7/8, size/8)), "size %d", size)
+	}
+}
+
+func TestProcessStripsMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, quadrants(256, 256), nil))
+	source := withOrientation(buf.Bytes(), 1)
+	assert.Contains(t, string(source), "Exif")
+
+	result, err := Process(source)
+	assert.NoError(t, err)
+	for _, data := range result {
+		assert.NotContains(t, string(data), "Exif")
+	}
+}
+
+func TestProcessAppliesOrientation(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, quadrants(256, 256), &jpeg.Options{Quality: 100}))
+
+	// 6: повернуть на 90 по часовой, красный угол уходит из левого верхнего в правый верхний
+	result, err := Process(withOrientation(buf.Bytes(), 6))
+	assert.NoError(t, err)
+	img, err := jpeg.Decode(bytes.NewReader(result[64]))
+	assert.NoError(t, err)
+	assert.False(t, isRed(img.At(8, 8)))
+	assert.True(t, isRed(img.At(56, 8)))
+}
+
+func TestOrient(t *testing.T) {
+	// у каждого варианта свой угол, куда попадает левый верхний пиксель
+	corners := map[int]image.Point{
+		1: {0, 0}, 2: {2, 0}, 3: {2, 2}, 4: {0, 2},
+		5: {0, 0}, 6: {2, 0}, 7: {2, 2}, 8: {0, 2},
+	}
+	for orientation, corner := range corners {
+		src := image.NewRGBA(image.Rect(0, 0, 3, 3))
+		src.SetRGBA(0, 0, red)
+		dst := orient(src, orientation)
+		assert.Equal(t, red, dst.RGBAAt(corner.X, corner.Y), "orientation %d", orientation)
+	}
+}
+
+// pngHeader валидный заголовок PNG с произвольными размерами и без данных
+func pngHeader(width, height uint32) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(ihdr)-4))
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestProcessRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "Decompression bomb", data: pngHeader(60000, 60000), wantErr: ErrTooLarge},
+		{name: "Too many pixels", data: pngHeader(8000, 8000), wantErr: ErrTooLarge},
+		{name: "Too small", data: encodePNG(t, quadrants(32, 200)), wantErr: ErrTooSmall},
+		{name: "Not an image", data: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"/>"), wantErr: ErrUnsupportedFormat},
+		{name: "Truncated", data: pngHeader(256, 256), wantErr: ErrInvalidImage},
+		{name: "Too many bytes", data: make([]byte, MaxBytes+1), wantErr: ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Process(tt.data)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
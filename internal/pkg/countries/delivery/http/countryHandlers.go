@@ -0,0 +1,115 @@
+package http
+
+import (
+	"errors"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CountryHandler struct {
+	uc countries.CountryUsecase
+}
+
+func NewCountryHandler(uc countries.CountryUsecase) *CountryHandler {
+	return &CountryHandler{uc: uc}
+}
+
+// GetCountry godoc
+// @Summary Get country by ID
+// @Tags countries
+// @Produce json
+// @Param        id   path      string  true  "Country ID"
+// @Success 200 {object} models.Country
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /countries/{id} [get]
+func (c *CountryHandler) GetCountry(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	vars := mux.Vars(r)
+	id, err := uuid.FromString(vars["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	neededCountry, err := c.uc.GetCountry(r.Context(), id)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	neededCountry.Sanitize()
+	helpers.WriteJSON(w, neededCountry)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetCountries godoc
+// @Summary Get list of countries with films count and average rating
+// @Tags countries
+// @Produce json
+// @Param        count   query     int  false  "Number of countries" default(10)
+// @Param        offset  query     int  false  "Offset" default(0)
+// @Success 200 {array} models.Country
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /countries [get]
+func (c *CountryHandler) GetCountries(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	pager := helpers.GetPagerFromRequest(r)
+
+	allCountries, err := c.uc.GetCountries(r.Context(), pager)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	for i := range allCountries {
+		allCountries[i].Sanitize()
+	}
+	helpers.WriteJSON(w, allCountries)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetFilmsByCountry godoc
+// @Summary Get films by country
+// @Tags countries
+// @Produce json
+// @Param        id   path      string  true  "Country ID"
+// @Param        count   query     int  false  "Number of films" default(10)
+// @Param        offset  query     int  false  "Offset" default(0)
+// @Success 200 {array} models.MainPageFilm
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /countries/{id}/films [get]
+func (c *CountryHandler) GetFilmsByCountry(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	vars := mux.Vars(r)
+
+	neededCountry, err := uuid.FromString(vars["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	pager := helpers.GetPagerFromRequest(r)
+
+	films, err := c.uc.GetFilmsByCountry(r.Context(), neededCountry, pager)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	for i := range films {
+		films[i].Sanitize()
+	}
+	helpers.WriteJSON(w, films)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
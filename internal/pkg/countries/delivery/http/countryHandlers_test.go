@@ -0,0 +1,224 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/countries/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestGetCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCountryUsecase(ctrl)
+	handler := NewCountryHandler(mockUsecase)
+
+	countryID := uuid.NewV4()
+	expectedCountry := models.Country{ID: countryID, Name: "Германия", FilmsCount: 5, Rating: 7.2}
+
+	tests := []struct {
+		name           string
+		varsID         string
+		mockSetup      func()
+		expectedStatus int
+		expectBody     bool
+	}{
+		{
+			name:   "Success",
+			varsID: countryID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCountry(gomock.Any(), countryID).Return(expectedCountry, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectBody:     true,
+		},
+		{
+			name:           "Invalid ID",
+			varsID:         "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Not found",
+			varsID: countryID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCountry(gomock.Any(), countryID).Return(models.Country{}, countries.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Internal error",
+			varsID: countryID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCountry(gomock.Any(), countryID).Return(models.Country{}, errors.New("internal error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/countries/"+tt.varsID, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/countries/{id}", handler.GetCountry)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectBody {
+				var decoded models.Country
+				err := json.Unmarshal(rec.Body.Bytes(), &decoded)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedCountry, decoded)
+			}
+		})
+	}
+}
+
+func TestGetCountries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCountryUsecase(ctrl)
+	handler := NewCountryHandler(mockUsecase)
+
+	expectedCountries := []models.Country{
+		{ID: uuid.NewV4(), Name: "Испания", FilmsCount: 1, Rating: 6.5},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  "/countries?count=5&offset=5",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCountries(gomock.Any(), models.Pager{Count: 5, Offset: 5}).Return(expectedCountries, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "No countries",
+			url:  "/countries",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCountries(gomock.Any(), models.Pager{Count: 10, Offset: 0}).Return([]models.Country{}, countries.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			handler.GetCountries(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestGetFilmsByCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockCountryUsecase(ctrl)
+	handler := NewCountryHandler(mockUsecase)
+
+	countryID := uuid.NewV4()
+	expectedFilms := []models.MainPageFilm{
+		{ID: uuid.NewV4(), Cover: "/covers/film.jpg", Title: "Броненосец Потемкин", Rating: 7.7, Year: 1925, Genre: "Драма"},
+	}
+
+	tests := []struct {
+		name           string
+		varsID         string
+		mockSetup      func()
+		expectedStatus int
+		expectBody     bool
+	}{
+		{
+			name:   "Success",
+			varsID: countryID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().
+					GetFilmsByCountry(gomock.Any(), countryID, models.Pager{Count: 10, Offset: 0}).
+					Return(expectedFilms, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectBody:     true,
+		},
+		{
+			name:           "Invalid ID",
+			varsID:         "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "No films",
+			varsID: countryID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().
+					GetFilmsByCountry(gomock.Any(), countryID, models.Pager{Count: 10, Offset: 0}).
+					Return([]models.MainPageFilm{}, countries.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/countries/"+tt.varsID+"/films", nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/countries/{id}/films", handler.GetFilmsByCountry)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectBody {
+				var decoded []models.MainPageFilm
+				err := json.Unmarshal(rec.Body.Bytes(), &decoded)
+				assert.NoError(t, err)
+				assert.Equal(t, expectedFilms, decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package countries
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type CountryUsecase interface {
+	GetCountry(ctx context.Context, id uuid.UUID) (models.Country, error)
+	GetCountries(ctx context.Context, pager models.Pager) ([]models.Country, error)
+	GetFilmsByCountry(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error)
+}
+
+type CountryRepo interface {
+	GetCountryByID(ctx context.Context, id uuid.UUID) (models.Country, error)
+	GetCountriesWithPagination(ctx context.Context, limit, offset int) ([]models.Country, error)
+	GetFilmsByCountry(ctx context.Context, countryID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error)
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/countries/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/countries/interfaces.go -destination=internal/pkg/countries/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/satori/go.uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCountryUsecase is a mock of CountryUsecase interface.
+type MockCountryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockCountryUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockCountryUsecaseMockRecorder is the mock recorder for MockCountryUsecase.
+type MockCountryUsecaseMockRecorder struct {
+	mock *MockCountryUsecase
+}
+
+// NewMockCountryUsecase creates a new mock instance.
+func NewMockCountryUsecase(ctrl *gomock.Controller) *MockCountryUsecase {
+	mock := &MockCountryUsecase{ctrl: ctrl}
+	mock.recorder = &MockCountryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCountryUsecase) EXPECT() *MockCountryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetCountries mocks base method.
+func (m *MockCountryUsecase) GetCountries(ctx context.Context, pager models.Pager) ([]models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountries", ctx, pager)
+	ret0, _ := ret[0].([]models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountries indicates an expected call of GetCountries.
+func (mr *MockCountryUsecaseMockRecorder) GetCountries(ctx, pager any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountries", reflect.TypeOf((*MockCountryUsecase)(nil).GetCountries), ctx, pager)
+}
+
+// GetCountry mocks base method.
+func (m *MockCountryUsecase) GetCountry(ctx context.Context, id uuid.UUID) (models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountry", ctx, id)
+	ret0, _ := ret[0].(models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountry indicates an expected call of GetCountry.
+func (mr *MockCountryUsecaseMockRecorder) GetCountry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountry", reflect.TypeOf((*MockCountryUsecase)(nil).GetCountry), ctx, id)
+}
+
+// GetFilmsByCountry mocks base method.
+func (m *MockCountryUsecase) GetFilmsByCountry(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmsByCountry", ctx, id, pager)
+	ret0, _ := ret[0].([]models.MainPageFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmsByCountry indicates an expected call of GetFilmsByCountry.
+func (mr *MockCountryUsecaseMockRecorder) GetFilmsByCountry(ctx, id, pager any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByCountry", reflect.TypeOf((*MockCountryUsecase)(nil).GetFilmsByCountry), ctx, id, pager)
+}
+
+// MockCountryRepo is a mock of CountryRepo interface.
+type MockCountryRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCountryRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCountryRepoMockRecorder is the mock recorder for MockCountryRepo.
+type MockCountryRepoMockRecorder struct {
+	mock *MockCountryRepo
+}
+
+// NewMockCountryRepo creates a new mock instance.
+func NewMockCountryRepo(ctrl *gomock.Controller) *MockCountryRepo {
+	mock := &MockCountryRepo{ctrl: ctrl}
+	mock.recorder = &MockCountryRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCountryRepo) EXPECT() *MockCountryRepoMockRecorder {
+	return m.recorder
+}
+
+// GetCountriesWithPagination mocks base method.
+func (m *MockCountryRepo) GetCountriesWithPagination(ctx context.Context, limit, offset int) ([]models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountriesWithPagination", ctx, limit, offset)
+	ret0, _ := ret[0].([]models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountriesWithPagination indicates an expected call of GetCountriesWithPagination.
+func (mr *MockCountryRepoMockRecorder) GetCountriesWithPagination(ctx, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountriesWithPagination", reflect.TypeOf((*MockCountryRepo)(nil).GetCountriesWithPagination), ctx, limit, offset)
+}
+
+// GetCountryByID mocks base method.
+func (m *MockCountryRepo) GetCountryByID(ctx context.Context, id uuid.UUID) (models.Country, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountryByID", ctx, id)
+	ret0, _ := ret[0].(models.Country)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountryByID indicates an expected call of GetCountryByID.
+func (mr *MockCountryRepoMockRecorder) GetCountryByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountryByID", reflect.TypeOf((*MockCountryRepo)(nil).GetCountryByID), ctx, id)
+}
+
+// GetFilmsByCountry mocks base method.
+func (m *MockCountryRepo) GetFilmsByCountry(ctx context.Context, countryID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmsByCountry", ctx, countryID, limit, offset)
+	ret0, _ := ret[0].([]models.MainPageFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmsByCountry indicates an expected call of GetFilmsByCountry.
+func (mr *MockCountryRepoMockRecorder) GetFilmsByCountry(ctx, countryID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByCountry", reflect.TypeOf((*MockCountryRepo)(nil).GetFilmsByCountry), ctx, countryID, limit, offset)
+}
@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"strconv"
+
+	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+)
+
+type CountryRepository struct {
+	db pgxtype.Querier
+}
+
+func NewCountryRepository(db pgxtype.Querier) *CountryRepository {
+	return &CountryRepository{db: db}
+}
+
+func roundRating(rating float64) float64 {
+	roundedRating, _ := strconv.ParseFloat(fmt.Sprintf("%.1f", rating), 64)
+	return roundedRating
+}
+
+func (c *CountryRepository) GetCountryByID(ctx context.Context, id uuid.UUID) (models.Country, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var country models.Country
+	err := c.db.QueryRow(
+		ctx,
+		GetCountryByIDQuery,
+		id,
+	).Scan(
+		&country.ID, &country.Name, &country.CreatedAt, &country.UpdatedAt,
+		&country.FilmsCount, &country.Rating,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("country is not found: " + err.Error())
+			return models.Country{}, countries.ErrorNotFound
+		}
+		logger.Error("failed to scan country: " + err.Error())
+		return models.Country{}, countries.ErrorInternalServerError
+	}
+	country.Rating = roundRating(country.Rating)
+
+	logger.Info("succesfully got country by id from db")
+	return country, nil
+}
+
+func (c *CountryRepository) GetCountriesWithPagination(ctx context.Context, limit, offset int) ([]models.Country, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	if limit <= 0 || offset < 0 {
+		return nil, countries.ErrorBadRequest
+	}
+
+	rows, err := c.db.Query(ctx, GetCountriesWithPaginationQuery, limit, offset)
+	if err != nil {
+		logger.Error("failed to get rows: " + err.Error())
+		return nil, countries.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	var result []models.Country
+	for rows.Next() {
+		var country models.Country
+		if err := rows.Scan(
+			&country.ID, &country.Name, &country.CreatedAt, &country.UpdatedAt,
+			&country.FilmsCount, &country.Rating,
+		); err != nil {
+			logger.Error("failed to scan country: " + err.Error())
+			continue
+		}
+		country.Rating = roundRating(country.Rating)
+		result = append(result, country)
+	}
+
+	logger.Info("succesfully got countries from db")
+	return result, nil
+}
+
+func (c *CountryRepository) GetFilmAvgRating(ctx context.Context, filmID uuid.UUID) (float64, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var avgRating float64
+	err := c.db.QueryRow(
+		ctx,
+		GetFilmAvgRatingQuery,
+		filmID,
+	).Scan(&avgRating)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("film is not found: " + err.Error())
+			return 0, countries.ErrorNotFound
+		}
+		logger.Error("failed to scan rating: " + err.Error())
+		return 0, countries.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got rating of film from db")
+	return roundRating(avgRating), nil
+}
+
+func (c *CountryRepository) GetFilmsByCountry(ctx context.Context, countryID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := c.db.Query(ctx, GetFilmsByCountryQuery, countryID, limit, offset)
+	if err != nil {
+		logger.Error("failed to get rows: " + err.Error())
+		return nil, countries.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	var films []models.MainPageFilm
+	for rows.Next() {
+		var film models.MainPageFilm
+		if err := rows.Scan(
+			&film.ID,
+			&film.Cover,
+			&film.Title,
+			&film.Year,
+			&film.Genre,
+		); err != nil {
+			logger.Error("failed to scan film: " + err.Error())
+			continue
+		}
+		rating, err := c.GetFilmAvgRating(ctx, film.ID)
+		if err != nil {
+			logger.Error("failed to get rating: " + err.Error())
+			film.Rating = 0.0
+		} else {
+			film.Rating = rating
+		}
+		films = append(films, film)
+	}
+
+	logger.Info("succesfully got films by country from db")
+	return films, nil
+}
@@ -0,0 +1,257 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+type MockRow struct {
+	err error
+}
+
+func (m MockRow) Scan(dest ...interface{}) error {
+	return m.err
+}
+
+func TestGetCountryByID(t *testing.T) {
+	countryID := uuid.NewV4()
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantCountry models.Country
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{
+					"id", "name", "created_at", "updated_at", "films_count", "rating",
+				}).
+					AddRow(countryID, "Франция", createdAt, updatedAt, 12, 7.4666).
+					ToPgxRows()
+				rows.Next()
+
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), GetCountryByIDQuery, countryID).
+					Return(rows)
+			},
+			wantCountry: models.Country{
+				ID:         countryID,
+				Name:       "Франция",
+				FilmsCount: 12,
+				Rating:     7.5,
+				CreatedAt:  createdAt,
+				UpdatedAt:  updatedAt,
+			},
+		},
+		{
+			name: "NotFound",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), GetCountryByIDQuery, countryID).
+					Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: countries.ErrorNotFound,
+		},
+		{
+			name: "DatabaseError",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), GetCountryByIDQuery, countryID).
+					Return(MockRow{err: assert.AnError})
+			},
+			wantErr: countries.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewCountryRepository(mockPool)
+			country, err := repo.GetCountryByID(testContext(), countryID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCountry, country)
+			}
+		})
+	}
+}
+
+func TestGetCountriesWithPagination(t *testing.T) {
+	countryID1 := uuid.NewV4()
+	countryID2 := uuid.NewV4()
+	createdAt := time.Now()
+	updatedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		limit         int
+		offset        int
+		repoMocker    func(*pgxpoolmock.MockPgxPool)
+		wantCountries []models.Country
+		wantErr       bool
+	}{
+		{
+			name:   "Success",
+			limit:  10,
+			offset: 0,
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{
+					"id", "name", "created_at", "updated_at", "films_count", "rating",
+				}).
+					AddRow(countryID1, "Россия", createdAt, updatedAt, 30, 6.94).
+					AddRow(countryID2, "США", createdAt, updatedAt, 0, 0.0).
+					ToPgxRows()
+
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetCountriesWithPaginationQuery, 10, 0).
+					Return(rows, nil)
+			},
+			wantCountries: []models.Country{
+				{ID: countryID1, Name: "Россия", FilmsCount: 30, Rating: 6.9, CreatedAt: createdAt, UpdatedAt: updatedAt},
+				{ID: countryID2, Name: "США", CreatedAt: createdAt, UpdatedAt: updatedAt},
+			},
+		},
+		{
+			name:       "InvalidLimit",
+			limit:      0,
+			offset:     0,
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {},
+			wantErr:    true,
+		},
+		{
+			name:   "QueryError",
+			limit:  10,
+			offset: 0,
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetCountriesWithPaginationQuery, 10, 0).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewCountryRepository(mockPool)
+			result, err := repo.GetCountriesWithPagination(testContext(), tt.limit, tt.offset)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantCountries, result)
+			}
+		})
+	}
+}
+
+func TestGetFilmsByCountry(t *testing.T) {
+	countryID := uuid.NewV4()
+	filmID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantFilms  []models.MainPageFilm
+		wantErr    bool
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mainRows := pgxpoolmock.NewRows([]string{
+					"id", "cover", "title", "year", "genre_title",
+				}).
+					AddRow(filmID, "/static/cover1.jpg", "Амели", 2001, "Комедия").
+					ToPgxRows()
+
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetFilmsByCountryQuery, countryID, 10, 0).
+					Return(mainRows, nil)
+
+				ratingRows := pgxpoolmock.NewRows([]string{"coalesce"}).
+					AddRow(8.04).
+					ToPgxRows()
+				ratingRows.Next()
+
+				mockPool.EXPECT().
+					QueryRow(gomock.Any(), GetFilmAvgRatingQuery, filmID).
+					Return(ratingRows)
+			},
+			wantFilms: []models.MainPageFilm{
+				{ID: filmID, Cover: "/static/cover1.jpg", Title: "Амели", Year: 2001, Genre: "Комедия", Rating: 8.0},
+			},
+		},
+		{
+			name: "QueryError",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetFilmsByCountryQuery, countryID, 10, 0).
+					Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewCountryRepository(mockPool)
+			films, err := repo.GetFilmsByCountry(testContext(), countryID, 10, 0)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, films)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFilms, films)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package repo
+
+import _ "embed"
+
+//go:embed sql/getCountryByIDQuery.sql
+var GetCountryByIDQuery string
+
+//go:embed sql/getCountriesWithPaginationQuery.sql
+var GetCountriesWithPaginationQuery string
+
+//go:embed sql/getFilmAvgRatingQuery.sql
+var GetFilmAvgRatingQuery string
+
+//go:embed sql/getFilmsByCountryQuery.sql
+var GetFilmsByCountryQuery string
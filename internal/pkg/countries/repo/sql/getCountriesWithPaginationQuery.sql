@@ -0,0 +1,15 @@
+SELECT
+    c.id, c.name, c.created_at, c.updated_at,
+    COUNT(f.id) AS films_count,
+    COALESCE(AVG(fr.avg_rating), 0) AS rating
+FROM country c
+LEFT JOIN film f ON f.country_id = c.id
+LEFT JOIN (
+    SELECT film_id, AVG(rating) AS avg_rating
+    FROM film_feedback
+    WHERE rating IS NOT NULL
+    GROUP BY film_id
+) fr ON fr.film_id = f.id
+GROUP BY c.id
+ORDER BY c.name
+LIMIT $1 OFFSET $2
\ No newline at end of file
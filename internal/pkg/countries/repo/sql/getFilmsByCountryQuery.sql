@@ -0,0 +1,7 @@
+SELECT 
+    f.id, f.cover, f.title, f.year, g.title as genre_title
+FROM film f
+JOIN genre g ON f.genre_id = g.id
+WHERE f.country_id = $1
+ORDER BY f.created_at DESC
+LIMIT $2 OFFSET $3
\ No newline at end of file
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type CountryUsecase struct {
+	countryRepo countries.CountryRepo
+}
+
+func NewCountryUsecase(countryRepo countries.CountryRepo) *CountryUsecase {
+	return &CountryUsecase{countryRepo: countryRepo}
+}
+
+func (uc *CountryUsecase) GetCountry(ctx context.Context, id uuid.UUID) (models.Country, error) {
+	neededCountry, err := uc.countryRepo.GetCountryByID(ctx, id)
+	if err != nil {
+		return models.Country{}, err
+	}
+	return neededCountry, nil
+}
+
+func (uc *CountryUsecase) GetCountries(ctx context.Context, pager models.Pager) ([]models.Country, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	allCountries, err := uc.countryRepo.GetCountriesWithPagination(ctx, pager.Count, pager.Offset)
+	if err != nil {
+		return []models.Country{}, err
+	}
+
+	if len(allCountries) == 0 {
+		logger.Info("no countries")
+		return []models.Country{}, countries.ErrorNotFound
+	}
+	return allCountries, nil
+}
+
+func (uc *CountryUsecase) GetFilmsByCountry(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	films, err := uc.countryRepo.GetFilmsByCountry(ctx, id, pager.Count, pager.Offset)
+	if err != nil {
+		return []models.MainPageFilm{}, err
+	}
+
+	if len(films) == 0 {
+		logger.Info("country has no films")
+		return []models.MainPageFilm{}, countries.ErrorNotFound
+	}
+	return films, nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/countries/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestCountryUsecase_GetCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCountryRepo(ctrl)
+	usecase := NewCountryUsecase(mockRepo)
+
+	countryID := uuid.NewV4()
+	expectedCountry := models.Country{ID: countryID, Name: "Япония", FilmsCount: 4, Rating: 8.1}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    models.Country
+		expectError error
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockRepo.EXPECT().GetCountryByID(gomock.Any(), countryID).Return(expectedCountry, nil)
+			},
+			expected: expectedCountry,
+		},
+		{
+			name: "Not found",
+			setupMock: func() {
+				mockRepo.EXPECT().GetCountryByID(gomock.Any(), countryID).Return(models.Country{}, countries.ErrorNotFound)
+			},
+			expected:    models.Country{},
+			expectError: countries.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			result, err := usecase.GetCountry(testContext(), countryID)
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCountryUsecase_GetCountries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCountryRepo(ctrl)
+	usecase := NewCountryUsecase(mockRepo)
+
+	pager := models.Pager{Count: 10, Offset: 0}
+	expectedCountries := []models.Country{
+		{ID: uuid.NewV4(), Name: "Италия", FilmsCount: 2, Rating: 7.7},
+		{ID: uuid.NewV4(), Name: "Корея", FilmsCount: 3, Rating: 8.3},
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []models.Country
+		expectError error
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockRepo.EXPECT().GetCountriesWithPagination(gomock.Any(), pager.Count, pager.Offset).Return(expectedCountries, nil)
+			},
+			expected: expectedCountries,
+		},
+		{
+			name: "Empty list",
+			setupMock: func() {
+				mockRepo.EXPECT().GetCountriesWithPagination(gomock.Any(), pager.Count, pager.Offset).Return([]models.Country{}, nil)
+			},
+			expected:    []models.Country{},
+			expectError: countries.ErrorNotFound,
+		},
+		{
+			name: "Repository error",
+			setupMock: func() {
+				mockRepo.EXPECT().GetCountriesWithPagination(gomock.Any(), pager.Count, pager.Offset).Return(nil, errors.New("db error"))
+			},
+			expected:    []models.Country{},
+			expectError: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			result, err := usecase.GetCountries(testContext(), pager)
+			if tt.expectError != nil {
+				assert.EqualError(t, err, tt.expectError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCountryUsecase_GetFilmsByCountry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCountryRepo(ctrl)
+	usecase := NewCountryUsecase(mockRepo)
+
+	countryID := uuid.NewV4()
+	pager := models.Pager{Count: 10, Offset: 0}
+	expectedFilms := []models.MainPageFilm{
+		{ID: uuid.NewV4(), Title: "Паразиты", Year: 2019, Genre: "Драма", Rating: 8.6},
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expected    []models.MainPageFilm
+		expectError error
+	}{
+		{
+			name: "Success",
+			setupMock: func() {
+				mockRepo.EXPECT().GetFilmsByCountry(gomock.Any(), countryID, pager.Count, pager.Offset).Return(expectedFilms, nil)
+			},
+			expected: expectedFilms,
+		},
+		{
+			name: "Country has no films",
+			setupMock: func() {
+				mockRepo.EXPECT().GetFilmsByCountry(gomock.Any(), countryID, pager.Count, pager.Offset).Return(nil, nil)
+			},
+			expected:    []models.MainPageFilm{},
+			expectError: countries.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			result, err := usecase.GetFilmsByCountry(testContext(), countryID, pager)
+			if tt.expectError != nil {
+				assert.ErrorIs(t, err, tt.expectError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"errors"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	atomContentType = "application/atom+xml; charset=utf-8"
+	rssContentType  = "application/rss+xml; charset=utf-8"
+)
+
+type FeedHandler struct {
+	uc feeds.FeedUsecase
+}
+
+func NewFeedHandler(uc feeds.FeedUsecase) *FeedHandler {
+	return &FeedHandler{uc: uc}
+}
+
+// notModified ставит Last-Modified и сверяет его с If-Modified-Since с точностью HTTP-даты до секунды
+func notModified(w http.ResponseWriter, r *http.Request, updated time.Time) bool {
+	if updated.IsZero() {
+		return false
+	}
+	w.Header().Set("Last-Modified", updated.UTC().Format(http.TimeFormat))
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || updated.Truncate(time.Second).After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// NewFilms godoc
+// @Summary Atom feed of newly added films
+// @Tags feeds
+// @Produce xml
+// @Param If-Modified-Since header string false "Last-Modified of the previously fetched feed"
+// @Success 200 {string} string "Atom feed"
+// @Success 304
+// @Failure 500 {object} models.Problem
+// @Router /feeds/films.atom [get]
+func (f *FeedHandler) NewFilms(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	feed, updated, err := f.uc.NewFilms(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	if notModified(w, r, updated) {
+		log.LogHandlerInfo(logger, "not modified", http.StatusNotModified)
+		return
+	}
+	helpers.WriteXMLWithContentType(w, atomContentType, feed)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// FilmReviews godoc
+// @Summary Atom feed of the newest film reviews
+// @Tags feeds
+// @Produce xml
+// @Param id path string true "Film ID"
+// @Param If-Modified-Since header string false "Last-Modified of the previously fetched feed"
+// @Success 200 {string} string "Atom feed"
+// @Success 304
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /films/{id}/feedbacks.atom [get]
+func (f *FeedHandler) FilmReviews(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	filmID, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	feed, updated, err := f.uc.FilmReviews(r.Context(), filmID)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	if notModified(w, r, updated) {
+		log.LogHandlerInfo(logger, "not modified", http.StatusNotModified)
+		return
+	}
+	helpers.WriteXMLWithContentType(w, atomContentType, feed)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// UserReviews godoc
+// @Summary RSS feed of user reviews
+// @Description Available only for users who show their reviews to everyone, otherwise 404
+// @Tags feeds
+// @Produce xml
+// @Param id path string true "User ID"
+// @Param If-Modified-Since header string false "Last-Modified of the previously fetched feed"
+// @Success 200 {string} string "RSS 2.0 feed"
+// @Success 304
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/{id}/reviews.rss [get]
+func (f *FeedHandler) UserReviews(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	feed, updated, err := f.uc.UserReviews(r.Context(), userID)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	if notModified(w, r, updated) {
+		log.LogHandlerInfo(logger, "not modified", http.StatusNotModified)
+		return
+	}
+	helpers.WriteXMLWithContentType(w, rssContentType, feed)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
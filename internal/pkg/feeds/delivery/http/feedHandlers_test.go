@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/feeds/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestFilmReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockFeedUsecase(ctrl)
+	handler := NewFeedHandler(mockUsecase)
+
+	filmID := uuid.NewV4()
+	updated := time.Date(2025, 4, 1, 10, 0, 0, 500, time.UTC)
+	feed := models.AtomFeed{
+		Xmlns: models.AtomXmlns,
+		Title: "Отзывы",
+		Entry: []models.AtomEntry{{Content: &models.AtomText{Type: "text", Body: "<b>Tom & Jerry</b>"}}},
+	}
+
+	tests := []struct {
+		name            string
+		id              string
+		ifModifiedSince string
+		mockSetup       func()
+		expectedStatus  int
+	}{
+		{
+			name: "Success",
+			id:   filmID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().FilmReviews(gomock.Any(), filmID).Return(feed, updated, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "Not modified",
+			id:              filmID.String(),
+			ifModifiedSince: "Tue, 01 Apr 2025 10:00:00 GMT",
+			mockSetup: func() {
+				mockUsecase.EXPECT().FilmReviews(gomock.Any(), filmID).Return(feed, updated, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:            "Modified since",
+			id:              filmID.String(),
+			ifModifiedSince: "Tue, 01 Apr 2025 09:59:59 GMT",
+			mockSetup: func() {
+				mockUsecase.EXPECT().FilmReviews(gomock.Any(), filmID).Return(feed, updated, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid id",
+			id:             "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Film not found",
+			id:   filmID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().FilmReviews(gomock.Any(), filmID).Return(models.AtomFeed{}, time.Time{}, feeds.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/films/"+tt.id+"/feedbacks.atom", nil).WithContext(testContext())
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/films/{id}/feedbacks.atom", handler.FilmReviews)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "application/atom+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+				assert.Equal(t, "Tue, 01 Apr 2025 10:00:00 GMT", rec.Header().Get("Last-Modified"))
+				// текст экранирован ровно один раз
+				assert.Contains(t, rec.Body.String(), "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;")
+				assert.NotContains(t, rec.Body.String(), "&amp;lt;")
+			}
+		})
+	}
+}
+
+func TestUserReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockFeedUsecase(ctrl)
+	handler := NewFeedHandler(mockUsecase)
+
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "Empty feed has no Last-Modified",
+			mockSetup: func() {
+				mockUsecase.EXPECT().UserReviews(gomock.Any(), userID).Return(models.RSS{Version: "2.0"}, time.Time{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Hidden reviews",
+			mockSetup: func() {
+				mockUsecase.EXPECT().UserReviews(gomock.Any(), userID).Return(models.RSS{}, time.Time{}, feeds.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+userID.String()+"/reviews.rss", nil).WithContext(testContext())
+			req.Header.Set("If-Modified-Since", "Tue, 01 Apr 2025 10:00:00 GMT")
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/users/{id}/reviews.rss", handler.UserReviews)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "application/rss+xml; charset=utf-8", rec.Header().Get("Content-Type"))
+				assert.Empty(t, rec.Header().Get("Last-Modified"))
+				assert.Contains(t, rec.Body.String(), `<rss version="2.0">`)
+			}
+		})
+	}
+}
+
+func TestNewFilms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockFeedUsecase(ctrl)
+	handler := NewFeedHandler(mockUsecase)
+
+	mockUsecase.EXPECT().NewFilms(gomock.Any()).Return(models.AtomFeed{}, time.Time{}, feeds.ErrorInternalServerError)
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/films.atom", nil).WithContext(testContext())
+	rec := httptest.NewRecorder()
+	handler.NewFilms(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
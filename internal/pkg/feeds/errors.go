@@ -0,0 +1,8 @@
+package feeds
+
+import "errors"
+
+var (
+	ErrorNotFound            = errors.New("not found")
+	ErrorInternalServerError = errors.New("internal server error")
+)
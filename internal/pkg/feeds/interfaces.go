@@ -0,0 +1,25 @@
+package feeds
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// FeedUsecase вместе с лентой отдаёт время её последнего изменения для Last-Modified;
+// у пустой ленты оно нулевое
+type FeedUsecase interface {
+	NewFilms(ctx context.Context) (models.AtomFeed, time.Time, error)
+	FilmReviews(ctx context.Context, filmID uuid.UUID) (models.AtomFeed, time.Time, error)
+	UserReviews(ctx context.Context, userID uuid.UUID) (models.RSS, time.Time, error)
+}
+
+type FeedRepo interface {
+	GetNewFilms(ctx context.Context, limit int) ([]models.FeedFilm, error)
+	GetFeedFilm(ctx context.Context, filmID uuid.UUID) (models.FeedFilm, error)
+	GetFilmReviews(ctx context.Context, filmID uuid.UUID, limit int) ([]models.FeedReview, error)
+	GetFeedUser(ctx context.Context, userID uuid.UUID) (models.FeedUser, error)
+	GetUserReviews(ctx context.Context, userID uuid.UUID, limit int) ([]models.FeedReview, error)
+}
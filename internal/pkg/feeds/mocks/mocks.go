@@ -0,0 +1,191 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/feeds/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/feeds/interfaces.go -destination=internal/pkg/feeds/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/satori/go.uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedUsecase is a mock of FeedUsecase interface.
+type MockFeedUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedUsecaseMockRecorder is the mock recorder for MockFeedUsecase.
+type MockFeedUsecaseMockRecorder struct {
+	mock *MockFeedUsecase
+}
+
+// NewMockFeedUsecase creates a new mock instance.
+func NewMockFeedUsecase(ctrl *gomock.Controller) *MockFeedUsecase {
+	mock := &MockFeedUsecase{ctrl: ctrl}
+	mock.recorder = &MockFeedUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedUsecase) EXPECT() *MockFeedUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FilmReviews mocks base method.
+func (m *MockFeedUsecase) FilmReviews(ctx context.Context, filmID uuid.UUID) (models.AtomFeed, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilmReviews", ctx, filmID)
+	ret0, _ := ret[0].(models.AtomFeed)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FilmReviews indicates an expected call of FilmReviews.
+func (mr *MockFeedUsecaseMockRecorder) FilmReviews(ctx, filmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilmReviews", reflect.TypeOf((*MockFeedUsecase)(nil).FilmReviews), ctx, filmID)
+}
+
+// NewFilms mocks base method.
+func (m *MockFeedUsecase) NewFilms(ctx context.Context) (models.AtomFeed, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewFilms", ctx)
+	ret0, _ := ret[0].(models.AtomFeed)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewFilms indicates an expected call of NewFilms.
+func (mr *MockFeedUsecaseMockRecorder) NewFilms(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewFilms", reflect.TypeOf((*MockFeedUsecase)(nil).NewFilms), ctx)
+}
+
+// UserReviews mocks base method.
+func (m *MockFeedUsecase) UserReviews(ctx context.Context, userID uuid.UUID) (models.RSS, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserReviews", ctx, userID)
+	ret0, _ := ret[0].(models.RSS)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UserReviews indicates an expected call of UserReviews.
+func (mr *MockFeedUsecaseMockRecorder) UserReviews(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserReviews", reflect.TypeOf((*MockFeedUsecase)(nil).UserReviews), ctx, userID)
+}
+
+// MockFeedRepo is a mock of FeedRepo interface.
+type MockFeedRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedRepoMockRecorder is the mock recorder for MockFeedRepo.
+type MockFeedRepoMockRecorder struct {
+	mock *MockFeedRepo
+}
+
+// NewMockFeedRepo creates a new mock instance.
+func NewMockFeedRepo(ctrl *gomock.Controller) *MockFeedRepo {
+	mock := &MockFeedRepo{ctrl: ctrl}
+	mock.recorder = &MockFeedRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepo) EXPECT() *MockFeedRepoMockRecorder {
+	return m.recorder
+}
+
+// GetFeedFilm mocks base method.
+func (m *MockFeedRepo) GetFeedFilm(ctx context.Context, filmID uuid.UUID) (models.FeedFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedFilm", ctx, filmID)
+	ret0, _ := ret[0].(models.FeedFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedFilm indicates an expected call of GetFeedFilm.
+func (mr *MockFeedRepoMockRecorder) GetFeedFilm(ctx, filmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedFilm", reflect.TypeOf((*MockFeedRepo)(nil).GetFeedFilm), ctx, filmID)
+}
+
+// GetFeedUser mocks base method.
+func (m *MockFeedRepo) GetFeedUser(ctx context.Context, userID uuid.UUID) (models.FeedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedUser", ctx, userID)
+	ret0, _ := ret[0].(models.FeedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedUser indicates an expected call of GetFeedUser.
+func (mr *MockFeedRepoMockRecorder) GetFeedUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedUser", reflect.TypeOf((*MockFeedRepo)(nil).GetFeedUser), ctx, userID)
+}
+
+// GetFilmReviews mocks base method.
+func (m *MockFeedRepo) GetFilmReviews(ctx context.Context, filmID uuid.UUID, limit int) ([]models.FeedReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmReviews", ctx, filmID, limit)
+	ret0, _ := ret[0].([]models.FeedReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmReviews indicates an expected call of GetFilmReviews.
+func (mr *MockFeedRepoMockRecorder) GetFilmReviews(ctx, filmID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmReviews", reflect.TypeOf((*MockFeedRepo)(nil).GetFilmReviews), ctx, filmID, limit)
+}
+
+// GetNewFilms mocks base method.
+func (m *MockFeedRepo) GetNewFilms(ctx context.Context, limit int) ([]models.FeedFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewFilms", ctx, limit)
+	ret0, _ := ret[0].([]models.FeedFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewFilms indicates an expected call of GetNewFilms.
+func (mr *MockFeedRepoMockRecorder) GetNewFilms(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewFilms", reflect.TypeOf((*MockFeedRepo)(nil).GetNewFilms), ctx, limit)
+}
+
+// GetUserReviews mocks base method.
+func (m *MockFeedRepo) GetUserReviews(ctx context.Context, userID uuid.UUID, limit int) ([]models.FeedReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReviews", ctx, userID, limit)
+	ret0, _ := ret[0].([]models.FeedReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReviews indicates an expected call of GetUserReviews.
+func (mr *MockFeedRepoMockRecorder) GetUserReviews(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReviews", reflect.TypeOf((*MockFeedRepo)(nil).GetUserReviews), ctx, userID, limit)
+}
@@ -0,0 +1,138 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+
+	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+)
+
+type FeedRepository struct {
+	db pgxtype.Querier
+}
+
+func NewFeedRepository(db pgxtype.Querier) *FeedRepository {
+	return &FeedRepository{db: db}
+}
+
+func scanFeedFilm(row pgx.Row) (models.FeedFilm, error) {
+	var film models.FeedFilm
+	err := row.Scan(&film.ID, &film.Title, &film.ShortDescription, &film.CreatedAt, &film.UpdatedAt)
+	return film, err
+}
+
+func (r *FeedRepository) GetNewFilms(ctx context.Context, limit int) ([]models.FeedFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	rows, err := r.db.Query(ctx, GetNewFilmsQuery, limit)
+	if err != nil {
+		logger.Error("failed to get new films: " + err.Error())
+		return nil, feeds.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	result := []models.FeedFilm{}
+	for rows.Next() {
+		film, err := scanFeedFilm(rows)
+		if err != nil {
+			logger.Error("failed to scan film: " + err.Error())
+			return nil, feeds.ErrorInternalServerError
+		}
+		result = append(result, film)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to get new films: " + err.Error())
+		return nil, feeds.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got new films from db")
+	return result, nil
+}
+
+func (r *FeedRepository) GetFeedFilm(ctx context.Context, filmID uuid.UUID) (models.FeedFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	film, err := scanFeedFilm(r.db.QueryRow(ctx, GetFeedFilmQuery, filmID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("film is not found")
+			return models.FeedFilm{}, feeds.ErrorNotFound
+		}
+		logger.Error("failed to scan film: " + err.Error())
+		return models.FeedFilm{}, feeds.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got film from db")
+	return film, nil
+}
+
+func (r *FeedRepository) getReviews(ctx context.Context, logger *slog.Logger, query string, id uuid.UUID, limit int) ([]models.FeedReview, error) {
+	rows, err := r.db.Query(ctx, query, id, limit)
+	if err != nil {
+		logger.Error("failed to get reviews: " + err.Error())
+		return nil, feeds.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	result := []models.FeedReview{}
+	for rows.Next() {
+		var review models.FeedReview
+		if err := rows.Scan(
+			&review.ID, &review.FilmID, &review.FilmTitle, &review.UserLogin,
+			&review.Title, &review.Text, &review.Rating, &review.CreatedAt, &review.UpdatedAt,
+		); err != nil {
+			logger.Error("failed to scan review: " + err.Error())
+			return nil, feeds.ErrorInternalServerError
+		}
+		result = append(result, review)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to get reviews: " + err.Error())
+		return nil, feeds.ErrorInternalServerError
+	}
+	return result, nil
+}
+
+func (r *FeedRepository) GetFilmReviews(ctx context.Context, filmID uuid.UUID, limit int) ([]models.FeedReview, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	reviews, err := r.getReviews(ctx, logger, GetFilmReviewsQuery, filmID, limit)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("succesfully got film reviews from db")
+	return reviews, nil
+}
+
+func (r *FeedRepository) GetFeedUser(ctx context.Context, userID uuid.UUID) (models.FeedUser, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var user models.FeedUser
+	err := r.db.QueryRow(ctx, GetFeedUserQuery, userID).Scan(
+		&user.ID, &user.Login,
+		&user.Privacy.ShowStats, &user.Privacy.ShowTaste, &user.Privacy.ShowReviews, &user.Privacy.FollowersOnly,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user is not found")
+			return models.FeedUser{}, feeds.ErrorNotFound
+		}
+		logger.Error("failed to scan user: " + err.Error())
+		return models.FeedUser{}, feeds.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got user from db")
+	return user, nil
+}
+
+func (r *FeedRepository) GetUserReviews(ctx context.Context, userID uuid.UUID, limit int) ([]models.FeedReview, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	reviews, err := r.getReviews(ctx, logger, GetUserReviewsQuery, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("succesfully got user reviews from db")
+	return reviews, nil
+}
@@ -0,0 +1,128 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+type MockRow struct {
+	err error
+}
+
+func (m MockRow) Scan(dest ...interface{}) error {
+	return m.err
+}
+
+func TestGetFilmReviews(t *testing.T) {
+	filmID := uuid.NewV4()
+	reviewID := uuid.NewV4()
+	created := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	text := "Текст"
+	rating := 8
+	columns := []string{"id", "film_id", "film_title", "login", "title", "text", "rating", "created_at", "updated_at"}
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantReviews []models.FeedReview
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(columns).
+					AddRow(reviewID, filmID, "Интерстеллар", "critic", "Шедевр", &text, &rating, created, created).
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetFilmReviewsQuery, filmID, 50).Return(rows, nil)
+			},
+			wantReviews: []models.FeedReview{{
+				ID: reviewID, FilmID: filmID, FilmTitle: "Интерстеллар", UserLogin: "critic", Title: "Шедевр",
+				Text: &text, Rating: &rating, CreatedAt: created, UpdatedAt: created,
+			}},
+		},
+		{
+			name: "Query error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetFilmReviewsQuery, filmID, 50).Return(nil, errors.New("db error"))
+			},
+			wantErr: feeds.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewFeedRepository(mockPool)
+			reviews, err := repo.GetFilmReviews(testContext(), filmID, 50)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReviews, reviews)
+		})
+	}
+}
+
+func TestGetFeedUser(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetFeedUserQuery, userID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: feeds.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetFeedUserQuery, userID).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: feeds.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewFeedRepository(mockPool)
+			_, err := repo.GetFeedUser(testContext(), userID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
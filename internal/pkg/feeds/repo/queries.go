@@ -0,0 +1,18 @@
+package repo
+
+import _ "embed"
+
+//go:embed sql/getNewFilmsQuery.sql
+var GetNewFilmsQuery string
+
+//go:embed sql/getFeedFilmQuery.sql
+var GetFeedFilmQuery string
+
+//go:embed sql/getFilmReviewsQuery.sql
+var GetFilmReviewsQuery string
+
+//go:embed sql/getFeedUserQuery.sql
+var GetFeedUserQuery string
+
+//go:embed sql/getUserReviewsQuery.sql
+var GetUserReviewsQuery string
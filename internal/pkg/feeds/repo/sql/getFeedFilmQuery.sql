@@ -0,0 +1,3 @@
+SELECT id, title, short_description, created_at, updated_at
+FROM film
+WHERE id = $1
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT id, login, show_stats, show_taste, show_reviews, followers_only
+FROM user_table
+WHERE id = $1
\ No newline at end of file
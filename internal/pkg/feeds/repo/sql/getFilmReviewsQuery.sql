@@ -0,0 +1,7 @@
+SELECT ff.id, f.id, f.title, u.login, ff.title, ff.text, ff.rating, ff.created_at, ff.updated_at
+FROM film_feedback ff
+JOIN film f ON f.id = ff.film_id
+JOIN user_table u ON u.id = ff.user_id
+WHERE ff.film_id = $1 AND ff.title IS NOT NULL AND ff.title != ''
+ORDER BY ff.created_at DESC, ff.id
+LIMIT $2
\ No newline at end of file
@@ -0,0 +1,4 @@
+SELECT id, title, short_description, created_at, updated_at
+FROM film
+ORDER BY created_at DESC, id
+LIMIT $1
\ No newline at end of file
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// FeedLimit сколько последних записей попадает в ленту
+	FeedLimit = 50
+
+	siteName = "DDFilms"
+	atomType = "application/atom+xml"
+)
+
+type FeedUsecase struct {
+	feedRepo feeds.FeedRepo
+	baseURL  string
+}
+
+func NewFeedUsecase(repo feeds.FeedRepo, baseURL string) *FeedUsecase {
+	return &FeedUsecase{feedRepo: repo, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// entryID постоянный id записи, не зависящий от адреса сайта
+func entryID(id uuid.UUID) string {
+	return "urn:uuid:" + id.String()
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// updatedOrNow у пустой ленты нет времени изменения, но элемент updated в Atom обязателен
+func updatedOrNow(updated time.Time) string {
+	if updated.IsZero() {
+		return atomTime(time.Now())
+	}
+	return atomTime(updated)
+}
+
+func later(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func ratingSummary(rating *int) *models.AtomText {
+	if rating == nil {
+		return nil
+	}
+	return &models.AtomText{Type: "text", Body: fmt.Sprintf("Оценка: %d/10", *rating)}
+}
+
+func reviewText(review models.FeedReview) string {
+	if review.Text == nil {
+		return ""
+	}
+	return *review.Text
+}
+
+func (uc *FeedUsecase) NewFilms(ctx context.Context) (models.AtomFeed, time.Time, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	films, err := uc.feedRepo.GetNewFilms(ctx, FeedLimit)
+	if err != nil {
+		return models.AtomFeed{}, time.Time{}, err
+	}
+
+	self := uc.baseURL + "/api/feeds/films.atom"
+	var updated time.Time
+	entries := make([]models.AtomEntry, 0, len(films))
+	for _, film := range films {
+		entry := models.AtomEntry{
+			ID:        entryID(film.ID),
+			Title:     film.Title,
+			Updated:   atomTime(film.UpdatedAt),
+			Published: atomTime(film.CreatedAt),
+			Link:      []models.AtomLink{{Href: uc.baseURL + "/films/" + film.ID.String(), Rel: "alternate", Type: "text/html"}},
+		}
+		if film.ShortDescription != nil {
+			entry.Summary = &models.AtomText{Type: "text", Body: *film.ShortDescription}
+		}
+		entries = append(entries, entry)
+		updated = later(updated, film.UpdatedAt)
+	}
+
+	logger.Info("succesfully built new films feed", slog.Int("entries", len(entries)))
+	return models.AtomFeed{
+		Xmlns:   models.AtomXmlns,
+		ID:      self,
+		Title:   siteName + " — новые фильмы",
+		Updated: updatedOrNow(updated),
+		Author:  &models.AtomPerson{Name: siteName},
+		Link: []models.AtomLink{
+			{Href: self, Rel: "self", Type: atomType},
+			{Href: uc.baseURL + "/", Rel: "alternate", Type: "text/html"},
+		},
+		Entry: entries,
+	}, updated, nil
+}
+
+func (uc *FeedUsecase) FilmReviews(ctx context.Context, filmID uuid.UUID) (models.AtomFeed, time.Time, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	film, err := uc.feedRepo.GetFeedFilm(ctx, filmID)
+	if err != nil {
+		return models.AtomFeed{}, time.Time{}, err
+	}
+	reviews, err := uc.feedRepo.GetFilmReviews(ctx, filmID, FeedLimit)
+	if err != nil {
+		return models.AtomFeed{}, time.Time{}, err
+	}
+
+	filmURL := uc.baseURL + "/films/" + film.ID.String()
+	self := uc.baseURL + "/api/films/" + film.ID.String() + "/feedbacks.atom"
+	updated := film.UpdatedAt
+	entries := make([]models.AtomEntry, 0, len(reviews))
+	for _, review := range reviews {
+		entries = append(entries, models.AtomEntry{
+			ID:        entryID(review.ID),
+			Title:     review.Title,
+			Updated:   atomTime(review.UpdatedAt),
+			Published: atomTime(review.CreatedAt),
+			Link:      []models.AtomLink{{Href: filmURL, Rel: "alternate", Type: "text/html"}},
+			Author:    &models.AtomPerson{Name: review.UserLogin},
+			Summary:   ratingSummary(review.Rating),
+			Content:   &models.AtomText{Type: "text", Body: reviewText(review)},
+		})
+		updated = later(updated, review.UpdatedAt)
+	}
+
+	logger.Info("succesfully built film reviews feed", slog.Int("entries", len(entries)))
+	return models.AtomFeed{
+		Xmlns:   models.AtomXmlns,
+		ID:      self,
+		Title:   "Отзывы о фильме «" + film.Title + "»",
+		Updated: atomTime(updated),
+		Author:  &models.AtomPerson{Name: siteName},
+		Link: []models.AtomLink{
+			{Href: self, Rel: "self", Type: atomType},
+			{Href: filmURL, Rel: "alternate", Type: "text/html"},
+		},
+		Entry: entries,
+	}, updated, nil
+}
+
+// UserReviews отдаёт ленту только тех, кто показывает отзывы всем: читалка лент приходит без сессии,
+// поэтому для закрытых профилей лента выглядит так же, как для несуществующих
+func (uc *FeedUsecase) UserReviews(ctx context.Context, userID uuid.UUID) (models.RSS, time.Time, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, err := uc.feedRepo.GetFeedUser(ctx, userID)
+	if err != nil {
+		return models.RSS{}, time.Time{}, err
+	}
+	if !user.Privacy.ShowReviews || user.Privacy.FollowersOnly {
+		logger.Info("user reviews are not public")
+		return models.RSS{}, time.Time{}, feeds.ErrorNotFound
+	}
+	reviews, err := uc.feedRepo.GetUserReviews(ctx, userID, FeedLimit)
+	if err != nil {
+		return models.RSS{}, time.Time{}, err
+	}
+
+	var updated time.Time
+	items := make([]models.RSSItem, 0, len(reviews))
+	for _, review := range reviews {
+		title := review.FilmTitle + ": " + review.Title
+		if review.Rating != nil {
+			title = fmt.Sprintf("%s (%d/10)", title, *review.Rating)
+		}
+		items = append(items, models.RSSItem{
+			Title:       title,
+			Link:        uc.baseURL + "/films/" + review.FilmID.String(),
+			Description: reviewText(review),
+			GUID:        models.RSSGUID{Value: entryID(review.ID)},
+			PubDate:     review.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+		updated = later(updated, review.UpdatedAt)
+	}
+
+	channel := models.RSSChannel{
+		Title:       "Отзывы " + user.Login + " на " + siteName,
+		Link:        uc.baseURL + "/users/" + user.ID.String(),
+		Description: "Последние отзывы пользователя " + user.Login,
+		Item:        items,
+	}
+	if !updated.IsZero() {
+		channel.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+
+	logger.Info("succesfully built user reviews feed", slog.Int("items", len(items)))
+	return models.RSS{Version: "2.0", Channel: channel}, updated, nil
+}
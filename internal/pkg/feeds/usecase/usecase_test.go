@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/feeds/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestFeedUsecase_NewFilms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	edited := time.Date(2025, 4, 1, 13, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	description := "Tom & Jerry <3"
+	films := []models.FeedFilm{
+		{ID: uuid.NewV4(), Title: "Новый", ShortDescription: &description, CreatedAt: created, UpdatedAt: edited},
+		{ID: uuid.NewV4(), Title: "Старый", CreatedAt: created, UpdatedAt: created},
+	}
+
+	mockRepo := mocks.NewMockFeedRepo(ctrl)
+	mockRepo.EXPECT().GetNewFilms(gomock.Any(), FeedLimit).Return(films, nil)
+
+	uc := NewFeedUsecase(mockRepo, "https://ddfilms.online/")
+	feed, updated, err := uc.NewFilms(testContext())
+	assert.NoError(t, err)
+	assert.True(t, edited.Equal(updated))
+	assert.Equal(t, "https://ddfilms.online/api/feeds/films.atom", feed.ID)
+	assert.Equal(t, "2025-04-01T10:00:00Z", feed.Updated)
+	assert.Len(t, feed.Entry, 2)
+	assert.Equal(t, "urn:uuid:"+films[0].ID.String(), feed.Entry[0].ID)
+	assert.Equal(t, "2025-03-01T10:00:00Z", feed.Entry[0].Published)
+	assert.Equal(t, "https://ddfilms.online/films/"+films[0].ID.String(), feed.Entry[0].Link[0].Href)
+	// текст кладётся как есть, экранирует его encoding/xml
+	assert.Equal(t, description, feed.Entry[0].Summary.Body)
+	assert.Nil(t, feed.Entry[1].Summary)
+}
+
+func TestFeedUsecase_FilmReviews(t *testing.T) {
+	filmID := uuid.NewV4()
+	filmUpdated := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	reviewUpdated := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	text := "Лучше, чем \"Начало\""
+	rating := 9
+
+	tests := []struct {
+		name          string
+		setupMock     func(mockRepo *mocks.MockFeedRepo)
+		expectUpdated time.Time
+		expectEntries int
+		expectErr     error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *mocks.MockFeedRepo) {
+				mockRepo.EXPECT().GetFeedFilm(gomock.Any(), filmID).Return(models.FeedFilm{ID: filmID, Title: "Интерстеллар", UpdatedAt: filmUpdated}, nil)
+				mockRepo.EXPECT().GetFilmReviews(gomock.Any(), filmID, FeedLimit).Return([]models.FeedReview{
+					{ID: uuid.NewV4(), FilmID: filmID, UserLogin: "critic", Title: "Шедевр", Text: &text, Rating: &rating, UpdatedAt: reviewUpdated},
+				}, nil)
+			},
+			expectUpdated: reviewUpdated,
+			expectEntries: 1,
+		},
+		{
+			name: "No reviews",
+			setupMock: func(mockRepo *mocks.MockFeedRepo) {
+				mockRepo.EXPECT().GetFeedFilm(gomock.Any(), filmID).Return(models.FeedFilm{ID: filmID, UpdatedAt: filmUpdated}, nil)
+				mockRepo.EXPECT().GetFilmReviews(gomock.Any(), filmID, FeedLimit).Return([]models.FeedReview{}, nil)
+			},
+			expectUpdated: filmUpdated,
+		},
+		{
+			name: "Film not found",
+			setupMock: func(mockRepo *mocks.MockFeedRepo) {
+				mockRepo.EXPECT().GetFeedFilm(gomock.Any(), filmID).Return(models.FeedFilm{}, feeds.ErrorNotFound)
+			},
+			expectErr: feeds.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockFeedRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := NewFeedUsecase(mockRepo, "https://ddfilms.online")
+			feed, updated, err := uc.FilmReviews(testContext(), filmID)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectUpdated, updated)
+			assert.Len(t, feed.Entry, tt.expectEntries)
+			if tt.expectEntries > 0 {
+				assert.Equal(t, "critic", feed.Entry[0].Author.Name)
+				assert.Equal(t, text, feed.Entry[0].Content.Body)
+				assert.Equal(t, "Оценка: 9/10", feed.Entry[0].Summary.Body)
+			}
+		})
+	}
+}
+
+func TestFeedUsecase_UserReviews(t *testing.T) {
+	userID := uuid.NewV4()
+	filmID := uuid.NewV4()
+	created := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	public := models.ProfilePrivacy{ShowStats: true, ShowTaste: true, ShowReviews: true}
+
+	tests := []struct {
+		name      string
+		privacy   models.ProfilePrivacy
+		setupMock func(mockRepo *mocks.MockFeedRepo)
+		expectErr error
+	}{
+		{
+			name:    "Public reviews",
+			privacy: public,
+			setupMock: func(mockRepo *mocks.MockFeedRepo) {
+				mockRepo.EXPECT().GetUserReviews(gomock.Any(), userID, FeedLimit).Return([]models.FeedReview{
+					{ID: uuid.NewV4(), FilmID: filmID, FilmTitle: "Интерстеллар", Title: "Шедевр", CreatedAt: created, UpdatedAt: created},
+				}, nil)
+			},
+		},
+		{
+			name:      "Reviews hidden",
+			privacy:   models.ProfilePrivacy{ShowReviews: false},
+			setupMock: func(mockRepo *mocks.MockFeedRepo) {},
+			expectErr: feeds.ErrorNotFound,
+		},
+		{
+			name:      "Followers only",
+			privacy:   models.ProfilePrivacy{ShowReviews: true, FollowersOnly: true},
+			setupMock: func(mockRepo *mocks.MockFeedRepo) {},
+			expectErr: feeds.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockFeedRepo(ctrl)
+			mockRepo.EXPECT().GetFeedUser(gomock.Any(), userID).Return(models.FeedUser{ID: userID, Login: "critic", Privacy: tt.privacy}, nil)
+			tt.setupMock(mockRepo)
+
+			uc := NewFeedUsecase(mockRepo, "https://ddfilms.online")
+			feed, updated, err := uc.UserReviews(testContext(), userID)
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, created, updated)
+			assert.Equal(t, "2.0", feed.Version)
+			assert.Equal(t, "Tue, 01 Apr 2025 10:00:00 +0000", feed.Channel.LastBuildDate)
+			assert.Len(t, feed.Channel.Item, 1)
+			assert.Equal(t, "Интерстеллар: Шедевр", feed.Channel.Item[0].Title)
+			assert.False(t, feed.Channel.Item[0].GUID.IsPermaLink)
+			assert.Equal(t, "https://ddfilms.online/films/"+filmID.String(), feed.Channel.Item[0].Link)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"kinopoisk/internal/pkg/auth"
 	"kinopoisk/internal/pkg/films"
 	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
 	"net/http"
@@ -34,19 +35,14 @@ func NewFilmHandler(uc films.FilmUsecase) *FilmHandler {
 // @Tags films
 // @Produce json
 // @Success 200 {object} models.PromoFilm
-// @Failure 404
-// @Failure 500
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /films/promo [get]
 func (c *FilmHandler) GetPromoFilm(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 	film, err := c.uc.GetPromoFilm(r.Context())
 	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 
@@ -61,25 +57,25 @@ func (c *FilmHandler) GetPromoFilm(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        count   query     int  false  "Number of films" default(10)
 // @Param        offset  query     int  false  "Offset" default(0)
+// @Param        country_id  query  string  false  "Country ID"
 // @Success      200     {array}   models.MainPageFilm
-// @Failure      400
-// @Failure 	 404
-// @Failure 	 500
+// @Failure      400 {object} models.Problem
+// @Failure 	 404 {object} models.Problem
+// @Failure 	 500 {object} models.Problem
 // @Router       /films [get]
 func (c *FilmHandler) GetFilms(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 	pager := helpers.GetPagerFromRequest(r)
+	filter, err := helpers.GetFilmFilterFromRequest(r)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
 
-	mainPageFilms, err := c.uc.GetFilms(r.Context(), pager)
+	mainPageFilms, err := c.uc.GetFilms(r.Context(), pager, filter)
 	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		case errors.Is(err, films.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	for i := range mainPageFilms {
@@ -95,9 +91,9 @@ func (c *FilmHandler) GetFilms(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      string  true  "Film ID"
 // @Success      200  {object}  models.FilmPage
-// @Failure      400
-// @Failure 	 404
-// @Failure 	 500
+// @Failure      400 {object} models.Problem
+// @Failure 	 404 {object} models.Problem
+// @Failure 	 500 {object} models.Problem
 // @Router       /films/{id} [get]
 func (c *FilmHandler) GetFilm(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -105,20 +101,13 @@ func (c *FilmHandler) GetFilm(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
 	film, err := c.uc.GetFilm(r.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		case errors.Is(err, films.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	film.Sanitize()
@@ -155,9 +144,9 @@ func (c *FilmHandler) Middleware(next http.Handler) http.Handler {
 // @Produce json
 // @Param        id   path      string  true  "Film ID"
 // @Success 200 {array} models.FilmFeedback
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /films/{id}/feedbacks [get]
 func (c *FilmHandler) GetFilmFeedbacks(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -165,7 +154,7 @@ func (c *FilmHandler) GetFilmFeedbacks(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
@@ -173,12 +162,7 @@ func (c *FilmHandler) GetFilmFeedbacks(w http.ResponseWriter, r *http.Request) {
 
 	feedbacks, err := c.uc.GetFilmFeedbacks(r.Context(), id, pager)
 	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	for i := range feedbacks {
@@ -196,10 +180,10 @@ func (c *FilmHandler) GetFilmFeedbacks(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param        id   path      string  true  "Film ID"
 // @Success 200 {object} models.FilmFeedback
-// @Failure 400
-// @Failure 401
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /films/{id}/feedbacks [post]
 func (c *FilmHandler) SendFeedback(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -207,7 +191,7 @@ func (c *FilmHandler) SendFeedback(w http.ResponseWriter, r *http.Request) {
 	filmID, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
@@ -215,7 +199,7 @@ func (c *FilmHandler) SendFeedback(w http.ResponseWriter, r *http.Request) {
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
-		w.WriteHeader(http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	req.Sanitize()
@@ -223,14 +207,7 @@ func (c *FilmHandler) SendFeedback(w http.ResponseWriter, r *http.Request) {
 	feedback, err := c.uc.SendFeedback(r.Context(), req, filmID)
 
 	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		case errors.Is(err, films.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	feedback.Sanitize()
@@ -246,10 +223,10 @@ func (c *FilmHandler) SendFeedback(w http.ResponseWriter, r *http.Request) {
 // @Param        id   path      string  true  "Film ID"
 // @Param input body models.FilmFeedbackInput true "Rating data (rating 1-10 is required)"
 // @Success 200 {object} models.FilmFeedback
-// @Failure 400
-// @Failure 401
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /films/{id}/rating [post]
 func (c *FilmHandler) SetRating(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -257,7 +234,7 @@ func (c *FilmHandler) SetRating(w http.ResponseWriter, r *http.Request) {
 	filmID, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of film"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
@@ -265,40 +242,17 @@ func (c *FilmHandler) SetRating(w http.ResponseWriter, r *http.Request) {
 	err = json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	req.Sanitize()
 
 	rating, err := c.uc.SetRating(r.Context(), req, filmID)
 	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		case errors.Is(err, films.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	rating.Sanitize()
 	helpers.WriteJSON(w, rating)
 	log.LogHandlerInfo(logger, "success", http.StatusOK)
 }
-
-func (c *FilmHandler) SiteMap(w http.ResponseWriter, r *http.Request) {
-	urlSet, err := c.uc.SiteMap(r.Context())
-	if err != nil {
-		switch {
-		case errors.Is(err, films.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		case errors.Is(err, films.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
-		return
-	}
-	helpers.WriteXML(w, urlSet)
-}
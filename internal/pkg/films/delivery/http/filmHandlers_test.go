@@ -131,6 +131,8 @@ func TestGetFilms(t *testing.T) {
 	mockUsecase := mocks.NewMockFilmUsecase(ctrl)
 	handler := NewFilmHandler(mockUsecase)
 
+	countryID := uuid.NewV4()
+
 	expectedFilms := []models.MainPageFilm{
 		{
 			ID:     uuid.NewV4(),
@@ -162,18 +164,38 @@ func TestGetFilms(t *testing.T) {
 			url:  "/films?count=10&offset=0",
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}).
+					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
+					Return(expectedFilms, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectBody:     true,
+		},
+		{
+			name: "Filter by country",
+			url:  "/films?count=10&offset=0&country_id=" + countryID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().
+					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}, models.FilmFilter{
+						CountryID: uuid.NullUUID{UUID: countryID, Valid: true},
+					}).
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
 			expectBody:     true,
 		},
+		{
+			name:           "Invalid country id",
+			url:            "/films?country_id=russia",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectBody:     false,
+		},
 		{
 			name: "Usecase not found error",
 			url:  "/films?count=10&offset=0",
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}).
+					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, films.ErrorNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -184,7 +206,7 @@ func TestGetFilms(t *testing.T) {
 			url:  "/films?count=10&offset=0",
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}).
+					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, films.ErrorBadRequest)
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -195,7 +217,7 @@ func TestGetFilms(t *testing.T) {
 			url:  "/films?count=10&offset=0",
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}).
+					GetFilms(gomock.Any(), models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, errors.New("internal error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -265,7 +287,7 @@ func TestGetFilm(t *testing.T) {
 		Image1:           &image1,
 		Image2:           &image2,
 		Image3:           &image3,
-		Actors:           []models.Actor{},
+		CastAndCrew:      []models.CrewGroup{},
 	}
 
 	tests := []struct {
@@ -9,21 +9,21 @@ import (
 
 type FilmUsecase interface {
 	GetPromoFilm(ctx context.Context) (models.PromoFilm, error)
-	GetFilms(ctx context.Context, pager models.Pager) ([]models.MainPageFilm, error)
+	GetFilms(ctx context.Context, pager models.Pager, filter models.FilmFilter) ([]models.MainPageFilm, error)
 	GetFilm(ctx context.Context, id uuid.UUID) (models.FilmPage, error)
 	GetFilmFeedbacks(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.FilmFeedback, error)
 	SendFeedback(ctx context.Context, req models.FilmFeedbackInput, filmID uuid.UUID) (models.FilmFeedback, error)
 	SetRating(ctx context.Context, req models.FilmFeedbackInput, filmID uuid.UUID) (models.FilmFeedback, error)
 	ValidateAndGetUser(ctx context.Context, token string) (models.User, error)
-	SiteMap(ctx context.Context) (models.Urlset, error)
 }
 
 type FilmRepo interface {
 	GetFilmByID(ctx context.Context, id uuid.UUID) (models.Film, error)
 	GetGenreTitle(ctx context.Context, genreID uuid.UUID) (string, error)
 	GetFilmAvgRating(ctx context.Context, filmID uuid.UUID) (float64, error)
-	GetFilmsWithPagination(ctx context.Context, limit, offset int) ([]models.MainPageFilm, error)
+	GetFilmsWithPagination(ctx context.Context, limit, offset int, filter models.FilmFilter) ([]models.MainPageFilm, error)
 	GetFilmPage(ctx context.Context, filmID uuid.UUID) (models.FilmPage, error)
+	GetFilmMedia(ctx context.Context, filmID uuid.UUID) ([]models.FilmMedia, error)
 	GetFilmFeedbacks(ctx context.Context, filmID uuid.UUID, limit, offset int) ([]models.FilmFeedback, error)
 	CheckUserFeedbackExists(ctx context.Context, userID, filmID uuid.UUID) (models.FilmFeedback, error)
 	UpdateFeedback(ctx context.Context, feedback models.FilmFeedback) error
@@ -32,3 +32,9 @@ type FilmRepo interface {
 	GetPromoFilmByID(ctx context.Context, id uuid.UUID) (models.PromoFilm, error)
 	GetUserByLogin(ctx context.Context, login string) (models.User, error)
 }
+
+// RatingObserver получает сохранённую оценку или отзыв после того, как пользователь поставил
+// или изменил оценку. NewFilmRating в нём уже пересчитан
+type RatingObserver interface {
+	RatingChanged(feedback models.FilmFeedback)
+}
@@ -73,18 +73,18 @@ func (mr *MockFilmUsecaseMockRecorder) GetFilmFeedbacks(ctx, id, pager any) *gom
 }
 
 // GetFilms mocks base method.
-func (m *MockFilmUsecase) GetFilms(ctx context.Context, pager models.Pager) ([]models.MainPageFilm, error) {
+func (m *MockFilmUsecase) GetFilms(ctx context.Context, pager models.Pager, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilms", ctx, pager)
+	ret := m.ctrl.Call(m, "GetFilms", ctx, pager, filter)
 	ret0, _ := ret[0].([]models.MainPageFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilms indicates an expected call of GetFilms.
-func (mr *MockFilmUsecaseMockRecorder) GetFilms(ctx, pager any) *gomock.Call {
+func (mr *MockFilmUsecaseMockRecorder) GetFilms(ctx, pager, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilms", reflect.TypeOf((*MockFilmUsecase)(nil).GetFilms), ctx, pager)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilms", reflect.TypeOf((*MockFilmUsecase)(nil).GetFilms), ctx, pager, filter)
 }
 
 // GetPromoFilm mocks base method.
@@ -132,21 +132,6 @@ func (mr *MockFilmUsecaseMockRecorder) SetRating(ctx, req, filmID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRating", reflect.TypeOf((*MockFilmUsecase)(nil).SetRating), ctx, req, filmID)
 }
 
-// SiteMap mocks base method.
-func (m *MockFilmUsecase) SiteMap(ctx context.Context) (models.Urlset, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SiteMap", ctx)
-	ret0, _ := ret[0].(models.Urlset)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// SiteMap indicates an expected call of SiteMap.
-func (mr *MockFilmUsecaseMockRecorder) SiteMap(ctx any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SiteMap", reflect.TypeOf((*MockFilmUsecase)(nil).SiteMap), ctx)
-}
-
 // ValidateAndGetUser mocks base method.
 func (m *MockFilmUsecase) ValidateAndGetUser(ctx context.Context, token string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -260,6 +245,21 @@ func (mr *MockFilmRepoMockRecorder) GetFilmFeedbacks(ctx, filmID, limit, offset
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmFeedbacks", reflect.TypeOf((*MockFilmRepo)(nil).GetFilmFeedbacks), ctx, filmID, limit, offset)
 }
 
+// GetFilmMedia mocks base method.
+func (m *MockFilmRepo) GetFilmMedia(ctx context.Context, filmID uuid.UUID) ([]models.FilmMedia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmMedia", ctx, filmID)
+	ret0, _ := ret[0].([]models.FilmMedia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmMedia indicates an expected call of GetFilmMedia.
+func (mr *MockFilmRepoMockRecorder) GetFilmMedia(ctx, filmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmMedia", reflect.TypeOf((*MockFilmRepo)(nil).GetFilmMedia), ctx, filmID)
+}
+
 // GetFilmPage mocks base method.
 func (m *MockFilmRepo) GetFilmPage(ctx context.Context, filmID uuid.UUID) (models.FilmPage, error) {
 	m.ctrl.T.Helper()
@@ -276,18 +276,18 @@ func (mr *MockFilmRepoMockRecorder) GetFilmPage(ctx, filmID any) *gomock.Call {
 }
 
 // GetFilmsWithPagination mocks base method.
-func (m *MockFilmRepo) GetFilmsWithPagination(ctx context.Context, limit, offset int) ([]models.MainPageFilm, error) {
+func (m *MockFilmRepo) GetFilmsWithPagination(ctx context.Context, limit, offset int, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsWithPagination", ctx, limit, offset)
+	ret := m.ctrl.Call(m, "GetFilmsWithPagination", ctx, limit, offset, filter)
 	ret0, _ := ret[0].([]models.MainPageFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsWithPagination indicates an expected call of GetFilmsWithPagination.
-func (mr *MockFilmRepoMockRecorder) GetFilmsWithPagination(ctx, limit, offset any) *gomock.Call {
+func (mr *MockFilmRepoMockRecorder) GetFilmsWithPagination(ctx, limit, offset, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsWithPagination", reflect.TypeOf((*MockFilmRepo)(nil).GetFilmsWithPagination), ctx, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsWithPagination", reflect.TypeOf((*MockFilmRepo)(nil).GetFilmsWithPagination), ctx, limit, offset, filter)
 }
 
 // GetGenreTitle mocks base method.
@@ -362,3 +362,39 @@ func (mr *MockFilmRepoMockRecorder) UpdateFeedback(ctx, feedback any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFeedback", reflect.TypeOf((*MockFilmRepo)(nil).UpdateFeedback), ctx, feedback)
 }
+
+// MockRatingObserver is a mock of RatingObserver interface.
+type MockRatingObserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingObserverMockRecorder
+	isgomock struct{}
+}
+
+// MockRatingObserverMockRecorder is the mock recorder for MockRatingObserver.
+type MockRatingObserverMockRecorder struct {
+	mock *MockRatingObserver
+}
+
+// NewMockRatingObserver creates a new mock instance.
+func NewMockRatingObserver(ctrl *gomock.Controller) *MockRatingObserver {
+	mock := &MockRatingObserver{ctrl: ctrl}
+	mock.recorder = &MockRatingObserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingObserver) EXPECT() *MockRatingObserverMockRecorder {
+	return m.recorder
+}
+
+// RatingChanged mocks base method.
+func (m *MockRatingObserver) RatingChanged(feedback models.FilmFeedback) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RatingChanged", feedback)
+}
+
+// RatingChanged indicates an expected call of RatingChanged.
+func (mr *MockRatingObserverMockRecorder) RatingChanged(feedback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RatingChanged", reflect.TypeOf((*MockRatingObserver)(nil).RatingChanged), feedback)
+}
@@ -126,10 +126,10 @@ func (r *FilmRepository) GetFilmAvgRating(ctx context.Context, filmID uuid.UUID)
 	return roundedRating, err
 }
 
-func (r *FilmRepository) GetFilmsWithPagination(ctx context.Context, limit, offset int) ([]models.MainPageFilm, error) {
+func (r *FilmRepository) GetFilmsWithPagination(ctx context.Context, limit, offset int, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	rows, err := r.db.Query(ctx, GetFilmsWithPaginationQuery, limit, offset)
+	rows, err := r.db.Query(ctx, GetFilmsWithPaginationQuery, limit, offset, filter.CountryID)
 	if err != nil {
 		logger.Error("failed to get rows: " + err.Error())
 		return nil, films.ErrorInternalServerError
@@ -186,26 +186,28 @@ func (r *FilmRepository) GetFilmPage(ctx context.Context, filmID uuid.UUID) (mod
 		result.Rating = 0
 	}
 
-	rows, err := r.db.Query(ctx, GetFilmActorsQuery, filmID)
+	rows, err := r.db.Query(ctx, GetFilmCrewQuery, filmID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			logger.Error("actors are not found: " + err.Error())
+			logger.Error("crew is not found: " + err.Error())
 			return result, films.ErrorNotFound
 		}
-		logger.Error("failed to scan actors: " + err.Error())
+		logger.Error("failed to scan crew: " + err.Error())
 		return result, films.ErrorInternalServerError
 	}
 	defer rows.Close()
 
+	var crew []models.CrewMember
 	for rows.Next() {
-		var actor models.Actor
+		var member models.CrewMember
 		if err := rows.Scan(
-			&actor.ID, &actor.RussianName, &actor.OriginalName, &actor.Photo, &actor.Height,
-			&actor.BirthDate, &actor.DeathDate, &actor.ZodiacSign, &actor.BirthPlace, &actor.MaritalStatus,
+			&member.ID, &member.RussianName, &member.OriginalName, &member.Photo,
+			&member.Role, &member.Character, &member.BillingOrder,
 		); err == nil {
-			result.Actors = append(result.Actors, actor)
+			crew = append(crew, member)
 		}
 	}
+	result.CastAndCrew = models.GroupCrew(crew)
 
 	logger.Info("succesfully got film from db")
 	return result, nil
@@ -244,6 +246,37 @@ func (r *FilmRepository) GetFilmFeedbacks(ctx context.Context, filmID uuid.UUID,
 	return feedbacks, nil
 }
 
+// GetFilmMedia галерея фильма в порядке показа; пустая, если фильм ещё не перенесён в film_media
+func (r *FilmRepository) GetFilmMedia(ctx context.Context, filmID uuid.UUID) ([]models.FilmMedia, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, GetFilmMediaQuery, filmID)
+	if err != nil {
+		logger.Error("failed to get film media: " + err.Error())
+		return nil, films.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	media := []models.FilmMedia{}
+	for rows.Next() {
+		var item models.FilmMedia
+		if err := rows.Scan(
+			&item.ID, &item.Type, &item.URL, &item.Position, &item.Width, &item.Height, &item.Caption,
+		); err != nil {
+			logger.Error("failed to scan film media: " + err.Error())
+			return nil, films.ErrorInternalServerError
+		}
+		media = append(media, item)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to get film media: " + err.Error())
+		return nil, films.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got film media from db")
+	return media, nil
+}
+
 func (r *FilmRepository) CheckUserFeedbackExists(ctx context.Context, userID, filmID uuid.UUID) (models.FilmFeedback, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 	var feedback models.FilmFeedback
@@ -355,7 +355,7 @@ func TestGetFilmsWithPagination(t *testing.T) {
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsWithPaginationQuery, limit, offset).
+					Query(gomock.Any(), GetFilmsWithPaginationQuery, limit, offset, uuid.NullUUID{}).
 					Return(mainRows, nil)
 
 				ratingRows1 := pgxpoolmock.NewRows([]string{"coalesce"}).
@@ -387,7 +387,7 @@ func TestGetFilmsWithPagination(t *testing.T) {
 			offset: offset,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsWithPaginationQuery, limit, offset).
+					Query(gomock.Any(), GetFilmsWithPaginationQuery, limit, offset, uuid.NullUUID{}).
 					Return(nil, assert.AnError)
 			},
 			wantFilms: nil,
@@ -404,7 +404,7 @@ func TestGetFilmsWithPagination(t *testing.T) {
 			tt.repoMocker(mockPool)
 
 			repo := NewFilmRepository(mockPool)
-			films, err := repo.GetFilmsWithPagination(testContext(), tt.limit, tt.offset)
+			films, err := repo.GetFilmsWithPagination(testContext(), tt.limit, tt.offset, models.FilmFilter{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -427,6 +427,10 @@ func TestGetFilmPage(t *testing.T) {
 	genre := "Drama"
 	country := "USA"
 	numberOfRatings := 100
+	actorID := uuid.NewV4()
+	directorID := uuid.NewV4()
+	actorOriginalName := "Actor Original Name"
+	character := "Главный герой"
 
 	tests := []struct {
 		name       string
@@ -484,27 +488,17 @@ func TestGetFilmPage(t *testing.T) {
 					QueryRow(gomock.Any(), GetFilmAvgRatingQuery, filmID).
 					Return(ratingRows)
 
-				actorRows := pgxpoolmock.NewRows([]string{
-					"id", "russian_name", "original_name", "photo", "height",
-					"birth_date", "death_date", "zodiac_sign", "birth_place", "marital_status",
+				crewRows := pgxpoolmock.NewRows([]string{
+					"id", "russian_name", "original_name", "photo",
+					"role", "character", "billing_order",
 				}).
-					AddRow(
-						uuid.NewV4(),
-						"Actor Name",
-						"Actor Original Name",
-						"/static/photo.jpg",
-						180,
-						time.Now(),
-						nil,
-						"Leo",
-						"Moscow",
-						"Single",
-					).
+					AddRow(actorID, "Актер", &actorOriginalName, "/static/photo.jpg", models.CrewRoleActor, &character, 1).
+					AddRow(directorID, "Режиссер", (*string)(nil), "/static/director.jpg", models.CrewRoleDirector, (*string)(nil), 0).
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmActorsQuery, filmID).
-					Return(actorRows, nil)
+					Query(gomock.Any(), GetFilmCrewQuery, filmID).
+					Return(crewRows, nil)
 			},
 			wantFilm: models.FilmPage{
 				ID:               filmID,
@@ -522,6 +516,20 @@ func TestGetFilmPage(t *testing.T) {
 				Country:          country,
 				NumberOfRatings:  numberOfRatings,
 				Rating:           8.5,
+				CastAndCrew: []models.CrewGroup{
+					{
+						Role: models.CrewRoleDirector,
+						Members: []models.CrewMember{
+							{ID: directorID, RussianName: "Режиссер", Photo: "/static/director.jpg", Role: models.CrewRoleDirector},
+						},
+					},
+					{
+						Role: models.CrewRoleActor,
+						Members: []models.CrewMember{
+							{ID: actorID, RussianName: "Актер", OriginalName: &actorOriginalName, Photo: "/static/photo.jpg", Role: models.CrewRoleActor, Character: &character, BillingOrder: 1},
+						},
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -547,6 +555,7 @@ func TestGetFilmPage(t *testing.T) {
 				assert.Equal(t, tt.wantFilm.Genre, film.Genre)
 				assert.Equal(t, tt.wantFilm.Country, film.Country)
 				assert.Equal(t, tt.wantFilm.Rating, film.Rating)
+				assert.Equal(t, tt.wantFilm.CastAndCrew, film.CastAndCrew)
 			}
 		})
 	}
@@ -991,3 +1000,63 @@ func TestGetUserByLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFilmMedia(t *testing.T) {
+	filmID := uuid.NewV4()
+	mediaID := uuid.NewV4()
+	width, height := 1920, 1080
+	columns := []string{"id", "type", "url", "position", "width", "height", "caption"}
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantMedia  []models.FilmMedia
+		wantErr    bool
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(columns).
+					AddRow(mediaID, models.FilmMediaStill, "films/a/b.jpg", 0, &width, &height, (*string)(nil)).
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetFilmMediaQuery, filmID).Return(rows, nil)
+			},
+			wantMedia: []models.FilmMedia{
+				{ID: mediaID, Type: models.FilmMediaStill, URL: "films/a/b.jpg", Width: &width, Height: &height},
+			},
+		},
+		{
+			name: "No media",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetFilmMediaQuery, filmID).Return(pgxpoolmock.NewRows(columns).ToPgxRows(), nil)
+			},
+			wantMedia: []models.FilmMedia{},
+		},
+		{
+			name: "QueryError",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetFilmMediaQuery, filmID).Return(nil, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewFilmRepository(mockPool)
+			media, err := repo.GetFilmMedia(testContext(), filmID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMedia, media)
+		})
+	}
+}
@@ -20,8 +20,8 @@ var GetFilmsWithPaginationQuery string
 //go:embed sql/getFilmPageQuery.sql
 var GetFilmPageQuery string
 
-//go:embed sql/getFilmActorsQuery.sql
-var GetFilmActorsQuery string
+//go:embed sql/getFilmCrewQuery.sql
+var GetFilmCrewQuery string
 
 //go:embed sql/getFilmFeedbacksQuery.sql
 var GetFilmFeedbacksQuery string
@@ -40,3 +40,6 @@ var SetRatingQuery string
 
 //go:embed sql/getUserByLoginQuery.sql
 var GetUserByLoginQuery string
+
+//go:embed sql/getFilmMediaQuery.sql
+var GetFilmMediaQuery string
@@ -0,0 +1,6 @@
+SELECT a.id, a.russian_name, a.original_name, a.photo,
+       aif.role, aif."character", aif.billing_order
+FROM actor a
+JOIN actor_in_film aif ON a.id = aif.actor_id
+WHERE aif.film_id = $1
+ORDER BY aif.role, aif.billing_order, a.russian_name
\ No newline at end of file
@@ -1,3 +1,4 @@
 SELECT id, version, login, password_hash, avatar, created_at, updated_at 
 FROM user_table 
-WHERE login = $1
\ No newline at end of file
+WHERE login = $1 
+  AND NOT EXISTS (SELECT 1 FROM user_deletion d WHERE d.user_id = user_table.id)
\ No newline at end of file
@@ -6,10 +6,11 @@ import (
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/auth"
 	"kinopoisk/internal/pkg/films"
+	"kinopoisk/internal/pkg/notifications"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
+	"math"
 	"math/rand"
-	"net/url"
 	"os"
 	"time"
 
@@ -17,15 +18,34 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// ratingShiftThreshold насколько должна сдвинуться средняя оценка, чтобы уведомить оценивших фильм
+const ratingShiftThreshold = 0.5
+
 type FilmUsecase struct {
-	filmRepo films.FilmRepo
-	secret   string
+	filmRepo  films.FilmRepo
+	secret    string
+	notifier  notifications.Producer
+	observers []films.RatingObserver
 }
 
-func NewFilmUsecase(repo films.FilmRepo) *FilmUsecase {
+func NewFilmUsecase(repo films.FilmRepo, notifier notifications.Producer, observers ...films.RatingObserver) *FilmUsecase {
 	return &FilmUsecase{
-		filmRepo: repo,
-		secret:   os.Getenv("JWT_SECRET"),
+		filmRepo:  repo,
+		secret:    os.Getenv("JWT_SECRET"),
+		notifier:  notifier,
+		observers: observers,
+	}
+}
+
+func (uc *FilmUsecase) notify(ctx context.Context, event models.NotificationEvent) {
+	if uc.notifier != nil {
+		uc.notifier.Notify(ctx, event)
+	}
+}
+
+func (uc *FilmUsecase) ratingChanged(feedback models.FilmFeedback) {
+	for _, observer := range uc.observers {
+		observer.RatingChanged(feedback)
 	}
 }
 
@@ -58,10 +78,10 @@ func (uc *FilmUsecase) GetPromoFilm(ctx context.Context) (models.PromoFilm, erro
 	return promoFilm, nil
 }
 
-func (uc *FilmUsecase) GetFilms(ctx context.Context, pager models.Pager) ([]models.MainPageFilm, error) {
+func (uc *FilmUsecase) GetFilms(ctx context.Context, pager models.Pager, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	mainPageFilms, err := uc.filmRepo.GetFilmsWithPagination(ctx, pager.Count, pager.Offset)
+	mainPageFilms, err := uc.filmRepo.GetFilmsWithPagination(ctx, pager.Count, pager.Offset, filter)
 	if err != nil {
 		return []models.MainPageFilm{}, err
 	}
@@ -80,6 +100,11 @@ func (uc *FilmUsecase) GetFilm(ctx context.Context, id uuid.UUID) (models.FilmPa
 	if err != nil {
 		return models.FilmPage{}, err
 	}
+	media, err := uc.filmRepo.GetFilmMedia(ctx, id)
+	if err != nil {
+		return models.FilmPage{}, err
+	}
+	film.SetMedia(media)
 	feedback, err := uc.filmRepo.CheckUserFeedbackExists(ctx, user.ID, id)
 	film.IsReviewed = false
 	emptyFeedback := ""
@@ -151,6 +176,8 @@ func (uc *FilmUsecase) SendFeedback(ctx context.Context, req models.FilmFeedback
 		return models.FilmFeedback{}, films.ErrorBadRequest
 	}
 
+	oldRating, _ := uc.filmRepo.GetFilmAvgRating(ctx, filmID)
+
 	existingFeedback, err := uc.filmRepo.CheckUserFeedbackExists(ctx, user.ID, filmID)
 	if err == nil {
 		// отзыв существует - обновляем
@@ -163,8 +190,7 @@ func (uc *FilmUsecase) SendFeedback(ctx context.Context, req models.FilmFeedback
 			return models.FilmFeedback{}, err
 		}
 
-		updatedFilm, _ := uc.filmRepo.GetFilmPage(ctx, filmID)
-		existingFeedback.NewFilmRating = updatedFilm.Rating
+		uc.feedbackSaved(ctx, user, &existingFeedback, oldRating)
 
 		return existingFeedback, nil
 	}
@@ -185,8 +211,14 @@ func (uc *FilmUsecase) SendFeedback(ctx context.Context, req models.FilmFeedback
 		return models.FilmFeedback{}, err
 	}
 
-	updatedFilm, _ := uc.filmRepo.GetFilmPage(ctx, filmID)
-	feedback.NewFilmRating = updatedFilm.Rating
+	updatedFilm := uc.feedbackSaved(ctx, user, &feedback, oldRating)
+	uc.notify(ctx, models.FilmReviewEvent{
+		FilmID:      filmID,
+		FilmTitle:   updatedFilm.Title,
+		ReviewID:    feedback.ID,
+		AuthorID:    user.ID,
+		AuthorLogin: user.Login,
+	})
 	return feedback, nil
 }
 
@@ -203,6 +235,8 @@ func (uc *FilmUsecase) SetRating(ctx context.Context, req models.FilmFeedbackInp
 		return models.FilmFeedback{}, films.ErrorBadRequest
 	}
 
+	oldRating, _ := uc.filmRepo.GetFilmAvgRating(ctx, filmID)
+
 	existingFeedback, err := uc.filmRepo.CheckUserFeedbackExists(ctx, user.ID, filmID)
 	if err == nil {
 		// запись существует - обновляем рейтинг
@@ -213,8 +247,7 @@ func (uc *FilmUsecase) SetRating(ctx context.Context, req models.FilmFeedbackInp
 			return models.FilmFeedback{}, err
 		}
 
-		updatedFilm, _ := uc.filmRepo.GetFilmPage(ctx, filmID)
-		existingFeedback.NewFilmRating = updatedFilm.Rating
+		uc.feedbackSaved(ctx, user, &existingFeedback, oldRating)
 
 		return existingFeedback, nil
 	}
@@ -233,12 +266,34 @@ func (uc *FilmUsecase) SetRating(ctx context.Context, req models.FilmFeedbackInp
 		return models.FilmFeedback{}, err
 	}
 
-	updatedFilm, _ := uc.filmRepo.GetFilmPage(ctx, filmID)
-	newFeedback.NewFilmRating = updatedFilm.Rating
+	uc.feedbackSaved(ctx, user, &newFeedback, oldRating)
 
 	return newFeedback, nil
 }
 
+// feedbackSaved заполняет новый рейтинг фильма, оповещает подписчиков после сохранения оценки
+// и возвращает обновлённый фильм
+func (uc *FilmUsecase) feedbackSaved(ctx context.Context, user models.User, feedback *models.FilmFeedback, oldRating float64) models.FilmPage {
+	updatedFilm, _ := uc.filmRepo.GetFilmPage(ctx, feedback.FilmID)
+	feedback.NewFilmRating = updatedFilm.Rating
+
+	published := *feedback
+	published.UserLogin = user.Login
+	published.UserAvatar = user.Avatar
+	uc.ratingChanged(published)
+
+	if oldRating > 0 && math.Abs(updatedFilm.Rating-oldRating) >= ratingShiftThreshold {
+		uc.notify(ctx, models.FilmRatingShiftEvent{
+			FilmID:    feedback.FilmID,
+			FilmTitle: updatedFilm.Title,
+			OldRating: oldRating,
+			NewRating: updatedFilm.Rating,
+			AuthorID:  user.ID,
+		})
+	}
+	return updatedFilm
+}
+
 func (uc *FilmUsecase) ParseToken(token string) (*jwt.Token, error) {
 	return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -285,23 +340,17 @@ func (uc *FilmUsecase) ValidateAndGetUser(ctx context.Context, token string) (mo
 		return models.User{}, films.ErrorUnauthorized
 	}
 
-	return user, nil
-}
-
-func (uc *FilmUsecase) SiteMap(ctx context.Context) (models.Urlset, error) {
-	var urlSet models.Urlset
-
-	urlSet.Xmlns = "https://www.sitemaps.org/schemas/sitemap/0.9/"
-	urlSet.URL = append(urlSet.URL, models.URLItem{Loc: "https://ddfilms.online/"})
-	mainPageFilms, err := uc.filmRepo.GetFilmsWithPagination(ctx, 10, 0)
-	if err != nil {
-		return models.Urlset{}, err
+	// логин после смены освобождается, поэтому токен сверяется ещё и по id
+	if id, _ := claims["id"].(string); id != user.ID.String() {
+		logger.Error("login belongs to another user")
+		return models.User{}, films.ErrorUnauthorized
 	}
-	for _, v := range mainPageFilms {
-		var item models.URLItem
-		item.Loc, _ = url.JoinPath("https://ddfilms.online/films/", v.ID.String())
-		item.Priority = 1.0
-		urlSet.URL = append(urlSet.URL, item)
+
+	// версия растёт при выходе и смене пароля или логина, старые токены после этого не принимаются
+	if version, ok := claims["version"].(float64); !ok || int(version) != user.Version {
+		logger.Error("stale token version")
+		return models.User{}, films.ErrorUnauthorized
 	}
-	return urlSet, nil
+
+	return user, nil
 }
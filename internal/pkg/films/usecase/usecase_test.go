@@ -12,6 +12,7 @@ import (
 	"kinopoisk/internal/pkg/films"
 	"kinopoisk/internal/pkg/films/mocks"
 	"kinopoisk/internal/pkg/middleware/logger"
+	notificationMocks "kinopoisk/internal/pkg/notifications/mocks"
 
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
@@ -37,7 +38,7 @@ func TestFilmUsecase_GetPromoFilm(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockFilmRepo(ctrl)
-	usecase := NewFilmUsecase(mockRepo)
+	usecase := NewFilmUsecase(mockRepo, nil)
 
 	promoFilmIDs := []string{
 		"8f9a0b1c-2d3e-4f5a-6b7c-8d9e0f1a2b3c",
@@ -118,7 +119,7 @@ func TestFilmUsecase_GetFilms(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockFilmRepo(ctrl)
-	usecase := NewFilmUsecase(mockRepo)
+	usecase := NewFilmUsecase(mockRepo, nil)
 
 	pager := models.Pager{
 		Count:  10,
@@ -154,7 +155,7 @@ func TestFilmUsecase_GetFilms(t *testing.T) {
 			name: "Success",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsWithPagination(gomock.Any(), pager.Count, pager.Offset).
+					GetFilmsWithPagination(gomock.Any(), pager.Count, pager.Offset, models.FilmFilter{}).
 					Return(expectedFilms, nil)
 			},
 			expected:    expectedFilms,
@@ -164,7 +165,7 @@ func TestFilmUsecase_GetFilms(t *testing.T) {
 			name: "Error - repository error",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsWithPagination(gomock.Any(), pager.Count, pager.Offset).
+					GetFilmsWithPagination(gomock.Any(), pager.Count, pager.Offset, models.FilmFilter{}).
 					Return(nil, films.ErrorInternalServerError)
 			},
 			expected:    []models.MainPageFilm{},
@@ -174,7 +175,7 @@ func TestFilmUsecase_GetFilms(t *testing.T) {
 			name: "Error - no films",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsWithPagination(gomock.Any(), pager.Count, pager.Offset).
+					GetFilmsWithPagination(gomock.Any(), pager.Count, pager.Offset, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, nil)
 			},
 			expected:    []models.MainPageFilm{},
@@ -185,7 +186,7 @@ func TestFilmUsecase_GetFilms(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
-			result, err := usecase.GetFilms(testContext(), pager)
+			result, err := usecase.GetFilms(testContext(), pager, models.FilmFilter{})
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -202,7 +203,7 @@ func TestFilmUsecase_GetFilm(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockFilmRepo(ctrl)
-	usecase := NewFilmUsecase(mockRepo)
+	usecase := NewFilmUsecase(mockRepo, nil)
 
 	filmID := uuid.NewV4()
 	userID := uuid.NewV4()
@@ -233,6 +234,9 @@ func TestFilmUsecase_GetFilm(t *testing.T) {
 				mockRepo.EXPECT().
 					GetFilmPage(gomock.Any(), filmID).
 					Return(expectedFilm, nil)
+				mockRepo.EXPECT().
+					GetFilmMedia(gomock.Any(), filmID).
+					Return([]models.FilmMedia{}, nil)
 				mockRepo.EXPECT().
 					CheckUserFeedbackExists(gomock.Any(), userID, filmID).
 					Return(models.FilmFeedback{
@@ -259,6 +263,9 @@ func TestFilmUsecase_GetFilm(t *testing.T) {
 				mockRepo.EXPECT().
 					GetFilmPage(gomock.Any(), filmID).
 					Return(expectedFilm, nil)
+				mockRepo.EXPECT().
+					GetFilmMedia(gomock.Any(), filmID).
+					Return([]models.FilmMedia{}, nil)
 				mockRepo.EXPECT().
 					CheckUserFeedbackExists(gomock.Any(), userID, filmID).
 					Return(models.FilmFeedback{}, films.ErrorNotFound)
@@ -279,6 +286,21 @@ func TestFilmUsecase_GetFilm(t *testing.T) {
 			expected:    models.FilmPage{},
 			expectError: true,
 		},
+		{
+			name: "Error - media",
+			ctx:  testContextWithUser(user),
+			setupMock: func() {
+				mockRepo.EXPECT().
+					GetFilmPage(gomock.Any(), filmID).
+					Return(expectedFilm, nil)
+				mockRepo.EXPECT().
+					GetFilmMedia(gomock.Any(), filmID).
+					Return(nil, films.ErrorInternalServerError)
+			},
+			filmID:      filmID,
+			expected:    models.FilmPage{},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -301,12 +323,69 @@ func TestFilmUsecase_GetFilm(t *testing.T) {
 	}
 }
 
+func TestFilmUsecase_GetFilm_Media(t *testing.T) {
+	filmID := uuid.NewV4()
+	still := "films/legacy/still.jpg"
+	legacyFilm := models.FilmPage{ID: filmID, Poster: "films/legacy/poster.jpg", Cover: "films/legacy/cover.jpg", Image1: &still}
+
+	tests := []struct {
+		name   string
+		media  []models.FilmMedia
+		verify func(t *testing.T, film models.FilmPage)
+	}{
+		{
+			name: "Gallery is built from legacy fields",
+			verify: func(t *testing.T, film models.FilmPage) {
+				assert.Len(t, film.Media, 3)
+				assert.Equal(t, models.FilmMediaPoster, film.Media[0].Type)
+				assert.Equal(t, models.FilmMediaBackdrop, film.Media[1].Type)
+				assert.Equal(t, still, film.Media[2].URL)
+				assert.Equal(t, 2, film.Media[2].Position)
+			},
+		},
+		{
+			name: "Legacy fields are filled from gallery",
+			media: []models.FilmMedia{
+				{Type: models.FilmMediaStill, URL: "films/new/1.jpg"},
+				{Type: models.FilmMediaPoster, URL: "films/new/poster.jpg", Position: 1},
+				{Type: models.FilmMediaTrailer, URL: "https://example.com/trailer", Position: 2},
+				{Type: models.FilmMediaStill, URL: "films/new/2.jpg", Position: 3},
+			},
+			verify: func(t *testing.T, film models.FilmPage) {
+				assert.Len(t, film.Media, 4)
+				assert.Equal(t, "films/new/poster.jpg", film.Poster)
+				assert.Equal(t, "films/legacy/cover.jpg", film.Cover)
+				assert.Equal(t, "https://example.com/trailer", *film.TrailerURL)
+				assert.Equal(t, "films/new/1.jpg", *film.Image1)
+				assert.Equal(t, "films/new/2.jpg", *film.Image2)
+				assert.Nil(t, film.Image3)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockFilmRepo(ctrl)
+			mockRepo.EXPECT().GetFilmPage(gomock.Any(), filmID).Return(legacyFilm, nil)
+			mockRepo.EXPECT().GetFilmMedia(gomock.Any(), filmID).Return(tt.media, nil)
+			mockRepo.EXPECT().CheckUserFeedbackExists(gomock.Any(), uuid.Nil, filmID).Return(models.FilmFeedback{}, films.ErrorNotFound)
+
+			film, err := NewFilmUsecase(mockRepo, nil).GetFilm(testContext(), filmID)
+			assert.NoError(t, err)
+			tt.verify(t, film)
+		})
+	}
+}
+
 func TestFilmUsecase_GetFilmFeedbacks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockFilmRepo(ctrl)
-	usecase := NewFilmUsecase(mockRepo)
+	usecase := NewFilmUsecase(mockRepo, nil)
 
 	filmID := uuid.NewV4()
 	userID := uuid.NewV4()
@@ -430,7 +509,7 @@ func TestFilmUsecase_SendFeedback(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockFilmRepo(ctrl)
-	usecase := NewFilmUsecase(mockRepo)
+	usecase := NewFilmUsecase(mockRepo, nil)
 
 	userID := uuid.NewV4()
 	filmID := uuid.NewV4()
@@ -455,6 +534,7 @@ func TestFilmUsecase_SendFeedback(t *testing.T) {
 			name: "Success - create new feedback",
 			ctx:  testContextWithUser(user),
 			setupMock: func() {
+				mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(8.0, nil)
 				mockRepo.EXPECT().
 					CheckUserFeedbackExists(gomock.Any(), userID, filmID).
 					Return(models.FilmFeedback{}, films.ErrorNotFound)
@@ -483,6 +563,7 @@ func TestFilmUsecase_SendFeedback(t *testing.T) {
 					Text:   &oldText,
 					Rating: 7,
 				}
+				mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(8.0, nil)
 				mockRepo.EXPECT().
 					CheckUserFeedbackExists(gomock.Any(), userID, filmID).
 					Return(existingFeedback, nil)
@@ -576,7 +657,7 @@ func TestFilmUsecase_SetRating(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockFilmRepo(ctrl)
-	usecase := NewFilmUsecase(mockRepo)
+	usecase := NewFilmUsecase(mockRepo, nil)
 
 	userID := uuid.NewV4()
 	filmID := uuid.NewV4()
@@ -598,6 +679,7 @@ func TestFilmUsecase_SetRating(t *testing.T) {
 			name: "Success - create new rating",
 			ctx:  testContextWithUser(user),
 			setupMock: func() {
+				mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(8.0, nil)
 				mockRepo.EXPECT().
 					CheckUserFeedbackExists(gomock.Any(), userID, filmID).
 					Return(models.FilmFeedback{}, films.ErrorNotFound)
@@ -622,6 +704,7 @@ func TestFilmUsecase_SetRating(t *testing.T) {
 					FilmID: filmID,
 					Rating: 7,
 				}
+				mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(8.0, nil)
 				mockRepo.EXPECT().
 					CheckUserFeedbackExists(gomock.Any(), userID, filmID).
 					Return(existingFeedback, nil)
@@ -677,3 +760,71 @@ func TestFilmUsecase_SetRating(t *testing.T) {
 		})
 	}
 }
+
+type ratingObserverStub struct {
+	users   []uuid.UUID
+	ratings []float64
+}
+
+func (o *ratingObserverStub) RatingChanged(feedback models.FilmFeedback) {
+	o.users = append(o.users, feedback.UserID)
+	o.ratings = append(o.ratings, feedback.NewFilmRating)
+}
+
+func TestFilmUsecase_SetRating_NotifiesObservers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilmRepo(ctrl)
+	observer := &ratingObserverStub{}
+	usecase := NewFilmUsecase(mockRepo, nil, observer)
+
+	user := models.User{ID: uuid.NewV4()}
+	filmID := uuid.NewV4()
+
+	mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(0.0, nil)
+	mockRepo.EXPECT().CheckUserFeedbackExists(gomock.Any(), user.ID, filmID).Return(models.FilmFeedback{}, films.ErrorNotFound)
+	mockRepo.EXPECT().CreateFeedback(gomock.Any(), gomock.Any()).Return(films.ErrorInternalServerError)
+	_, err := usecase.SetRating(testContextWithUser(user), models.FilmFeedbackInput{Rating: 8}, filmID)
+	assert.Error(t, err)
+	assert.Empty(t, observer.users)
+
+	mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(0.0, nil)
+	mockRepo.EXPECT().CheckUserFeedbackExists(gomock.Any(), user.ID, filmID).Return(models.FilmFeedback{}, films.ErrorNotFound)
+	mockRepo.EXPECT().CreateFeedback(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetFilmPage(gomock.Any(), filmID).Return(models.FilmPage{Rating: 8}, nil)
+	_, err = usecase.SetRating(testContextWithUser(user), models.FilmFeedbackInput{Rating: 8}, filmID)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{user.ID}, observer.users)
+	assert.Equal(t, []float64{8}, observer.ratings)
+}
+
+func TestFilmUsecase_SendFeedback_Notifies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockFilmRepo(ctrl)
+	mockNotifier := notificationMocks.NewMockProducer(ctrl)
+	usecase := NewFilmUsecase(mockRepo, mockNotifier)
+
+	user := models.User{ID: uuid.NewV4(), Login: "critic"}
+	filmID := uuid.NewV4()
+	input := models.FilmFeedbackInput{Title: "Шедевр", Text: "Очень длинный и содержательный текст отзыва", Rating: 10}
+
+	mockRepo.EXPECT().GetFilmAvgRating(gomock.Any(), filmID).Return(6.2, nil)
+	mockRepo.EXPECT().CheckUserFeedbackExists(gomock.Any(), user.ID, filmID).Return(models.FilmFeedback{}, films.ErrorNotFound)
+	mockRepo.EXPECT().CreateFeedback(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().GetFilmPage(gomock.Any(), filmID).Return(models.FilmPage{Title: "Дюна", Rating: 7.1}, nil)
+
+	var events []models.NotificationEvent
+	mockNotifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Times(2).Do(func(_ context.Context, event models.NotificationEvent) {
+		events = append(events, event)
+	})
+
+	feedback, err := usecase.SendFeedback(testContextWithUser(user), input, filmID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.NotificationEvent{
+		models.FilmRatingShiftEvent{FilmID: filmID, FilmTitle: "Дюна", OldRating: 6.2, NewRating: 7.1, AuthorID: user.ID},
+		models.FilmReviewEvent{FilmID: filmID, FilmTitle: "Дюна", ReviewID: feedback.ID, AuthorID: user.ID, AuthorLogin: "critic"},
+	}, events)
+}
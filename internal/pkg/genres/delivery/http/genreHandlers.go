@@ -4,6 +4,7 @@ import (
 	"errors"
 	"kinopoisk/internal/pkg/genres"
 	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
 	"net/http"
@@ -26,9 +27,9 @@ func NewGenreHandler(uc genres.GenreUsecase) *GenreHandler {
 // @Produce json
 // @Param        id   path      string  true  "Genre ID"
 // @Success 200 {object} models.Genre
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /genres/{id} [get]
 func (g *GenreHandler) GetGenre(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -36,18 +37,13 @@ func (g *GenreHandler) GetGenre(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of genre"), http.StatusUnauthorized)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
 	neededGenre, err := g.uc.GetGenre(r.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, genres.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	neededGenre.Sanitize()
@@ -60,8 +56,8 @@ func (g *GenreHandler) GetGenre(w http.ResponseWriter, r *http.Request) {
 // @Tags genres
 // @Produce json
 // @Success 200 {array} models.Genre
-// @Failure 404
-// @Failure 500
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /genres [get]
 func (g *GenreHandler) GetGenres(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -69,12 +65,7 @@ func (g *GenreHandler) GetGenres(w http.ResponseWriter, r *http.Request) {
 
 	allGenres, err := g.uc.GetGenres(r.Context(), pager)
 	if err != nil {
-		switch {
-		case errors.Is(err, genres.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	for i := range allGenres {
@@ -89,10 +80,11 @@ func (g *GenreHandler) GetGenres(w http.ResponseWriter, r *http.Request) {
 // @Tags genres
 // @Produce json
 // @Param        id   path      string  true  "Genre ID"
+// @Param        country_id  query  string  false  "Country ID"
 // @Success 200 {array} models.MainPageFilm
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /genres/{id}/films [get]
 func (g *GenreHandler) GetFilmsByGenre(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -102,20 +94,21 @@ func (g *GenreHandler) GetFilmsByGenre(w http.ResponseWriter, r *http.Request) {
 	neededGenre, err := uuid.FromString(idStr)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of genre"), http.StatusUnauthorized)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
 	pager := helpers.GetPagerFromRequest(r)
+	filter, err := helpers.GetFilmFilterFromRequest(r)
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of country"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
 
-	films, err := g.uc.GetFilmsByGenre(r.Context(), neededGenre, pager)
+	films, err := g.uc.GetFilmsByGenre(r.Context(), neededGenre, pager, filter)
 	if err != nil {
-		switch {
-		case errors.Is(err, genres.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	for i := range films {
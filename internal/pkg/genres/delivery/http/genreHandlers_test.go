@@ -264,7 +264,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 			varsID: genreIDStr,
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilmsByGenre(gomock.Any(), genreID, models.Pager{Count: 10, Offset: 0}).
+					GetFilmsByGenre(gomock.Any(), genreID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
 					Return(expectedFilms, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -284,7 +284,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 			varsID: genreIDStr,
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilmsByGenre(gomock.Any(), genreID, models.Pager{Count: 10, Offset: 0}).
+					GetFilmsByGenre(gomock.Any(), genreID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, genres.ErrorNotFound)
 			},
 			expectedStatus: http.StatusNotFound,
@@ -296,7 +296,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 			varsID: genreIDStr,
 			mockSetup: func() {
 				mockUsecase.EXPECT().
-					GetFilmsByGenre(gomock.Any(), genreID, models.Pager{Count: 10, Offset: 0}).
+					GetFilmsByGenre(gomock.Any(), genreID, models.Pager{Count: 10, Offset: 0}, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, errors.New("internal error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
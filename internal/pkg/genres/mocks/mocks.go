@@ -43,18 +43,18 @@ func (m *MockGenreUsecase) EXPECT() *MockGenreUsecaseMockRecorder {
 }
 
 // GetFilmsByGenre mocks base method.
-func (m *MockGenreUsecase) GetFilmsByGenre(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error) {
+func (m *MockGenreUsecase) GetFilmsByGenre(ctx context.Context, id uuid.UUID, pager models.Pager, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsByGenre", ctx, id, pager)
+	ret := m.ctrl.Call(m, "GetFilmsByGenre", ctx, id, pager, filter)
 	ret0, _ := ret[0].([]models.MainPageFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsByGenre indicates an expected call of GetFilmsByGenre.
-func (mr *MockGenreUsecaseMockRecorder) GetFilmsByGenre(ctx, id, pager any) *gomock.Call {
+func (mr *MockGenreUsecaseMockRecorder) GetFilmsByGenre(ctx, id, pager, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByGenre", reflect.TypeOf((*MockGenreUsecase)(nil).GetFilmsByGenre), ctx, id, pager)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByGenre", reflect.TypeOf((*MockGenreUsecase)(nil).GetFilmsByGenre), ctx, id, pager, filter)
 }
 
 // GetGenre mocks base method.
@@ -112,18 +112,18 @@ func (m *MockGenreRepo) EXPECT() *MockGenreRepoMockRecorder {
 }
 
 // GetFilmsByGenre mocks base method.
-func (m *MockGenreRepo) GetFilmsByGenre(ctx context.Context, genreID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error) {
+func (m *MockGenreRepo) GetFilmsByGenre(ctx context.Context, genreID uuid.UUID, limit, offset int, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsByGenre", ctx, genreID, limit, offset)
+	ret := m.ctrl.Call(m, "GetFilmsByGenre", ctx, genreID, limit, offset, filter)
 	ret0, _ := ret[0].([]models.MainPageFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsByGenre indicates an expected call of GetFilmsByGenre.
-func (mr *MockGenreRepoMockRecorder) GetFilmsByGenre(ctx, genreID, limit, offset any) *gomock.Call {
+func (mr *MockGenreRepoMockRecorder) GetFilmsByGenre(ctx, genreID, limit, offset, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByGenre", reflect.TypeOf((*MockGenreRepo)(nil).GetFilmsByGenre), ctx, genreID, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByGenre", reflect.TypeOf((*MockGenreRepo)(nil).GetFilmsByGenre), ctx, genreID, limit, offset, filter)
 }
 
 // GetGenreByID mocks base method.
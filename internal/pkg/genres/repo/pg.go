@@ -100,10 +100,10 @@ func (g *GenreRepository) GetFilmAvgRating(ctx context.Context, filmID uuid.UUID
 	return roundedRating, err
 }
 
-func (g *GenreRepository) GetFilmsByGenre(ctx context.Context, genreID uuid.UUID, limit, offset int) ([]models.MainPageFilm, error) {
+func (g *GenreRepository) GetFilmsByGenre(ctx context.Context, genreID uuid.UUID, limit, offset int, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 
-	rows, err := g.db.Query(ctx, GetFilmsByGenreQuery, genreID, limit, offset)
+	rows, err := g.db.Query(ctx, GetFilmsByGenreQuery, genreID, limit, offset, filter.CountryID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			logger.Error("genre is not found: " + err.Error())
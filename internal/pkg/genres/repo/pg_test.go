@@ -298,7 +298,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByGenreQuery, genreID, limit, offset).
+					Query(gomock.Any(), GetFilmsByGenreQuery, genreID, limit, offset, uuid.NullUUID{}).
 					Return(mainRows, nil)
 
 				ratingRows1 := pgxpoolmock.NewRows([]string{"coalesce"}).
@@ -345,7 +345,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 			offset:  offset,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByGenreQuery, genreID, limit, offset).
+					Query(gomock.Any(), GetFilmsByGenreQuery, genreID, limit, offset, uuid.NullUUID{}).
 					Return(nil, assert.AnError)
 			},
 			wantFilms: nil,
@@ -364,7 +364,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 					ToPgxRows()
 
 				mockPool.EXPECT().
-					Query(gomock.Any(), GetFilmsByGenreQuery, genreID, limit, offset).
+					Query(gomock.Any(), GetFilmsByGenreQuery, genreID, limit, offset, uuid.NullUUID{}).
 					Return(mainRows, nil)
 
 				ratingRows := pgxpoolmock.NewRows([]string{"coalesce"}).
@@ -399,7 +399,7 @@ func TestGetFilmsByGenre(t *testing.T) {
 			tt.repoMocker(mockPool)
 
 			repo := NewGenreRepository(mockPool)
-			films, err := repo.GetFilmsByGenre(testContext(), tt.genreID, tt.limit, tt.offset)
+			films, err := repo.GetFilmsByGenre(testContext(), tt.genreID, tt.limit, tt.offset, models.FilmFilter{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -3,7 +3,7 @@ SELECT
 FROM film f
 JOIN genre g ON f.genre_id = g.id
 LEFT JOIN film_feedback ff ON f.id = ff.film_id
-WHERE g.id = $1
+WHERE g.id = $1 AND ($4::uuid IS NULL OR f.country_id = $4::uuid)
 GROUP BY f.id, g.title
 ORDER BY f.created_at DESC
 LIMIT $2 OFFSET $3
\ No newline at end of file
@@ -1,3 +1,3 @@
-SELECT id, title, description, icon, created_at, updated_at 
+SELECT id, title, COALESCE(description, ''), COALESCE(icon, ''), created_at, updated_at 
 FROM genre 
 WHERE id = $1
\ No newline at end of file
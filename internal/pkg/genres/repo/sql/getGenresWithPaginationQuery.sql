@@ -1,4 +1,4 @@
-SELECT id, title, description, icon, created_at, updated_at 
+SELECT id, title, COALESCE(description, ''), COALESCE(icon, ''), created_at, updated_at 
 FROM genre 
 ORDER BY title
 LIMIT $1 OFFSET $2
\ No newline at end of file
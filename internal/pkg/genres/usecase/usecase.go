@@ -40,9 +40,9 @@ func (uc *GenreUsecase) GetGenres(ctx context.Context, pager models.Pager) ([]mo
 	return allGenres, nil
 }
 
-func (uc *GenreUsecase) GetFilmsByGenre(ctx context.Context, id uuid.UUID, pager models.Pager) ([]models.MainPageFilm, error) {
+func (uc *GenreUsecase) GetFilmsByGenre(ctx context.Context, id uuid.UUID, pager models.Pager, filter models.FilmFilter) ([]models.MainPageFilm, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
-	films, err := uc.genreRepo.GetFilmsByGenre(ctx, id, pager.Count, pager.Offset)
+	films, err := uc.genreRepo.GetFilmsByGenre(ctx, id, pager.Count, pager.Offset, filter)
 	if err != nil {
 		return []models.MainPageFilm{}, err
 	}
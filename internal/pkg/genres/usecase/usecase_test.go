@@ -218,7 +218,7 @@ func TestGenreUsecase_GetFilmsByGenre(t *testing.T) {
 			name: "Success",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByGenre(gomock.Any(), genreID, pager.Count, pager.Offset).
+					GetFilmsByGenre(gomock.Any(), genreID, pager.Count, pager.Offset, models.FilmFilter{}).
 					Return(expectedFilms, nil)
 			},
 			genreID:     genreID,
@@ -229,7 +229,7 @@ func TestGenreUsecase_GetFilmsByGenre(t *testing.T) {
 			name: "Error - repository error",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByGenre(gomock.Any(), genreID, pager.Count, pager.Offset).
+					GetFilmsByGenre(gomock.Any(), genreID, pager.Count, pager.Offset, models.FilmFilter{}).
 					Return(nil, errors.New("database error"))
 			},
 			genreID:     genreID,
@@ -241,7 +241,7 @@ func TestGenreUsecase_GetFilmsByGenre(t *testing.T) {
 			name: "Error - no films",
 			setupMock: func() {
 				mockRepo.EXPECT().
-					GetFilmsByGenre(gomock.Any(), genreID, pager.Count, pager.Offset).
+					GetFilmsByGenre(gomock.Any(), genreID, pager.Count, pager.Offset, models.FilmFilter{}).
 					Return([]models.MainPageFilm{}, nil)
 			},
 			genreID:     genreID,
@@ -254,7 +254,7 @@ func TestGenreUsecase_GetFilmsByGenre(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
-			result, err := usecase.GetFilmsByGenre(testContext(), tt.genreID, pager)
+			result, err := usecase.GetFilmsByGenre(testContext(), tt.genreID, pager, models.FilmFilter{})
 
 			if tt.expectError {
 				assert.Error(t, err)
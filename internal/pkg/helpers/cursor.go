@@ -0,0 +1,19 @@
+package helpers
+
+import (
+	"kinopoisk/internal/models"
+	"net/http"
+)
+
+// GetCursorFromRequest возвращает nil, если клиент запрашивает первую страницу
+func GetCursorFromRequest(r *http.Request) (*models.Cursor, error) {
+	encoded := r.URL.Query().Get("cursor")
+	if encoded == "" {
+		return nil, nil
+	}
+	cursor, err := models.ParseCursor(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"kinopoisk/internal/models"
+	"net/http"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+func GetFilmFilterFromRequest(r *http.Request) (models.FilmFilter, error) {
+	var filter models.FilmFilter
+
+	countryID := r.URL.Query().Get("country_id")
+	if countryID != "" {
+		id, err := uuid.FromString(countryID)
+		if err != nil {
+			return models.FilmFilter{}, err
+		}
+		filter.CountryID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	return filter, nil
+}
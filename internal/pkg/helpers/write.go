@@ -14,14 +14,25 @@ func WriteJSON(w http.ResponseWriter, data interface{}) {
 	}
 }
 
-func WriteError(w http.ResponseWriter, status int) {
+func WriteJSONWithStatus(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
 }
 
 func WriteXML(w http.ResponseWriter, data interface{}) {
-	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
-	err := xml.NewEncoder(w).Encode(data)
+	WriteXMLWithContentType(w, "text/xml; charset=utf-8", data)
+}
+
+// WriteXMLWithContentType нужен лентам: у Atom и RSS свои типы. Документ собирается
+// целиком до записи, чтобы при ошибке вернуть 500, а не обрезанный XML
+func WriteXMLWithContentType(w http.ResponseWriter, contentType string, data interface{}) {
+	content, err := xml.Marshal(data)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
+		return
 	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(content)
 }
@@ -0,0 +1,9 @@
+package mail
+
+import "errors"
+
+var (
+	ErrorBadMessage = errors.New("bad message")
+	ErrorSendFailed = errors.New("failed to send mail")
+	ErrorNoMailDir  = errors.New("mail dir is not set")
+)
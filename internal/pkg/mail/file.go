@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"context"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// FileSender для локальной разработки и тестов: письмо сохраняется в отдельном каталоге файлом .eml,
+// который открывается любым почтовым клиентом. В письмах ссылки для сброса пароля,
+// поэтому в лог приложения попадает только имя файла
+type FileSender struct {
+	dir  string
+	from string
+}
+
+func NewFileSender(dir, from string) (*FileSender, error) {
+	if dir == "" {
+		return nil, ErrorNoMailDir
+	}
+	return &FileSender{dir: dir, from: from}, nil
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	now := time.Now()
+	raw, err := msg.build(s.from, now)
+	if err != nil {
+		logger.Error("failed to build mail: " + err.Error())
+		return ErrorBadMessage
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		logger.Error("failed to create mail dir: " + err.Error())
+		return ErrorSendFailed
+	}
+	name := now.UTC().Format("20060102T150405") + "-" + uuid.NewV4().String() + ".eml"
+	if err := os.WriteFile(filepath.Join(s.dir, name), raw, 0o600); err != nil {
+		logger.Error("failed to write mail: " + err.Error())
+		return ErrorSendFailed
+	}
+	logger.Info("succesfully saved mail", slog.String("file", name))
+	return nil
+}
@@ -0,0 +1,76 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestBuildMessage(t *testing.T) {
+	msg := Message{
+		To:      "user@example.com",
+		Subject: "Восстановление пароля",
+		Body:    "Ссылка:\nhttps://ddfilms.example/reset-password?token=abc",
+	}
+
+	raw, err := msg.build("DDFilms <noreply@ddfilms.example>", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	assert.NoError(t, err)
+
+	headers, body, found := strings.Cut(string(raw), "\r\n\r\n")
+	assert.True(t, found)
+	assert.Contains(t, headers, "To: user@example.com\r\n")
+	assert.Contains(t, headers, "@ddfilms.example>\r\n")
+	assert.Contains(t, headers, "Content-Transfer-Encoding: quoted-printable")
+
+	subject := headers[strings.Index(headers, "Subject: ")+len("Subject: "):]
+	subject = subject[:strings.Index(subject, "\r\n")]
+	decoded, err := new(mime.WordDecoder).DecodeHeader(subject)
+	assert.NoError(t, err)
+	assert.Equal(t, msg.Subject, decoded)
+	assert.Contains(t, body, "token=3Dabc")
+}
+
+func TestBuildMessageRejectsHeaderInjection(t *testing.T) {
+	for _, to := range []string{"user@example.com\r\nBcc: spam@example.com", "not an address", ""} {
+		_, err := Message{To: to, Subject: "s", Body: "b"}.build("noreply@ddfilms.example", time.Now())
+		assert.ErrorIs(t, err, ErrorBadMessage, to)
+	}
+
+	raw, err := Message{To: "user@example.com", Subject: "тема\r\nBcc: spam@example.com", Body: "b"}.build("noreply@ddfilms.example", time.Now())
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "\r\nBcc:")
+}
+
+func TestFileSender(t *testing.T) {
+	dir := t.TempDir()
+	sender, err := NewFileSender(filepath.Join(dir, "mail"), "noreply@ddfilms.example")
+	assert.NoError(t, err)
+
+	err = sender.Send(testContext(), Message{To: "user@example.com", Subject: "Привет", Body: "текст"})
+	assert.NoError(t, err)
+
+	files, err := os.ReadDir(filepath.Join(dir, "mail"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.True(t, strings.HasSuffix(files[0].Name(), ".eml"))
+
+	_, err = NewFileSender("", "noreply@ddfilms.example")
+	assert.ErrorIs(t, err, ErrorNoMailDir)
+
+	err = sender.Send(testContext(), Message{To: "bad", Subject: "s", Body: "b"})
+	assert.ErrorIs(t, err, ErrorBadMessage)
+}
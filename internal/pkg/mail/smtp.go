@@ -0,0 +1,54 @@
+package mail
+
+import (
+	"context"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender отправляет письма через SMTP-сервер; STARTTLS включается, если сервер его поддерживает
+type SMTPSender struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(host, port, username, password, from string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+		auth: auth,
+	}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, msg Message) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	raw, err := msg.build(s.from, time.Now())
+	if err != nil {
+		logger.Error("failed to build mail: " + err.Error())
+		return ErrorBadMessage
+	}
+
+	from, err := mail.ParseAddress(s.from)
+	if err != nil {
+		logger.Error("invalid sender address: " + err.Error())
+		return ErrorBadMessage
+	}
+	to, _ := mail.ParseAddress(msg.To)
+
+	if err := smtp.SendMail(s.addr, s.auth, from.Address, []string{to.Address}, raw); err != nil {
+		logger.Error("failed to send mail: " + err.Error())
+		return ErrorSendFailed
+	}
+	logger.Info("succesfully sent mail")
+	return nil
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/meta"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// botAgents подстроки User-Agent поисковых роботов и превью соцсетей и мессенджеров,
+// которые не исполняют JS и видят только то, что отдал сервер
+var botAgents = []string{
+	"googlebot", "bingbot", "yandex", "duckduckbot", "baiduspider", "applebot",
+	"facebookexternalhit", "twitterbot", "telegrambot", "vkshare", "whatsapp",
+	"slackbot", "discordbot", "linkedinbot", "pinterest", "redditbot",
+}
+
+func isBot(userAgent string) bool {
+	userAgent = strings.ToLower(userAgent)
+	for _, bot := range botAgents {
+		if strings.Contains(userAgent, bot) {
+			return true
+		}
+	}
+	return false
+}
+
+// localPath не даёт сделать из редиректа открытый редирект на чужой хост. Браузеры выкидывают
+// из адреса табы и переводы строк и читают обратный слэш как прямой, поэтому "/\t/evil.com" для них тот же "//evil.com"
+func localPath(path string) bool {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.ContainsRune(path, '\\') {
+		return false
+	}
+	if strings.IndexFunc(path, unicode.IsControl) >= 0 {
+		return false
+	}
+	parsed, err := url.Parse(path)
+	return err == nil && parsed.Scheme == "" && parsed.Host == ""
+}
+
+// html/template сам экранирует значения в атрибутах, а JSON-LD внутри <script> кодирует как JS,
+// поэтому его передаём уже готовой строкой template.JS
+var prerenderTemplate = template.Must(template.New("prerender").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<meta name="description" content="{{.Description}}">
+<link rel="canonical" href="{{.Canonical}}">
+{{range .Tags}}{{if .Property}}<meta property="{{.Property}}" content="{{.Content}}">
+{{else}}<meta name="{{.Name}}" content="{{.Content}}">
+{{end}}{{end}}{{if .JSONLD}}<script type="application/ld+json">{{.JSONLD}}</script>
+{{end}}</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Description}}</p>
+<a href="{{.Canonical}}">{{.Canonical}}</a>
+</body>
+</html>
+`))
+
+type prerenderPage struct {
+	models.PageMeta
+	JSONLD template.JS
+}
+
+type MetaHandler struct {
+	uc meta.MetaUsecase
+}
+
+func NewMetaHandler(uc meta.MetaUsecase) *MetaHandler {
+	return &MetaHandler{uc: uc}
+}
+
+// GetMeta godoc
+// @Summary Get title, description, OpenGraph tags and JSON-LD for a page
+// @Tags meta
+// @Produce json
+// @Param path query string true "Page path, e.g. /films/{id}, /actors/{id} or /genres/{id}"
+// @Success 200 {object} models.PageMeta
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /meta [get]
+func (h *MetaHandler) GetMeta(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	pageMeta, err := h.uc.GetPageMeta(r.Context(), r.URL.Query().Get("path"))
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	helpers.WriteJSON(w, pageMeta)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// Prerender godoc
+// @Summary HTML page with meta tags for crawlers and link previews
+// @Description Bots get a minimal HTML page with meta tags and JSON-LD, other clients are redirected to the page itself
+// @Tags meta
+// @Produce html
+// @Param path query string true "Page path, e.g. /films/{id}"
+// @Success 200 {string} string "Prerendered HTML"
+// @Success 302
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /prerender [get]
+func (h *MetaHandler) Prerender(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	path := r.URL.Query().Get("path")
+	if !localPath(path) {
+		log.LogHandlerError(logger, errors.New("invalid page path"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Vary", "User-Agent")
+	if !isBot(r.UserAgent()) {
+		http.Redirect(w, r, path, http.StatusFound)
+		log.LogHandlerInfo(logger, "redirected", http.StatusFound)
+		return
+	}
+
+	pageMeta, err := h.uc.GetPageMeta(r.Context(), path)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	page := prerenderPage{PageMeta: pageMeta}
+	if pageMeta.JSONLD != nil {
+		jsonld, err := json.Marshal(pageMeta.JSONLD)
+		if err != nil {
+			log.LogHandlerError(logger, err, http.StatusInternalServerError)
+			problem.WriteStatus(w, r, http.StatusInternalServerError)
+			return
+		}
+		page.JSONLD = template.JS(jsonld)
+	}
+
+	var body bytes.Buffer
+	if err := prerenderTemplate.Execute(&body, page); err != nil {
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		problem.WriteStatus(w, r, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(body.Bytes())
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
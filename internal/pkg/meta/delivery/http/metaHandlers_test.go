@@ -0,0 +1,199 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/meta"
+	"kinopoisk/internal/pkg/meta/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+const botAgent = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+
+func testPageMeta() models.PageMeta {
+	return models.PageMeta{
+		Title:       "Tom & Jerry — DDFilms",
+		Description: `Кот "Том" <и> мышь`,
+		Canonical:   "https://ddfilms.online/films/1",
+		Tags: []models.MetaTag{
+			{Property: "og:title", Content: "Tom & Jerry"},
+			{Name: "twitter:card", Content: "summary"},
+		},
+		JSONLD: models.SchemaMovie{Context: "https://schema.org", Type: "Movie", Name: "</script><script>alert(1)</script>"},
+	}
+}
+
+func TestGetMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockMetaUsecase(ctrl)
+	handler := NewMetaHandler(mockUsecase)
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetPageMeta(gomock.Any(), "/films/1").Return(testPageMeta(), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Bad path",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetPageMeta(gomock.Any(), "/films/1").Return(models.PageMeta{}, meta.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Not found",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetPageMeta(gomock.Any(), "/films/1").Return(models.PageMeta{}, meta.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Internal error",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetPageMeta(gomock.Any(), "/films/1").Return(models.PageMeta{}, meta.ErrorInternalServerError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			req := httptest.NewRequest(http.MethodGet, "/api/meta?path="+url.QueryEscape("/films/1"), nil).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			handler.GetMeta(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var body map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, "Tom & Jerry — DDFilms", body["title"])
+				assert.Equal(t, "Movie", body["json_ld"].(map[string]interface{})["@type"])
+			}
+		})
+	}
+}
+
+func TestPrerender(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockMetaUsecase(ctrl)
+	handler := NewMetaHandler(mockUsecase)
+
+	tests := []struct {
+		name             string
+		path             string
+		userAgent        string
+		mockSetup        func()
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:      "Bot",
+			path:      "/films/1",
+			userAgent: botAgent,
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetPageMeta(gomock.Any(), "/films/1").Return(testPageMeta(), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "Browser is redirected",
+			path:             "/films/1",
+			userAgent:        "Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0",
+			mockSetup:        func() {},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "/films/1",
+		},
+		{
+			name:           "Open redirect",
+			path:           "//evil.example/films/1",
+			userAgent:      "Mozilla/5.0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Open redirect through tab",
+			path:           "/\t/evil.example",
+			userAgent:      "Mozilla/5.0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Open redirect through backslash",
+			path:           "/\\evil.example",
+			userAgent:      "Mozilla/5.0",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			path:      "/films/1",
+			userAgent: "TelegramBot (like TwitterBot)",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetPageMeta(gomock.Any(), "/films/1").Return(models.PageMeta{}, meta.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			req := httptest.NewRequest(http.MethodGet, "/api/prerender?path="+url.QueryEscape(tt.path), nil).WithContext(testContext())
+			req.Header.Set("User-Agent", tt.userAgent)
+			w := httptest.NewRecorder()
+
+			handler.Prerender(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+			if tt.expectedStatus == http.StatusOK {
+				body := w.Body.String()
+				assert.Equal(t, "User-Agent", w.Header().Get("Vary"))
+				assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+				assert.Contains(t, body, `<title>Tom &amp; Jerry — DDFilms</title>`)
+				assert.Contains(t, body, `<meta property="og:title" content="Tom &amp; Jerry">`)
+				assert.Contains(t, body, `<meta name="twitter:card" content="summary">`)
+				assert.Contains(t, body, `<link rel="canonical" href="https://ddfilms.online/films/1">`)
+				assert.Contains(t, body, `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Movie"`)
+				assert.NotContains(t, body, "</script><script>")
+			}
+		})
+	}
+}
+
+func TestIsBot(t *testing.T) {
+	assert.True(t, isBot(botAgent))
+	assert.True(t, isBot("facebookexternalhit/1.1"))
+	assert.True(t, isBot("Mozilla/5.0 (compatible; YandexBot/3.0)"))
+	assert.False(t, isBot("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Safari/604.1"))
+	assert.False(t, isBot(""))
+}
@@ -0,0 +1,26 @@
+package meta
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type MetaUsecase interface {
+	GetPageMeta(ctx context.Context, path string) (models.PageMeta, error)
+}
+
+// FilmSource, ActorSource и GenreSource закрываются репозиториями фильмов, актеров и жанров,
+// поэтому рейтинг и число оценок в метаданных те же, что и на странице фильма
+type FilmSource interface {
+	GetFilmPage(ctx context.Context, filmID uuid.UUID) (models.FilmPage, error)
+}
+
+type ActorSource interface {
+	GetActorByID(ctx context.Context, id uuid.UUID) (models.Actor, error)
+}
+
+type GenreSource interface {
+	GetGenreByID(ctx context.Context, id uuid.UUID) (models.Genre, error)
+}
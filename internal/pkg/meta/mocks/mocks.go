@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/meta/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/meta/interfaces.go -destination=internal/pkg/meta/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/satori/go.uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMetaUsecase is a mock of MetaUsecase interface.
+type MockMetaUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetaUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockMetaUsecaseMockRecorder is the mock recorder for MockMetaUsecase.
+type MockMetaUsecaseMockRecorder struct {
+	mock *MockMetaUsecase
+}
+
+// NewMockMetaUsecase creates a new mock instance.
+func NewMockMetaUsecase(ctrl *gomock.Controller) *MockMetaUsecase {
+	mock := &MockMetaUsecase{ctrl: ctrl}
+	mock.recorder = &MockMetaUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetaUsecase) EXPECT() *MockMetaUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetPageMeta mocks base method.
+func (m *MockMetaUsecase) GetPageMeta(ctx context.Context, path string) (models.PageMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageMeta", ctx, path)
+	ret0, _ := ret[0].(models.PageMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageMeta indicates an expected call of GetPageMeta.
+func (mr *MockMetaUsecaseMockRecorder) GetPageMeta(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageMeta", reflect.TypeOf((*MockMetaUsecase)(nil).GetPageMeta), ctx, path)
+}
+
+// MockFilmSource is a mock of FilmSource interface.
+type MockFilmSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilmSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockFilmSourceMockRecorder is the mock recorder for MockFilmSource.
+type MockFilmSourceMockRecorder struct {
+	mock *MockFilmSource
+}
+
+// NewMockFilmSource creates a new mock instance.
+func NewMockFilmSource(ctrl *gomock.Controller) *MockFilmSource {
+	mock := &MockFilmSource{ctrl: ctrl}
+	mock.recorder = &MockFilmSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilmSource) EXPECT() *MockFilmSourceMockRecorder {
+	return m.recorder
+}
+
+// GetFilmPage mocks base method.
+func (m *MockFilmSource) GetFilmPage(ctx context.Context, filmID uuid.UUID) (models.FilmPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmPage", ctx, filmID)
+	ret0, _ := ret[0].(models.FilmPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmPage indicates an expected call of GetFilmPage.
+func (mr *MockFilmSourceMockRecorder) GetFilmPage(ctx, filmID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmPage", reflect.TypeOf((*MockFilmSource)(nil).GetFilmPage), ctx, filmID)
+}
+
+// MockActorSource is a mock of ActorSource interface.
+type MockActorSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockActorSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockActorSourceMockRecorder is the mock recorder for MockActorSource.
+type MockActorSourceMockRecorder struct {
+	mock *MockActorSource
+}
+
+// NewMockActorSource creates a new mock instance.
+func NewMockActorSource(ctrl *gomock.Controller) *MockActorSource {
+	mock := &MockActorSource{ctrl: ctrl}
+	mock.recorder = &MockActorSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActorSource) EXPECT() *MockActorSourceMockRecorder {
+	return m.recorder
+}
+
+// GetActorByID mocks base method.
+func (m *MockActorSource) GetActorByID(ctx context.Context, id uuid.UUID) (models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorByID", ctx, id)
+	ret0, _ := ret[0].(models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorByID indicates an expected call of GetActorByID.
+func (mr *MockActorSourceMockRecorder) GetActorByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorByID", reflect.TypeOf((*MockActorSource)(nil).GetActorByID), ctx, id)
+}
+
+// MockGenreSource is a mock of GenreSource interface.
+type MockGenreSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockGenreSourceMockRecorder is the mock recorder for MockGenreSource.
+type MockGenreSourceMockRecorder struct {
+	mock *MockGenreSource
+}
+
+// NewMockGenreSource creates a new mock instance.
+func NewMockGenreSource(ctrl *gomock.Controller) *MockGenreSource {
+	mock := &MockGenreSource{ctrl: ctrl}
+	mock.recorder = &MockGenreSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreSource) EXPECT() *MockGenreSourceMockRecorder {
+	return m.recorder
+}
+
+// GetGenreByID mocks base method.
+func (m *MockGenreSource) GetGenreByID(ctx context.Context, id uuid.UUID) (models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreByID", ctx, id)
+	ret0, _ := ret[0].(models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreByID indicates an expected call of GetGenreByID.
+func (mr *MockGenreSourceMockRecorder) GetGenreByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreByID", reflect.TypeOf((*MockGenreSource)(nil).GetGenreByID), ctx, id)
+}
@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/actors"
+	"kinopoisk/internal/pkg/films"
+	"kinopoisk/internal/pkg/genres"
+	"kinopoisk/internal/pkg/meta"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	siteName = "DDFilms"
+	locale   = "ru_RU"
+	// descriptionLength больше поисковики и соцсети всё равно обрезают
+	descriptionLength = 200
+	// movieActors сколько актеров из начала титров попадает в JSON-LD фильма
+	movieActors = 10
+)
+
+type MetaUsecase struct {
+	films   meta.FilmSource
+	actors  meta.ActorSource
+	genres  meta.GenreSource
+	baseURL string
+}
+
+func NewMetaUsecase(films meta.FilmSource, actors meta.ActorSource, genres meta.GenreSource, baseURL string) *MetaUsecase {
+	return &MetaUsecase{films: films, actors: actors, genres: genres, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// parsePath принимает путь страницы SPA вида /films/{id}; query и фрагмент отбрасываются
+func parsePath(path string) (string, uuid.UUID, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", uuid.Nil, meta.ErrorBadRequest
+	}
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return "", uuid.Nil, meta.ErrorBadRequest
+	}
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) != 2 {
+		return "", uuid.Nil, meta.ErrorNotFound
+	}
+	id, err := uuid.FromString(parts[1])
+	if err != nil {
+		return "", uuid.Nil, meta.ErrorNotFound
+	}
+	return parts[0], id, nil
+}
+
+func sourceError(err error) error {
+	if errors.Is(err, films.ErrorNotFound) || errors.Is(err, actors.ErrorNotFound) || errors.Is(err, genres.ErrorNotFound) {
+		return meta.ErrorNotFound
+	}
+	return meta.ErrorInternalServerError
+}
+
+func truncate(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
+
+func (uc *MetaUsecase) staticURL(path string) string {
+	if path == "" {
+		return ""
+	}
+	return uc.baseURL + "/static/" + strings.TrimPrefix(path, "/")
+}
+
+func (uc *MetaUsecase) GetPageMeta(ctx context.Context, path string) (models.PageMeta, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	kind, id, err := parsePath(path)
+	if err != nil {
+		logger.Info("unsupported page path", slog.String("path", path))
+		return models.PageMeta{}, err
+	}
+
+	var result models.PageMeta
+	switch kind {
+	case "films":
+		result, err = uc.filmMeta(ctx, id)
+	case "actors":
+		result, err = uc.actorMeta(ctx, id)
+	case "genres":
+		result, err = uc.genreMeta(ctx, id)
+	default:
+		return models.PageMeta{}, meta.ErrorNotFound
+	}
+	if err != nil {
+		return models.PageMeta{}, sourceError(err)
+	}
+	logger.Info("succesfully built page meta", slog.String("path", path))
+	return result, nil
+}
+
+// page собирает общие для всех страниц теги OpenGraph и Twitter
+func (uc *MetaUsecase) page(ogType, title, description, canonical, image string) models.PageMeta {
+	tags := []models.MetaTag{
+		{Property: "og:type", Content: ogType},
+		{Property: "og:site_name", Content: siteName},
+		{Property: "og:locale", Content: locale},
+		{Property: "og:title", Content: title},
+		{Property: "og:description", Content: description},
+		{Property: "og:url", Content: canonical},
+	}
+	card := "summary"
+	if image != "" {
+		tags = append(tags, models.MetaTag{Property: "og:image", Content: image})
+		card = "summary_large_image"
+	}
+	tags = append(tags,
+		models.MetaTag{Name: "twitter:card", Content: card},
+		models.MetaTag{Name: "twitter:title", Content: title},
+		models.MetaTag{Name: "twitter:description", Content: description},
+	)
+	if image != "" {
+		tags = append(tags, models.MetaTag{Name: "twitter:image", Content: image})
+	}
+	return models.PageMeta{
+		Title:       title + " — " + siteName,
+		Description: description,
+		Canonical:   canonical,
+		Image:       image,
+		Tags:        tags,
+	}
+}
+
+func (uc *MetaUsecase) person(member models.CrewMember) models.SchemaPerson {
+	return models.SchemaPerson{Type: "Person", Name: member.RussianName, URL: uc.baseURL + "/actors/" + member.ID.String()}
+}
+
+func (uc *MetaUsecase) filmMeta(ctx context.Context, id uuid.UUID) (models.PageMeta, error) {
+	film, err := uc.films.GetFilmPage(ctx, id)
+	if err != nil {
+		return models.PageMeta{}, err
+	}
+
+	title := fmt.Sprintf("%s (%d)", film.Title, film.Year)
+	description := film.ShortDescription
+	if description == "" {
+		description = film.Description
+	}
+	canonical := uc.baseURL + "/films/" + film.ID.String()
+	result := uc.page("video.movie", title, truncate(description, descriptionLength), canonical, uc.staticURL(film.Poster))
+
+	movie := models.SchemaMovie{
+		Context:         "https://schema.org",
+		Type:            "Movie",
+		Name:            film.Title,
+		URL:             canonical,
+		Image:           result.Image,
+		Description:     result.Description,
+		DateCreated:     fmt.Sprintf("%d", film.Year),
+		Genre:           film.Genre,
+		ContentRating:   film.AgeCategory,
+		CountryOfOrigin: film.Country,
+	}
+	if film.OriginalTitle != nil {
+		movie.AlternateName = *film.OriginalTitle
+	}
+	if film.Duration > 0 {
+		movie.Duration = fmt.Sprintf("PT%dM", film.Duration)
+	}
+	for _, group := range film.CastAndCrew {
+		switch group.Role {
+		case models.CrewRoleDirector:
+			for _, member := range group.Members {
+				movie.Director = append(movie.Director, uc.person(member))
+			}
+		case models.CrewRoleActor:
+			for _, member := range group.Members[:min(len(group.Members), movieActors)] {
+				movie.Actor = append(movie.Actor, uc.person(member))
+			}
+		}
+	}
+	// поисковики отклоняют aggregateRating без оценок
+	if film.NumberOfRatings > 0 {
+		movie.AggregateRating = &models.SchemaAggregateRating{
+			Type:        "AggregateRating",
+			RatingValue: film.Rating,
+			RatingCount: film.NumberOfRatings,
+			BestRating:  10,
+			WorstRating: 1,
+		}
+	}
+	result.JSONLD = movie
+	return result, nil
+}
+
+func (uc *MetaUsecase) actorMeta(ctx context.Context, id uuid.UUID) (models.PageMeta, error) {
+	actor, err := uc.actors.GetActorByID(ctx, id)
+	if err != nil {
+		return models.PageMeta{}, err
+	}
+
+	description := actor.RussianName
+	if !actor.BirthDate.IsZero() {
+		description += ", род. " + actor.BirthDate.Format("02.01.2006")
+	}
+	if actor.BirthPlace != "" {
+		description += ", " + actor.BirthPlace
+	}
+	canonical := uc.baseURL + "/actors/" + actor.ID.String()
+	result := uc.page("profile", actor.RussianName, truncate(description, descriptionLength), canonical, uc.staticURL(actor.Photo))
+
+	person := models.SchemaPerson{
+		Context:    "https://schema.org",
+		Type:       "Person",
+		Name:       actor.RussianName,
+		URL:        canonical,
+		Image:      result.Image,
+		BirthPlace: actor.BirthPlace,
+	}
+	if actor.OriginalName != nil {
+		person.AlternateName = *actor.OriginalName
+	}
+	if !actor.BirthDate.IsZero() {
+		person.BirthDate = actor.BirthDate.Format("2006-01-02")
+	}
+	if actor.DeathDate != nil {
+		person.DeathDate = actor.DeathDate.Format("2006-01-02")
+	}
+	if actor.Height > 0 {
+		person.Height = fmt.Sprintf("%d cm", actor.Height)
+	}
+	result.JSONLD = person
+	return result, nil
+}
+
+func (uc *MetaUsecase) genreMeta(ctx context.Context, id uuid.UUID) (models.PageMeta, error) {
+	genre, err := uc.genres.GetGenreByID(ctx, id)
+	if err != nil {
+		return models.PageMeta{}, err
+	}
+
+	canonical := uc.baseURL + "/genres/" + genre.ID.String()
+	return uc.page("website", genre.Title, truncate(genre.Description, descriptionLength), canonical, ""), nil
+}
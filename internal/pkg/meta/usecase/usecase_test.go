@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/actors"
+	"kinopoisk/internal/pkg/films"
+	"kinopoisk/internal/pkg/meta"
+	"kinopoisk/internal/pkg/meta/mocks"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func tagContent(tags []models.MetaTag, key string) string {
+	for _, tag := range tags {
+		if tag.Property == key || tag.Name == key {
+			return tag.Content
+		}
+	}
+	return ""
+}
+
+func newTestUsecase(ctrl *gomock.Controller) (*MetaUsecase, *mocks.MockFilmSource, *mocks.MockActorSource, *mocks.MockGenreSource) {
+	filmSource := mocks.NewMockFilmSource(ctrl)
+	actorSource := mocks.NewMockActorSource(ctrl)
+	genreSource := mocks.NewMockGenreSource(ctrl)
+	return NewMetaUsecase(filmSource, actorSource, genreSource, "https://ddfilms.online/"), filmSource, actorSource, genreSource
+}
+
+func TestMetaUsecase_GetPageMeta_Film(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, filmSource, _, _ := newTestUsecase(ctrl)
+
+	filmID := uuid.NewV4()
+	directorID := uuid.NewV4()
+	original := "Interstellar"
+	actorsGroup := models.CrewGroup{Role: models.CrewRoleActor}
+	for i := 0; i < movieActors+2; i++ {
+		actorsGroup.Members = append(actorsGroup.Members, models.CrewMember{ID: uuid.NewV4(), RussianName: "Актер"})
+	}
+	film := models.FilmPage{
+		ID:               filmID,
+		Title:            "Интерстеллар",
+		OriginalTitle:    &original,
+		Poster:           "posters/interstellar.webp",
+		ShortDescription: strings.Repeat("космос ", 100),
+		AgeCategory:      "12+",
+		Year:             2014,
+		Genre:            "Фантастика",
+		Duration:         169,
+		Rating:           8.6,
+		NumberOfRatings:  1200,
+		CastAndCrew: []models.CrewGroup{
+			{Role: models.CrewRoleDirector, Members: []models.CrewMember{{ID: directorID, RussianName: "Кристофер Нолан"}}},
+			actorsGroup,
+		},
+	}
+	filmSource.EXPECT().GetFilmPage(gomock.Any(), filmID).Return(film, nil)
+
+	result, err := uc.GetPageMeta(testContext(), "/films/"+filmID.String()+"?tab=reviews")
+	assert.NoError(t, err)
+
+	canonical := "https://ddfilms.online/films/" + filmID.String()
+	assert.Equal(t, "Интерстеллар (2014) — DDFilms", result.Title)
+	assert.Equal(t, canonical, result.Canonical)
+	assert.Equal(t, descriptionLength, utf8.RuneCountInString(result.Description))
+	assert.Equal(t, "https://ddfilms.online/static/posters/interstellar.webp", result.Image)
+	assert.Equal(t, "video.movie", tagContent(result.Tags, "og:type"))
+	assert.Equal(t, canonical, tagContent(result.Tags, "og:url"))
+	assert.Equal(t, result.Image, tagContent(result.Tags, "og:image"))
+	assert.Equal(t, "summary_large_image", tagContent(result.Tags, "twitter:card"))
+
+	movie, ok := result.JSONLD.(models.SchemaMovie)
+	assert.True(t, ok)
+	assert.Equal(t, "Movie", movie.Type)
+	assert.Equal(t, original, movie.AlternateName)
+	assert.Equal(t, "PT169M", movie.Duration)
+	assert.Len(t, movie.Director, 1)
+	assert.Equal(t, "https://ddfilms.online/actors/"+directorID.String(), movie.Director[0].URL)
+	assert.Len(t, movie.Actor, movieActors)
+	assert.Equal(t, &models.SchemaAggregateRating{
+		Type: "AggregateRating", RatingValue: 8.6, RatingCount: 1200, BestRating: 10, WorstRating: 1,
+	}, movie.AggregateRating)
+}
+
+func TestMetaUsecase_GetPageMeta_FilmWithoutRatings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, filmSource, _, _ := newTestUsecase(ctrl)
+
+	filmID := uuid.NewV4()
+	filmSource.EXPECT().GetFilmPage(gomock.Any(), filmID).Return(models.FilmPage{ID: filmID, Title: "Новинка", Description: "Описание"}, nil)
+
+	result, err := uc.GetPageMeta(testContext(), "/films/"+filmID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "Описание", result.Description)
+	assert.Equal(t, "summary", tagContent(result.Tags, "twitter:card"))
+	assert.Nil(t, result.JSONLD.(models.SchemaMovie).AggregateRating)
+}
+
+func TestMetaUsecase_GetPageMeta_Actor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, _, actorSource, _ := newTestUsecase(ctrl)
+
+	actorID := uuid.NewV4()
+	death := time.Date(2020, 5, 2, 0, 0, 0, 0, time.UTC)
+	actorSource.EXPECT().GetActorByID(gomock.Any(), actorID).Return(models.Actor{
+		ID:          actorID,
+		RussianName: "Актер",
+		Photo:       "actors/photo.webp",
+		Height:      180,
+		BirthDate:   time.Date(1950, 1, 9, 0, 0, 0, 0, time.UTC),
+		DeathDate:   &death,
+		BirthPlace:  "Москва",
+	}, nil)
+
+	result, err := uc.GetPageMeta(testContext(), "/actors/"+actorID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "Актер, род. 09.01.1950, Москва", result.Description)
+	assert.Equal(t, "profile", tagContent(result.Tags, "og:type"))
+
+	person, ok := result.JSONLD.(models.SchemaPerson)
+	assert.True(t, ok)
+	assert.Equal(t, "1950-01-09", person.BirthDate)
+	assert.Equal(t, "2020-05-02", person.DeathDate)
+	assert.Equal(t, "180 cm", person.Height)
+}
+
+func TestMetaUsecase_GetPageMeta_Genre(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, _, _, genreSource := newTestUsecase(ctrl)
+
+	genreID := uuid.NewV4()
+	genreSource.EXPECT().GetGenreByID(gomock.Any(), genreID).Return(models.Genre{ID: genreID, Title: "Драма", Description: "Фильмы\nо жизни"}, nil)
+
+	result, err := uc.GetPageMeta(testContext(), "/genres/"+genreID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "Фильмы о жизни", result.Description)
+	assert.Equal(t, "website", tagContent(result.Tags, "og:type"))
+	assert.Nil(t, result.JSONLD)
+}
+
+func TestMetaUsecase_GetPageMeta_Errors(t *testing.T) {
+	id := uuid.NewV4()
+
+	tests := []struct {
+		name      string
+		path      string
+		setupMock func(filmSource *mocks.MockFilmSource, actorSource *mocks.MockActorSource)
+		expectErr error
+	}{
+		{name: "Empty path", path: "", expectErr: meta.ErrorBadRequest},
+		{name: "Absolute URL", path: "https://evil.example/films/" + id.String(), expectErr: meta.ErrorBadRequest},
+		{name: "Unknown section", path: "/users/" + id.String(), expectErr: meta.ErrorNotFound},
+		{name: "Invalid id", path: "/films/abc", expectErr: meta.ErrorNotFound},
+		{name: "Nested path", path: "/films/" + id.String() + "/reviews", expectErr: meta.ErrorNotFound},
+		{
+			name: "Film not found",
+			path: "/films/" + id.String(),
+			setupMock: func(filmSource *mocks.MockFilmSource, actorSource *mocks.MockActorSource) {
+				filmSource.EXPECT().GetFilmPage(gomock.Any(), id).Return(models.FilmPage{}, films.ErrorNotFound)
+			},
+			expectErr: meta.ErrorNotFound,
+		},
+		{
+			name: "Actor source failure",
+			path: "/actors/" + id.String(),
+			setupMock: func(filmSource *mocks.MockFilmSource, actorSource *mocks.MockActorSource) {
+				actorSource.EXPECT().GetActorByID(gomock.Any(), id).Return(models.Actor{}, actors.ErrorInternalServerError)
+			},
+			expectErr: meta.ErrorInternalServerError,
+		},
+		{
+			name: "Unexpected error",
+			path: "/films/" + id.String(),
+			setupMock: func(filmSource *mocks.MockFilmSource, actorSource *mocks.MockActorSource) {
+				filmSource.EXPECT().GetFilmPage(gomock.Any(), id).Return(models.FilmPage{}, errors.New("boom"))
+			},
+			expectErr: meta.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			uc, filmSource, actorSource, _ := newTestUsecase(ctrl)
+			if tt.setupMock != nil {
+				tt.setupMock(filmSource, actorSource)
+			}
+
+			_, err := uc.GetPageMeta(testContext(), tt.path)
+			assert.ErrorIs(t, err, tt.expectErr)
+		})
+	}
+}
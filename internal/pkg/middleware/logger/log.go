@@ -12,15 +12,45 @@ import (
 type contextKey string
 
 const (
-	LoggerKey contextKey = "logger"
+	LoggerKey    contextKey = "logger"
+	RequestIDKey contextKey = "request_id"
+
+	RequestIDHeader = "X-Request-ID"
+	maxRequestID    = 64
 )
 
+// validRequestID пропускает ID от nginx ($request_id) и похожие, чтобы в логи
+// и ответы не попадали произвольные строки от клиента
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestID {
+		return false
+	}
+	for _, char := range id {
+		if !(char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' || char == '-' || char == '_') {
+			return false
+		}
+	}
+	return true
+}
+
 func LoggerMiddleware(logger *slog.Logger) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := context.WithValue(r.Context(), LoggerKey, logger.With(slog.String("ID", uuid.NewV4().String())))
+			id := r.Header.Get(RequestIDHeader)
+			if !validRequestID(id) {
+				id = uuid.NewV4().String()
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			ctx := context.WithValue(r.Context(), LoggerKey, logger.With(slog.String("ID", id)))
+			ctx = context.WithValue(ctx, RequestIDKey, id)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey).(string)
+	return id
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"errors"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+type NotificationHandler struct {
+	uc notifications.NotificationUsecase
+}
+
+func NewNotificationHandler(uc notifications.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{uc: uc}
+}
+
+// GetNotifications godoc
+// @Summary      Get notifications of current user, newest first
+// @Tags         notifications
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from previous page"
+// @Param        count   query  int  false  "Number of notifications"
+// @Success      200  {object}  models.NotificationPage
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /notifications [get]
+func (n *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	cursor, err := helpers.GetCursorFromRequest(r)
+	if err != nil {
+		log.LogHandlerError(logger, err, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	page, err := n.uc.GetNotifications(r.Context(), cursor, helpers.GetPagerFromRequest(r).Count)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	page.Sanitize()
+
+	helpers.WriteJSON(w, page)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetUnreadCount godoc
+// @Summary      Get number of unread notifications
+// @Tags         notifications
+// @Produce      json
+// @Success      200  {object}  models.UnreadCount
+// @Failure      401 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /notifications/unread [get]
+func (n *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	count, err := n.uc.GetUnreadCount(r.Context())
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	helpers.WriteJSON(w, models.UnreadCount{UnreadCount: count})
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// MarkRead godoc
+// @Summary      Mark notification as read
+// @Tags         notifications
+// @Param        id   path      string  true  "Notification ID"
+// @Success      200
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /notifications/{id}/read [post]
+func (n *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of notification"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	if err := n.uc.MarkRead(r.Context(), id); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// MarkAllRead godoc
+// @Summary      Mark all notifications of current user as read
+// @Tags         notifications
+// @Success      200
+// @Failure      401 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /notifications/read-all [post]
+func (n *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	if err := n.uc.MarkAllRead(r.Context()); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
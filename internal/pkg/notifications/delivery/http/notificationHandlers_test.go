@@ -0,0 +1,149 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/notifications/mocks"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestGetNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockNotificationUsecase(ctrl)
+	handler := NewNotificationHandler(mockUsecase)
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			url:  "/notifications?count=5",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetNotifications(gomock.Any(), (*models.Cursor)(nil), 5).Return(models.NotificationPage{
+					Items: []models.Notification{{
+						ID:      uuid.NewV4(),
+						Type:    models.NotificationFilmReview,
+						Payload: models.NotificationPayload{FilmTitle: "<i>Дюна</i>"},
+					}},
+					UnreadCount: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Malformed cursor",
+			url:            "/notifications?cursor=bm9wZQ",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Internal error",
+			url:  "/notifications",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetNotifications(gomock.Any(), (*models.Cursor)(nil), 10).
+					Return(models.NotificationPage{}, notifications.ErrorInternalServerError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			handler.GetNotifications(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var decoded models.NotificationPage
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+				assert.Equal(t, 1, decoded.UnreadCount)
+				assert.Equal(t, "&lt;i&gt;Дюна&lt;/i&gt;", decoded.Items[0].Payload.FilmTitle)
+			}
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockNotificationUsecase(ctrl)
+	handler := NewNotificationHandler(mockUsecase)
+	notificationID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		varsID         string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			varsID: notificationID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().MarkRead(gomock.Any(), notificationID).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			varsID:         "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Not found",
+			varsID: notificationID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().MarkRead(gomock.Any(), notificationID).Return(notifications.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/notifications/"+tt.varsID+"/read", nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/notifications/{id}/read", handler.MarkRead)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
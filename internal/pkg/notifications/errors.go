@@ -0,0 +1,10 @@
+package notifications
+
+import "errors"
+
+var (
+	ErrorBadRequest          = errors.New("bad request")
+	ErrorUnauthorized        = errors.New("user is unauthorized")
+	ErrorNotFound            = errors.New("not found")
+	ErrorInternalServerError = errors.New("internal server error")
+)
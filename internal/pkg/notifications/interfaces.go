@@ -0,0 +1,36 @@
+package notifications
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// Producer принимает события от других usecase. Продюсеры не знают, кому и как
+// будут доставлены уведомления, и не получают ошибок доставки
+type Producer interface {
+	Notify(ctx context.Context, event models.NotificationEvent)
+}
+
+// Channel дополнительный способ доставки уже сохранённых во входящие уведомлений
+type Channel interface {
+	Deliver(ctx context.Context, notifications []models.Notification)
+}
+
+type NotificationUsecase interface {
+	Producer
+	GetNotifications(ctx context.Context, cursor *models.Cursor, count int) (models.NotificationPage, error)
+	GetUnreadCount(ctx context.Context) (int, error)
+	MarkRead(ctx context.Context, id uuid.UUID) error
+	MarkAllRead(ctx context.Context) error
+}
+
+type NotificationRepo interface {
+	GetFilmRaters(ctx context.Context, filmID, excludeUserID uuid.UUID) ([]uuid.UUID, error)
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error
+	GetNotifications(ctx context.Context, userID uuid.UUID, cursor *models.Cursor, limit int) ([]models.Notification, error)
+	GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,154 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgtype/pgxtype"
+	uuid "github.com/satori/go.uuid"
+)
+
+type NotificationRepository struct {
+	db pgxtype.Querier
+}
+
+func NewNotificationRepository(db pgxtype.Querier) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// notificationRecord строка для jsonb_to_recordset при пакетной вставке
+type notificationRecord struct {
+	ID        uuid.UUID                  `json:"id"`
+	UserID    uuid.UUID                  `json:"user_id"`
+	Type      string                     `json:"type"`
+	Payload   models.NotificationPayload `json:"payload"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+func (r *NotificationRepository) GetFilmRaters(ctx context.Context, filmID, excludeUserID uuid.UUID) ([]uuid.UUID, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, GetFilmRatersQuery, filmID, excludeUserID)
+	if err != nil {
+		logger.Error("failed to query raters of film: " + err.Error())
+		return nil, notifications.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	result := []uuid.UUID{}
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			logger.Error("failed to scan rater of film: " + err.Error())
+			return nil, notifications.ErrorInternalServerError
+		}
+		result = append(result, userID)
+	}
+	return result, nil
+}
+
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, batch []models.Notification) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	records := make([]notificationRecord, 0, len(batch))
+	for _, n := range batch {
+		records = append(records, notificationRecord{
+			ID:        n.ID,
+			UserID:    n.UserID,
+			Type:      n.Type,
+			Payload:   n.Payload,
+			CreatedAt: n.CreatedAt,
+		})
+	}
+	encoded, err := json.Marshal(records)
+	if err != nil {
+		logger.Error("failed to encode notifications: " + err.Error())
+		return notifications.ErrorInternalServerError
+	}
+
+	if _, err := r.db.Exec(ctx, CreateNotificationsQuery, encoded); err != nil {
+		logger.Error("failed to insert notifications: " + err.Error())
+		return notifications.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully created notifications in db")
+	return nil
+}
+
+func (r *NotificationRepository) GetNotifications(ctx context.Context, userID uuid.UUID, cursor *models.Cursor, limit int) ([]models.Notification, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var cursorTime *time.Time
+	var cursorID uuid.NullUUID
+	if cursor != nil {
+		cursorTime = &cursor.CreatedAt
+		cursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := r.db.Query(ctx, GetNotificationsQuery, userID, cursorTime, cursorID, limit)
+	if err != nil {
+		logger.Error("failed to query notifications: " + err.Error())
+		return nil, notifications.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	result := []models.Notification{}
+	for rows.Next() {
+		n := models.Notification{UserID: userID}
+		var payload []byte
+		if err := rows.Scan(&n.ID, &n.Type, &payload, &n.Read, &n.CreatedAt); err != nil {
+			logger.Error("failed to scan notification: " + err.Error())
+			return nil, notifications.ErrorInternalServerError
+		}
+		if err := json.Unmarshal(payload, &n.Payload); err != nil {
+			logger.Error("failed to decode payload of notification: " + err.Error())
+			return nil, notifications.ErrorInternalServerError
+		}
+		result = append(result, n)
+	}
+
+	logger.Info("succesfully got notifications from db")
+	return result, nil
+}
+
+func (r *NotificationRepository) GetUnreadCount(ctx context.Context, userID uuid.UUID) (int, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var count int
+	if err := r.db.QueryRow(ctx, GetUnreadCountQuery, userID).Scan(&count); err != nil {
+		logger.Error("failed to count unread notifications: " + err.Error())
+		return 0, notifications.ErrorInternalServerError
+	}
+	return count, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := r.db.Exec(ctx, MarkReadQuery, userID, id)
+	if err != nil {
+		logger.Error("failed to mark notification as read: " + err.Error())
+		return notifications.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("notification not exists")
+		return notifications.ErrorNotFound
+	}
+
+	logger.Info("succesfully marked notification as read in db")
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if _, err := r.db.Exec(ctx, MarkAllReadQuery, userID); err != nil {
+		logger.Error("failed to mark notifications as read: " + err.Error())
+		return notifications.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully marked all notifications as read in db")
+	return nil
+}
@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/notifications"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestCreateNotifications(t *testing.T) {
+	filmID := uuid.NewV4()
+	batch := []models.Notification{{
+		ID:        uuid.NewV4(),
+		UserID:    uuid.NewV4(),
+		Type:      models.NotificationFilmReview,
+		Payload:   models.NotificationPayload{FilmID: &filmID, FilmTitle: "Дюна"},
+		CreatedAt: time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC),
+	}}
+
+	tests := []struct {
+		name    string
+		execErr error
+		wantErr error
+	}{
+		{name: "Success"},
+		{name: "Database error", execErr: errors.New("db error"), wantErr: notifications.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), CreateNotificationsQuery, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, args ...interface{}) (pgconn.CommandTag, error) {
+					var records []map[string]interface{}
+					assert.NoError(t, json.Unmarshal(args[0].([]byte), &records))
+					assert.Len(t, records, 1)
+					assert.Equal(t, batch[0].UserID.String(), records[0]["user_id"])
+					assert.Equal(t, models.NotificationFilmReview, records[0]["type"])
+					assert.Equal(t, "Дюна", records[0]["payload"].(map[string]interface{})["film_title"])
+					return pgconn.CommandTag("INSERT 0 1"), tt.execErr
+				})
+
+			repo := NewNotificationRepository(mockPool)
+			err := repo.CreateNotifications(testContext(), batch)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetNotifications(t *testing.T) {
+	userID := uuid.NewV4()
+	notificationID := uuid.NewV4()
+	createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	cursor := &models.Cursor{CreatedAt: createdAt.Add(time.Hour), ID: uuid.NewV4()}
+	columns := []string{"id", "type", "payload", "read", "created_at"}
+
+	tests := []struct {
+		name       string
+		cursor     *models.Cursor
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		want       []models.Notification
+		wantErr    error
+	}{
+		{
+			name: "First page",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(columns).
+					AddRow(notificationID, models.NotificationSecurity, []byte(`{"action":"password_changed"}`), false, createdAt).
+					ToPgxRows()
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetNotificationsQuery, userID, (*time.Time)(nil), uuid.NullUUID{}, 11).
+					Return(rows, nil)
+			},
+			want: []models.Notification{{
+				ID:        notificationID,
+				UserID:    userID,
+				Type:      models.NotificationSecurity,
+				Payload:   models.NotificationPayload{Action: models.SecurityPasswordChanged},
+				CreatedAt: createdAt,
+			}},
+		},
+		{
+			name:   "Next page",
+			cursor: cursor,
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(columns).ToPgxRows()
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetNotificationsQuery, userID, &cursor.CreatedAt, uuid.NullUUID{UUID: cursor.ID, Valid: true}, 11).
+					Return(rows, nil)
+			},
+			want: []models.Notification{},
+		},
+		{
+			name: "Broken payload",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(columns).
+					AddRow(notificationID, models.NotificationSecurity, []byte(`{`), false, createdAt).
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetNotificationsQuery, userID, gomock.Any(), gomock.Any(), 11).Return(rows, nil)
+			},
+			wantErr: notifications.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewNotificationRepository(mockPool)
+			result, err := repo.GetNotifications(testContext(), userID, tt.cursor, 11)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	userID := uuid.NewV4()
+	notificationID := uuid.NewV4()
+
+	tests := []struct {
+		name    string
+		tag     pgconn.CommandTag
+		execErr error
+		wantErr error
+	}{
+		{name: "Success", tag: pgconn.CommandTag("UPDATE 1")},
+		{name: "Not found or foreign", tag: pgconn.CommandTag("UPDATE 0"), wantErr: notifications.ErrorNotFound},
+		{name: "Database error", execErr: errors.New("db error"), wantErr: notifications.ErrorInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			mockPool.EXPECT().Exec(gomock.Any(), MarkReadQuery, userID, notificationID).Return(tt.tag, tt.execErr)
+
+			repo := NewNotificationRepository(mockPool)
+			err := repo.MarkRead(testContext(), userID, notificationID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package repo
+
+import _ "embed"
+
+//go:embed sql/getFilmRatersQuery.sql
+var GetFilmRatersQuery string
+
+//go:embed sql/createNotificationsQuery.sql
+var CreateNotificationsQuery string
+
+//go:embed sql/getNotificationsQuery.sql
+var GetNotificationsQuery string
+
+//go:embed sql/getUnreadCountQuery.sql
+var GetUnreadCountQuery string
+
+//go:embed sql/markReadQuery.sql
+var MarkReadQuery string
+
+//go:embed sql/markAllReadQuery.sql
+var MarkAllReadQuery string
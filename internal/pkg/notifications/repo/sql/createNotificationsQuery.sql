@@ -0,0 +1,3 @@
+INSERT INTO notification (id, user_id, type, payload, created_at)
+SELECT n.id, n.user_id, n.type, n.payload, n.created_at
+FROM jsonb_to_recordset($1::jsonb) AS n(id uuid, user_id uuid, type text, payload jsonb, created_at timestamptz)
\ No newline at end of file
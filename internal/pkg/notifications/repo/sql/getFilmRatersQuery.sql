@@ -0,0 +1,3 @@
+SELECT DISTINCT user_id
+FROM film_feedback
+WHERE film_id = $1 AND user_id <> $2
\ No newline at end of file
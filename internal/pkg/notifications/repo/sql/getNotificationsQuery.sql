@@ -0,0 +1,6 @@
+SELECT id, type, payload, read_at IS NOT NULL, created_at
+FROM notification
+WHERE user_id = $1
+    AND ($2::timestamptz IS NULL OR (created_at, id) < ($2::timestamptz, $3::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT $4
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+FROM notification
+WHERE user_id = $1 AND read_at IS NULL
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE notification
+SET read_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND read_at IS NULL
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE notification
+SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+WHERE id = $2 AND user_id = $1
\ No newline at end of file
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type NotificationUsecase struct {
+	notificationRepo notifications.NotificationRepo
+	channels         []notifications.Channel
+}
+
+func NewNotificationUsecase(repo notifications.NotificationRepo, channels ...notifications.Channel) *NotificationUsecase {
+	return &NotificationUsecase{notificationRepo: repo, channels: channels}
+}
+
+func currentUser(ctx context.Context) (models.User, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("user is unauthorized")
+		return models.User{}, notifications.ErrorUnauthorized
+	}
+	return user, nil
+}
+
+// recipients определяет получателей и содержимое уведомления для события
+func (uc *NotificationUsecase) recipients(ctx context.Context, event models.NotificationEvent) ([]uuid.UUID, models.NotificationPayload, error) {
+	switch e := event.(type) {
+	case models.FilmReviewEvent:
+		users, err := uc.notificationRepo.GetFilmRaters(ctx, e.FilmID, e.AuthorID)
+		return users, models.NotificationPayload{
+			FilmID:      &e.FilmID,
+			FilmTitle:   e.FilmTitle,
+			ReviewID:    &e.ReviewID,
+			AuthorID:    &e.AuthorID,
+			AuthorLogin: e.AuthorLogin,
+		}, err
+	case models.FilmRatingShiftEvent:
+		users, err := uc.notificationRepo.GetFilmRaters(ctx, e.FilmID, e.AuthorID)
+		return users, models.NotificationPayload{
+			FilmID:    &e.FilmID,
+			FilmTitle: e.FilmTitle,
+			OldRating: &e.OldRating,
+			NewRating: &e.NewRating,
+		}, err
+	case models.SecurityEvent:
+		return []uuid.UUID{e.UserID}, models.NotificationPayload{Action: e.Action}, nil
+	default:
+		return nil, models.NotificationPayload{}, notifications.ErrorBadRequest
+	}
+}
+
+func (uc *NotificationUsecase) Notify(ctx context.Context, event models.NotificationEvent) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	users, payload, err := uc.recipients(ctx, event)
+	if err != nil {
+		logger.Error("failed to resolve recipients", "type", event.NotificationType(), "error", err)
+		return
+	}
+	if len(users) == 0 {
+		return
+	}
+
+	createdAt := time.Now().UTC()
+	batch := make([]models.Notification, 0, len(users))
+	for _, userID := range users {
+		batch = append(batch, models.Notification{
+			ID:        uuid.NewV4(),
+			UserID:    userID,
+			Type:      event.NotificationType(),
+			Payload:   payload,
+			CreatedAt: createdAt,
+		})
+	}
+
+	if err := uc.notificationRepo.CreateNotifications(ctx, batch); err != nil {
+		logger.Error("failed to save notifications", "type", event.NotificationType(), "error", err)
+		return
+	}
+	for _, channel := range uc.channels {
+		channel.Deliver(ctx, batch)
+	}
+}
+
+func (uc *NotificationUsecase) GetNotifications(ctx context.Context, cursor *models.Cursor, count int) (models.NotificationPage, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return models.NotificationPage{}, err
+	}
+
+	// лишняя запись показывает, есть ли следующая страница
+	items, err := uc.notificationRepo.GetNotifications(ctx, user.ID, cursor, count+1)
+	if err != nil {
+		return models.NotificationPage{}, err
+	}
+	unread, err := uc.notificationRepo.GetUnreadCount(ctx, user.ID)
+	if err != nil {
+		return models.NotificationPage{}, err
+	}
+
+	page := models.NotificationPage{Items: items, UnreadCount: unread}
+	if len(items) > count {
+		page.Items = items[:count]
+		last := page.Items[count-1]
+		page.NextCursor = models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return page, nil
+}
+
+func (uc *NotificationUsecase) GetUnreadCount(ctx context.Context) (int, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uc.notificationRepo.GetUnreadCount(ctx, user.ID)
+}
+
+func (uc *NotificationUsecase) MarkRead(ctx context.Context, id uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.notificationRepo.MarkRead(ctx, user.ID, id)
+}
+
+func (uc *NotificationUsecase) MarkAllRead(ctx context.Context) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.notificationRepo.MarkAllRead(ctx, user.ID)
+}
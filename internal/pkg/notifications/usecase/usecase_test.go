@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/notifications/mocks"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestNotificationUsecase_Notify(t *testing.T) {
+	filmID := uuid.NewV4()
+	authorID := uuid.NewV4()
+	raters := []uuid.UUID{uuid.NewV4(), uuid.NewV4()}
+
+	tests := []struct {
+		name          string
+		event         models.NotificationEvent
+		setupMock     func(mockRepo *mocks.MockNotificationRepo)
+		wantDelivered []uuid.UUID
+		wantType      string
+	}{
+		{
+			name:  "Review goes to other raters",
+			event: models.FilmReviewEvent{FilmID: filmID, FilmTitle: "Дюна", ReviewID: uuid.NewV4(), AuthorID: authorID, AuthorLogin: "critic"},
+			setupMock: func(mockRepo *mocks.MockNotificationRepo) {
+				mockRepo.EXPECT().GetFilmRaters(gomock.Any(), filmID, authorID).Return(raters, nil)
+				mockRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+			wantDelivered: raters,
+			wantType:      models.NotificationFilmReview,
+		},
+		{
+			name:  "Rating shift goes to other raters",
+			event: models.FilmRatingShiftEvent{FilmID: filmID, OldRating: 6.1, NewRating: 7.4, AuthorID: authorID},
+			setupMock: func(mockRepo *mocks.MockNotificationRepo) {
+				mockRepo.EXPECT().GetFilmRaters(gomock.Any(), filmID, authorID).Return(raters, nil)
+				mockRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Len(2)).Return(nil)
+			},
+			wantDelivered: raters,
+			wantType:      models.NotificationRatingShift,
+		},
+		{
+			name:  "Security event goes to owner",
+			event: models.SecurityEvent{UserID: authorID, Action: models.SecurityPasswordChanged},
+			setupMock: func(mockRepo *mocks.MockNotificationRepo) {
+				mockRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Len(1)).Return(nil)
+			},
+			wantDelivered: []uuid.UUID{authorID},
+			wantType:      models.NotificationSecurity,
+		},
+		{
+			name:  "No recipients",
+			event: models.FilmReviewEvent{FilmID: filmID, AuthorID: authorID},
+			setupMock: func(mockRepo *mocks.MockNotificationRepo) {
+				mockRepo.EXPECT().GetFilmRaters(gomock.Any(), filmID, authorID).Return([]uuid.UUID{}, nil)
+			},
+		},
+		{
+			name:  "Saving fails",
+			event: models.SecurityEvent{UserID: authorID, Action: models.SecurityPasswordChanged},
+			setupMock: func(mockRepo *mocks.MockNotificationRepo) {
+				mockRepo.EXPECT().CreateNotifications(gomock.Any(), gomock.Any()).Return(notifications.ErrorInternalServerError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockNotificationRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			var delivered []models.Notification
+			channel := mocks.NewMockChannel(ctrl)
+			if tt.wantDelivered != nil {
+				channel.EXPECT().Deliver(gomock.Any(), gomock.Any()).Do(func(_ context.Context, batch []models.Notification) {
+					delivered = batch
+				})
+			}
+
+			NewNotificationUsecase(mockRepo, channel).Notify(testContext(), tt.event)
+
+			users := []uuid.UUID{}
+			for _, n := range delivered {
+				assert.Equal(t, tt.wantType, n.Type)
+				users = append(users, n.UserID)
+			}
+			if tt.wantDelivered != nil {
+				assert.Equal(t, tt.wantDelivered, users)
+			} else {
+				assert.Empty(t, users)
+			}
+		})
+	}
+}
+
+func TestNotificationUsecase_GetNotifications(t *testing.T) {
+	user := models.User{ID: uuid.NewV4(), Login: "cinephile"}
+	base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	items := make([]models.Notification, 3)
+	for i := range items {
+		items[i] = models.Notification{ID: uuid.NewV4(), Type: models.NotificationSecurity, CreatedAt: base.Add(-time.Duration(i) * time.Minute)}
+	}
+
+	t.Run("Has next page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mocks.NewMockNotificationRepo(ctrl)
+		mockRepo.EXPECT().GetNotifications(gomock.Any(), user.ID, nil, 3).Return(items, nil)
+		mockRepo.EXPECT().GetUnreadCount(gomock.Any(), user.ID).Return(7, nil)
+
+		ctx := context.WithValue(testContext(), auth.UserKey, user)
+		page, err := NewNotificationUsecase(mockRepo).GetNotifications(ctx, nil, 2)
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.Equal(t, 7, page.UnreadCount)
+		assert.Equal(t, models.Cursor{CreatedAt: items[1].CreatedAt, ID: items[1].ID}.Encode(), page.NextCursor)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		_, err := NewNotificationUsecase(mocks.NewMockNotificationRepo(ctrl)).GetNotifications(testContext(), nil, 2)
+		assert.ErrorIs(t, err, notifications.ErrorUnauthorized)
+	})
+}
+
+func TestNotificationUsecase_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := models.User{ID: uuid.NewV4()}
+	notificationID := uuid.NewV4()
+
+	mockRepo := mocks.NewMockNotificationRepo(ctrl)
+	mockRepo.EXPECT().MarkRead(gomock.Any(), user.ID, notificationID).Return(notifications.ErrorNotFound)
+
+	ctx := context.WithValue(testContext(), auth.UserKey, user)
+	err := NewNotificationUsecase(mockRepo).MarkRead(ctx, notificationID)
+	assert.ErrorIs(t, err, notifications.ErrorNotFound)
+}
@@ -0,0 +1,123 @@
+package password
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Params параметры argon2id; сохраняются в самом хэше, поэтому их можно менять без миграции
+type Params struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// DefaultParams рекомендации OWASP для argon2id с небольшим запасом по времени
+var DefaultParams = Params{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 2,
+	SaltLen: 16,
+	KeyLen:  32,
+}
+
+// старый формат: 8 байт соли и 32 байта ключа без параметров
+var legacyParams = Params{
+	Time:    1,
+	Memory:  64 * 1024,
+	Threads: 4,
+	SaltLen: 8,
+	KeyLen:  32,
+}
+
+const phcPrefix = "$argon2id$"
+
+var b64 = base64.RawStdEncoding
+
+// Hash возвращает хэш в формате PHC: $argon2id$v=19$m=65536,t=3,p=2$<соль>$<ключ>
+func Hash(plain string) ([]byte, error) {
+	return HashWithParams(plain, DefaultParams)
+}
+
+func HashWithParams(plain string, params Params) ([]byte, error) {
+	salt := make([]byte, params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := derive(plain, salt, params)
+	encoded := fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", phcPrefix, argon2.Version,
+		params.Memory, params.Time, params.Threads, b64.EncodeToString(salt), b64.EncodeToString(key))
+	return []byte(encoded), nil
+}
+
+// Verify сверяет пароль с хэшем. needsRehash означает, что пароль верный,
+// но хэш посчитан старым форматом или другими параметрами и его стоит пересохранить
+func Verify(encoded []byte, plain string) (ok bool, needsRehash bool) {
+	if !bytes.HasPrefix(encoded, []byte(phcPrefix)) {
+		return verifyLegacy(encoded, plain), true
+	}
+
+	params, salt, key, err := decode(string(encoded))
+	if err != nil {
+		return false, false
+	}
+	if subtle.ConstantTimeCompare(derive(plain, salt, params), key) != 1 {
+		return false, false
+	}
+	return true, params != DefaultParams
+}
+
+func verifyLegacy(encoded []byte, plain string) bool {
+	if len(encoded) != int(legacyParams.SaltLen+legacyParams.KeyLen) {
+		return false
+	}
+	salt, key := encoded[:legacyParams.SaltLen], encoded[legacyParams.SaltLen:]
+	// старые пароли были только ASCII, нормализация их не меняет
+	return subtle.ConstantTimeCompare(derive(plain, salt, legacyParams), key) == 1
+}
+
+func decode(encoded string) (Params, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return Params{}, nil, nil, fmt.Errorf("invalid hash format")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("unsupported argon2 version")
+	}
+
+	var params Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("invalid hash params: %w", err)
+	}
+	if params.Time == 0 || params.Threads == 0 {
+		return Params{}, nil, nil, fmt.Errorf("invalid hash params")
+	}
+
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	key, err := b64.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, fmt.Errorf("invalid key")
+	}
+	params.SaltLen = uint32(len(salt))
+	params.KeyLen = uint32(len(key))
+	return params, salt, key, nil
+}
+
+// derive нормализует пароль в NFKC, чтобы одинаково выглядящие строки с разных клавиатур давали один хэш
+func derive(plain string, salt []byte, params Params) []byte {
+	return argon2.IDKey([]byte(norm.NFKC.String(plain)), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+}
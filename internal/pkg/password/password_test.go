@@ -0,0 +1,118 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/argon2"
+)
+
+func TestHash(t *testing.T) {
+	hash, err := Hash("correct horse battery staple")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(hash), "$argon2id$v=19$m=65536,t=3,p=2$"))
+
+	other, err := Hash("correct horse battery staple")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salt must be random")
+}
+
+func TestVerify(t *testing.T) {
+	hash, err := Hash("пароль из пяти слов")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		hash        []byte
+		password    string
+		ok          bool
+		needsRehash bool
+	}{
+		{name: "Correct", hash: hash, password: "пароль из пяти слов", ok: true},
+		{name: "Wrong", hash: hash, password: "пароль из шести слов"},
+		{name: "Empty hash", hash: nil, password: "password", needsRehash: true},
+		{name: "Broken hash", hash: []byte("$argon2id$v=19$m=1,t=1$xx$yy"), password: "password"},
+		{name: "Unknown version", hash: []byte("$argon2id$v=16$m=65536,t=3,p=2$c2FsdA$a2V5"), password: "password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needsRehash := Verify(tt.hash, tt.password)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.needsRehash, needsRehash)
+			}
+		})
+	}
+}
+
+func TestVerifyNormalizesUnicode(t *testing.T) {
+	// "é" одним символом и "e" с комбинируемым акутом
+	hash, err := Hash("caf\u00e9 au lait")
+	assert.NoError(t, err)
+	ok, _ := Verify(hash, "cafe\u0301 au lait")
+	assert.True(t, ok)
+}
+
+func TestVerifyLegacy(t *testing.T) {
+	salt := []byte("12345678")
+	legacy := append(salt, argon2.IDKey([]byte("oldpass1"), salt, 1, 64*1024, 4, 32)...)
+
+	ok, needsRehash := Verify(legacy, "oldpass1")
+	assert.True(t, ok)
+	assert.True(t, needsRehash)
+
+	ok, _ = Verify(legacy, "oldpass2")
+	assert.False(t, ok)
+}
+
+func TestVerifyChangedParams(t *testing.T) {
+	weak := Params{Time: 1, Memory: 8 * 1024, Threads: 1, SaltLen: 16, KeyLen: 32}
+	hash, err := HashWithParams("long enough passphrase", weak)
+	assert.NoError(t, err)
+
+	ok, needsRehash := Verify(hash, "long enough passphrase")
+	assert.True(t, ok)
+	assert.True(t, needsRehash)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	policy, err := NewPolicy(strings.NewReader("# свой список\nddfilms2025\n"))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		expected bool
+	}{
+		{name: "Valid", login: "testuser", password: "testpass123", expected: true},
+		{name: "Passphrase with spaces", login: "testuser", password: "четыре случайных слова подряд", expected: true},
+		{name: "Max length", login: "testuser", password: strings.Repeat("ж", MaxLength), expected: true},
+		{name: "Too long", login: "testuser", password: strings.Repeat("ж", MaxLength+1), expected: false},
+		{name: "Too short", login: "testuser", password: "pass123", expected: false},
+		{name: "Control character", login: "testuser", password: "testpass\n123", expected: false},
+		{name: "Invalid UTF-8", login: "testuser", password: "testpass\xff123", expected: false},
+		{name: "Equals login", login: "TestUser1", password: "testuser1", expected: false},
+		{name: "Common password", login: "testuser", password: "Password123", expected: false},
+		{name: "Blocked by extra list", login: "testuser", password: "DDFilms2025", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, valid := policy.Validate(tt.login, tt.password)
+			assert.Equal(t, tt.expected, valid)
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policy, err := LoadPolicy("")
+	assert.NoError(t, err)
+	_, valid := policy.Validate("testuser", "qwerty123")
+	assert.False(t, valid)
+
+	_, err = LoadPolicy("/nonexistent/blocklist.txt")
+	assert.Error(t, err)
+}
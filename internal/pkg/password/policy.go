@@ -0,0 +1,95 @@
+package password
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MinLength = 8
+	MaxLength = 128
+)
+
+// blocklist.txt самые частые пароли из публичных утечек, по одному на строку
+//
+//go:embed blocklist.txt
+var defaultBlocklist string
+
+// Policy проверяет новый пароль: длина в символах, а не байтах, любые печатные
+// символы Юникода и запрет паролей из списка утечек
+type Policy struct {
+	blocked map[string]struct{}
+}
+
+// NewPolicy политика со встроенным списком и дополнительными паролями из reader, если он задан
+func NewPolicy(extra io.Reader) (*Policy, error) {
+	policy := &Policy{blocked: make(map[string]struct{})}
+	if err := policy.load(strings.NewReader(defaultBlocklist)); err != nil {
+		return nil, err
+	}
+	if extra != nil {
+		if err := policy.load(extra); err != nil {
+			return nil, err
+		}
+	}
+	return policy, nil
+}
+
+// LoadPolicy дополняет встроенный список файлом path; пустой path оставляет только встроенный
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return NewPolicy(nil)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return NewPolicy(file)
+}
+
+func (p *Policy) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.blocked[strings.ToLower(line)] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+// Validate проверяет пароль в открытом виде (до html-экранирования)
+func (p *Policy) Validate(login, password string) (string, bool) {
+	if !utf8.ValidString(password) {
+		return "Password is not valid UTF-8", false
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < MinLength || length > MaxLength {
+		return "Invalid password length", false
+	}
+
+	for _, char := range password {
+		if char != ' ' && !unicode.IsGraphic(char) {
+			return "Password contains invalid characters", false
+		}
+	}
+
+	lowered := strings.ToLower(password)
+	if login != "" && lowered == strings.ToLower(login) {
+		return "Password equals login", false
+	}
+	if p != nil {
+		if _, blocked := p.blocked[lowered]; blocked {
+			return "Password is too common", false
+		}
+	}
+	return "Ok", true
+}
@@ -0,0 +1,100 @@
+package problem
+
+import "net/http"
+
+// Коды ошибок — часть API: клиенты сравнивают их, а не текст, поэтому
+// существующие коды не переименовываются
+const (
+	CodeBadRequest          = "bad_request"
+	CodeUnauthorized        = "unauthorized"
+	CodeForbidden           = "forbidden"
+	CodeNotFound            = "not_found"
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeConflict            = "conflict"
+	CodePayloadTooLarge     = "payload_too_large"
+	CodeTooManyRequests     = "too_many_requests"
+	CodeInternal            = "internal_error"
+	CodeBadGateway          = "bad_gateway"
+	CodeServiceUnavailable  = "service_unavailable"
+	CodeValidationFailed    = "validation_failed"
+	CodeLoginInvalid        = "login_invalid"
+	CodeLoginTaken          = "login_taken"
+	CodeEmailTaken          = "email_taken"
+	CodePasswordInvalid     = "password_invalid"
+	CodeWrongPassword       = "wrong_password"
+	CodeWrongCredentials    = "wrong_credentials"
+	CodeTwoFactorRequired   = "two_factor_required"
+	CodeIdentityProvider    = "identity_provider_failed"
+	CodeRealtimeUnavailable = "realtime_unavailable"
+)
+
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusBadGateway:            CodeBadGateway,
+	http.StatusServiceUnavailable:    CodeServiceUnavailable,
+}
+
+const (
+	langRu = "ru"
+	langEn = "en"
+
+	defaultLang = langRu
+)
+
+// messages тексты для людей по языкам; язык без перевода кода получает текст defaultLang
+var messages = map[string]map[string]string{
+	langRu: {
+		CodeBadRequest:          "Некорректный запрос",
+		CodeUnauthorized:        "Требуется авторизация",
+		CodeForbidden:           "Недостаточно прав",
+		CodeNotFound:            "Не найдено",
+		CodeMethodNotAllowed:    "Метод не поддерживается",
+		CodeConflict:            "Данные были изменены или уже существуют",
+		CodePayloadTooLarge:     "Слишком большой запрос",
+		CodeTooManyRequests:     "Слишком много запросов, попробуйте позже",
+		CodeInternal:            "Внутренняя ошибка сервера",
+		CodeBadGateway:          "Внешний сервис не ответил",
+		CodeServiceUnavailable:  "Сервис временно недоступен",
+		CodeValidationFailed:    "Проверьте заполнение полей",
+		CodeLoginInvalid:        "Логин должен быть от 6 до 15 символов из латинских букв, цифр и знаков",
+		CodeLoginTaken:          "Этот логин уже занят",
+		CodeEmailTaken:          "Этот адрес уже подтверждён другим пользователем",
+		CodePasswordInvalid:     "Пароль не подходит: слишком короткий, слишком распространённый или совпадает с логином",
+		CodeWrongPassword:       "Неверный пароль",
+		CodeWrongCredentials:    "Неверный логин или пароль",
+		CodeTwoFactorRequired:   "Требуется второй фактор",
+		CodeIdentityProvider:    "Не удалось войти через внешний сервис",
+		CodeRealtimeUnavailable: "Обновления в реальном времени недоступны",
+	},
+	langEn: {
+		CodeBadRequest:          "Bad request",
+		CodeUnauthorized:        "Authorization required",
+		CodeForbidden:           "Insufficient permissions",
+		CodeNotFound:            "Not found",
+		CodeMethodNotAllowed:    "Method not allowed",
+		CodeConflict:            "Data was modified or already exists",
+		CodePayloadTooLarge:     "Request is too large",
+		CodeTooManyRequests:     "Too many requests, try again later",
+		CodeInternal:            "Internal server error",
+		CodeBadGateway:          "Upstream service failed",
+		CodeServiceUnavailable:  "Service is temporarily unavailable",
+		CodeValidationFailed:    "Some fields are invalid",
+		CodeLoginInvalid:        "Login must be 6 to 15 latin letters, digits or symbols",
+		CodeLoginTaken:          "This login is already taken",
+		CodeEmailTaken:          "This email is already verified by another user",
+		CodePasswordInvalid:     "Password is too short, too common or equals the login",
+		CodeWrongPassword:       "Wrong password",
+		CodeWrongCredentials:    "Wrong login or password",
+		CodeTwoFactorRequired:   "Second factor is required",
+		CodeIdentityProvider:    "Sign in with the external service failed",
+		CodeRealtimeUnavailable: "Realtime updates are unavailable",
+	},
+}
@@ -0,0 +1,107 @@
+package problem
+
+import (
+	"errors"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/actors"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/countries"
+	"kinopoisk/internal/pkg/feeds"
+	"kinopoisk/internal/pkg/films"
+	"kinopoisk/internal/pkg/genres"
+	"kinopoisk/internal/pkg/meta"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/realtime"
+	"kinopoisk/internal/pkg/sitemap"
+	"kinopoisk/internal/pkg/social"
+	"kinopoisk/internal/pkg/users"
+	"net/http"
+)
+
+type mapping struct {
+	errs   []error
+	status int
+	code   string
+	// field поле запроса, к которому относится ошибка, если она про одно поле
+	field string
+}
+
+// domainErrors единственное место, где ошибки домена превращаются в статус и код ответа.
+// Уточнения оборачивают общие ошибки своего пакета, поэтому идут раньше них;
+// всё, что не нашлось, отдаётся как internal_error
+var domainErrors = []mapping{
+	{errs: []error{auth.ErrorLoginTaken, users.ErrorLoginTaken}, status: http.StatusConflict, code: CodeLoginTaken, field: "login"},
+	{errs: []error{users.ErrorEmailTaken}, status: http.StatusConflict, code: CodeEmailTaken, field: "email"},
+	{errs: []error{auth.ErrorInvalidLogin, users.ErrorInvalidLogin}, status: http.StatusBadRequest, code: CodeLoginInvalid, field: "login"},
+	{errs: []error{auth.ErrorWeakPassword, users.ErrorWeakPassword}, status: http.StatusBadRequest, code: CodePasswordInvalid, field: "password"},
+	{errs: []error{auth.ErrorWrongPassword, users.ErrorWrongPassword}, status: http.StatusBadRequest, code: CodeWrongPassword, field: "password"},
+	{errs: []error{auth.ErrorWrongCredentials}, status: http.StatusBadRequest, code: CodeWrongCredentials},
+	{errs: []error{auth.ErrorTwoFactorRequired}, status: http.StatusUnauthorized, code: CodeTwoFactorRequired},
+	{errs: []error{admin.ErrorTwoFactorRequired}, status: http.StatusForbidden, code: CodeTwoFactorRequired},
+	{errs: []error{auth.ErrorBadGateway}, status: http.StatusBadGateway, code: CodeIdentityProvider},
+	{errs: []error{realtime.ErrorHubClosed}, status: http.StatusServiceUnavailable, code: CodeRealtimeUnavailable},
+
+	{
+		errs: []error{
+			actors.ErrorBadRequest, admin.ErrorBadRequest, auth.ErrorBadRequest, countries.ErrorBadRequest,
+			films.ErrorBadRequest, genres.ErrorBadRequest, meta.ErrorBadRequest, notifications.ErrorBadRequest,
+			realtime.ErrorBadRequest, social.ErrorBadRequest, users.ErrorBadRequest,
+		},
+		status: http.StatusBadRequest,
+		code:   CodeBadRequest,
+	},
+	{
+		errs: []error{
+			admin.ErrorUnauthorized, auth.ErrorUnauthorized, films.ErrorUnauthorized, notifications.ErrorUnauthorized,
+			realtime.ErrorUnauthorized, social.ErrorUnauthorized, users.ErrorUnauthorized,
+		},
+		status: http.StatusUnauthorized,
+		code:   CodeUnauthorized,
+	},
+	{
+		errs:   []error{admin.ErrorForbidden, auth.ErrorForbidden, social.ErrorForbidden},
+		status: http.StatusForbidden,
+		code:   CodeForbidden,
+	},
+	{
+		errs: []error{
+			actors.ErrorNotFound, admin.ErrorNotFound, auth.ErrorNotFound, countries.ErrorNotFound, feeds.ErrorNotFound,
+			films.ErrorNotFound, genres.ErrorNotFound, meta.ErrorNotFound, notifications.ErrorNotFound,
+			sitemap.ErrorNotFound, social.ErrorNotFound, users.ErrorNotFound,
+		},
+		status: http.StatusNotFound,
+		code:   CodeNotFound,
+	},
+	{
+		errs:   []error{admin.ErrorConflict, auth.ErrorConflict, users.ErrorConflict},
+		status: http.StatusConflict,
+		code:   CodeConflict,
+	},
+	{
+		errs:   []error{users.ErrorTooManyRequests},
+		status: http.StatusTooManyRequests,
+		code:   CodeTooManyRequests,
+	},
+}
+
+// resolve находит статус, код и ошибки по полям для ошибки домена
+func resolve(err error) (int, string, []models.FieldError) {
+	var validationErr *admin.ValidationError
+	if errors.As(err, &validationErr) {
+		return http.StatusBadRequest, CodeValidationFailed, validationErr.Fields
+	}
+
+	for _, m := range domainErrors {
+		for _, target := range m.errs {
+			if !errors.Is(err, target) {
+				continue
+			}
+			if m.field != "" {
+				return m.status, m.code, []models.FieldError{{Field: m.field}}
+			}
+			return m.status, m.code, nil
+		}
+	}
+	return http.StatusInternalServerError, CodeInternal, nil
+}
@@ -0,0 +1,104 @@
+package problem
+
+import (
+	"encoding/json"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ContentType = "application/problem+json; charset=utf-8"
+	typePrefix  = "urn:ddfilms:problem:"
+)
+
+// WriteError отвечает на ошибку домена: статус и код берутся из domainErrors.
+// Ошибка пишется в лог с выбранным статусом, клиент видит только код и текст
+func WriteError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error) {
+	status, code, fields := resolve(err)
+	log.LogHandlerError(logger, err, status)
+	write(w, r, status, code, fields)
+}
+
+// WriteStatus отвечает ошибкой без ошибки домена за ней: неверный JSON, id в пути,
+// отсутствие сессии. Код общий для статуса
+func WriteStatus(w http.ResponseWriter, r *http.Request, status int) {
+	code, ok := statusCodes[status]
+	if !ok {
+		code = "http_" + strconv.Itoa(status)
+	}
+	write(w, r, status, code, nil)
+}
+
+func write(w http.ResponseWriter, r *http.Request, status int, code string, fields []models.FieldError) {
+	lang := Language(r.Header.Get("Accept-Language"))
+	result := models.Problem{
+		Type:      typePrefix + code,
+		Title:     Message(lang, code),
+		Status:    status,
+		Code:      code,
+		Instance:  r.URL.Path,
+		RequestID: logger.GetRequestID(r.Context()),
+	}
+	for _, field := range fields {
+		// ошибку про одно поле объясняет текст самого кода
+		if field.Message == "" {
+			field.Message = result.Title
+		}
+		result.Errors = append(result.Errors, field)
+	}
+	if result.Title == "" {
+		result.Title = http.StatusText(status)
+	}
+	result.Sanitize()
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+// Message текст кода на языке lang; если перевода нет, берётся текст на defaultLang
+func Message(lang, code string) string {
+	if message, ok := messages[lang][code]; ok {
+		return message
+	}
+	return messages[defaultLang][code]
+}
+
+// Language выбирает из Accept-Language поддерживаемый язык с наибольшим q
+func Language(acceptLanguage string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := messages[lang]; !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return defaultLang
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
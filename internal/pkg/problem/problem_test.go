@@ -0,0 +1,160 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/admin"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/films"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/users"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testRequest(acceptLanguage string) *http.Request {
+	ctx := context.WithValue(context.Background(), logger.LoggerKey, testLogger())
+	ctx = context.WithValue(ctx, logger.RequestIDKey, "req-1")
+	r := httptest.NewRequest(http.MethodPut, "/api/users/change/login", nil).WithContext(ctx)
+	if acceptLanguage != "" {
+		r.Header.Set("Accept-Language", acceptLanguage)
+	}
+	return r
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) models.Problem {
+	var result models.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return result
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedFields []string
+	}{
+		{name: "Login taken", err: users.ErrorLoginTaken, expectedStatus: http.StatusConflict, expectedCode: CodeLoginTaken, expectedFields: []string{"login"}},
+		{name: "Email taken", err: users.ErrorEmailTaken, expectedStatus: http.StatusConflict, expectedCode: CodeEmailTaken, expectedFields: []string{"email"}},
+		{name: "Invalid login", err: auth.ErrorInvalidLogin, expectedStatus: http.StatusBadRequest, expectedCode: CodeLoginInvalid, expectedFields: []string{"login"}},
+		{name: "Weak password", err: users.ErrorWeakPassword, expectedStatus: http.StatusBadRequest, expectedCode: CodePasswordInvalid, expectedFields: []string{"password"}},
+		{name: "Wrong credentials", err: auth.ErrorWrongCredentials, expectedStatus: http.StatusBadRequest, expectedCode: CodeWrongCredentials},
+		{name: "Generic conflict", err: users.ErrorConflict, expectedStatus: http.StatusConflict, expectedCode: CodeConflict},
+		{name: "Wrapped not found", err: fmt.Errorf("load film: %w", films.ErrorNotFound), expectedStatus: http.StatusNotFound, expectedCode: CodeNotFound},
+		{name: "Forbidden", err: admin.ErrorForbidden, expectedStatus: http.StatusForbidden, expectedCode: CodeForbidden},
+		{name: "Editor without two factor", err: admin.ErrorTwoFactorRequired, expectedStatus: http.StatusForbidden, expectedCode: CodeTwoFactorRequired},
+		{
+			name: "Validation",
+			err: admin.NewValidationError(
+				models.FieldError{Field: "title", Message: "is required"},
+				models.FieldError{Field: "year", Message: "must be between 1888 and 2030"},
+			),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+			expectedFields: []string{"title", "year"},
+		},
+		{name: "Unknown error", err: errors.New("connection reset"), expectedStatus: http.StatusInternalServerError, expectedCode: CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, testRequest(""), testLogger(), tt.err)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+			result := decode(t, w)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedCode, result.Code)
+			assert.Equal(t, "urn:ddfilms:problem:"+tt.expectedCode, result.Type)
+			assert.Equal(t, Message(defaultLang, tt.expectedCode), result.Title)
+			assert.Equal(t, "/api/users/change/login", result.Instance)
+			assert.Equal(t, "req-1", result.RequestID)
+
+			fields := []string{}
+			for _, field := range result.Errors {
+				fields = append(fields, field.Field)
+				assert.NotEmpty(t, field.Message)
+			}
+			if tt.expectedFields == nil {
+				assert.Empty(t, fields)
+			} else {
+				assert.Equal(t, tt.expectedFields, fields)
+			}
+			// внутренний текст ошибки не уходит клиенту
+			assert.NotContains(t, w.Body.String(), "connection reset")
+		})
+	}
+}
+
+func TestWriteStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteStatus(w, testRequest("en-US,en;q=0.9"), http.StatusUnauthorized)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+	result := decode(t, w)
+	assert.Equal(t, CodeUnauthorized, result.Code)
+	assert.Equal(t, "Authorization required", result.Title)
+
+	w = httptest.NewRecorder()
+	WriteStatus(w, testRequest(""), http.StatusTeapot)
+	result = decode(t, w)
+	assert.Equal(t, "http_418", result.Code)
+	assert.NotEmpty(t, result.Title)
+}
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: langRu},
+		{header: "en", expected: langEn},
+		{header: "en-GB,en;q=0.8", expected: langEn},
+		{header: "de-DE,en;q=0.5,ru;q=0.7", expected: langRu},
+		{header: "ru;q=0.1,EN", expected: langEn},
+		{header: "en;q=0,fr", expected: langRu},
+		{header: "fr-FR,de", expected: langRu},
+		{header: "en;q=abc", expected: langRu},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Language(tt.header))
+		})
+	}
+}
+
+// у каждого кода, который может уйти клиенту, есть текст на каждом языке
+func TestMessagesComplete(t *testing.T) {
+	codes := []string{CodeValidationFailed}
+	for _, code := range statusCodes {
+		codes = append(codes, code)
+	}
+	for _, m := range domainErrors {
+		codes = append(codes, m.code)
+	}
+
+	for lang := range messages {
+		for _, code := range codes {
+			_, ok := messages[lang][code]
+			assert.True(t, ok, "no %s message for %s", lang, code)
+		}
+	}
+}
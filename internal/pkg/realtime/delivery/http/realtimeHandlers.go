@@ -0,0 +1,138 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/realtime"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	CookieName = "DDFilmsJWT"
+	// maxFilmTopics ограничивает число фильмов в одном подключении
+	maxFilmTopics = 20
+	// retryMillis через сколько браузер переподключится, если поток оборвался
+	retryMillis = 3000
+)
+
+type RealtimeHandler struct {
+	hub       *realtime.Hub
+	validator realtime.UserValidator
+	heartbeat time.Duration
+}
+
+func NewRealtimeHandler(hub *realtime.Hub, validator realtime.UserValidator, heartbeat time.Duration) *RealtimeHandler {
+	return &RealtimeHandler{
+		hub:       hub,
+		validator: validator,
+		heartbeat: heartbeat,
+	}
+}
+
+// topicsFromRequest собирает темы из film_id и JWT-куки. Аноним может следить только за фильмами,
+// личная тема добавляется, если кука есть и валидна
+func (h *RealtimeHandler) topicsFromRequest(r *http.Request) ([]string, error) {
+	filmIDs := r.URL.Query()["film_id"]
+	if len(filmIDs) > maxFilmTopics {
+		return nil, realtime.ErrorBadRequest
+	}
+
+	topics := make([]string, 0, len(filmIDs)+1)
+	for _, rawID := range filmIDs {
+		filmID, err := uuid.FromString(rawID)
+		if err != nil {
+			return nil, realtime.ErrorBadRequest
+		}
+		topics = append(topics, realtime.FilmTopic(filmID))
+	}
+
+	cookie, err := r.Cookie(CookieName)
+	if err == nil {
+		user, err := h.validator.ValidateAndGetUser(r.Context(), cookie.Value)
+		if err != nil {
+			return nil, realtime.ErrorUnauthorized
+		}
+		topics = append(topics, realtime.UserTopic(user.ID))
+	}
+
+	if len(topics) == 0 {
+		return nil, realtime.ErrorUnauthorized
+	}
+	return topics, nil
+}
+
+// Subscribe godoc
+// @Summary      Subscribe to live updates (Server-Sent Events)
+// @Description  Streams new reviews and rating changes of the given films. With a valid JWT cookie also streams updates of own reviews and notifications. Events: review, rating, own_feedback, notification
+// @Tags         realtime
+// @Produce      text/event-stream
+// @Param        film_id  query  []string  false  "Film IDs to follow"  collectionFormat(multi)
+// @Success      200
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Failure      503 {object} models.Problem
+// @Router       /events [get]
+func (h *RealtimeHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	topics, err := h.topicsFromRequest(r)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("streaming is not supported"), http.StatusInternalServerError)
+		problem.WriteStatus(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	sub, err := h.hub.Subscribe(topics...)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	defer h.hub.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retryMillis)
+	flusher.Flush()
+	log.LogHandlerInfo(logger, "stream opened", http.StatusOK)
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			// комментарий не даёт прокси закрыть соединение по таймауту
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-sub.Events():
+			if !ok {
+				logger.Info("stream closed: " + sub.Err().Error())
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
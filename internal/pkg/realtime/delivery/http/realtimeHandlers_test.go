@@ -0,0 +1,180 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/realtime"
+	"kinopoisk/internal/pkg/realtime/mocks"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestSubscribeRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	handler := NewRealtimeHandler(realtime.NewHub(4), mockValidator, time.Minute)
+
+	tooMany := "/events?"
+	for i := 0; i <= maxFilmTopics; i++ {
+		tooMany += "film_id=" + uuid.NewV4().String() + "&"
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		cookie         string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:           "Anonymous without films",
+			url:            "/events",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid film id",
+			url:            "/events?film_id=abc",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Too many films",
+			url:            tooMany,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Invalid token",
+			url:    "/events?film_id=" + uuid.NewV4().String(),
+			cookie: "bad",
+			mockSetup: func() {
+				mockValidator.EXPECT().ValidateAndGetUser(gomock.Any(), "bad").Return(models.User{}, realtime.ErrorUnauthorized)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CookieName, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			handler.Subscribe(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestSubscribeClosedHub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := realtime.NewHub(4)
+	hub.Close()
+	handler := NewRealtimeHandler(hub, mocks.NewMockUserValidator(ctrl), time.Minute)
+
+	req := httptest.NewRequest(http.MethodGet, "/events?film_id="+uuid.NewV4().String(), nil).WithContext(testContext())
+	w := httptest.NewRecorder()
+
+	handler.Subscribe(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestSubscribeStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockValidator := mocks.NewMockUserValidator(ctrl)
+	hub := realtime.NewHub(4)
+	handler := NewRealtimeHandler(hub, mockValidator, time.Minute)
+
+	user := models.User{ID: uuid.NewV4()}
+	filmID := uuid.NewV4()
+	mockValidator.EXPECT().ValidateAndGetUser(gomock.Any(), "token").Return(user, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/events?film_id="+filmID.String(), nil).WithContext(testContext())
+	req.AddCookie(&http.Cookie{Name: CookieName, Value: "token"})
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		handler.Subscribe(w, req)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return hub.Subscribers(realtime.UserTopic(user.ID)) == 1
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, hub.Publish(realtime.FilmTopic(filmID), realtime.EventRating, models.FilmRatingUpdate{FilmID: filmID, Rating: 8}))
+	assert.NoError(t, hub.Publish(realtime.UserTopic(user.ID), realtime.EventNotification, models.Notification{}))
+
+	// остановка сервера закрывает хаб, и поток должен завершиться сам
+	hub.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed")
+	}
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "retry: 3000\n\n")
+	assert.Contains(t, body, "id: 1\nevent: rating\ndata: {\"film_id\":\""+filmID.String()+"\",\"rating\":8}\n\n")
+	assert.Contains(t, body, "id: 2\nevent: notification\n")
+}
+
+func TestSubscribeClientGone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hub := realtime.NewHub(4)
+	handler := NewRealtimeHandler(hub, mocks.NewMockUserValidator(ctrl), time.Millisecond)
+	filmID := uuid.NewV4()
+	topic := realtime.FilmTopic(filmID)
+
+	ctx, cancel := context.WithCancel(testContext())
+	req := httptest.NewRequest(http.MethodGet, "/events?film_id="+filmID.String(), nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		handler.Subscribe(w, req)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return hub.Subscribers(topic) == 1
+	}, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, 0, hub.Subscribers(topic))
+}
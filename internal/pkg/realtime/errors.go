@@ -0,0 +1,11 @@
+package realtime
+
+import "errors"
+
+var (
+	ErrorBadRequest          = errors.New("bad request")
+	ErrorUnauthorized        = errors.New("user is unauthorized")
+	ErrorHubClosed           = errors.New("hub is closed")
+	ErrorSlowConsumer        = errors.New("subscriber is too slow")
+	ErrorInternalServerError = errors.New("internal server error")
+)
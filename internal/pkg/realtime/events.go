@@ -0,0 +1,40 @@
+package realtime
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+)
+
+// RatingChanged реализует films.RatingObserver: страница фильма получает новый рейтинг
+// и текст отзыва, а автор - свою запись, чтобы обновить её в других вкладках
+func (h *Hub) RatingChanged(feedback models.FilmFeedback) {
+	feedback.IsMine = false
+	feedback.Sanitize()
+
+	filmTopic := FilmTopic(feedback.FilmID)
+	_ = h.Publish(filmTopic, EventRating, models.FilmRatingUpdate{
+		FilmID: feedback.FilmID,
+		Rating: feedback.NewFilmRating,
+	})
+	if feedback.Title != nil {
+		_ = h.Publish(filmTopic, EventReview, feedback)
+	}
+
+	feedback.IsMine = true
+	_ = h.Publish(UserTopic(feedback.UserID), EventOwnFeedback, feedback)
+}
+
+// Deliver реализует notifications.Channel: уведомления о чужих отзывах и сдвиге рейтинга
+// фильмов, которые пользователь оценил, приходят ему сразу после сохранения
+func (h *Hub) Deliver(ctx context.Context, batch []models.Notification) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	for _, notification := range batch {
+		notification.Sanitize()
+		if err := h.Publish(UserTopic(notification.UserID), EventNotification, notification); err != nil {
+			logger.Error("failed to publish notification: " + err.Error())
+			return
+		}
+	}
+}
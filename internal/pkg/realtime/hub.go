@@ -0,0 +1,165 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	EventReview       = "review"
+	EventRating       = "rating"
+	EventOwnFeedback  = "own_feedback"
+	EventNotification = "notification"
+)
+
+// Event одно сообщение для подписчиков; Data уже сериализован, чтобы не кодировать его на каждого клиента
+type Event struct {
+	ID    uint64
+	Topic string
+	Type  string
+	Data  json.RawMessage
+}
+
+func FilmTopic(filmID uuid.UUID) string {
+	return "film:" + filmID.String()
+}
+
+func UserTopic(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// Subscription канал событий одного клиента. Канал закрывается, когда клиент отписался,
+// не успевал читать или хаб остановлен; причину возвращает Err
+type Subscription struct {
+	topics []string
+	events chan Event
+	err    error
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err возвращает причину закрытия; читать только после закрытия канала
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Hub рассылает события подписчикам тем в пределах одного процесса. Отправка никогда
+// не блокируется: клиент с заполненным буфером отключается и должен переподключиться
+type Hub struct {
+	mu         sync.Mutex
+	bufferSize int
+	lastID     uint64
+	closed     bool
+	topics     map[string]map[*Subscription]struct{}
+}
+
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		bufferSize: bufferSize,
+		topics:     make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(topics ...string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrorHubClosed
+	}
+
+	sub := &Subscription{
+		topics: topics,
+		events: make(chan Event, h.bufferSize),
+	}
+	for _, topic := range topics {
+		subscribers, ok := h.topics[topic]
+		if !ok {
+			subscribers = make(map[*Subscription]struct{})
+			h.topics[topic] = subscribers
+		}
+		subscribers[sub] = struct{}{}
+	}
+	return sub, nil
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub, nil)
+}
+
+// remove вызывается под мьютексом; повторный вызов для уже удалённой подписки ничего не делает
+func (h *Hub) remove(sub *Subscription, reason error) {
+	removed := false
+	for _, topic := range sub.topics {
+		subscribers, ok := h.topics[topic]
+		if !ok {
+			continue
+		}
+		if _, ok := subscribers[sub]; ok {
+			removed = true
+			delete(subscribers, sub)
+		}
+		if len(subscribers) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+	if removed {
+		sub.err = reason
+		close(sub.events)
+	}
+}
+
+// Publish сериализует data и отправляет событие всем подписчикам темы
+func (h *Hub) Publish(topic, eventType string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return ErrorHubClosed
+	}
+
+	h.lastID++
+	event := Event{ID: h.lastID, Topic: topic, Type: eventType, Data: raw}
+	for sub := range h.topics[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			h.remove(sub, ErrorSlowConsumer)
+		}
+	}
+	return nil
+}
+
+// Subscribers количество подписчиков темы
+func (h *Hub) Subscribers(topic string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.topics[topic])
+}
+
+// Close отключает всех подписчиков, чтобы открытые потоки завершились до остановки сервера
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, subscribers := range h.topics {
+		for sub := range subscribers {
+			h.remove(sub, ErrorHubClosed)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestHubFanOut(t *testing.T) {
+	hub := NewHub(4)
+	filmID := uuid.NewV4()
+
+	first, err := hub.Subscribe(FilmTopic(filmID))
+	assert.NoError(t, err)
+	second, err := hub.Subscribe(FilmTopic(filmID), UserTopic(uuid.NewV4()))
+	assert.NoError(t, err)
+	other, err := hub.Subscribe(FilmTopic(uuid.NewV4()))
+	assert.NoError(t, err)
+
+	assert.NoError(t, hub.Publish(FilmTopic(filmID), EventRating, models.FilmRatingUpdate{FilmID: filmID, Rating: 7.5}))
+
+	for _, sub := range []*Subscription{first, second} {
+		event := <-sub.Events()
+		assert.Equal(t, EventRating, event.Type)
+		assert.Equal(t, uint64(1), event.ID)
+		assert.JSONEq(t, `{"film_id":"`+filmID.String()+`","rating":7.5}`, string(event.Data))
+	}
+	assert.Empty(t, other.Events())
+}
+
+func TestHubDropsSlowConsumer(t *testing.T) {
+	hub := NewHub(2)
+	topic := FilmTopic(uuid.NewV4())
+
+	slow, err := hub.Subscribe(topic)
+	assert.NoError(t, err)
+	fast, err := hub.Subscribe(topic)
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, hub.Publish(topic, EventRating, i))
+		<-fast.Events()
+	}
+
+	received := 0
+	for range slow.Events() {
+		received++
+	}
+	assert.Equal(t, 2, received)
+	assert.ErrorIs(t, slow.Err(), ErrorSlowConsumer)
+	assert.Equal(t, 1, hub.Subscribers(topic))
+}
+
+func TestHubUnsubscribe(t *testing.T) {
+	hub := NewHub(2)
+	topic := UserTopic(uuid.NewV4())
+
+	sub, err := hub.Subscribe(topic)
+	assert.NoError(t, err)
+	hub.Unsubscribe(sub)
+	hub.Unsubscribe(sub)
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Subscribers(topic))
+	assert.NoError(t, hub.Publish(topic, EventRating, 1))
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub(2)
+	topic := FilmTopic(uuid.NewV4())
+
+	sub, err := hub.Subscribe(topic)
+	assert.NoError(t, err)
+
+	hub.Close()
+	hub.Close()
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.ErrorIs(t, sub.Err(), ErrorHubClosed)
+
+	_, err = hub.Subscribe(topic)
+	assert.ErrorIs(t, err, ErrorHubClosed)
+	assert.ErrorIs(t, hub.Publish(topic, EventRating, 1), ErrorHubClosed)
+}
+
+func TestHubRatingChanged(t *testing.T) {
+	hub := NewHub(4)
+	filmID, userID := uuid.NewV4(), uuid.NewV4()
+	title, text := "<b>Шедевр</b>", "текст"
+
+	film, err := hub.Subscribe(FilmTopic(filmID))
+	assert.NoError(t, err)
+	author, err := hub.Subscribe(UserTopic(userID))
+	assert.NoError(t, err)
+
+	feedback := models.FilmFeedback{ID: uuid.NewV4(), UserID: userID, FilmID: filmID, Title: &title, Text: &text, Rating: 9, NewFilmRating: 8.5}
+	hub.RatingChanged(feedback)
+	// исходный отзыв не меняется
+	assert.Equal(t, "<b>Шедевр</b>", *feedback.Title)
+
+	rating := <-film.Events()
+	assert.Equal(t, EventRating, rating.Type)
+	review := <-film.Events()
+	assert.Equal(t, EventReview, review.Type)
+	var published models.FilmFeedback
+	assert.NoError(t, json.Unmarshal(review.Data, &published))
+	assert.Equal(t, "&lt;b&gt;Шедевр&lt;/b&gt;", *published.Title)
+	assert.False(t, published.IsMine)
+
+	own := <-author.Events()
+	assert.Equal(t, EventOwnFeedback, own.Type)
+	assert.NoError(t, json.Unmarshal(own.Data, &published))
+	assert.True(t, published.IsMine)
+
+	// оценка без текста не публикуется как отзыв
+	hub.RatingChanged(models.FilmFeedback{UserID: userID, FilmID: filmID, Rating: 5, NewFilmRating: 8})
+	assert.Equal(t, EventRating, (<-film.Events()).Type)
+	assert.Empty(t, film.Events())
+}
+
+func TestHubDeliver(t *testing.T) {
+	hub := NewHub(4)
+	userID := uuid.NewV4()
+
+	sub, err := hub.Subscribe(UserTopic(userID))
+	assert.NoError(t, err)
+
+	hub.Deliver(testContext(), []models.Notification{
+		{ID: uuid.NewV4(), UserID: userID, Type: models.NotificationFilmReview, Payload: models.NotificationPayload{AuthorLogin: "<script>"}},
+		{ID: uuid.NewV4(), UserID: uuid.NewV4(), Type: models.NotificationFilmReview},
+	})
+
+	event := <-sub.Events()
+	assert.Equal(t, EventNotification, event.Type)
+	var notification models.Notification
+	assert.NoError(t, json.Unmarshal(event.Data, &notification))
+	assert.Equal(t, "&lt;script&gt;", notification.Payload.AuthorLogin)
+	assert.Empty(t, sub.Events())
+}
@@ -0,0 +1,11 @@
+package realtime
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+)
+
+// UserValidator проверяет JWT из куки так же, как это делают middleware остальных доменов
+type UserValidator interface {
+	ValidateAndGetUser(ctx context.Context, token string) (models.User, error)
+}
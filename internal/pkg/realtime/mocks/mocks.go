@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/realtime/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/realtime/interfaces.go -destination=internal/pkg/realtime/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserValidator is a mock of UserValidator interface.
+type MockUserValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserValidatorMockRecorder
+	isgomock struct{}
+}
+
+// MockUserValidatorMockRecorder is the mock recorder for MockUserValidator.
+type MockUserValidatorMockRecorder struct {
+	mock *MockUserValidator
+}
+
+// NewMockUserValidator creates a new mock instance.
+func NewMockUserValidator(ctrl *gomock.Controller) *MockUserValidator {
+	mock := &MockUserValidator{ctrl: ctrl}
+	mock.recorder = &MockUserValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserValidator) EXPECT() *MockUserValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateAndGetUser mocks base method.
+func (m *MockUserValidator) ValidateAndGetUser(ctx context.Context, token string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAndGetUser", ctx, token)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAndGetUser indicates an expected call of ValidateAndGetUser.
+func (mr *MockUserValidatorMockRecorder) ValidateAndGetUser(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAndGetUser", reflect.TypeOf((*MockUserValidator)(nil).ValidateAndGetUser), ctx, token)
+}
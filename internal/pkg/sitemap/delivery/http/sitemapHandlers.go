@@ -0,0 +1,70 @@
+package http
+
+import (
+	"bytes"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/sitemap"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type SitemapHandler struct {
+	uc sitemap.SitemapUsecase
+}
+
+func NewSitemapHandler(uc sitemap.SitemapUsecase) *SitemapHandler {
+	return &SitemapHandler{uc: uc}
+}
+
+// writeSitemap отдаёт файл через ServeContent, чтобы поисковики получали 304 на If-Modified-Since
+func writeSitemap(w http.ResponseWriter, r *http.Request, file models.SitemapFile) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	http.ServeContent(w, r, "", file.GeneratedAt, bytes.NewReader(file.Content))
+}
+
+// Index godoc
+// @Summary Get sitemap index
+// @Description Links child sitemaps with the home page, films, genres and actors, at most 50000 URLs each
+// @Tags sitemap
+// @Produce xml
+// @Success 200 {string} string "Sitemap index XML"
+// @Success 304
+// @Failure 500 {object} models.Problem
+// @Router /sitemap.xml [get]
+func (s *SitemapHandler) Index(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	file, err := s.uc.Index(r.Context())
+	if err != nil {
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		problem.WriteStatus(w, r, http.StatusInternalServerError)
+		return
+	}
+	writeSitemap(w, r, file)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// File godoc
+// @Summary Get child sitemap
+// @Tags sitemap
+// @Produce xml
+// @Param name path string true "File name from the sitemap index without extension, e.g. films-1"
+// @Success 200 {string} string "Sitemap XML"
+// @Success 304
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /sitemap/{name}.xml [get]
+func (s *SitemapHandler) File(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	file, err := s.uc.File(r.Context(), mux.Vars(r)["name"]+".xml")
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	writeSitemap(w, r, file)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
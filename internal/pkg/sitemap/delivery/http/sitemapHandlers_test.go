@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/sitemap"
+	"kinopoisk/internal/pkg/sitemap/mocks"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestSitemapFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSitemapUsecase(ctrl)
+	handler := NewSitemapHandler(mockUsecase)
+
+	generatedAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	file := models.SitemapFile{Content: []byte("<urlset></urlset>"), GeneratedAt: generatedAt}
+
+	tests := []struct {
+		name            string
+		url             string
+		ifModifiedSince string
+		mockSetup       func()
+		expectedStatus  int
+		expectedBody    string
+	}{
+		{
+			name: "Success",
+			url:  "/sitemap/films-1.xml",
+			mockSetup: func() {
+				mockUsecase.EXPECT().File(gomock.Any(), "films-1.xml").Return(file, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<urlset></urlset>",
+		},
+		{
+			name:            "Not modified",
+			url:             "/sitemap/films-1.xml",
+			ifModifiedSince: generatedAt.Format(http.TimeFormat),
+			mockSetup: func() {
+				mockUsecase.EXPECT().File(gomock.Any(), "films-1.xml").Return(file, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name: "Unknown file",
+			url:  "/sitemap/films-9.xml",
+			mockSetup: func() {
+				mockUsecase.EXPECT().File(gomock.Any(), "films-9.xml").Return(models.SitemapFile{}, sitemap.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			if tt.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/sitemap/{name:[a-z]+-[0-9]+}.xml", handler.File)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusNotFound {
+				assert.Contains(t, rec.Body.String(), `"code":"not_found"`)
+				return
+			}
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "application/xml; charset=utf-8", rec.Header().Get("Content-Type"))
+				assert.Equal(t, generatedAt.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestSitemapIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSitemapUsecase(ctrl)
+	handler := NewSitemapHandler(mockUsecase)
+
+	mockUsecase.EXPECT().Index(gomock.Any()).Return(models.SitemapFile{}, sitemap.ErrorInternalServerError)
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil).WithContext(testContext())
+	rec := httptest.NewRecorder()
+	handler.Index(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
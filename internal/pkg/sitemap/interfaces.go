@@ -0,0 +1,15 @@
+package sitemap
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+)
+
+type SitemapUsecase interface {
+	Index(ctx context.Context) (models.SitemapFile, error)
+	File(ctx context.Context, name string) (models.SitemapFile, error)
+}
+
+type SitemapRepo interface {
+	GetSitemapEntries(ctx context.Context) ([]models.SitemapEntry, error)
+}
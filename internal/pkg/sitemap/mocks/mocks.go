@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/sitemap/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/sitemap/interfaces.go -destination=internal/pkg/sitemap/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSitemapUsecase is a mock of SitemapUsecase interface.
+type MockSitemapUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSitemapUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSitemapUsecaseMockRecorder is the mock recorder for MockSitemapUsecase.
+type MockSitemapUsecaseMockRecorder struct {
+	mock *MockSitemapUsecase
+}
+
+// NewMockSitemapUsecase creates a new mock instance.
+func NewMockSitemapUsecase(ctrl *gomock.Controller) *MockSitemapUsecase {
+	mock := &MockSitemapUsecase{ctrl: ctrl}
+	mock.recorder = &MockSitemapUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSitemapUsecase) EXPECT() *MockSitemapUsecaseMockRecorder {
+	return m.recorder
+}
+
+// File mocks base method.
+func (m *MockSitemapUsecase) File(ctx context.Context, name string) (models.SitemapFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "File", ctx, name)
+	ret0, _ := ret[0].(models.SitemapFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// File indicates an expected call of File.
+func (mr *MockSitemapUsecaseMockRecorder) File(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "File", reflect.TypeOf((*MockSitemapUsecase)(nil).File), ctx, name)
+}
+
+// Index mocks base method.
+func (m *MockSitemapUsecase) Index(ctx context.Context) (models.SitemapFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index", ctx)
+	ret0, _ := ret[0].(models.SitemapFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockSitemapUsecaseMockRecorder) Index(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockSitemapUsecase)(nil).Index), ctx)
+}
+
+// MockSitemapRepo is a mock of SitemapRepo interface.
+type MockSitemapRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSitemapRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSitemapRepoMockRecorder is the mock recorder for MockSitemapRepo.
+type MockSitemapRepoMockRecorder struct {
+	mock *MockSitemapRepo
+}
+
+// NewMockSitemapRepo creates a new mock instance.
+func NewMockSitemapRepo(ctrl *gomock.Controller) *MockSitemapRepo {
+	mock := &MockSitemapRepo{ctrl: ctrl}
+	mock.recorder = &MockSitemapRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSitemapRepo) EXPECT() *MockSitemapRepoMockRecorder {
+	return m.recorder
+}
+
+// GetSitemapEntries mocks base method.
+func (m *MockSitemapRepo) GetSitemapEntries(ctx context.Context) ([]models.SitemapEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSitemapEntries", ctx)
+	ret0, _ := ret[0].([]models.SitemapEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSitemapEntries indicates an expected call of GetSitemapEntries.
+func (mr *MockSitemapRepoMockRecorder) GetSitemapEntries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSitemapEntries", reflect.TypeOf((*MockSitemapRepo)(nil).GetSitemapEntries), ctx)
+}
@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/sitemap"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+
+	"github.com/jackc/pgtype/pgxtype"
+)
+
+type SitemapRepository struct {
+	db pgxtype.Querier
+}
+
+func NewSitemapRepository(db pgxtype.Querier) *SitemapRepository {
+	return &SitemapRepository{db: db}
+}
+
+// GetSitemapEntries отдаёт все публичные страницы каталога, отсортированные по разделу и id,
+// чтобы страница не переезжала из одного дочернего файла в другой между перестроениями
+func (r *SitemapRepository) GetSitemapEntries(ctx context.Context) ([]models.SitemapEntry, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	rows, err := r.db.Query(ctx, GetSitemapEntriesQuery)
+	if err != nil {
+		logger.Error("failed to get sitemap entries: " + err.Error())
+		return nil, sitemap.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	entries := []models.SitemapEntry{}
+	for rows.Next() {
+		var entry models.SitemapEntry
+		if err := rows.Scan(&entry.Kind, &entry.ID, &entry.UpdatedAt); err != nil {
+			logger.Error("failed to scan sitemap entry: " + err.Error())
+			return nil, sitemap.ErrorInternalServerError
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to get sitemap entries: " + err.Error())
+		return nil, sitemap.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got sitemap entries from db", slog.Int("count", len(entries)))
+	return entries, nil
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/sitemap"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestGetSitemapEntries(t *testing.T) {
+	filmID := uuid.NewV4()
+	actorID := uuid.NewV4()
+	updatedAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"kind", "id", "updated_at"}
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantEntries []models.SitemapEntry
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(columns).
+					AddRow("actors", actorID, (*time.Time)(nil)).
+					AddRow("films", filmID, &updatedAt).
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetSitemapEntriesQuery).Return(rows, nil)
+			},
+			wantEntries: []models.SitemapEntry{
+				{Kind: "actors", ID: actorID},
+				{Kind: "films", ID: filmID, UpdatedAt: &updatedAt},
+			},
+		},
+		{
+			name: "Query error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetSitemapEntriesQuery).Return(nil, assert.AnError)
+			},
+			wantErr: sitemap.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewSitemapRepository(mockPool)
+			entries, err := repo.GetSitemapEntries(testContext())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEntries, entries)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package repo
+
+import _ "embed"
+
+//go:embed sql/getSitemapEntriesQuery.sql
+var GetSitemapEntriesQuery string
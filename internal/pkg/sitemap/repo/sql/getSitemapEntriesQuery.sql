@@ -0,0 +1,9 @@
+SELECT 'films' AS kind, f.id, GREATEST(f.updated_at, max(m.updated_at)) AS updated_at
+FROM film f
+LEFT JOIN film_media m ON m.film_id = f.id
+GROUP BY f.id
+UNION ALL
+SELECT 'actors', id, updated_at FROM actor
+UNION ALL
+SELECT 'genres', id, updated_at FROM genre
+ORDER BY kind, id
\ No newline at end of file
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/sitemap"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// URLsPerFile предел протокола sitemap для одного файла
+const URLsPerFile = 50000
+
+type section struct {
+	kind       string
+	changefreq string
+	priority   float64
+}
+
+// sections разделы в том порядке, в котором они идут в индексе; kind совпадает с путём на сайте
+var sections = []section{
+	{kind: "films", changefreq: "weekly", priority: 0.8},
+	{kind: "genres", changefreq: "weekly", priority: 0.6},
+	{kind: "actors", changefreq: "monthly", priority: 0.5},
+}
+
+// snapshot собранные файлы, после построения не меняются
+type snapshot struct {
+	index       []byte
+	files       map[string][]byte
+	generatedAt time.Time
+}
+
+// SitemapUsecase держит собранные sitemap в памяти и пересобирает их при изменениях каталога
+type SitemapUsecase struct {
+	repo    sitemap.SitemapRepo
+	baseURL string
+	perFile int
+	mu      sync.RWMutex
+	current *snapshot
+	refresh chan struct{}
+}
+
+func NewSitemapUsecase(repo sitemap.SitemapRepo, baseURL string) *SitemapUsecase {
+	return &SitemapUsecase{
+		repo:    repo,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		perFile: URLsPerFile,
+		refresh: make(chan struct{}, 1),
+	}
+}
+
+func lastmod(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func later(a, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.After(*a)) {
+		return b
+	}
+	return a
+}
+
+func encode(data interface{}) ([]byte, error) {
+	content, err := xml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), content...), nil
+}
+
+func (uc *SitemapUsecase) build(entries []models.SitemapEntry, now time.Time) (*snapshot, error) {
+	byKind := make(map[string][]models.SitemapEntry, len(sections))
+	var latest *time.Time
+	for _, entry := range entries {
+		byKind[entry.Kind] = append(byKind[entry.Kind], entry)
+		latest = later(latest, entry.UpdatedAt)
+	}
+
+	s := &snapshot{files: make(map[string][]byte), generatedAt: now}
+	index := models.SitemapIndex{Xmlns: models.SitemapXmlns}
+	add := func(name string, urls []models.URLItem, modified *time.Time) error {
+		content, err := encode(models.Urlset{Xmlns: models.SitemapXmlns, URL: urls})
+		if err != nil {
+			return err
+		}
+		s.files[name] = content
+		index.Sitemap = append(index.Sitemap, models.SitemapItem{Loc: uc.baseURL + "/sitemap/" + name, Lastmod: lastmod(modified)})
+		return nil
+	}
+
+	// главная меняется вместе с каталогом
+	home := []models.URLItem{{Loc: uc.baseURL + "/", Lastmod: lastmod(latest), Changefreq: "daily", Priority: 1}}
+	if err := add("pages-1.xml", home, latest); err != nil {
+		return nil, err
+	}
+	for _, sec := range sections {
+		kindEntries := byKind[sec.kind]
+		for start := 0; start < len(kindEntries); start += uc.perFile {
+			chunk := kindEntries[start:min(start+uc.perFile, len(kindEntries))]
+			urls := make([]models.URLItem, 0, len(chunk))
+			var modified *time.Time
+			for _, entry := range chunk {
+				urls = append(urls, models.URLItem{
+					Loc:        uc.baseURL + "/" + sec.kind + "/" + entry.ID.String(),
+					Lastmod:    lastmod(entry.UpdatedAt),
+					Changefreq: sec.changefreq,
+					Priority:   sec.priority,
+				})
+				modified = later(modified, entry.UpdatedAt)
+			}
+			if err := add(fmt.Sprintf("%s-%d.xml", sec.kind, start/uc.perFile+1), urls, modified); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var err error
+	s.index, err = encode(index)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh собирает все файлы заново и атомарно подменяет их
+func (uc *SitemapUsecase) Refresh(ctx context.Context) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	entries, err := uc.repo.GetSitemapEntries(ctx)
+	if err != nil {
+		logger.Error("failed to refresh sitemap: " + err.Error())
+		return err
+	}
+	next, err := uc.build(entries, time.Now())
+	if err != nil {
+		logger.Error("failed to build sitemap: " + err.Error())
+		return sitemap.ErrorInternalServerError
+	}
+
+	uc.mu.Lock()
+	uc.current = next
+	uc.mu.Unlock()
+	logger.Info("succesfully refreshed sitemap", slog.Int("urls", len(entries)+1), slog.Int("files", len(next.files)))
+	return nil
+}
+
+// CatalogueChanged не блокирует вызывающего: пачка изменений схлопывается в одну пересборку
+func (uc *SitemapUsecase) CatalogueChanged() {
+	select {
+	case uc.refresh <- struct{}{}:
+	default:
+	}
+}
+
+// Run пересобирает sitemap по сигналам CatalogueChanged, пока не отменен ctx
+func (uc *SitemapUsecase) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-uc.refresh:
+			_ = uc.Refresh(ctx)
+		}
+	}
+}
+
+// snapshot собирает sitemap при первом запросе, если при старте база была недоступна
+func (uc *SitemapUsecase) snapshot(ctx context.Context) (*snapshot, error) {
+	uc.mu.RLock()
+	current := uc.current
+	uc.mu.RUnlock()
+	if current != nil {
+		return current, nil
+	}
+	if err := uc.Refresh(ctx); err != nil {
+		return nil, sitemap.ErrorInternalServerError
+	}
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.current, nil
+}
+
+func (uc *SitemapUsecase) Index(ctx context.Context) (models.SitemapFile, error) {
+	s, err := uc.snapshot(ctx)
+	if err != nil {
+		return models.SitemapFile{}, err
+	}
+	return models.SitemapFile{Content: s.index, GeneratedAt: s.generatedAt}, nil
+}
+
+func (uc *SitemapUsecase) File(ctx context.Context, name string) (models.SitemapFile, error) {
+	s, err := uc.snapshot(ctx)
+	if err != nil {
+		return models.SitemapFile{}, err
+	}
+	content, ok := s.files[name]
+	if !ok {
+		return models.SitemapFile{}, sitemap.ErrorNotFound
+	}
+	return models.SitemapFile{Content: content, GeneratedAt: s.generatedAt}, nil
+}
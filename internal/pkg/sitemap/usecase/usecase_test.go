@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"encoding/xml"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/sitemap"
+	"kinopoisk/internal/pkg/sitemap/mocks"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestSitemapUsecase_Index(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	older := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 4, 1, 10, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	entries := []models.SitemapEntry{
+		{Kind: "films", ID: uuid.NewV4(), UpdatedAt: &older},
+		{Kind: "films", ID: uuid.NewV4(), UpdatedAt: &newer},
+		{Kind: "films", ID: uuid.NewV4()},
+		{Kind: "actors", ID: uuid.NewV4(), UpdatedAt: &older},
+	}
+
+	mockRepo := mocks.NewMockSitemapRepo(ctrl)
+	mockRepo.EXPECT().GetSitemapEntries(gomock.Any()).Return(entries, nil)
+
+	uc := NewSitemapUsecase(mockRepo, "https://ddfilms.online/")
+	uc.perFile = 2
+
+	file, err := uc.Index(testContext())
+	assert.NoError(t, err)
+	var index models.SitemapIndex
+	assert.NoError(t, xml.Unmarshal(file.Content, &index))
+	assert.Equal(t, []models.SitemapItem{
+		{Loc: "https://ddfilms.online/sitemap/pages-1.xml", Lastmod: "2025-04-01T07:00:00Z"},
+		{Loc: "https://ddfilms.online/sitemap/films-1.xml", Lastmod: "2025-04-01T07:00:00Z"},
+		{Loc: "https://ddfilms.online/sitemap/films-2.xml"},
+		{Loc: "https://ddfilms.online/sitemap/actors-1.xml", Lastmod: "2025-03-01T10:00:00Z"},
+	}, index.Sitemap)
+
+	// второй запрос берётся из кэша, в базу не ходит
+	child, err := uc.File(testContext(), "films-1.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, file.GeneratedAt, child.GeneratedAt)
+	var urlset models.Urlset
+	assert.NoError(t, xml.Unmarshal(child.Content, &urlset))
+	assert.Len(t, urlset.URL, 2)
+	assert.Equal(t, "https://ddfilms.online/films/"+entries[0].ID.String(), urlset.URL[0].Loc)
+	assert.Equal(t, "2025-03-01T10:00:00Z", urlset.URL[0].Lastmod)
+	assert.Equal(t, "weekly", urlset.URL[0].Changefreq)
+
+	_, err = uc.File(testContext(), "genres-1.xml")
+	assert.ErrorIs(t, err, sitemap.ErrorNotFound)
+}
+
+func TestSitemapUsecase_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	first := []models.SitemapEntry{{Kind: "genres", ID: uuid.NewV4()}}
+	second := append(first, models.SitemapEntry{Kind: "actors", ID: uuid.NewV4()})
+
+	mockRepo := mocks.NewMockSitemapRepo(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().GetSitemapEntries(gomock.Any()).Return(first, nil),
+		mockRepo.EXPECT().GetSitemapEntries(gomock.Any()).Return(nil, sitemap.ErrorInternalServerError),
+		mockRepo.EXPECT().GetSitemapEntries(gomock.Any()).Return(second, nil),
+	)
+
+	uc := NewSitemapUsecase(mockRepo, "https://ddfilms.online")
+	assert.NoError(t, uc.Refresh(testContext()))
+	_, err := uc.File(testContext(), "actors-1.xml")
+	assert.ErrorIs(t, err, sitemap.ErrorNotFound)
+
+	// неудачная пересборка оставляет прежние файлы
+	assert.Error(t, uc.Refresh(testContext()))
+	_, err = uc.File(testContext(), "genres-1.xml")
+	assert.NoError(t, err)
+
+	assert.NoError(t, uc.Refresh(testContext()))
+	_, err = uc.File(testContext(), "actors-1.xml")
+	assert.NoError(t, err)
+}
+
+func TestSitemapUsecase_RepoUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockSitemapRepo(ctrl)
+	mockRepo.EXPECT().GetSitemapEntries(gomock.Any()).Return(nil, sitemap.ErrorInternalServerError)
+
+	uc := NewSitemapUsecase(mockRepo, "https://ddfilms.online")
+	_, err := uc.Index(testContext())
+	assert.ErrorIs(t, err, sitemap.ErrorInternalServerError)
+}
+
+func TestSitemapUsecase_CatalogueChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refreshed := make(chan struct{})
+	var once sync.Once
+	mockRepo := mocks.NewMockSitemapRepo(ctrl)
+	mockRepo.EXPECT().GetSitemapEntries(gomock.Any()).DoAndReturn(func(ctx context.Context) ([]models.SitemapEntry, error) {
+		once.Do(func() { close(refreshed) })
+		return []models.SitemapEntry{}, nil
+	}).MinTimes(1)
+
+	uc := NewSitemapUsecase(mockRepo, "https://ddfilms.online")
+	ctx, cancel := context.WithCancel(testContext())
+	defer cancel()
+	go uc.Run(ctx)
+
+	// сигнал не блокирует, даже если пересборка уже запрошена
+	uc.CatalogueChanged()
+	uc.CatalogueChanged()
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("sitemap was not refreshed")
+	}
+}
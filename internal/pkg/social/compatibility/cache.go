@@ -0,0 +1,91 @@
+package compatibility
+
+import (
+	"bytes"
+	"sync"
+
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type pair struct {
+	first, second uuid.UUID
+}
+
+type entry struct {
+	value                       models.Compatibility
+	firstVersion, secondVersion uint64
+}
+
+// Cache хранит результат для каждой пары пользователей. Каждая новая оценка
+// увеличивает версию пользователя, и записи со старой версией больше не выдаются
+type Cache struct {
+	mu         sync.Mutex
+	maxEntries int
+	versions   map[uuid.UUID]uint64
+	entries    map[pair]entry
+}
+
+func NewCache(maxEntries int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		versions:   make(map[uuid.UUID]uint64),
+		entries:    make(map[pair]entry),
+	}
+}
+
+func newPair(a, b uuid.UUID) (pair, bool) {
+	if bytes.Compare(a.Bytes(), b.Bytes()) > 0 {
+		return pair{first: b, second: a}, true
+	}
+	return pair{first: a, second: b}, false
+}
+
+// RatingChanged реализует films.RatingObserver
+func (c *Cache) RatingChanged(feedback models.FilmFeedback) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.versions[feedback.UserID]++
+}
+
+// GetOrCompute возвращает результат из кэша или считает его через compute.
+// compute всегда вызывается для упорядоченной пары (first, second)
+func (c *Cache) GetOrCompute(userID, otherID uuid.UUID, compute func(first, second uuid.UUID) (models.Compatibility, error)) (models.Compatibility, error) {
+	key, swapped := newPair(userID, otherID)
+
+	c.mu.Lock()
+	firstVersion, secondVersion := c.versions[key.first], c.versions[key.second]
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok && cached.firstVersion == firstVersion && cached.secondVersion == secondVersion {
+		return orient(cached.value, swapped), nil
+	}
+
+	value, err := compute(key.first, key.second)
+	if err != nil {
+		return models.Compatibility{}, err
+	}
+
+	c.mu.Lock()
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		// кэш переполнен, проще начать заново, чем вытеснять по одной записи
+		c.entries = make(map[pair]entry)
+	}
+	// версии сняты до подсчёта, поэтому оценка, поставленная во время подсчёта, сделает запись устаревшей
+	c.entries[key] = entry{value: value, firstVersion: firstVersion, secondVersion: secondVersion}
+	c.mu.Unlock()
+
+	return orient(value, swapped), nil
+}
+
+// orient отдаёт копию, чтобы вызывающий код мог менять результат, не портя кэш
+func orient(value models.Compatibility, swapped bool) models.Compatibility {
+	if swapped {
+		return swap(value)
+	}
+	value.Agree = append([]models.CompatibilityFilm{}, value.Agree...)
+	value.Disagree = append([]models.CompatibilityFilm{}, value.Disagree...)
+	return value
+}
@@ -0,0 +1,119 @@
+package compatibility
+
+import (
+	"errors"
+	"testing"
+
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type computeCounter struct {
+	calls int
+	films []models.CompatibilityFilm
+	pairs [][2]uuid.UUID
+}
+
+func (c *computeCounter) compute(first, second uuid.UUID) (models.Compatibility, error) {
+	c.calls++
+	c.pairs = append(c.pairs, [2]uuid.UUID{first, second})
+	return Compute(first, second, c.films, 1, 5), nil
+}
+
+func TestCacheHitAndOrientation(t *testing.T) {
+	cache := NewCache(10)
+	counter := &computeCounter{films: []models.CompatibilityFilm{film(1, "Дюна", 9, 3)}}
+
+	fromA, err := cache.GetOrCompute(userA, userB, counter.compute)
+	assert.NoError(t, err)
+	fromB, err := cache.GetOrCompute(userB, userA, counter.compute)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, counter.calls)
+	assert.Equal(t, [][2]uuid.UUID{{userA, userB}}, counter.pairs)
+
+	assert.Equal(t, userA, fromA.UserID)
+	assert.Equal(t, 9, fromA.Disagree[0].UserRating)
+	assert.Equal(t, userB, fromB.UserID)
+	assert.Equal(t, userA, fromB.OtherUserID)
+	assert.Equal(t, 3, fromB.Disagree[0].UserRating)
+	assert.Equal(t, 9, fromB.Disagree[0].OtherRating)
+}
+
+func TestCacheReturnsCopies(t *testing.T) {
+	cache := NewCache(10)
+	counter := &computeCounter{films: []models.CompatibilityFilm{film(1, "<b>Дюна</b>", 9, 9)}}
+
+	first, _ := cache.GetOrCompute(userA, userB, counter.compute)
+	first.Agree[0].Title = "changed"
+
+	second, _ := cache.GetOrCompute(userA, userB, counter.compute)
+	assert.Equal(t, "<b>Дюна</b>", second.Agree[0].Title)
+}
+
+func TestCacheInvalidatedByRating(t *testing.T) {
+	userC := uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000c")
+	cache := NewCache(10)
+	counter := &computeCounter{films: []models.CompatibilityFilm{film(1, "Дюна", 9, 9)}}
+
+	_, _ = cache.GetOrCompute(userA, userB, counter.compute)
+	_, _ = cache.GetOrCompute(userA, userC, counter.compute)
+	assert.Equal(t, 2, counter.calls)
+
+	cache.RatingChanged(models.FilmFeedback{UserID: userB})
+	_, _ = cache.GetOrCompute(userA, userC, counter.compute)
+	assert.Equal(t, 2, counter.calls)
+	_, _ = cache.GetOrCompute(userB, userA, counter.compute)
+	assert.Equal(t, 3, counter.calls)
+
+	cache.RatingChanged(models.FilmFeedback{UserID: userA})
+	_, _ = cache.GetOrCompute(userA, userC, counter.compute)
+	assert.Equal(t, 4, counter.calls)
+}
+
+func TestCacheRatingDuringCompute(t *testing.T) {
+	cache := NewCache(10)
+	calls := 0
+	compute := func(first, second uuid.UUID) (models.Compatibility, error) {
+		calls++
+		if calls == 1 {
+			// оценка пришла, пока шёл подсчёт: результат не должен закэшироваться как свежий
+			cache.RatingChanged(models.FilmFeedback{UserID: userA})
+		}
+		return models.Compatibility{UserID: first, OtherUserID: second}, nil
+	}
+
+	_, _ = cache.GetOrCompute(userA, userB, compute)
+	_, _ = cache.GetOrCompute(userA, userB, compute)
+	_, _ = cache.GetOrCompute(userA, userB, compute)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheDoesNotStoreErrors(t *testing.T) {
+	cache := NewCache(10)
+	calls := 0
+	failing := func(first, second uuid.UUID) (models.Compatibility, error) {
+		calls++
+		return models.Compatibility{}, errors.New("db error")
+	}
+
+	_, err := cache.GetOrCompute(userA, userB, failing)
+	assert.Error(t, err)
+	_, err = cache.GetOrCompute(userA, userB, failing)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCacheOverflow(t *testing.T) {
+	cache := NewCache(1)
+	userC := uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000c")
+	counter := &computeCounter{}
+
+	_, _ = cache.GetOrCompute(userA, userB, counter.compute)
+	_, _ = cache.GetOrCompute(userA, userC, counter.compute)
+	_, _ = cache.GetOrCompute(userA, userB, counter.compute)
+	assert.Equal(t, 3, counter.calls)
+	assert.Len(t, cache.entries, 1)
+}
@@ -0,0 +1,128 @@
+package compatibility
+
+import (
+	"math"
+	"sort"
+
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// оценки, отличающиеся не больше чем на agreeMaxDiff, считаются совпадением,
+// а начиная с disagreeMinDiff — расхождением
+const (
+	agreeMaxDiff    = 1
+	disagreeMinDiff = 3
+)
+
+// Compute считает корреляцию Пирсона по общим оценкам пользователей и выбирает
+// не больше limit фильмов, в которых они сошлись и разошлись сильнее всего
+func Compute(userID, otherID uuid.UUID, films []models.CompatibilityFilm, minOverlap, limit int) models.Compatibility {
+	result := models.Compatibility{
+		UserID:      userID,
+		OtherUserID: otherID,
+		CommonFilms: len(films),
+		Agree:       []models.CompatibilityFilm{},
+		Disagree:    []models.CompatibilityFilm{},
+	}
+	if len(films) >= minOverlap {
+		result.Score = pearson(films)
+	}
+
+	sorted := make([]models.CompatibilityFilm, len(films))
+	copy(sorted, films)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		di, dj := diff(sorted[i]), diff(sorted[j])
+		if di != dj {
+			return di < dj
+		}
+		si, sj := sorted[i].UserRating+sorted[i].OtherRating, sorted[j].UserRating+sorted[j].OtherRating
+		if si != sj {
+			return si > sj
+		}
+		return less(sorted[i], sorted[j])
+	})
+	for _, film := range sorted {
+		if len(result.Agree) == limit || diff(film) > agreeMaxDiff {
+			break
+		}
+		result.Agree = append(result.Agree, film)
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		di, dj := diff(sorted[i]), diff(sorted[j])
+		if di != dj {
+			return di > dj
+		}
+		return less(sorted[i], sorted[j])
+	})
+	for _, film := range sorted {
+		if len(result.Disagree) == limit || diff(film) < disagreeMinDiff {
+			break
+		}
+		result.Disagree = append(result.Disagree, film)
+	}
+
+	return result
+}
+
+func pearson(films []models.CompatibilityFilm) *float64 {
+	n := float64(len(films))
+	var sumX, sumY float64
+	for _, film := range films {
+		sumX += float64(film.UserRating)
+		sumY += float64(film.OtherRating)
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var cov, varX, varY float64
+	for _, film := range films {
+		dx := float64(film.UserRating) - meanX
+		dy := float64(film.OtherRating) - meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	// у одинаковых оценок нет разброса, корреляция не определена
+	if varX == 0 || varY == 0 {
+		return nil
+	}
+
+	score := math.Round(cov/math.Sqrt(varX*varY)*100) / 100
+	return &score
+}
+
+func diff(film models.CompatibilityFilm) int {
+	d := film.UserRating - film.OtherRating
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+func less(a, b models.CompatibilityFilm) bool {
+	if a.Title != b.Title {
+		return a.Title < b.Title
+	}
+	return a.FilmID.String() < b.FilmID.String()
+}
+
+// swap разворачивает результат, посчитанный для пары в обратном порядке
+func swap(c models.Compatibility) models.Compatibility {
+	swapped := c
+	swapped.UserID, swapped.OtherUserID = c.OtherUserID, c.UserID
+	swapped.Agree = swapFilms(c.Agree)
+	swapped.Disagree = swapFilms(c.Disagree)
+	return swapped
+}
+
+func swapFilms(films []models.CompatibilityFilm) []models.CompatibilityFilm {
+	result := make([]models.CompatibilityFilm, len(films))
+	for i, film := range films {
+		film.UserRating, film.OtherRating = film.OtherRating, film.UserRating
+		result[i] = film
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package compatibility
+
+import (
+	"testing"
+
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	userA = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000a")
+	userB = uuid.FromStringOrNil("00000000-0000-0000-0000-00000000000b")
+)
+
+func film(n byte, title string, userRating, otherRating int) models.CompatibilityFilm {
+	id := uuid.UUID{}
+	id[15] = n
+	return models.CompatibilityFilm{FilmID: id, Title: title, UserRating: userRating, OtherRating: otherRating}
+}
+
+func scorePtr(v float64) *float64 {
+	return &v
+}
+
+func TestComputeScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		films     []models.CompatibilityFilm
+		wantScore *float64
+	}{
+		{
+			name: "Identical taste",
+			films: []models.CompatibilityFilm{
+				film(1, "А", 2, 2), film(2, "Б", 4, 4), film(3, "В", 6, 6), film(4, "Г", 8, 8), film(5, "Д", 10, 10),
+			},
+			wantScore: scorePtr(1),
+		},
+		{
+			name: "Opposite taste",
+			films: []models.CompatibilityFilm{
+				film(1, "А", 1, 10), film(2, "Б", 3, 8), film(3, "В", 5, 6), film(4, "Г", 7, 4), film(5, "Д", 9, 2),
+			},
+			wantScore: scorePtr(-1),
+		},
+		{
+			name: "Partial correlation",
+			films: []models.CompatibilityFilm{
+				film(1, "А", 8, 7), film(2, "Б", 6, 7), film(3, "В", 9, 10), film(4, "Г", 3, 5), film(5, "Д", 5, 3),
+			},
+			wantScore: scorePtr(0.79),
+		},
+		{
+			name: "Not enough common films",
+			films: []models.CompatibilityFilm{
+				film(1, "А", 8, 8), film(2, "Б", 6, 6), film(3, "В", 9, 9), film(4, "Г", 3, 3),
+			},
+		},
+		{
+			name: "No variance",
+			films: []models.CompatibilityFilm{
+				film(1, "А", 7, 8), film(2, "Б", 7, 6), film(3, "В", 7, 9), film(4, "Г", 7, 3), film(5, "Д", 7, 5),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Compute(userA, userB, tt.films, 5, 5)
+			assert.Equal(t, len(tt.films), result.CommonFilms)
+			assert.Equal(t, tt.wantScore, result.Score)
+		})
+	}
+}
+
+func TestComputeAgreeDisagree(t *testing.T) {
+	films := []models.CompatibilityFilm{
+		film(1, "Дюна", 9, 9),
+		film(2, "Аватар", 5, 5),
+		film(3, "Титаник", 10, 2),
+		film(4, "Матрица", 8, 7),
+		film(5, "Брат", 3, 7),
+		film(6, "Сталкер", 6, 8),
+		film(7, "Солярис", 2, 6),
+	}
+
+	result := Compute(userA, userB, films, 5, 3)
+
+	titles := func(films []models.CompatibilityFilm) []string {
+		out := make([]string, 0, len(films))
+		for _, f := range films {
+			out = append(out, f.Title)
+		}
+		return out
+	}
+	// совпадения: сначала одинаковые оценки, из них выше оценённые
+	assert.Equal(t, []string{"Дюна", "Аватар", "Матрица"}, titles(result.Agree))
+	// расхождения: сначала самые большие, при равенстве по названию
+	assert.Equal(t, []string{"Титаник", "Брат", "Солярис"}, titles(result.Disagree))
+}
+
+func TestComputeDoesNotModifyInput(t *testing.T) {
+	films := []models.CompatibilityFilm{film(2, "Б", 1, 9), film(1, "А", 5, 5)}
+	original := append([]models.CompatibilityFilm{}, films...)
+
+	Compute(userA, userB, films, 1, 5)
+	assert.Equal(t, original, films)
+}
@@ -0,0 +1,207 @@
+package http
+
+import (
+	"errors"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/social"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+type SocialHandler struct {
+	uc social.SocialUsecase
+}
+
+func NewSocialHandler(uc social.SocialUsecase) *SocialHandler {
+	return &SocialHandler{uc: uc}
+}
+
+// Follow godoc
+// @Summary      Follow user
+// @Tags         social
+// @Param        id   path      string  true  "User ID"
+// @Success      200
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /users/{id}/follow [post]
+func (s *SocialHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.uc.Follow(r.Context(), id); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// Unfollow godoc
+// @Summary      Unfollow user
+// @Tags         social
+// @Param        id   path      string  true  "User ID"
+// @Success      200
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /users/{id}/follow [delete]
+func (s *SocialHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	if err := s.uc.Unfollow(r.Context(), id); err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetFollowers godoc
+// @Summary      Get followers of user
+// @Tags         social
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Param        count   query  int  false  "Number of users"
+// @Param        offset  query  int  false  "Offset"
+// @Success      200  {array}   models.FollowUser
+// @Failure      400 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /users/{id}/followers [get]
+func (s *SocialHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	followers, err := s.uc.GetFollowers(r.Context(), id, helpers.GetPagerFromRequest(r))
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	for i := range followers {
+		followers[i].Sanitize()
+	}
+
+	helpers.WriteJSON(w, followers)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetFollowing godoc
+// @Summary      Get users followed by user
+// @Tags         social
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Param        count   query  int  false  "Number of users"
+// @Param        offset  query  int  false  "Offset"
+// @Success      200  {array}   models.FollowUser
+// @Failure      400 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /users/{id}/following [get]
+func (s *SocialHandler) GetFollowing(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	following, err := s.uc.GetFollowing(r.Context(), id, helpers.GetPagerFromRequest(r))
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	for i := range following {
+		following[i].Sanitize()
+	}
+
+	helpers.WriteJSON(w, following)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetFeed godoc
+// @Summary      Get recent ratings and reviews of followed users
+// @Tags         social
+// @Produce      json
+// @Param        cursor  query  string  false  "Cursor from previous page"
+// @Param        count   query  int  false  "Number of items"
+// @Success      200  {object}  models.FeedPage
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /feed [get]
+func (s *SocialHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	cursor, err := helpers.GetCursorFromRequest(r)
+	if err != nil {
+		log.LogHandlerError(logger, err, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	count := helpers.GetPagerFromRequest(r).Count
+	page, err := s.uc.GetFeed(r.Context(), cursor, count)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	page.Sanitize()
+
+	helpers.WriteJSON(w, page)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// GetCompatibility godoc
+// @Summary      Get taste compatibility of current user with another user
+// @Tags         social
+// @Produce      json
+// @Param        id   path      string  true  "User ID"
+// @Success      200  {object}  models.Compatibility
+// @Failure      400 {object} models.Problem
+// @Failure      401 {object} models.Problem
+// @Failure      403 {object} models.Problem
+// @Failure      404 {object} models.Problem
+// @Failure      500 {object} models.Problem
+// @Router       /users/{id}/compatibility [get]
+func (s *SocialHandler) GetCompatibility(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.uc.GetCompatibility(r.Context(), id)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	result.Sanitize()
+
+	helpers.WriteJSON(w, result)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
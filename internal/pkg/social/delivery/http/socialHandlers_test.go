@@ -0,0 +1,221 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/social"
+	"kinopoisk/internal/pkg/social/mocks"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestFollow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSocialUsecase(ctrl)
+	handler := NewSocialHandler(mockUsecase)
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		varsID         string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			varsID: userID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().Follow(gomock.Any(), userID).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			varsID:         "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Follow himself",
+			varsID: userID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().Follow(gomock.Any(), userID).Return(social.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "User not found",
+			varsID: userID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().Follow(gomock.Any(), userID).Return(social.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodPost, "/users/"+tt.varsID+"/follow", nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/users/{id}/follow", handler.Follow)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestGetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSocialUsecase(ctrl)
+	handler := NewSocialHandler(mockUsecase)
+
+	cursor := models.Cursor{CreatedAt: time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC), ID: uuid.NewV4()}
+	page := func() models.FeedPage {
+		return models.FeedPage{
+			Items:      []models.FeedItem{{ID: uuid.NewV4(), Type: models.FeedItemRating, UserLogin: "<b>critic</b>"}},
+			NextCursor: "next",
+		}
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name: "First page",
+			url:  "/feed",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetFeed(gomock.Any(), (*models.Cursor)(nil), 10).Return(page(), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "With cursor",
+			url:  "/feed?count=5&cursor=" + cursor.Encode(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetFeed(gomock.Any(), &cursor, 5).Return(page(), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Malformed cursor",
+			url:            "/feed?cursor=bm9wZQ",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Unauthorized",
+			url:  "/feed",
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetFeed(gomock.Any(), (*models.Cursor)(nil), 10).Return(models.FeedPage{}, social.ErrorUnauthorized)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			handler.GetFeed(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var decoded models.FeedPage
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+				assert.Equal(t, "&lt;b&gt;critic&lt;/b&gt;", decoded.Items[0].UserLogin)
+				assert.Equal(t, "next", decoded.NextCursor)
+			}
+		})
+	}
+}
+
+func TestGetCompatibility(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockSocialUsecase(ctrl)
+	handler := NewSocialHandler(mockUsecase)
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		varsID         string
+		mockSetup      func()
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			varsID: userID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCompatibility(gomock.Any(), userID).
+					Return(models.Compatibility{OtherUserID: userID, Agree: []models.CompatibilityFilm{}, Disagree: []models.CompatibilityFilm{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid ID",
+			varsID:         "not-a-uuid",
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Followers only",
+			varsID: userID.String(),
+			mockSetup: func() {
+				mockUsecase.EXPECT().GetCompatibility(gomock.Any(), userID).Return(models.Compatibility{}, social.ErrorForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.varsID+"/compatibility", nil).WithContext(testContext())
+			rec := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.HandleFunc("/users/{id}/compatibility", handler.GetCompatibility)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
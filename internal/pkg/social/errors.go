@@ -0,0 +1,11 @@
+package social
+
+import "errors"
+
+var (
+	ErrorBadRequest          = errors.New("bad request")
+	ErrorUnauthorized        = errors.New("user is unauthorized")
+	ErrorForbidden           = errors.New("forbidden")
+	ErrorNotFound            = errors.New("not found")
+	ErrorInternalServerError = errors.New("internal server error")
+)
@@ -0,0 +1,33 @@
+package social
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+type SocialUsecase interface {
+	Follow(ctx context.Context, followeeID uuid.UUID) error
+	Unfollow(ctx context.Context, followeeID uuid.UUID) error
+	GetFollowers(ctx context.Context, userID uuid.UUID, pager models.Pager) ([]models.FollowUser, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, pager models.Pager) ([]models.FollowUser, error)
+	GetFeed(ctx context.Context, cursor *models.Cursor, count int) (models.FeedPage, error)
+	GetCompatibility(ctx context.Context, otherID uuid.UUID) (models.Compatibility, error)
+}
+
+type SocialRepo interface {
+	CheckUserExists(ctx context.Context, userID uuid.UUID) (bool, error)
+	CreateFollow(ctx context.Context, followerID, followeeID uuid.UUID) error
+	DeleteFollow(ctx context.Context, followerID, followeeID uuid.UUID) error
+	GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, cursor *models.Cursor, limit int) ([]models.FeedItem, error)
+	CanViewActivity(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error)
+	GetCoRatedFilms(ctx context.Context, userID, otherID uuid.UUID) ([]models.CompatibilityFilm, error)
+}
+
+// CompatibilityCache хранит посчитанную совместимость пар пользователей
+type CompatibilityCache interface {
+	GetOrCompute(userID, otherID uuid.UUID, compute func(first, second uuid.UUID) (models.Compatibility, error)) (models.Compatibility, error)
+}
@@ -0,0 +1,312 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/pkg/social/interfaces.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/pkg/social/interfaces.go -destination=internal/pkg/social/mocks/mocks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "kinopoisk/internal/models"
+	reflect "reflect"
+
+	uuid "github.com/satori/go.uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSocialUsecase is a mock of SocialUsecase interface.
+type MockSocialUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockSocialUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockSocialUsecaseMockRecorder is the mock recorder for MockSocialUsecase.
+type MockSocialUsecaseMockRecorder struct {
+	mock *MockSocialUsecase
+}
+
+// NewMockSocialUsecase creates a new mock instance.
+func NewMockSocialUsecase(ctrl *gomock.Controller) *MockSocialUsecase {
+	mock := &MockSocialUsecase{ctrl: ctrl}
+	mock.recorder = &MockSocialUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSocialUsecase) EXPECT() *MockSocialUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockSocialUsecase) Follow(ctx context.Context, followeeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockSocialUsecaseMockRecorder) Follow(ctx, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockSocialUsecase)(nil).Follow), ctx, followeeID)
+}
+
+// GetCompatibility mocks base method.
+func (m *MockSocialUsecase) GetCompatibility(ctx context.Context, otherID uuid.UUID) (models.Compatibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompatibility", ctx, otherID)
+	ret0, _ := ret[0].(models.Compatibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompatibility indicates an expected call of GetCompatibility.
+func (mr *MockSocialUsecaseMockRecorder) GetCompatibility(ctx, otherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompatibility", reflect.TypeOf((*MockSocialUsecase)(nil).GetCompatibility), ctx, otherID)
+}
+
+// GetFeed mocks base method.
+func (m *MockSocialUsecase) GetFeed(ctx context.Context, cursor *models.Cursor, count int) (models.FeedPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, cursor, count)
+	ret0, _ := ret[0].(models.FeedPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockSocialUsecaseMockRecorder) GetFeed(ctx, cursor, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockSocialUsecase)(nil).GetFeed), ctx, cursor, count)
+}
+
+// GetFollowers mocks base method.
+func (m *MockSocialUsecase) GetFollowers(ctx context.Context, userID uuid.UUID, pager models.Pager) ([]models.FollowUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, userID, pager)
+	ret0, _ := ret[0].([]models.FollowUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockSocialUsecaseMockRecorder) GetFollowers(ctx, userID, pager any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockSocialUsecase)(nil).GetFollowers), ctx, userID, pager)
+}
+
+// GetFollowing mocks base method.
+func (m *MockSocialUsecase) GetFollowing(ctx context.Context, userID uuid.UUID, pager models.Pager) ([]models.FollowUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userID, pager)
+	ret0, _ := ret[0].([]models.FollowUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockSocialUsecaseMockRecorder) GetFollowing(ctx, userID, pager any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockSocialUsecase)(nil).GetFollowing), ctx, userID, pager)
+}
+
+// Unfollow mocks base method.
+func (m *MockSocialUsecase) Unfollow(ctx context.Context, followeeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockSocialUsecaseMockRecorder) Unfollow(ctx, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockSocialUsecase)(nil).Unfollow), ctx, followeeID)
+}
+
+// MockSocialRepo is a mock of SocialRepo interface.
+type MockSocialRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSocialRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockSocialRepoMockRecorder is the mock recorder for MockSocialRepo.
+type MockSocialRepoMockRecorder struct {
+	mock *MockSocialRepo
+}
+
+// NewMockSocialRepo creates a new mock instance.
+func NewMockSocialRepo(ctrl *gomock.Controller) *MockSocialRepo {
+	mock := &MockSocialRepo{ctrl: ctrl}
+	mock.recorder = &MockSocialRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSocialRepo) EXPECT() *MockSocialRepoMockRecorder {
+	return m.recorder
+}
+
+// CanViewActivity mocks base method.
+func (m *MockSocialRepo) CanViewActivity(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanViewActivity", ctx, viewerID, ownerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanViewActivity indicates an expected call of CanViewActivity.
+func (mr *MockSocialRepoMockRecorder) CanViewActivity(ctx, viewerID, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanViewActivity", reflect.TypeOf((*MockSocialRepo)(nil).CanViewActivity), ctx, viewerID, ownerID)
+}
+
+// CheckUserExists mocks base method.
+func (m *MockSocialRepo) CheckUserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckUserExists", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckUserExists indicates an expected call of CheckUserExists.
+func (mr *MockSocialRepoMockRecorder) CheckUserExists(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserExists", reflect.TypeOf((*MockSocialRepo)(nil).CheckUserExists), ctx, userID)
+}
+
+// CreateFollow mocks base method.
+func (m *MockSocialRepo) CreateFollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFollow", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateFollow indicates an expected call of CreateFollow.
+func (mr *MockSocialRepoMockRecorder) CreateFollow(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFollow", reflect.TypeOf((*MockSocialRepo)(nil).CreateFollow), ctx, followerID, followeeID)
+}
+
+// DeleteFollow mocks base method.
+func (m *MockSocialRepo) DeleteFollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFollow", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFollow indicates an expected call of DeleteFollow.
+func (mr *MockSocialRepoMockRecorder) DeleteFollow(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFollow", reflect.TypeOf((*MockSocialRepo)(nil).DeleteFollow), ctx, followerID, followeeID)
+}
+
+// GetCoRatedFilms mocks base method.
+func (m *MockSocialRepo) GetCoRatedFilms(ctx context.Context, userID, otherID uuid.UUID) ([]models.CompatibilityFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCoRatedFilms", ctx, userID, otherID)
+	ret0, _ := ret[0].([]models.CompatibilityFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCoRatedFilms indicates an expected call of GetCoRatedFilms.
+func (mr *MockSocialRepoMockRecorder) GetCoRatedFilms(ctx, userID, otherID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoRatedFilms", reflect.TypeOf((*MockSocialRepo)(nil).GetCoRatedFilms), ctx, userID, otherID)
+}
+
+// GetFeed mocks base method.
+func (m *MockSocialRepo) GetFeed(ctx context.Context, userID uuid.UUID, cursor *models.Cursor, limit int) ([]models.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userID, cursor, limit)
+	ret0, _ := ret[0].([]models.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockSocialRepoMockRecorder) GetFeed(ctx, userID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockSocialRepo)(nil).GetFeed), ctx, userID, cursor, limit)
+}
+
+// GetFollowers mocks base method.
+func (m *MockSocialRepo) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]models.FollowUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockSocialRepoMockRecorder) GetFollowers(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockSocialRepo)(nil).GetFollowers), ctx, userID, limit, offset)
+}
+
+// GetFollowing mocks base method.
+func (m *MockSocialRepo) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]models.FollowUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockSocialRepoMockRecorder) GetFollowing(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockSocialRepo)(nil).GetFollowing), ctx, userID, limit, offset)
+}
+
+// MockCompatibilityCache is a mock of CompatibilityCache interface.
+type MockCompatibilityCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompatibilityCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockCompatibilityCacheMockRecorder is the mock recorder for MockCompatibilityCache.
+type MockCompatibilityCacheMockRecorder struct {
+	mock *MockCompatibilityCache
+}
+
+// NewMockCompatibilityCache creates a new mock instance.
+func NewMockCompatibilityCache(ctrl *gomock.Controller) *MockCompatibilityCache {
+	mock := &MockCompatibilityCache{ctrl: ctrl}
+	mock.recorder = &MockCompatibilityCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompatibilityCache) EXPECT() *MockCompatibilityCacheMockRecorder {
+	return m.recorder
+}
+
+// GetOrCompute mocks base method.
+func (m *MockCompatibilityCache) GetOrCompute(userID, otherID uuid.UUID, compute func(uuid.UUID, uuid.UUID) (models.Compatibility, error)) (models.Compatibility, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCompute", userID, otherID, compute)
+	ret0, _ := ret[0].(models.Compatibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrCompute indicates an expected call of GetOrCompute.
+func (mr *MockCompatibilityCacheMockRecorder) GetOrCompute(userID, otherID, compute any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCompute", reflect.TypeOf((*MockCompatibilityCache)(nil).GetOrCompute), userID, otherID, compute)
+}
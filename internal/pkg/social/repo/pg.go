@@ -0,0 +1,182 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/social"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	foreignKeyViolation = "23503"
+	checkViolation      = "23514"
+)
+
+type SocialRepository struct {
+	db pgxtype.Querier
+}
+
+func NewSocialRepository(db pgxtype.Querier) *SocialRepository {
+	return &SocialRepository{db: db}
+}
+
+func (r *SocialRepository) CheckUserExists(ctx context.Context, userID uuid.UUID) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var exists bool
+	if err := r.db.QueryRow(ctx, CheckUserExistsQuery, userID).Scan(&exists); err != nil {
+		logger.Error("failed to check user: " + err.Error())
+		return false, social.ErrorInternalServerError
+	}
+	return exists, nil
+}
+
+func (r *SocialRepository) CreateFollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := r.db.Exec(ctx, CreateFollowQuery, followerID, followeeID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case foreignKeyViolation:
+				logger.Error("followee not exists: " + pgErr.Message)
+				return social.ErrorNotFound
+			case checkViolation:
+				logger.Error("user tried to follow himself: " + pgErr.Message)
+				return social.ErrorBadRequest
+			}
+		}
+		logger.Error("failed to follow user: " + err.Error())
+		return social.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully followed user in db")
+	return nil
+}
+
+func (r *SocialRepository) DeleteFollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if _, err := r.db.Exec(ctx, DeleteFollowQuery, followerID, followeeID); err != nil {
+		logger.Error("failed to unfollow user: " + err.Error())
+		return social.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully unfollowed user in db")
+	return nil
+}
+
+func (r *SocialRepository) getFollowUsers(ctx context.Context, query string, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		logger.Error("failed to query follow list: " + err.Error())
+		return nil, social.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	result := []models.FollowUser{}
+	for rows.Next() {
+		var user models.FollowUser
+		if err := rows.Scan(&user.ID, &user.Login, &user.Avatar, &user.FollowedAt); err != nil {
+			logger.Error("failed to scan follow list: " + err.Error())
+			return nil, social.ErrorInternalServerError
+		}
+		result = append(result, user)
+	}
+
+	logger.Info("succesfully got follow list from db")
+	return result, nil
+}
+
+func (r *SocialRepository) GetFollowers(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error) {
+	return r.getFollowUsers(ctx, GetFollowersQuery, userID, limit, offset)
+}
+
+func (r *SocialRepository) GetFollowing(ctx context.Context, userID uuid.UUID, limit, offset int) ([]models.FollowUser, error) {
+	return r.getFollowUsers(ctx, GetFollowingQuery, userID, limit, offset)
+}
+
+func (r *SocialRepository) GetFeed(ctx context.Context, userID uuid.UUID, cursor *models.Cursor, limit int) ([]models.FeedItem, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var cursorTime *time.Time
+	var cursorID uuid.NullUUID
+	if cursor != nil {
+		cursorTime = &cursor.CreatedAt
+		cursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+	}
+
+	rows, err := r.db.Query(ctx, GetFeedQuery, userID, cursorTime, cursorID, limit)
+	if err != nil {
+		logger.Error("failed to query feed: " + err.Error())
+		return nil, social.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	items := []models.FeedItem{}
+	for rows.Next() {
+		var item models.FeedItem
+		if err := rows.Scan(
+			&item.ID, &item.UserID, &item.UserLogin, &item.UserAvatar,
+			&item.FilmID, &item.FilmTitle, &item.FilmCover,
+			&item.Title, &item.Text, &item.Rating, &item.CreatedAt,
+		); err != nil {
+			logger.Error("failed to scan feed item: " + err.Error())
+			return nil, social.ErrorInternalServerError
+		}
+		item.Type = models.FeedItemRating
+		if item.Title != nil && *item.Title != "" {
+			item.Type = models.FeedItemReview
+		}
+		items = append(items, item)
+	}
+
+	logger.Info("succesfully got feed from db")
+	return items, nil
+}
+
+func (r *SocialRepository) CanViewActivity(ctx context.Context, viewerID, ownerID uuid.UUID) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var visible bool
+	if err := r.db.QueryRow(ctx, CanViewActivityQuery, viewerID, ownerID).Scan(&visible); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return false, social.ErrorNotFound
+		}
+		logger.Error("failed to check activity visibility: " + err.Error())
+		return false, social.ErrorInternalServerError
+	}
+	return visible, nil
+}
+
+func (r *SocialRepository) GetCoRatedFilms(ctx context.Context, userID, otherID uuid.UUID) ([]models.CompatibilityFilm, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := r.db.Query(ctx, GetCoRatedFilmsQuery, userID, otherID)
+	if err != nil {
+		logger.Error("failed to query co-rated films: " + err.Error())
+		return nil, social.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	result := []models.CompatibilityFilm{}
+	for rows.Next() {
+		var film models.CompatibilityFilm
+		if err := rows.Scan(&film.FilmID, &film.Title, &film.Cover, &film.UserRating, &film.OtherRating); err != nil {
+			logger.Error("failed to scan co-rated film: " + err.Error())
+			return nil, social.ErrorInternalServerError
+		}
+		result = append(result, film)
+	}
+
+	logger.Info("succesfully got co-rated films from db")
+	return result, nil
+}
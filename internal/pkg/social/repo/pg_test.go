@@ -0,0 +1,280 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/social"
+
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+type MockRow struct {
+	err error
+}
+
+func (m MockRow) Scan(dest ...interface{}) error {
+	return m.err
+}
+
+var feedColumns = []string{
+	"id", "user_id", "login", "avatar", "film_id", "film_title", "cover", "title", "text", "rating", "created_at",
+}
+
+func TestCreateFollow(t *testing.T) {
+	followerID := uuid.NewV4()
+	followeeID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), CreateFollowQuery, followerID, followeeID).Return(pgconn.CommandTag("INSERT 0 1"), nil)
+			},
+		},
+		{
+			name: "Already following",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), CreateFollowQuery, followerID, followeeID).Return(pgconn.CommandTag("INSERT 0 0"), nil)
+			},
+		},
+		{
+			name: "Followee not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), CreateFollowQuery, followerID, followeeID).
+					Return(nil, &pgconn.PgError{Code: foreignKeyViolation})
+			},
+			wantErr: social.ErrorNotFound,
+		},
+		{
+			name: "Self follow",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), CreateFollowQuery, followerID, followeeID).
+					Return(nil, &pgconn.PgError{Code: checkViolation})
+			},
+			wantErr: social.ErrorBadRequest,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), CreateFollowQuery, followerID, followeeID).Return(nil, errors.New("db error"))
+			},
+			wantErr: social.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewSocialRepository(mockPool)
+			err := repo.CreateFollow(testContext(), followerID, followeeID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetFollowers(t *testing.T) {
+	userID := uuid.NewV4()
+	follower := models.FollowUser{ID: uuid.NewV4(), Login: "cinephile", Avatar: "avatars/default.png", FollowedAt: time.Now()}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	rows := pgxpoolmock.NewRows([]string{"id", "login", "avatar", "created_at"}).
+		AddRow(follower.ID, follower.Login, follower.Avatar, follower.FollowedAt).
+		ToPgxRows()
+	mockPool.EXPECT().Query(gomock.Any(), GetFollowersQuery, userID, 10, 0).Return(rows, nil)
+
+	repo := NewSocialRepository(mockPool)
+	followers, err := repo.GetFollowers(testContext(), userID, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.FollowUser{follower}, followers)
+}
+
+func TestGetFeed(t *testing.T) {
+	userID := uuid.NewV4()
+	authorID := uuid.NewV4()
+	filmID := uuid.NewV4()
+	createdAt := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	cursor := &models.Cursor{CreatedAt: createdAt.Add(time.Hour), ID: uuid.NewV4()}
+	rating := 8
+	title := "Шедевр"
+	text := "Смотреть всем"
+	emptyTitle := ""
+
+	ratingID := uuid.NewV4()
+	reviewID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		cursor     *models.Cursor
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantTypes  []string
+		wantErr    error
+	}{
+		{
+			name: "First page",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(feedColumns).
+					AddRow(reviewID, authorID, "critic", "", filmID, "Дюна", "/covers/dune.png", &title, &text, &rating, createdAt).
+					AddRow(ratingID, authorID, "critic", "", filmID, "Дюна", "/covers/dune.png", &emptyTitle, (*string)(nil), &rating, createdAt).
+					ToPgxRows()
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetFeedQuery, userID, (*time.Time)(nil), uuid.NullUUID{}, 11).
+					Return(rows, nil)
+			},
+			wantTypes: []string{models.FeedItemReview, models.FeedItemRating},
+		},
+		{
+			name:   "Next page",
+			cursor: cursor,
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows(feedColumns).ToPgxRows()
+				mockPool.EXPECT().
+					Query(gomock.Any(), GetFeedQuery, userID, &cursor.CreatedAt, uuid.NullUUID{UUID: cursor.ID, Valid: true}, 11).
+					Return(rows, nil)
+			},
+			wantTypes: []string{},
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetFeedQuery, gomock.Any(), gomock.Any(), gomock.Any(), 11).
+					Return(nil, errors.New("db error"))
+			},
+			wantErr: social.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewSocialRepository(mockPool)
+			items, err := repo.GetFeed(testContext(), userID, tt.cursor, 11)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			types := make([]string, 0, len(items))
+			for _, item := range items {
+				types = append(types, item.Type)
+			}
+			assert.Equal(t, tt.wantTypes, types)
+		})
+	}
+}
+
+func TestCanViewActivity(t *testing.T) {
+	viewerID := uuid.NewV4()
+	ownerID := uuid.NewV4()
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantVisible bool
+		wantErr     error
+	}{
+		{
+			name: "Visible",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"visible"}).AddRow(true).ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), CanViewActivityQuery, viewerID, ownerID).Return(rows)
+			},
+			wantVisible: true,
+		},
+		{
+			name: "User not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), CanViewActivityQuery, viewerID, ownerID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: social.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), CanViewActivityQuery, viewerID, ownerID).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: social.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewSocialRepository(mockPool)
+			visible, err := repo.CanViewActivity(testContext(), viewerID, ownerID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVisible, visible)
+		})
+	}
+}
+
+func TestGetCoRatedFilms(t *testing.T) {
+	userID := uuid.NewV4()
+	otherID := uuid.NewV4()
+	film := models.CompatibilityFilm{FilmID: uuid.NewV4(), Title: "Дюна", Cover: "/covers/dune.png", UserRating: 9, OtherRating: 4}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	rows := pgxpoolmock.NewRows([]string{"id", "title", "cover", "rating", "rating"}).
+		AddRow(film.FilmID, film.Title, film.Cover, film.UserRating, film.OtherRating).
+		ToPgxRows()
+	mockPool.EXPECT().Query(gomock.Any(), GetCoRatedFilmsQuery, userID, otherID).Return(rows, nil)
+
+	repo := NewSocialRepository(mockPool)
+	films, err := repo.GetCoRatedFilms(testContext(), userID, otherID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CompatibilityFilm{film}, films)
+}
@@ -0,0 +1,27 @@
+package repo
+
+import _ "embed"
+
+//go:embed sql/checkUserExistsQuery.sql
+var CheckUserExistsQuery string
+
+//go:embed sql/createFollowQuery.sql
+var CreateFollowQuery string
+
+//go:embed sql/deleteFollowQuery.sql
+var DeleteFollowQuery string
+
+//go:embed sql/getFollowersQuery.sql
+var GetFollowersQuery string
+
+//go:embed sql/getFollowingQuery.sql
+var GetFollowingQuery string
+
+//go:embed sql/getFeedQuery.sql
+var GetFeedQuery string
+
+//go:embed sql/canViewActivityQuery.sql
+var CanViewActivityQuery string
+
+//go:embed sql/getCoRatedFilmsQuery.sql
+var GetCoRatedFilmsQuery string
@@ -0,0 +1,5 @@
+SELECT NOT u.followers_only OR EXISTS (
+    SELECT 1 FROM user_follow WHERE follower_id = $1 AND followee_id = u.id
+)
+FROM user_table u
+WHERE u.id = $2
\ No newline at end of file
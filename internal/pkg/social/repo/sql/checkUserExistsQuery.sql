@@ -0,0 +1 @@
+SELECT EXISTS (SELECT 1 FROM user_table WHERE id = $1)
\ No newline at end of file
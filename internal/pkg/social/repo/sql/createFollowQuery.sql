@@ -0,0 +1,3 @@
+INSERT INTO user_follow (follower_id, followee_id)
+VALUES ($1, $2)
+ON CONFLICT (follower_id, followee_id) DO NOTHING
\ No newline at end of file
@@ -0,0 +1,8 @@
+SELECT f.id, f.title, COALESCE(f.cover, ''), a.rating, b.rating
+FROM film_feedback a
+JOIN film_feedback b ON b.film_id = a.film_id AND b.user_id = $2
+JOIN film f ON f.id = a.film_id
+WHERE a.user_id = $1
+    AND a.rating IS NOT NULL
+    AND b.rating IS NOT NULL
+ORDER BY f.id
\ No newline at end of file
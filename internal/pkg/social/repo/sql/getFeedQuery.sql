@@ -0,0 +1,20 @@
+SELECT
+    ff.id,
+    ff.user_id,
+    u.login,
+    COALESCE(u.avatar, ''),
+    ff.film_id,
+    f.title,
+    COALESCE(f.cover, ''),
+    ff.title,
+    ff.text,
+    ff.rating,
+    ff.created_at
+FROM user_follow uf
+JOIN film_feedback ff ON ff.user_id = uf.followee_id
+JOIN user_table u ON u.id = ff.user_id
+JOIN film f ON f.id = ff.film_id
+WHERE uf.follower_id = $1
+    AND ($2::timestamptz IS NULL OR (ff.created_at, ff.id) < ($2::timestamptz, $3::uuid))
+ORDER BY ff.created_at DESC, ff.id DESC
+LIMIT $4
\ No newline at end of file
@@ -0,0 +1,6 @@
+SELECT u.id, u.login, COALESCE(u.avatar, ''), uf.created_at
+FROM user_follow uf
+JOIN user_table u ON u.id = uf.follower_id
+WHERE uf.followee_id = $1
+ORDER BY uf.created_at DESC, u.login
+LIMIT $2 OFFSET $3
\ No newline at end of file
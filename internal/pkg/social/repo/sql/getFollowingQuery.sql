@@ -0,0 +1,6 @@
+SELECT u.id, u.login, COALESCE(u.avatar, ''), uf.created_at
+FROM user_follow uf
+JOIN user_table u ON u.id = uf.followee_id
+WHERE uf.follower_id = $1
+ORDER BY uf.created_at DESC, u.login
+LIMIT $2 OFFSET $3
\ No newline at end of file
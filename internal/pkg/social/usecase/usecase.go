@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/social"
+	"kinopoisk/internal/pkg/social/compatibility"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// для корреляции нужно хотя бы столько общих оценок
+const (
+	compatibilityMinOverlap = 5
+	compatibilityFilmsLimit = 5
+)
+
+type SocialUsecase struct {
+	socialRepo         social.SocialRepo
+	compatibilityCache social.CompatibilityCache
+}
+
+func NewSocialUsecase(repo social.SocialRepo, compatibilityCache social.CompatibilityCache) *SocialUsecase {
+	return &SocialUsecase{socialRepo: repo, compatibilityCache: compatibilityCache}
+}
+
+func currentUser(ctx context.Context) (models.User, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, ok := ctx.Value(auth.UserKey).(models.User)
+	if !ok {
+		logger.Error("user is unauthorized")
+		return models.User{}, social.ErrorUnauthorized
+	}
+	return user, nil
+}
+
+func (uc *SocialUsecase) Follow(ctx context.Context, followeeID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if user.ID == followeeID {
+		logger.Error("user can not follow himself")
+		return social.ErrorBadRequest
+	}
+	return uc.socialRepo.CreateFollow(ctx, user.ID, followeeID)
+}
+
+func (uc *SocialUsecase) Unfollow(ctx context.Context, followeeID uuid.UUID) error {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	return uc.socialRepo.DeleteFollow(ctx, user.ID, followeeID)
+}
+
+func (uc *SocialUsecase) checkUser(ctx context.Context, userID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	exists, err := uc.socialRepo.CheckUserExists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		logger.Error("user not exists")
+		return social.ErrorNotFound
+	}
+	return nil
+}
+
+func (uc *SocialUsecase) GetFollowers(ctx context.Context, userID uuid.UUID, pager models.Pager) ([]models.FollowUser, error) {
+	if err := uc.checkUser(ctx, userID); err != nil {
+		return []models.FollowUser{}, err
+	}
+	return uc.socialRepo.GetFollowers(ctx, userID, pager.Count, pager.Offset)
+}
+
+func (uc *SocialUsecase) GetFollowing(ctx context.Context, userID uuid.UUID, pager models.Pager) ([]models.FollowUser, error) {
+	if err := uc.checkUser(ctx, userID); err != nil {
+		return []models.FollowUser{}, err
+	}
+	return uc.socialRepo.GetFollowing(ctx, userID, pager.Count, pager.Offset)
+}
+
+func (uc *SocialUsecase) GetFeed(ctx context.Context, cursor *models.Cursor, count int) (models.FeedPage, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return models.FeedPage{}, err
+	}
+
+	// лишняя запись показывает, есть ли следующая страница
+	items, err := uc.socialRepo.GetFeed(ctx, user.ID, cursor, count+1)
+	if err != nil {
+		return models.FeedPage{}, err
+	}
+
+	page := models.FeedPage{Items: items}
+	if len(items) > count {
+		page.Items = items[:count]
+		last := page.Items[count-1]
+		page.NextCursor = models.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return page, nil
+}
+
+func (uc *SocialUsecase) GetCompatibility(ctx context.Context, otherID uuid.UUID) (models.Compatibility, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	user, err := currentUser(ctx)
+	if err != nil {
+		return models.Compatibility{}, err
+	}
+	if user.ID == otherID {
+		logger.Error("compatibility with himself requested")
+		return models.Compatibility{}, social.ErrorBadRequest
+	}
+
+	visible, err := uc.socialRepo.CanViewActivity(ctx, user.ID, otherID)
+	if err != nil {
+		return models.Compatibility{}, err
+	}
+	if !visible {
+		logger.Error("activity of user is visible only to followers")
+		return models.Compatibility{}, social.ErrorForbidden
+	}
+
+	compute := func(first, second uuid.UUID) (models.Compatibility, error) {
+		films, err := uc.socialRepo.GetCoRatedFilms(ctx, first, second)
+		if err != nil {
+			return models.Compatibility{}, err
+		}
+		return compatibility.Compute(first, second, films, compatibilityMinOverlap, compatibilityFilmsLimit), nil
+	}
+	if uc.compatibilityCache == nil {
+		return compute(user.ID, otherID)
+	}
+	return uc.compatibilityCache.GetOrCompute(user.ID, otherID, compute)
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/social"
+	"kinopoisk/internal/pkg/social/compatibility"
+	"kinopoisk/internal/pkg/social/mocks"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+}
+
+func testContext() context.Context {
+	testLogger := testLogger()
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestSocialUsecase_Follow(t *testing.T) {
+	user := models.User{ID: uuid.NewV4(), Login: "cinephile"}
+	followeeID := uuid.NewV4()
+
+	tests := []struct {
+		name      string
+		withUser  bool
+		followee  uuid.UUID
+		setupMock func(mockRepo *mocks.MockSocialRepo)
+		errorType error
+	}{
+		{
+			name:     "Success",
+			withUser: true,
+			followee: followeeID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CreateFollow(gomock.Any(), user.ID, followeeID).Return(nil)
+			},
+		},
+		{
+			name:      "Follow himself",
+			withUser:  true,
+			followee:  user.ID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {},
+			errorType: social.ErrorBadRequest,
+		},
+		{
+			name:      "Unauthorized",
+			followee:  followeeID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {},
+			errorType: social.ErrorUnauthorized,
+		},
+		{
+			name:     "Followee not found",
+			withUser: true,
+			followee: followeeID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CreateFollow(gomock.Any(), user.ID, followeeID).Return(social.ErrorNotFound)
+			},
+			errorType: social.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSocialRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			ctx := testContext()
+			if tt.withUser {
+				ctx = context.WithValue(ctx, auth.UserKey, user)
+			}
+
+			err := NewSocialUsecase(mockRepo, nil).Follow(ctx, tt.followee)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSocialUsecase_GetFollowers(t *testing.T) {
+	userID := uuid.NewV4()
+	pager := models.Pager{Count: 10, Offset: 0}
+	followers := []models.FollowUser{{ID: uuid.NewV4(), Login: "critic"}}
+
+	tests := []struct {
+		name      string
+		setupMock func(mockRepo *mocks.MockSocialRepo)
+		expected  []models.FollowUser
+		errorType error
+	}{
+		{
+			name: "Success",
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CheckUserExists(gomock.Any(), userID).Return(true, nil)
+				mockRepo.EXPECT().GetFollowers(gomock.Any(), userID, 10, 0).Return(followers, nil)
+			},
+			expected: followers,
+		},
+		{
+			name: "User not found",
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CheckUserExists(gomock.Any(), userID).Return(false, nil)
+			},
+			errorType: social.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSocialRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			result, err := NewSocialUsecase(mockRepo, nil).GetFollowers(testContext(), userID, pager)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSocialUsecase_GetFeed(t *testing.T) {
+	user := models.User{ID: uuid.NewV4(), Login: "cinephile"}
+	base := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	items := make([]models.FeedItem, 3)
+	for i := range items {
+		items[i] = models.FeedItem{ID: uuid.NewV4(), Type: models.FeedItemRating, CreatedAt: base.Add(-time.Duration(i) * time.Minute)}
+	}
+
+	tests := []struct {
+		name           string
+		count          int
+		setupMock      func(mockRepo *mocks.MockSocialRepo)
+		wantItems      int
+		wantNextCursor string
+	}{
+		{
+			name:  "Has next page",
+			count: 2,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().GetFeed(gomock.Any(), user.ID, nil, 3).Return(items, nil)
+			},
+			wantItems:      2,
+			wantNextCursor: models.Cursor{CreatedAt: items[1].CreatedAt, ID: items[1].ID}.Encode(),
+		},
+		{
+			name:  "Last page",
+			count: 5,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().GetFeed(gomock.Any(), user.ID, nil, 6).Return(items, nil)
+			},
+			wantItems: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSocialRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			ctx := context.WithValue(testContext(), auth.UserKey, user)
+			page, err := NewSocialUsecase(mockRepo, nil).GetFeed(ctx, nil, tt.count)
+			assert.NoError(t, err)
+			assert.Len(t, page.Items, tt.wantItems)
+			assert.Equal(t, tt.wantNextCursor, page.NextCursor)
+		})
+	}
+}
+
+func TestSocialUsecase_GetCompatibility(t *testing.T) {
+	user := models.User{ID: uuid.FromStringOrNil("00000000-0000-0000-0000-0000000000ff"), Login: "cinephile"}
+	otherID := uuid.FromStringOrNil("00000000-0000-0000-0000-000000000001")
+	films := []models.CompatibilityFilm{
+		{FilmID: uuid.NewV4(), Title: "Дюна", UserRating: 9, OtherRating: 2},
+	}
+
+	tests := []struct {
+		name      string
+		other     uuid.UUID
+		setupMock func(mockRepo *mocks.MockSocialRepo)
+		errorType error
+	}{
+		{
+			name:  "Success",
+			other: otherID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CanViewActivity(gomock.Any(), user.ID, otherID).Return(true, nil)
+				// пара считается в каноническом порядке, поэтому оценки приходят развёрнутыми
+				mockRepo.EXPECT().GetCoRatedFilms(gomock.Any(), otherID, user.ID).Return(films, nil)
+			},
+		},
+		{
+			name:      "With himself",
+			other:     user.ID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {},
+			errorType: social.ErrorBadRequest,
+		},
+		{
+			name:  "Followers only",
+			other: otherID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CanViewActivity(gomock.Any(), user.ID, otherID).Return(false, nil)
+			},
+			errorType: social.ErrorForbidden,
+		},
+		{
+			name:  "User not found",
+			other: otherID,
+			setupMock: func(mockRepo *mocks.MockSocialRepo) {
+				mockRepo.EXPECT().CanViewActivity(gomock.Any(), user.ID, otherID).Return(false, social.ErrorNotFound)
+			},
+			errorType: social.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockSocialRepo(ctrl)
+			tt.setupMock(mockRepo)
+
+			ctx := context.WithValue(testContext(), auth.UserKey, user)
+			result, err := NewSocialUsecase(mockRepo, compatibility.NewCache(10)).GetCompatibility(ctx, tt.other)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID, result.UserID)
+			assert.Equal(t, otherID, result.OtherUserID)
+			assert.Equal(t, 1, result.CommonFilms)
+			assert.Nil(t, result.Score)
+			assert.Equal(t, 2, result.Disagree[0].UserRating)
+			assert.Equal(t, 9, result.Disagree[0].OtherRating)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// tempPrefix у недописанных файлов; List их пропускает
+const tempPrefix = ".tmp-"
+
+// DiskBucket хранит объекты файлами под root, ключ это относительный путь.
+// Метаданные не сохраняются: тип определяется по расширению, кэширование задаёт FileHandler
+type DiskBucket struct {
+	root string
+}
+
+func NewDiskBucket(root string) *DiskBucket {
+	return &DiskBucket{root: root}
+}
+
+func (b *DiskBucket) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrorInvalidKey
+	}
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
+
+// Put пишет во временный файл и переименовывает, чтобы читатели не видели файл наполовину
+func (b *DiskBucket) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	filePath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logger.Error("failed to create dir: " + err.Error())
+		return ErrorStorage
+	}
+	tmp, err := os.CreateTemp(dir, tempPrefix+"*")
+	if err != nil {
+		logger.Error("failed to create file: " + err.Error())
+		return ErrorStorage
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		logger.Error("failed to write file: " + err.Error())
+		return ErrorStorage
+	}
+	if err := tmp.Close(); err != nil {
+		logger.Error("failed to write file: " + err.Error())
+		return ErrorStorage
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		logger.Error("failed to chmod file: " + err.Error())
+		return ErrorStorage
+	}
+	if err := os.Rename(tmp.Name(), filePath); err != nil {
+		logger.Error("failed to rename file: " + err.Error())
+		return ErrorStorage
+	}
+
+	logger.Info("succesfully put object", slog.String("key", key))
+	return nil
+}
+
+func (b *DiskBucket) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	filePath, err := b.path(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ObjectInfo{}, ErrorNotFound
+		}
+		logger.Error("failed to open file: " + err.Error())
+		return nil, ObjectInfo{}, ErrorStorage
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		logger.Error("failed to stat file: " + err.Error())
+		return nil, ObjectInfo{}, ErrorStorage
+	}
+	if stat.IsDir() {
+		file.Close()
+		return nil, ObjectInfo{}, ErrorNotFound
+	}
+
+	return file, b.info(key, stat), nil
+}
+
+func (b *DiskBucket) Delete(ctx context.Context, key string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	filePath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrorNotFound
+		}
+		logger.Error("failed to delete file: " + err.Error())
+		return ErrorStorage
+	}
+
+	logger.Info("succesfully deleted object", slog.String("key", key))
+	return nil
+}
+
+func (b *DiskBucket) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	filePath, err := b.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ObjectInfo{}, ErrorNotFound
+		}
+		logger.Error("failed to stat file: " + err.Error())
+		return ObjectInfo{}, ErrorStorage
+	}
+	if stat.IsDir() {
+		return ObjectInfo{}, ErrorNotFound
+	}
+	return b.info(key, stat), nil
+}
+
+// List ключи с префиксом prefix; префикс не обязан заканчиваться на "/"
+func (b *DiskBucket) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	dir := b.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		if !validKey(prefix[:i]) {
+			return nil, ErrorInvalidKey
+		}
+		dir = filepath.Join(b.root, filepath.FromSlash(prefix[:i]))
+	}
+
+	objects := []ObjectInfo{}
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), tempPrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(b.root, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		stat, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		objects = append(objects, b.info(key, stat))
+		return nil
+	})
+	if err != nil {
+		logger.Error("failed to list files: " + err.Error())
+		return nil, ErrorStorage
+	}
+
+	logger.Info("succesfully listed objects", slog.Int("count", len(objects)))
+	return objects, nil
+}
+
+func (b *DiskBucket) info(key string, stat fs.FileInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:         path.Clean(key),
+		Size:        stat.Size(),
+		ContentType: contentTypeByKey(key),
+		ModTime:     stat.ModTime(),
+	}
+}
@@ -0,0 +1,10 @@
+package storage
+
+import "errors"
+
+var (
+	ErrorNotFound   = errors.New("object not found")
+	ErrorInvalidKey = errors.New("invalid object key")
+	ErrorStorage    = errors.New("storage error")
+	ErrorNoSecret   = errors.New("signing key is not set")
+)
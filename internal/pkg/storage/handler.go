@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultCacheControl ключи аватаров и кадров уникальны и не перезаписываются
+const DefaultCacheControl = "public, max-age=31536000, immutable"
+
+// FileHandler раздаёт объекты хранилища по пути запроса без ведущего "/",
+// то есть /static/avatars/x.jpg отдаёт ключ static/avatars/x.jpg, как и в бакете S3
+type FileHandler struct {
+	bucket       Bucket
+	cacheControl string
+}
+
+func NewFileHandler(bucket Bucket, cacheControl string) *FileHandler {
+	if cacheControl == "" {
+		cacheControl = DefaultCacheControl
+	}
+	return &FileHandler{bucket: bucket, cacheControl: cacheControl}
+}
+
+func (h *FileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		problem.WriteStatus(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	body, info, err := h.bucket.Get(r.Context(), key)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrorNotFound), errors.Is(err, ErrorInvalidKey):
+			log.LogHandlerError(logger, err, http.StatusNotFound)
+			problem.WriteStatus(w, r, http.StatusNotFound)
+		default:
+			log.LogHandlerError(logger, err, http.StatusInternalServerError)
+			problem.WriteStatus(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+	defer body.Close()
+
+	cacheControl := info.CacheControl
+	if cacheControl == "" {
+		cacheControl = h.cacheControl
+	}
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// загруженные пользователями файлы не должны исполняться как страница нашего сайта
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+
+	// ServeContent отвечает на If-Modified-Since и Range, ему нужен Seeker; у S3 его нет
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", info.ModTime, seeker)
+		log.LogHandlerInfo(logger, "success", http.StatusOK)
+		return
+	}
+
+	if !info.ModTime.IsZero() {
+		w.Header().Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	if r.Method == http.MethodHead {
+		log.LogHandlerInfo(logger, "success", http.StatusOK)
+		return
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Bucket хранилище файлов по ключам вида "static/avatars/<id>/512.jpg"
+type Bucket interface {
+	Put(ctx context.Context, key string, body io.Reader, opts PutOptions) error
+	// Get возвращает содержимое, которое нужно закрыть; у дискового хранилища оно ещё и io.Seeker
+	Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+}
+
+// Presigner выдаёт ссылку, по которой клиент загружает объект сам, минуя наш сервер
+type Presigner interface {
+	PresignPut(ctx context.Context, key string, opts PresignOptions) (PresignedPut, error)
+}
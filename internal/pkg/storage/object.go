@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	CacheControl string
+	ModTime      time.Time
+}
+
+type PutOptions struct {
+	ContentType  string
+	CacheControl string
+	// Public делает объект доступным без подписи; диск раздаёт всё, что в нём лежит
+	Public bool
+}
+
+// validKey не пускает ключи, которые на диске вышли бы за пределы корня
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || strings.ContainsRune(key, 0) {
+		return false
+	}
+	return path.Clean(key) == key && key != "." && key != ".." && !strings.HasPrefix(key, "../")
+}
+
+// contentTypeByKey тип по расширению, если он не был задан при загрузке
+func contentTypeByKey(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// PresignOptions условия загрузки, которые входят в подпись: другой тип или размер хранилище не примет
+type PresignOptions struct {
+	ContentType string
+	Size        int64
+	Expires     time.Duration
+}
+
+// PresignedPut запрос, который должен выполнить клиент; заголовки из Headers отправляются как есть
+type PresignedPut struct {
+	URL       string
+	Method    string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
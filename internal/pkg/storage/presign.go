@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DiskPresigner повторяет для диска presigned PUT из S3: выдаёт ссылку с подписью
+// и сам принимает по ней загрузку. Ссылка ведёт на baseURL + ключ, поэтому
+// ServeHTTP надо смонтировать по тому же префиксу с http.StripPrefix
+type DiskPresigner struct {
+	bucket  *DiskBucket
+	baseURL string
+	secret  []byte
+}
+
+// NewDiskPresigner без ключа не создаётся: пустой ключ позволил бы любому подписать загрузку
+func NewDiskPresigner(bucket *DiskBucket, baseURL string, secret []byte) (*DiskPresigner, error) {
+	if len(secret) == 0 {
+		return nil, ErrorNoSecret
+	}
+	return &DiskPresigner{bucket: bucket, baseURL: strings.TrimSuffix(baseURL, "/"), secret: secret}, nil
+}
+
+func (p *DiskPresigner) sign(key, contentType string, size, expires int64) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(strings.Join([]string{
+		http.MethodPut, key, contentType, strconv.FormatInt(size, 10), strconv.FormatInt(expires, 10),
+	}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *DiskPresigner) PresignPut(ctx context.Context, key string, opts PresignOptions) (PresignedPut, error) {
+	if !validKey(key) {
+		return PresignedPut{}, ErrorInvalidKey
+	}
+
+	expiresAt := time.Now().Add(opts.Expires)
+	expires := expiresAt.Unix()
+	query := url.Values{}
+	query.Set("size", strconv.FormatInt(opts.Size, 10))
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", p.sign(key, opts.ContentType, opts.Size, expires))
+
+	return PresignedPut{
+		URL:       p.baseURL + "/" + key + "?" + query.Encode(),
+		Method:    http.MethodPut,
+		Headers:   map[string]string{"Content-Type": opts.ContentType},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ServeHTTP принимает загрузку по подписанной ссылке; тело должно быть ровно
+// того размера и типа, что были подписаны
+func (p *DiskPresigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", "PUT")
+		problem.WriteStatus(w, r, http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	query := r.URL.Query()
+	size, sizeErr := strconv.ParseInt(query.Get("size"), 10, 64)
+	expires, expiresErr := strconv.ParseInt(query.Get("expires"), 10, 64)
+	contentType := r.Header.Get("Content-Type")
+	if sizeErr != nil || expiresErr != nil || !validKey(key) ||
+		!hmac.Equal([]byte(query.Get("signature")), []byte(p.sign(key, contentType, size, expires))) {
+		log.LogHandlerError(logger, errors.New("invalid signature"), http.StatusForbidden)
+		problem.WriteStatus(w, r, http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() > expires {
+		log.LogHandlerError(logger, errors.New("signature expired"), http.StatusForbidden)
+		problem.WriteStatus(w, r, http.StatusForbidden)
+		return
+	}
+	if r.ContentLength >= 0 && r.ContentLength != size {
+		log.LogHandlerError(logger, errors.New("content length mismatch"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	// читаем на байт больше, чтобы отличить слишком длинное тело без Content-Length
+	data, err := io.ReadAll(io.LimitReader(r.Body, size+1))
+	if err != nil {
+		log.LogHandlerError(logger, err, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	if int64(len(data)) != size {
+		log.LogHandlerError(logger, errors.New("content length mismatch"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	if err := p.bucket.Put(r.Context(), key, bytes.NewReader(data), PutOptions{ContentType: contentType}); err != nil {
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		problem.WriteStatus(w, r, http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
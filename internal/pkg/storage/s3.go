@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+type S3Bucket struct {
+	client  *s3.Client
+	presign *s3.PresignClient
+	bucket  string
+}
+
+func NewS3Bucket(client *s3.Client, bucket string) *S3Bucket {
+	return &S3Bucket{client: client, presign: s3.NewPresignClient(client), bucket: bucket}
+}
+
+// isNotFound GetObject отвечает NoSuchKey, а HeadObject без тела только кодом NotFound
+func isNotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey")
+}
+
+func (b *S3Bucket) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if !validKey(key) {
+		return ErrorInvalidKey
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(opts.ContentType),
+	}
+	if opts.ContentType == "" {
+		input.ContentType = aws.String(contentTypeByKey(key))
+	}
+	if opts.CacheControl != "" {
+		input.CacheControl = aws.String(opts.CacheControl)
+	}
+	if opts.Public {
+		input.ACL = types.ObjectCannedACLPublicRead
+	}
+
+	if _, err := b.client.PutObject(ctx, input); err != nil {
+		logger.Error("failed to put object to S3", "error", err, "key", key)
+		return ErrorStorage
+	}
+
+	logger.Info("succesfully put object", slog.String("key", key))
+	return nil
+}
+
+func (b *S3Bucket) Get(ctx context.Context, key string) (io.ReadCloser, ObjectInfo, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if !validKey(key) {
+		return nil, ObjectInfo{}, ErrorInvalidKey
+	}
+
+	output, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ObjectInfo{}, ErrorNotFound
+		}
+		logger.Error("failed to get object from S3", "error", err, "key", key)
+		return nil, ObjectInfo{}, ErrorStorage
+	}
+
+	return output.Body, ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(output.ContentLength),
+		ContentType:  aws.ToString(output.ContentType),
+		CacheControl: aws.ToString(output.CacheControl),
+		ModTime:      aws.ToTime(output.LastModified),
+	}, nil
+}
+
+// Delete в S3 удаление отсутствующего ключа не ошибка, поэтому ErrorNotFound отсюда не возвращается
+func (b *S3Bucket) Delete(ctx context.Context, key string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if !validKey(key) {
+		return ErrorInvalidKey
+	}
+
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		logger.Error("failed to delete object from S3", "error", err, "key", key)
+		return ErrorStorage
+	}
+
+	logger.Info("succesfully deleted object", slog.String("key", key))
+	return nil
+}
+
+func (b *S3Bucket) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if !validKey(key) {
+		return ObjectInfo{}, ErrorInvalidKey
+	}
+
+	output, err := b.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return ObjectInfo{}, ErrorNotFound
+		}
+		logger.Error("failed to stat object in S3", "error", err, "key", key)
+		return ObjectInfo{}, ErrorStorage
+	}
+
+	return ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(output.ContentLength),
+		ContentType:  aws.ToString(output.ContentType),
+		CacheControl: aws.ToString(output.CacheControl),
+		ModTime:      aws.ToTime(output.LastModified),
+	}, nil
+}
+
+func (b *S3Bucket) List(ctx context.Context, prefix string) ([]ObjectInfo, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	objects := []ObjectInfo{}
+	paginator := s3.NewListObjectsV2Paginator(b.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(b.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Error("failed to list objects in S3", "error", err, "prefix", prefix)
+			return nil, ErrorStorage
+		}
+		for _, object := range page.Contents {
+			objects = append(objects, ObjectInfo{
+				Key:     aws.ToString(object.Key),
+				Size:    aws.ToInt64(object.Size),
+				ModTime: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+
+	logger.Info("succesfully listed objects", slog.Int("count", len(objects)))
+	return objects, nil
+}
+
+// PresignPut подписывает Content-Type и Content-Length, поэтому S3 отклонит загрузку другого размера или типа
+func (b *S3Bucket) PresignPut(ctx context.Context, key string, opts PresignOptions) (PresignedPut, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if !validKey(key) {
+		return PresignedPut{}, ErrorInvalidKey
+	}
+
+	expiresAt := time.Now().Add(opts.Expires)
+	request, err := b.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(b.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(opts.ContentType),
+		ContentLength: aws.Int64(opts.Size),
+	}, s3.WithPresignExpires(opts.Expires))
+	if err != nil {
+		logger.Error("failed to presign upload", "error", err, "key", key)
+		return PresignedPut{}, ErrorStorage
+	}
+
+	headers := make(map[string]string, len(request.SignedHeader))
+	for name := range request.SignedHeader {
+		// Host браузер выставляет сам и переопределить его не даст
+		if http.CanonicalHeaderKey(name) == "Host" {
+			continue
+		}
+		headers[http.CanonicalHeaderKey(name)] = request.SignedHeader.Get(name)
+	}
+
+	logger.Info("succesfully presigned upload", slog.String("key", key))
+	return PresignedPut{URL: request.URL, Method: request.Method, Headers: headers, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/pkg/middleware/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestDiskBucket(t *testing.T) {
+	ctx := testContext()
+	bucket := NewDiskBucket(t.TempDir())
+
+	assert.NoError(t, bucket.Put(ctx, "static/avatars/a/64.jpg", strings.NewReader("small"), PutOptions{}))
+	assert.NoError(t, bucket.Put(ctx, "static/avatars/a/512.jpg", strings.NewReader("large"), PutOptions{}))
+	assert.NoError(t, bucket.Put(ctx, "static/posters/b.png", strings.NewReader("poster"), PutOptions{}))
+	// перезапись заменяет файл целиком
+	assert.NoError(t, bucket.Put(ctx, "static/avatars/a/64.jpg", strings.NewReader("tiny"), PutOptions{}))
+
+	body, info, err := bucket.Get(ctx, "static/avatars/a/64.jpg")
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "tiny", string(content))
+	assert.Equal(t, "image/jpeg", info.ContentType)
+	assert.Equal(t, int64(4), info.Size)
+
+	info, err = bucket.Stat(ctx, "static/posters/b.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", info.ContentType)
+
+	objects, err := bucket.List(ctx, "static/avatars/")
+	assert.NoError(t, err)
+	keys := []string{}
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	assert.ElementsMatch(t, []string{"static/avatars/a/64.jpg", "static/avatars/a/512.jpg"}, keys)
+
+	objects, err = bucket.List(ctx, "static/nothing/")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+
+	assert.NoError(t, bucket.Delete(ctx, "static/avatars/a/64.jpg"))
+	assert.ErrorIs(t, bucket.Delete(ctx, "static/avatars/a/64.jpg"), ErrorNotFound)
+	_, err = bucket.Stat(ctx, "static/avatars/a/64.jpg")
+	assert.ErrorIs(t, err, ErrorNotFound)
+	_, _, err = bucket.Get(ctx, "static/avatars/a")
+	assert.ErrorIs(t, err, ErrorNotFound)
+}
+
+func TestDiskBucketRejectsEscapingKeys(t *testing.T) {
+	ctx := testContext()
+	root := t.TempDir()
+	bucket := NewDiskBucket(filepath.Join(root, "bucket"))
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "a/../../outside", "a\\b", ".", ".."} {
+		assert.ErrorIs(t, bucket.Put(ctx, key, strings.NewReader("x"), PutOptions{}), ErrorInvalidKey, key)
+		_, _, err := bucket.Get(ctx, key)
+		assert.ErrorIs(t, err, ErrorInvalidKey, key)
+	}
+	_, err := os.Stat(filepath.Join(root, "outside"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestFileHandler(t *testing.T) {
+	ctx := testContext()
+	bucket := NewDiskBucket(t.TempDir())
+	assert.NoError(t, bucket.Put(ctx, "static/avatars/a/512.jpg", strings.NewReader("jpeg bytes"), PutOptions{}))
+	handler := NewFileHandler(bucket, "")
+
+	t.Run("Get", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/static/avatars/a/512.jpg", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "jpeg bytes", w.Body.String())
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		assert.Equal(t, DefaultCacheControl, w.Header().Get("Cache-Control"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+	})
+
+	t.Run("Not modified", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/static/avatars/a/512.jpg", nil).WithContext(ctx)
+		r.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("Head", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodHead, "/static/avatars/a/512.jpg", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, "10", w.Header().Get("Content-Length"))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/static/avatars/b/512.jpg", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Traversal", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/static/../../etc/passwd", nil).WithContext(ctx)
+		r.URL.Path = "/static/../../etc/passwd"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Method not allowed", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/static/avatars/a/512.jpg", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestDiskPresigner(t *testing.T) {
+	ctx := testContext()
+	bucket := NewDiskBucket(t.TempDir())
+	_, err := NewDiskPresigner(bucket, "http://localhost/uploads/", nil)
+	assert.ErrorIs(t, err, ErrorNoSecret)
+
+	presigner, err := NewDiskPresigner(bucket, "http://localhost/uploads/", []byte("secret"))
+	assert.NoError(t, err)
+	handler := http.StripPrefix("/uploads", presigner)
+
+	put := func(rawURL, contentType, body string) int {
+		r := httptest.NewRequest(http.MethodPut, rawURL, strings.NewReader(body)).WithContext(ctx)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	signed, err := presigner.PresignPut(ctx, "uploads/avatars/a", PresignOptions{ContentType: "image/png", Size: 5, Expires: time.Minute})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, signed.Method)
+	assert.Equal(t, "image/png", signed.Headers["Content-Type"])
+	assert.True(t, strings.HasPrefix(signed.URL, "http://localhost/uploads/uploads/avatars/a?"))
+
+	t.Run("Wrong content type", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, put(signed.URL, "image/jpeg", "12345"))
+	})
+
+	t.Run("Wrong size", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, put(signed.URL, "image/png", "123456"))
+	})
+
+	t.Run("Other key", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, put(strings.Replace(signed.URL, "avatars/a", "avatars/b", 1), "image/png", "12345"))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired, err := presigner.PresignPut(ctx, "uploads/avatars/a", PresignOptions{ContentType: "image/png", Size: 5, Expires: -time.Minute})
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, put(expired.URL, "image/png", "12345"))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, put(signed.URL, "image/png", "12345"))
+		body, _, err := bucket.Get(ctx, "uploads/avatars/a")
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(body)
+		body.Close()
+		assert.Equal(t, "12345", string(content))
+	})
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
+	"kinopoisk/internal/pkg/users"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const exportFormatCSV = "csv"
+
+// ExportData godoc
+// @Summary Export personal data
+// @Description Downloads profile, ratings and reviews of the current user. format=json (default) returns one JSON file, format=csv returns a zip archive with profile.csv, ratings.csv and reviews.csv
+// @Tags users
+// @Produce json
+// @Produce application/zip
+// @Param format query string false "Archive format" Enums(json, csv)
+// @Success 200 {object} models.UserExport
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/me/export [get]
+func (u *UserHandler) ExportData(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != exportFormatCSV {
+		log.LogHandlerError(logger, errors.New("unknown export format "+format), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	export, err := u.uc.ExportData(r.Context(), userID)
+	if err != nil {
+		problem.WriteError(w, r, logger, sessionError(err))
+		return
+	}
+
+	// выгрузка скачивается файлом, а не рендерится, поэтому данные отдаются как есть, без Sanitize
+	filename := "ddfilms-export-" + export.ExportedAt.Format("2006-01-02")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-store")
+	if format == exportFormatCSV {
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.zip"`)
+		if err := writeExportArchive(w, export); err != nil {
+			log.LogHandlerError(logger, err, http.StatusInternalServerError)
+			return
+		}
+		log.LogHandlerInfo(logger, "success", http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`.json"`)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(export); err != nil {
+		log.LogHandlerError(logger, err, http.StatusInternalServerError)
+		return
+	}
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// writeExportArchive пишет zip с тремя csv; первая строка каждого файла заголовок
+func writeExportArchive(w io.Writer, export models.UserExport) error {
+	archive := zip.NewWriter(w)
+
+	profile := export.Profile
+	email := ""
+	if profile.Email != nil {
+		email = *profile.Email
+	}
+	err := writeCSV(archive, "profile.csv", [][]string{
+		{"id", "login", "email", "email_verified", "avatar", "role", "show_stats", "show_taste", "show_reviews", "followers_only", "created_at", "updated_at"},
+		{
+			profile.ID.String(), profile.Login, email, strconv.FormatBool(profile.EmailVerified), profile.Avatar, profile.Role,
+			strconv.FormatBool(profile.Privacy.ShowStats), strconv.FormatBool(profile.Privacy.ShowTaste),
+			strconv.FormatBool(profile.Privacy.ShowReviews), strconv.FormatBool(profile.Privacy.FollowersOnly),
+			profile.CreatedAt.Format(time.RFC3339), profile.UpdatedAt.Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	ratings := [][]string{{"film_id", "film_title", "rating", "created_at"}}
+	for _, rating := range export.Ratings {
+		ratings = append(ratings, []string{
+			rating.FilmID.String(), rating.FilmTitle, strconv.Itoa(rating.Rating), rating.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	if err := writeCSV(archive, "ratings.csv", ratings); err != nil {
+		return err
+	}
+
+	reviews := [][]string{{"id", "film_id", "film_title", "title", "text", "rating", "created_at", "updated_at"}}
+	for _, review := range export.Reviews {
+		rating := ""
+		if review.Rating != nil {
+			rating = strconv.Itoa(*review.Rating)
+		}
+		reviews = append(reviews, []string{
+			review.ID.String(), review.FilmID.String(), review.FilmTitle, stringOrEmpty(review.Title), stringOrEmpty(review.Text),
+			rating, review.CreatedAt.Format(time.RFC3339), review.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	if err := writeCSV(archive, "reviews.csv", reviews); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func writeCSV(archive *zip.Writer, name string, records [][]string) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	for _, record := range records {
+		for i := range record {
+			record[i] = escapeFormula(record[i])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeFormula не даёт табличным редакторам выполнить текст отзыва как формулу
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Schedules deletion of the current user after a grace period and signs out. Signing in before delete_after cancels the deletion. Afterwards the account, its ratings, reviews and avatar are deleted permanently
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body models.DeleteAccountInput true "Current password"
+// @Success 200 {object} models.AccountDeletion
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/me [delete]
+func (u *UserHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	var req models.DeleteAccountInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
+
+	deletion, err := u.uc.DeleteAccount(r.Context(), userID, req.Password)
+	if err != nil {
+		problem.WriteError(w, r, logger, sessionError(err))
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    "",
+		HttpOnly: false,
+		Secure:   u.cookieSecure,
+		SameSite: u.cookieSamesite,
+		Expires:  time.Now().Add(-12 * time.Hour),
+		Path:     "/",
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		HttpOnly: true,
+		Secure:   u.cookieSecure,
+		SameSite: u.cookieSamesite,
+		Expires:  time.Now().Add(-12 * time.Hour),
+		Path:     "/",
+	})
+
+	helpers.WriteJSON(w, deletion)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
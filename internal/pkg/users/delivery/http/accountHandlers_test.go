@@ -0,0 +1,200 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/users"
+	"kinopoisk/internal/pkg/users/mocks"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testExport(userID uuid.UUID) models.UserExport {
+	title := "=HYPERLINK(\"http://evil\")"
+	text := "Фильм <b>хороший</b>"
+	rating := 9
+	return models.UserExport{
+		ExportedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Profile:    models.ExportProfile{ID: userID, Login: "testuser", Avatar: "avatars/default.png", Role: models.RoleUser},
+		Ratings:    []models.ExportRating{{FilmID: uuid.NewV4(), FilmTitle: "Брат", Rating: rating}},
+		Reviews:    []models.ExportFeedback{{ID: uuid.NewV4(), FilmTitle: "Брат", Title: &title, Text: &text, Rating: &rating}},
+	}
+}
+
+func TestExportData(t *testing.T) {
+	userID := uuid.NewV4()
+
+	t.Run("JSON", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+		mockUsecase.EXPECT().ExportData(gomock.Any(), userID).Return(testExport(userID), nil)
+
+		ctx := context.WithValue(testContext(), users.UserKey, userID)
+		r := httptest.NewRequest(http.MethodGet, "/users/me/export", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		NewUserHandler(mockUsecase).ExportData(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `attachment; filename="ddfilms-export-2026-10-19.json"`, w.Header().Get("Content-Disposition"))
+		var export models.UserExport
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+		// файл не экранируется: в выгрузке то, что лежит в базе
+		assert.Equal(t, "Фильм <b>хороший</b>", *export.Reviews[0].Text)
+	})
+
+	t.Run("CSV archive", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+		mockUsecase.EXPECT().ExportData(gomock.Any(), userID).Return(testExport(userID), nil)
+
+		ctx := context.WithValue(testContext(), users.UserKey, userID)
+		r := httptest.NewRequest(http.MethodGet, "/users/me/export?format=csv", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		NewUserHandler(mockUsecase).ExportData(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		assert.NoError(t, err)
+		files := map[string][][]string{}
+		for _, file := range archive.File {
+			reader, err := file.Open()
+			assert.NoError(t, err)
+			records, err := csv.NewReader(reader).ReadAll()
+			assert.NoError(t, err)
+			files[file.Name] = records
+		}
+		assert.Len(t, files, 3)
+		assert.Equal(t, "testuser", files["profile.csv"][1][1])
+		assert.Equal(t, "9", files["ratings.csv"][1][2])
+		assert.Equal(t, `'=HYPERLINK("http://evil")`, files["reviews.csv"][1][3])
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+
+		ctx := context.WithValue(testContext(), users.UserKey, userID)
+		r := httptest.NewRequest(http.MethodGet, "/users/me/export?format=xml", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		NewUserHandler(mockUsecase).ExportData(w, r)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("No user", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+
+		r := httptest.NewRequest(http.MethodGet, "/users/me/export", nil).WithContext(testContext())
+		w := httptest.NewRecorder()
+
+		NewUserHandler(mockUsecase).ExportData(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	userID := uuid.NewV4()
+	deleteAfter := time.Now().Add(14 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name           string
+		body           string
+		withUser       bool
+		mockSetup      func(mockUsecase *mocks.MockUsersUsecase)
+		expectedStatus int
+	}{
+		{
+			name:     "Success",
+			body:     `{"password":"pass&word1"}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), userID, "pass&amp;word1").
+					Return(models.AccountDeletion{DeleteAfter: deleteAfter}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "No user",
+			body:           `{"password":"password1"}`,
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid body",
+			body:           `{`,
+			withUser:       true,
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Wrong password",
+			body:     `{"password":"wrong"}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), userID, "wrong").Return(models.AccountDeletion{}, users.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Internal error",
+			body:     `{"password":"password1"}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().DeleteAccount(gomock.Any(), userID, "password1").Return(models.AccountDeletion{}, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			handler := NewUserHandler(mockUsecase)
+
+			ctx := testContext()
+			if tt.withUser {
+				ctx = context.WithValue(ctx, users.UserKey, userID)
+			}
+			r := httptest.NewRequest(http.MethodDelete, "/users/me", bytes.NewBufferString(tt.body)).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.DeleteAccount(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var deletion models.AccountDeletion
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &deletion))
+				assert.True(t, deleteAfter.Equal(deletion.DeleteAfter))
+				for _, cookie := range w.Result().Cookies() {
+					assert.Empty(t, cookie.Value, cookie.Name)
+				}
+				assert.Len(t, w.Result().Cookies(), 2)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"html"
 	"io"
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/helpers"
+	"kinopoisk/internal/pkg/problem"
 	"kinopoisk/internal/pkg/users"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
@@ -48,13 +50,21 @@ func NewUserHandler(uc users.UsersUsecase) *UserHandler {
 	}
 }
 
+// sessionError для ручек текущего пользователя: если его уже нет, сессия недействительна, а не 404
+func sessionError(err error) error {
+	if errors.Is(err, users.ErrorNotFound) {
+		return users.ErrorUnauthorized
+	}
+	return err
+}
+
 func (u *UserHandler) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 		csrfCookie, err := r.Cookie(CSRFCookieName)
 		if err != nil {
 			log.LogHandlerError(logger, errors.New("invalid csrf token"), http.StatusUnauthorized)
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 			return
 		}
 		var csrfToken string
@@ -68,14 +78,14 @@ func (u *UserHandler) Middleware(next http.Handler) http.Handler {
 				csrfToken = tokenFromForm
 			} else {
 				log.LogHandlerError(logger, errors.New("csrf-token is empty"), http.StatusUnauthorized)
-				helpers.WriteError(w, http.StatusUnauthorized)
+				problem.WriteStatus(w, r, http.StatusUnauthorized)
 				return
 			}
 		}
 
 		if csrfCookie.Value != csrfToken {
 			log.LogHandlerError(logger, errors.New("invalid csrf-token"), http.StatusUnauthorized)
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 			return
 		}
 		var token string
@@ -86,7 +96,7 @@ func (u *UserHandler) Middleware(next http.Handler) http.Handler {
 
 		user, err := u.uc.ValidateAndGetUser(r.Context(), token)
 		if err != nil {
-			helpers.WriteError(w, http.StatusUnauthorized)
+			problem.WriteStatus(w, r, http.StatusUnauthorized)
 			return
 		}
 		user.Sanitize()
@@ -97,15 +107,32 @@ func (u *UserHandler) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// OptionalMiddleware кладёт в контекст ID пользователя, если он авторизован,
+// но пропускает запрос и без авторизации
+func (u *UserHandler) OptionalMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(CookieName)
+		if err == nil && cookie.Value != "" {
+			user, err := u.uc.ValidateAndGetUser(r.Context(), cookie.Value)
+			if err == nil {
+				ctx := context.WithValue(r.Context(), users.UserKey, user.ID)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // GetUser godoc
 // @Summary Get user by ID
 // @Tags users
 // @Produce json
 // @Param        id   path      string  true  "Genre ID"
 // @Success 200 {object} models.User
-// @Failure 400
-// @Failure 404
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /users/{id} [get]
 func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
@@ -113,18 +140,13 @@ func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.FromString(vars["id"])
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 
 	neededUser, err := u.uc.GetUser(r.Context(), id)
 	if err != nil {
-		switch {
-		case errors.Is(err, users.ErrorNotFound):
-			helpers.WriteError(w, http.StatusNotFound)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 	neededUser.Sanitize()
@@ -132,6 +154,103 @@ func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	log.LogHandlerInfo(logger, "success", http.StatusOK)
 }
 
+// GetUserProfile godoc
+// @Summary Get public profile of user with activity statistics
+// @Tags users
+// @Produce json
+// @Param        id   path      string  true  "User ID"
+// @Success 200 {object} models.UserProfile
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/{id}/profile [get]
+func (u *UserHandler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	id, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid id of user"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	profile, err := u.uc.GetUserProfile(r.Context(), id)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	profile.Sanitize()
+	helpers.WriteJSON(w, profile)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ResolveLogin godoc
+// @Summary Redirect to profile by login
+// @Description Redirects to /users/{id}/profile of the user with this login. Old logins after a change redirect permanently (301) to the current owner
+// @Tags users
+// @Param login path string true "Current or previous login"
+// @Success 301
+// @Success 302
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/by-login/{login} [get]
+func (u *UserHandler) ResolveLogin(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	// логины хранятся экранированными, как их сохранила регистрация
+	login := html.EscapeString(mux.Vars(r)["login"])
+
+	resolution, err := u.uc.ResolveLogin(r.Context(), login)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	status := http.StatusFound
+	if resolution.Previous {
+		status = http.StatusMovedPermanently
+	}
+	// владелец логина может смениться, поэтому редирект не кэшируется надолго
+	w.Header().Set("Cache-Control", "no-cache")
+	// путь относительный, http.Redirect достраивает его от пути запроса вместе с префиксом /api
+	http.Redirect(w, r, "../"+resolution.ID.String()+"/profile", status)
+	log.LogHandlerInfo(logger, "success", status)
+}
+
+// ChangePrivacy godoc
+// @Summary Change which parts of the public profile are visible
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body models.ProfilePrivacy true "Privacy settings"
+// @Success 200 {object} models.ProfilePrivacy
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/change/privacy [put]
+func (u *UserHandler) ChangePrivacy(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ProfilePrivacy
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	privacy, err := u.uc.ChangePrivacy(r.Context(), userID, req)
+	if err != nil {
+		problem.WriteError(w, r, logger, sessionError(err))
+		return
+	}
+	helpers.WriteJSON(w, privacy)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
 // ChangePassword godoc
 // @Summary Change user password
 // @Tags users
@@ -139,16 +258,16 @@ func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param input body models.ChangePasswordInput true "Password data (old_password and new_password are required)"
 // @Success 200 {object} models.User
-// @Failure 400
-// @Failure 401
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /users/password [put]
 func (u *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
 	if !ok {
 		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
-		helpers.WriteError(w, http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
 		return
 	}
 
@@ -156,19 +275,78 @@ func (u *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	req.Sanitize()
 
 	user, token, err := u.uc.ChangePassword(r.Context(), userID, req.OldPassword, req.NewPassword)
 	if err != nil {
-		switch {
-		case errors.Is(err, users.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	csrfToken := uuid.NewV4().String()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    csrfToken,
+		HttpOnly: false,
+		Secure:   u.cookieSecure,
+		SameSite: u.cookieSamesite,
+		Expires:  time.Now().Add(12 * time.Hour),
+		Path:     "/",
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		HttpOnly: true,
+		Secure:   u.cookieSecure,
+		SameSite: u.cookieSamesite,
+		Expires:  time.Now().Add(12 * time.Hour),
+		Path:     "/",
+	})
+	user.Sanitize()
+	w.Header().Set("X-CSRF-Token", csrfToken)
+	helpers.WriteJSON(w, user)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ChangeLogin godoc
+// @Summary Change user login
+// @Description Requires the current password unless the account was created through a provider and has none yet. The old login stays reserved for the user and keeps resolving to the profile; login can be changed once in 30 days. Existing sessions are signed out, the response sets a new session cookie
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body models.ChangeLoginInput true "New login and current password"
+// @Success 200 {object} models.User
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 429 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/change/login [put]
+func (u *UserHandler) ChangeLogin(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ChangeLoginInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
+
+	user, token, err := u.uc.ChangeLogin(r.Context(), userID, req.Login, req.Password)
+	if err != nil {
+		problem.WriteError(w, r, logger, sessionError(err))
 		return
 	}
 
@@ -201,22 +379,23 @@ func (u *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 
 // ChangeAvatar godoc
 // @Summary Change user avatar
+// @Description The image is center-cropped to a square, resized to 64, 256 and 512 px and re-encoded to JPEG without metadata. Sides must be between 64 and 8192 px
 // @Tags users
 // @Accept multipart/form-data
 // @Produce json
 // @Param avatar formData file true "Avatar image file (required, max 10MB, formats: jpg, png, webp)"
 // @Success 200 {object} models.User
-// @Failure 400
-// @Failure 401
-// @Failure 413
-// @Failure 500
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /users/avatar [put]
 func (u *UserHandler) ChangeAvatar(w http.ResponseWriter, r *http.Request) {
 	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
 	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
 	if !ok {
 		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
-		helpers.WriteError(w, http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
 		return
 	}
 
@@ -235,10 +414,10 @@ func (u *UserHandler) ChangeAvatar(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if errors.As(err, new(*http.MaxBytesError)) {
 			log.LogHandlerError(logger, errors.New("file is too large"), http.StatusRequestEntityTooLarge)
-			helpers.WriteError(w, http.StatusRequestEntityTooLarge)
+			problem.WriteStatus(w, r, http.StatusRequestEntityTooLarge)
 			return
 		}
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	defer func() {
@@ -250,7 +429,7 @@ func (u *UserHandler) ChangeAvatar(w http.ResponseWriter, r *http.Request) {
 	file, _, err := newReq.FormFile("avatar")
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("failed to read file"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
 		return
 	}
 	defer func() {
@@ -263,20 +442,112 @@ func (u *UserHandler) ChangeAvatar(w http.ResponseWriter, r *http.Request) {
 	buffer, err := io.ReadAll(file)
 	if err != nil {
 		log.LogHandlerError(logger, errors.New("failed to read file"), http.StatusBadRequest)
-		helpers.WriteError(w, http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	user, token, err := u.uc.ChangeUserAvatar(r.Context(), userID, buffer)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+
+	csrfToken := uuid.NewV4().String()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    csrfToken,
+		HttpOnly: false,
+		Secure:   u.cookieSecure,
+		SameSite: u.cookieSamesite,
+		Expires:  time.Now().Add(12 * time.Hour),
+		Path:     "/",
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    token,
+		HttpOnly: true,
+		Secure:   u.cookieSecure,
+		SameSite: u.cookieSamesite,
+		Expires:  time.Now().Add(12 * time.Hour),
+		Path:     "/",
+	})
+	user.Sanitize()
+	w.Header().Set("X-CSRF-Token", csrfToken)
+	helpers.WriteJSON(w, user)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// RequestAvatarUpload godoc
+// @Summary Request a presigned URL to upload an avatar directly to storage
+// @Description The returned request must be sent as is: method, url and headers are signed together with content type and size. After the upload the slot has to be confirmed within an hour
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body models.AvatarUploadInput true "Content type (image/jpeg, image/png, image/webp) and exact size in bytes (max 10MB)"
+// @Success 200 {object} models.AvatarUploadSlot
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/change/avatar/upload [post]
+func (u *UserHandler) RequestAvatarUpload(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
 		return
 	}
 
-	fileFormat := http.DetectContentType(buffer)
+	var req models.AvatarUploadInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
 
-	user, token, err := u.uc.ChangeUserAvatar(r.Context(), userID, buffer, fileFormat)
+	slot, err := u.uc.RequestAvatarUpload(r.Context(), userID, req.ContentType, req.Size)
 	if err != nil {
-		switch {
-		case errors.Is(err, users.ErrorBadRequest):
-			helpers.WriteError(w, http.StatusBadRequest)
-		default:
-			helpers.WriteError(w, http.StatusInternalServerError)
-		}
+		problem.WriteError(w, r, logger, sessionError(err))
+		return
+	}
+	helpers.WriteJSON(w, slot)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ConfirmAvatarUpload godoc
+// @Summary Confirm a direct avatar upload and make it the user avatar
+// @Description The uploaded file is processed like in PUT /users/change/avatar and then removed from the upload area, so a slot can be confirmed only once
+// @Tags users
+// @Produce json
+// @Param id path string true "Upload slot ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/change/avatar/upload/{id}/confirm [post]
+func (u *UserHandler) ConfirmAvatarUpload(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	uploadID, err := uuid.FromString(mux.Vars(r)["id"])
+	if err != nil {
+		log.LogHandlerError(logger, errors.New("invalid upload id"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	user, token, err := u.uc.ConfirmAvatarUpload(r.Context(), userID, uploadID)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
 		return
 	}
 
@@ -306,3 +577,157 @@ func (u *UserHandler) ChangeAvatar(w http.ResponseWriter, r *http.Request) {
 	helpers.WriteJSON(w, user)
 	log.LogHandlerInfo(logger, "success", http.StatusOK)
 }
+
+// GetEmail godoc
+// @Summary Get email of current user and whether it is verified
+// @Tags users
+// @Produce json
+// @Success 200 {object} models.UserEmail
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/me/email [get]
+func (u *UserHandler) GetEmail(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	email, err := u.uc.GetEmail(r.Context(), userID)
+	if err != nil {
+		problem.WriteError(w, r, logger, sessionError(err))
+		return
+	}
+	email.Sanitize()
+	helpers.WriteJSON(w, email)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ChangeEmail godoc
+// @Summary Set email of current user and send a verification link to it
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body models.ChangeEmailInput true "New email and current password"
+// @Success 200 {object} models.UserEmail
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/change/email [put]
+func (u *UserHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+	userID, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		log.LogHandlerError(logger, errors.New("no user"), http.StatusUnauthorized)
+		problem.WriteStatus(w, r, http.StatusUnauthorized)
+		return
+	}
+
+	var req models.ChangeEmailInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
+
+	email, err := u.uc.ChangeEmail(r.Context(), userID, req.Email, req.Password)
+	if err != nil {
+		problem.WriteError(w, r, logger, sessionError(err))
+		return
+	}
+	email.Sanitize()
+	helpers.WriteJSON(w, email)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// VerifyEmail godoc
+// @Summary Confirm email with the single-use token from the verification letter
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param input body models.VerifyEmailInput true "Token from the letter"
+// @Success 200 {object} models.UserEmail
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/email/verify [post]
+func (u *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	var req models.VerifyEmailInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	email, err := u.uc.VerifyEmail(r.Context(), req.Token)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	email.Sanitize()
+	helpers.WriteJSON(w, email)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ForgotPassword godoc
+// @Summary Send a password reset link to the verified email
+// @Description Always answers 200 for a well-formed email, so the response does not reveal whether the address is registered
+// @Tags users
+// @Accept json
+// @Param input body models.ForgotPasswordInput true "Verified email of the account"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/password/forgot [post]
+func (u *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	var req models.ForgotPasswordInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+
+	err := u.uc.ForgotPassword(r.Context(), req.Email)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
+
+// ResetPassword godoc
+// @Summary Set a new password with the single-use token from the reset letter
+// @Tags users
+// @Accept json
+// @Param input body models.ResetPasswordInput true "Token from the letter and new password"
+// @Success 200
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /users/password/reset [post]
+func (u *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	logger := log.GetLoggerFromContext(r.Context()).With(slog.String("func", log.GetFuncName()))
+
+	var req models.ResetPasswordInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.LogHandlerError(logger, errors.New("invalid request"), http.StatusBadRequest)
+		problem.WriteStatus(w, r, http.StatusBadRequest)
+		return
+	}
+	req.Sanitize()
+
+	err := u.uc.ResetPassword(r.Context(), req.Token, req.Password)
+	if err != nil {
+		problem.WriteError(w, r, logger, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	log.LogHandlerInfo(logger, "success", http.StatusOK)
+}
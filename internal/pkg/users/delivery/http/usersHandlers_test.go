@@ -3,6 +3,7 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -181,6 +182,208 @@ func TestChangePassword(t *testing.T) {
 	}
 }
 
+func TestChangeLogin(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		body           string
+		ucErr          error
+		expectedStatus int
+		expectedCode   string
+		expectedField  string
+	}{
+		{name: "Success", body: `{"login":"newlogin","password":"password1"}`, expectedStatus: http.StatusOK},
+		{name: "Invalid body", body: `{`, expectedStatus: http.StatusBadRequest, expectedCode: "bad_request"},
+		{name: "Wrong password", body: `{"login":"newlogin","password":"wrong"}`, ucErr: users.ErrorWrongPassword, expectedStatus: http.StatusBadRequest, expectedCode: "wrong_password", expectedField: "password"},
+		{name: "Invalid login", body: `{"login":"newlogin","password":"password1"}`, ucErr: users.ErrorInvalidLogin, expectedStatus: http.StatusBadRequest, expectedCode: "login_invalid", expectedField: "login"},
+		{name: "Login taken", body: `{"login":"newlogin","password":"password1"}`, ucErr: users.ErrorLoginTaken, expectedStatus: http.StatusConflict, expectedCode: "login_taken", expectedField: "login"},
+		{name: "Version conflict", body: `{"login":"newlogin","password":"password1"}`, ucErr: users.ErrorConflict, expectedStatus: http.StatusConflict, expectedCode: "conflict"},
+		{name: "Cooldown", body: `{"login":"newlogin","password":"password1"}`, ucErr: users.ErrorTooManyRequests, expectedStatus: http.StatusTooManyRequests, expectedCode: "too_many_requests"},
+		{name: "User deleted", body: `{"login":"newlogin","password":"password1"}`, ucErr: users.ErrorNotFound, expectedStatus: http.StatusUnauthorized, expectedCode: "unauthorized"},
+		{name: "Internal error", body: `{"login":"newlogin","password":"password1"}`, ucErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError, expectedCode: "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			if tt.name != "Invalid body" {
+				mockUsecase.EXPECT().ChangeLogin(gomock.Any(), userID, "newlogin", gomock.Any()).
+					Return(models.User{ID: userID, Login: "newlogin"}, "new-token", tt.ucErr)
+			}
+
+			ctx := context.WithValue(testContext(), users.UserKey, userID)
+			r := httptest.NewRequest(http.MethodPut, "/users/change/login", bytes.NewBufferString(tt.body)).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			NewUserHandler(mockUsecase).ChangeLogin(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var got models.Problem
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, tt.expectedCode, got.Code)
+				if tt.expectedField != "" {
+					assert.Len(t, got.Errors, 1)
+					assert.Equal(t, tt.expectedField, got.Errors[0].Field)
+				}
+			}
+			if tt.expectedStatus == http.StatusOK {
+				var session string
+				for _, cookie := range w.Result().Cookies() {
+					if cookie.Name == CookieName {
+						session = cookie.Value
+					}
+				}
+				assert.Equal(t, "new-token", session)
+			}
+		})
+	}
+}
+
+func TestRequestAvatarUpload(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		body           string
+		ucErr          error
+		expectedStatus int
+	}{
+		{name: "Success", body: `{"content_type":"image/png","size":1024}`, expectedStatus: http.StatusOK},
+		{name: "Invalid body", body: `{`, expectedStatus: http.StatusBadRequest},
+		{name: "Rejected", body: `{"content_type":"image/png","size":1024}`, ucErr: users.ErrorBadRequest, expectedStatus: http.StatusBadRequest},
+		{name: "Internal error", body: `{"content_type":"image/png","size":1024}`, ucErr: errors.New("storage error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			slot := models.AvatarUploadSlot{ID: uuid.NewV4(), URL: "https://storage/x?signature=a&expires=1", Method: http.MethodPut}
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			if tt.name != "Invalid body" {
+				mockUsecase.EXPECT().RequestAvatarUpload(gomock.Any(), userID, "image/png", int64(1024)).Return(slot, tt.ucErr)
+			}
+
+			ctx := context.WithValue(testContext(), users.UserKey, userID)
+			r := httptest.NewRequest(http.MethodPost, "/users/change/avatar/upload", bytes.NewBufferString(tt.body)).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			NewUserHandler(mockUsecase).RequestAvatarUpload(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var got models.AvatarUploadSlot
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				// подписанная ссылка должна дойти до клиента без изменений
+				assert.Equal(t, slot.URL, got.URL)
+			}
+		})
+	}
+}
+
+func TestConfirmAvatarUpload(t *testing.T) {
+	userID := uuid.NewV4()
+	uploadID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		id             string
+		ucErr          error
+		expectedStatus int
+	}{
+		{name: "Success", id: uploadID.String(), expectedStatus: http.StatusOK},
+		{name: "Invalid id", id: "abc", expectedStatus: http.StatusBadRequest},
+		{name: "Not uploaded", id: uploadID.String(), ucErr: users.ErrorNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Not an image", id: uploadID.String(), ucErr: users.ErrorBadRequest, expectedStatus: http.StatusBadRequest},
+		{name: "Internal error", id: uploadID.String(), ucErr: errors.New("storage error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			if tt.name != "Invalid id" {
+				mockUsecase.EXPECT().ConfirmAvatarUpload(gomock.Any(), userID, uploadID).
+					Return(models.User{ID: userID, Login: "user"}, "new-token", tt.ucErr)
+			}
+
+			ctx := context.WithValue(testContext(), users.UserKey, userID)
+			r := httptest.NewRequest(http.MethodPost, "/users/change/avatar/upload/"+tt.id+"/confirm", nil).WithContext(ctx)
+			r = mux.SetURLVars(r, map[string]string{"id": tt.id})
+			w := httptest.NewRecorder()
+
+			NewUserHandler(mockUsecase).ConfirmAvatarUpload(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var session string
+				for _, cookie := range w.Result().Cookies() {
+					if cookie.Name == CookieName {
+						session = cookie.Value
+					}
+				}
+				assert.Equal(t, "new-token", session)
+			}
+		})
+	}
+}
+
+func TestResolveLogin(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name             string
+		resolution       models.LoginResolution
+		ucErr            error
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:             "Current login",
+			resolution:       models.LoginResolution{ID: userID, Login: "testuser"},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "/api/users/" + userID.String() + "/profile",
+		},
+		{
+			name:             "Previous login",
+			resolution:       models.LoginResolution{ID: userID, Login: "newlogin", Previous: true},
+			expectedStatus:   http.StatusMovedPermanently,
+			expectedLocation: "/api/users/" + userID.String() + "/profile",
+		},
+		{name: "Not found", ucErr: users.ErrorNotFound, expectedStatus: http.StatusNotFound},
+		{name: "Internal error", ucErr: errors.New("db error"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			mockUsecase.EXPECT().ResolveLogin(gomock.Any(), "testuser").Return(tt.resolution, tt.ucErr)
+
+			router := mux.NewRouter()
+			router.HandleFunc("/api/users/by-login/{login}", NewUserHandler(mockUsecase).ResolveLogin)
+
+			r := httptest.NewRequest(http.MethodGet, "/api/users/by-login/testuser", nil).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
 func TestMiddleware(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -291,3 +494,348 @@ func TestMiddleware(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionalMiddleware(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		cookie     string
+		setupMocks func(mockUsecase *mocks.MockUsersUsecase)
+		wantUser   bool
+	}{
+		{
+			name:   "Authorized",
+			cookie: "jwt-token",
+			setupMocks: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ValidateAndGetUser(gomock.Any(), "jwt-token").Return(models.User{ID: userID}, nil)
+			},
+			wantUser: true,
+		},
+		{
+			name:   "Invalid token",
+			cookie: "invalid-jwt-token",
+			setupMocks: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ValidateAndGetUser(gomock.Any(), "invalid-jwt-token").
+					Return(models.User{}, errors.New("invalid token"))
+			},
+		},
+		{
+			name:       "Anonymous",
+			setupMocks: func(mockUsecase *mocks.MockUsersUsecase) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			tt.setupMocks(mockUsecase)
+
+			var gotUser bool
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				id, ok := r.Context().Value(users.UserKey).(uuid.UUID)
+				gotUser = ok && id == userID
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest("GET", "/users/"+userID.String()+"/profile", nil).WithContext(testContext())
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: CookieName, Value: tt.cookie})
+			}
+			w := httptest.NewRecorder()
+
+			NewUserHandler(mockUsecase).OptionalMiddleware(nextHandler).ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.wantUser, gotUser)
+		})
+	}
+}
+
+func TestGetUserProfile(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		userID         string
+		mockSetup      func(mockUsecase *mocks.MockUsersUsecase)
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			userID: userID.String(),
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().GetUserProfile(gomock.Any(), userID).
+					Return(models.UserProfile{ID: userID, Login: "cinephile"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid UUID",
+			userID:         "invalid-uuid",
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Not found",
+			userID: userID.String(),
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().GetUserProfile(gomock.Any(), userID).
+					Return(models.UserProfile{}, users.ErrorNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+
+			router := mux.NewRouter()
+			handler := NewUserHandler(mockUsecase)
+			router.HandleFunc("/users/{id}/profile", handler.GetUserProfile)
+
+			r := httptest.NewRequest("GET", "/users/"+tt.userID+"/profile", nil).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestChangePrivacy(t *testing.T) {
+	userID := uuid.NewV4()
+	privacy := models.ProfilePrivacy{ShowStats: true, ShowTaste: false, ShowReviews: true}
+
+	tests := []struct {
+		name           string
+		body           string
+		withUser       bool
+		mockSetup      func(mockUsecase *mocks.MockUsersUsecase)
+		expectedStatus int
+	}{
+		{
+			name:     "Success",
+			body:     `{"show_stats":true,"show_taste":false,"show_reviews":true}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ChangePrivacy(gomock.Any(), userID, privacy).Return(privacy, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "No user",
+			body:           `{}`,
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Invalid body",
+			body:           `{`,
+			withUser:       true,
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:     "Internal error",
+			body:     `{"show_stats":true,"show_taste":false,"show_reviews":true}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ChangePrivacy(gomock.Any(), userID, privacy).
+					Return(models.ProfilePrivacy{}, users.ErrorInternalServerError)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			handler := NewUserHandler(mockUsecase)
+
+			ctx := testContext()
+			if tt.withUser {
+				ctx = context.WithValue(ctx, users.UserKey, userID)
+			}
+			r := httptest.NewRequest(http.MethodPut, "/users/change/privacy", bytes.NewBufferString(tt.body)).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ChangePrivacy(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name           string
+		body           string
+		withUser       bool
+		mockSetup      func(mockUsecase *mocks.MockUsersUsecase)
+		expectedStatus int
+	}{
+		{
+			name:     "Success",
+			body:     `{"email":"user@example.com","password":"Secret1!"}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ChangeEmail(gomock.Any(), userID, "user@example.com", "Secret1!").
+					Return(models.UserEmail{Email: "user@example.com"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "Wrong password",
+			body:     `{"email":"user@example.com","password":"nope"}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ChangeEmail(gomock.Any(), userID, "user@example.com", "nope").Return(models.UserEmail{}, users.ErrorWrongPassword)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "No user",
+			body:           `{"email":"user@example.com"}`,
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:     "Invalid email",
+			body:     `{"email":"nope","password":"Secret1!"}`,
+			withUser: true,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().ChangeEmail(gomock.Any(), userID, "nope", "Secret1!").Return(models.UserEmail{}, users.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			handler := NewUserHandler(mockUsecase)
+
+			ctx := testContext()
+			if tt.withUser {
+				ctx = context.WithValue(ctx, users.UserKey, userID)
+			}
+			r := httptest.NewRequest(http.MethodPut, "/users/change/email", bytes.NewBufferString(tt.body)).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			handler.ChangeEmail(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockUsecase *mocks.MockUsersUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			body: `{"token":"abc"}`,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().VerifyEmail(gomock.Any(), "abc").Return(models.UserEmail{Email: "user@example.com", Verified: true}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Used token",
+			body: `{"token":"abc"}`,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().VerifyEmail(gomock.Any(), "abc").Return(models.UserEmail{}, users.ErrorBadRequest)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Email verified by another user",
+			body: `{"token":"abc"}`,
+			mockSetup: func(mockUsecase *mocks.MockUsersUsecase) {
+				mockUsecase.EXPECT().VerifyEmail(gomock.Any(), "abc").Return(models.UserEmail{}, users.ErrorEmailTaken)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "Invalid body",
+			body:           `{`,
+			mockSetup:      func(mockUsecase *mocks.MockUsersUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+			tt.mockSetup(mockUsecase)
+			handler := NewUserHandler(mockUsecase)
+
+			r := httptest.NewRequest(http.MethodPost, "/users/email/verify", bytes.NewBufferString(tt.body)).WithContext(testContext())
+			w := httptest.NewRecorder()
+
+			handler.VerifyEmail(w, r)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestForgotAndResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUsecase := mocks.NewMockUsersUsecase(ctrl)
+	handler := NewUserHandler(mockUsecase)
+
+	mockUsecase.EXPECT().ForgotPassword(gomock.Any(), "user@example.com").Return(nil)
+	r := httptest.NewRequest(http.MethodPost, "/users/password/forgot", bytes.NewBufferString(`{"email":"user@example.com"}`)).WithContext(testContext())
+	w := httptest.NewRecorder()
+	handler.ForgotPassword(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockUsecase.EXPECT().ForgotPassword(gomock.Any(), "user@example.com").Return(users.ErrorInternalServerError)
+	r = httptest.NewRequest(http.MethodPost, "/users/password/forgot", bytes.NewBufferString(`{"email":"user@example.com"}`)).WithContext(testContext())
+	w = httptest.NewRecorder()
+	handler.ForgotPassword(w, r)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// пароль экранируется так же, как при входе
+	mockUsecase.EXPECT().ResetPassword(gomock.Any(), "abc", "pass&amp;word").Return(nil)
+	r = httptest.NewRequest(http.MethodPost, "/users/password/reset", bytes.NewBufferString(`{"token":"abc","password":"pass&word"}`)).WithContext(testContext())
+	w = httptest.NewRecorder()
+	handler.ResetPassword(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockUsecase.EXPECT().ResetPassword(gomock.Any(), "abc", "password1").Return(users.ErrorBadRequest)
+	r = httptest.NewRequest(http.MethodPost, "/users/password/reset", bytes.NewBufferString(`{"token":"abc","password":"password1"}`)).WithContext(testContext())
+	w = httptest.NewRecorder()
+	handler.ResetPassword(w, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
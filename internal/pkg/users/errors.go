@@ -1,10 +1,22 @@
 package users
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrorBadRequest          = errors.New("bad request")
 	ErrorUnauthorized        = errors.New("user is unauthorized")
 	ErrorInternalServerError = errors.New("internal server error")
 	ErrorNotFound            = errors.New("not found")
+	ErrorConflict            = errors.New("conflict")
+	ErrorTooManyRequests     = errors.New("too many requests")
+
+	// уточнения общих ошибок, коды ответа у них те же, что у одноимённых ошибок auth
+	ErrorInvalidLogin  = fmt.Errorf("invalid login: %w", ErrorBadRequest)
+	ErrorWeakPassword  = fmt.Errorf("password does not satisfy the policy: %w", ErrorBadRequest)
+	ErrorWrongPassword = fmt.Errorf("wrong password: %w", ErrorBadRequest)
+	ErrorLoginTaken    = fmt.Errorf("login is taken: %w", ErrorConflict)
+	ErrorEmailTaken    = fmt.Errorf("email is verified by another user: %w", ErrorConflict)
 )
@@ -3,18 +3,32 @@ package users
 import (
 	"context"
 	"kinopoisk/internal/models"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
 )
 
 type UsersUsecase interface {
-	GenerateToken(id uuid.UUID, login string) (string, error)
+	GenerateToken(id uuid.UUID, login string, version int) (string, error)
 	ParseToken(token string) (*jwt.Token, error)
 	GetUser(ctx context.Context, id uuid.UUID) (models.User, error)
 	ValidateAndGetUser(ctx context.Context, token string) (models.User, error)
 	ChangePassword(ctx context.Context, id uuid.UUID, oldPassword string, newPassword string) (models.User, string, error)
-	ChangeUserAvatar(ctx context.Context, userID uuid.UUID, fileBytes []byte, fileFormat string) (models.User, string, error)
+	ChangeUserAvatar(ctx context.Context, userID uuid.UUID, fileBytes []byte) (models.User, string, error)
+	RequestAvatarUpload(ctx context.Context, userID uuid.UUID, contentType string, size int64) (models.AvatarUploadSlot, error)
+	ConfirmAvatarUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (models.User, string, error)
+	GetUserProfile(ctx context.Context, id uuid.UUID) (models.UserProfile, error)
+	ChangePrivacy(ctx context.Context, userID uuid.UUID, privacy models.ProfilePrivacy) (models.ProfilePrivacy, error)
+	GetEmail(ctx context.Context, userID uuid.UUID) (models.UserEmail, error)
+	ChangeEmail(ctx context.Context, userID uuid.UUID, email string, plainPassword string) (models.UserEmail, error)
+	VerifyEmail(ctx context.Context, token string) (models.UserEmail, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	ExportData(ctx context.Context, userID uuid.UUID) (models.UserExport, error)
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (models.AccountDeletion, error)
+	ChangeLogin(ctx context.Context, userID uuid.UUID, login string, password string) (models.User, string, error)
+	ResolveLogin(ctx context.Context, login string) (models.LoginResolution, error)
 }
 
 type UsersRepo interface {
@@ -22,9 +36,39 @@ type UsersRepo interface {
 	GetUserByLogin(ctx context.Context, login string) (models.User, error)
 	UpdateUserPassword(ctx context.Context, version int, userID uuid.UUID, passwordHash []byte) error
 	UpdateUserAvatar(ctx context.Context, version int, userID uuid.UUID, avatarPath string) error
+	GetUserProfile(ctx context.Context, id uuid.UUID) (models.UserProfile, error)
+	UpdateUserPrivacy(ctx context.Context, userID uuid.UUID, privacy models.ProfilePrivacy) (models.ProfilePrivacy, error)
+	IsFollower(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+	GetUserStats(ctx context.Context, userID uuid.UUID) (models.ProfileStats, error)
+	GetUserFavouriteGenres(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileGenre, error)
+	GetUserFavouriteActors(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileActor, error)
+	GetUserLatestReviews(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileReview, error)
+	GetUserEmail(ctx context.Context, userID uuid.UUID) (models.UserEmail, error)
+	UpdateUserEmail(ctx context.Context, userID uuid.UUID, email string) error
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	MarkEmailVerified(ctx context.Context, userID uuid.UUID, email string) error
+	CreateUserToken(ctx context.Context, token models.UserToken) error
+	ConsumeUserToken(ctx context.Context, tokenHash []byte, purpose string) (models.UserToken, error)
+	GetUserToken(ctx context.Context, tokenHash []byte, purpose string) (models.UserToken, error)
+	ResetUserPassword(ctx context.Context, tokenHash []byte, passwordHash []byte) (uuid.UUID, error)
+	GetUserExport(ctx context.Context, userID uuid.UUID) (models.ExportProfile, error)
+	GetUserFeedbacks(ctx context.Context, userID uuid.UUID) ([]models.ExportFeedback, error)
+	ScheduleUserDeletion(ctx context.Context, userID uuid.UUID, deleteAfter time.Time) error
+	DeleteDueUsers(ctx context.Context, limit int) ([]models.DeletedUser, error)
+	IsLoginAvailable(ctx context.Context, userID uuid.UUID, login string) (bool, error)
+	GetLastLoginChange(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	UpdateUserLogin(ctx context.Context, version int, userID uuid.UUID, login string, reservedUntil time.Time) error
+	ResolveLogin(ctx context.Context, login string) (models.LoginResolution, error)
+	GetAvatarPaths(ctx context.Context) ([]string, error)
 }
 
 type StorageRepo interface {
 	DeleteAvatar(ctx context.Context, avatarPath string) error
-	UploadAvatar(ctx context.Context, userID string, buffer []byte, fileFormat string, avatarExtension string) (string, error)
+	UploadAvatar(ctx context.Context, images map[int][]byte) (string, error)
+	ListAvatarObjects(ctx context.Context) ([]models.AvatarObject, error)
+	DeleteAvatarObject(ctx context.Context, avatarPath string) error
+	PresignAvatarUpload(ctx context.Context, userID uuid.UUID, contentType string, size int64, ttl time.Duration) (models.AvatarUploadSlot, error)
+	GetAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID, limit int64) ([]byte, error)
+	DeleteAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID) error
+	DeleteStaleAvatarUploads(ctx context.Context, olderThan time.Time) (int, error)
 }
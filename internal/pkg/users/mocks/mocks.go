@@ -13,6 +13,7 @@ import (
 	context "context"
 	models "kinopoisk/internal/models"
 	reflect "reflect"
+	time "time"
 
 	jwt "github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
@@ -43,6 +44,37 @@ func (m *MockUsersUsecase) EXPECT() *MockUsersUsecaseMockRecorder {
 	return m.recorder
 }
 
+// ChangeEmail mocks base method.
+func (m *MockUsersUsecase) ChangeEmail(ctx context.Context, userID uuid.UUID, email, plainPassword string) (models.UserEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, userID, email, plainPassword)
+	ret0, _ := ret[0].(models.UserEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockUsersUsecaseMockRecorder) ChangeEmail(ctx, userID, email, plainPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockUsersUsecase)(nil).ChangeEmail), ctx, userID, email, plainPassword)
+}
+
+// ChangeLogin mocks base method.
+func (m *MockUsersUsecase) ChangeLogin(ctx context.Context, userID uuid.UUID, login, password string) (models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeLogin", ctx, userID, login, password)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ChangeLogin indicates an expected call of ChangeLogin.
+func (mr *MockUsersUsecaseMockRecorder) ChangeLogin(ctx, userID, login, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeLogin", reflect.TypeOf((*MockUsersUsecase)(nil).ChangeLogin), ctx, userID, login, password)
+}
+
 // ChangePassword mocks base method.
 func (m *MockUsersUsecase) ChangePassword(ctx context.Context, id uuid.UUID, oldPassword, newPassword string) (models.User, string, error) {
 	m.ctrl.T.Helper()
@@ -59,10 +91,25 @@ func (mr *MockUsersUsecaseMockRecorder) ChangePassword(ctx, id, oldPassword, new
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUsersUsecase)(nil).ChangePassword), ctx, id, oldPassword, newPassword)
 }
 
+// ChangePrivacy mocks base method.
+func (m *MockUsersUsecase) ChangePrivacy(ctx context.Context, userID uuid.UUID, privacy models.ProfilePrivacy) (models.ProfilePrivacy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePrivacy", ctx, userID, privacy)
+	ret0, _ := ret[0].(models.ProfilePrivacy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePrivacy indicates an expected call of ChangePrivacy.
+func (mr *MockUsersUsecaseMockRecorder) ChangePrivacy(ctx, userID, privacy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePrivacy", reflect.TypeOf((*MockUsersUsecase)(nil).ChangePrivacy), ctx, userID, privacy)
+}
+
 // ChangeUserAvatar mocks base method.
-func (m *MockUsersUsecase) ChangeUserAvatar(ctx context.Context, userID uuid.UUID, fileBytes []byte, fileFormat string) (models.User, string, error) {
+func (m *MockUsersUsecase) ChangeUserAvatar(ctx context.Context, userID uuid.UUID, fileBytes []byte) (models.User, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ChangeUserAvatar", ctx, userID, fileBytes, fileFormat)
+	ret := m.ctrl.Call(m, "ChangeUserAvatar", ctx, userID, fileBytes)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -70,24 +117,99 @@ func (m *MockUsersUsecase) ChangeUserAvatar(ctx context.Context, userID uuid.UUI
 }
 
 // ChangeUserAvatar indicates an expected call of ChangeUserAvatar.
-func (mr *MockUsersUsecaseMockRecorder) ChangeUserAvatar(ctx, userID, fileBytes, fileFormat any) *gomock.Call {
+func (mr *MockUsersUsecaseMockRecorder) ChangeUserAvatar(ctx, userID, fileBytes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserAvatar", reflect.TypeOf((*MockUsersUsecase)(nil).ChangeUserAvatar), ctx, userID, fileBytes)
+}
+
+// ConfirmAvatarUpload mocks base method.
+func (m *MockUsersUsecase) ConfirmAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID) (models.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmAvatarUpload", ctx, userID, uploadID)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ConfirmAvatarUpload indicates an expected call of ConfirmAvatarUpload.
+func (mr *MockUsersUsecaseMockRecorder) ConfirmAvatarUpload(ctx, userID, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmAvatarUpload", reflect.TypeOf((*MockUsersUsecase)(nil).ConfirmAvatarUpload), ctx, userID, uploadID)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockUsersUsecase) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (models.AccountDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, password)
+	ret0, _ := ret[0].(models.AccountDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockUsersUsecaseMockRecorder) DeleteAccount(ctx, userID, password any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserAvatar", reflect.TypeOf((*MockUsersUsecase)(nil).ChangeUserAvatar), ctx, userID, fileBytes, fileFormat)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUsersUsecase)(nil).DeleteAccount), ctx, userID, password)
+}
+
+// ExportData mocks base method.
+func (m *MockUsersUsecase) ExportData(ctx context.Context, userID uuid.UUID) (models.UserExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportData", ctx, userID)
+	ret0, _ := ret[0].(models.UserExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportData indicates an expected call of ExportData.
+func (mr *MockUsersUsecaseMockRecorder) ExportData(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportData", reflect.TypeOf((*MockUsersUsecase)(nil).ExportData), ctx, userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockUsersUsecase) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockUsersUsecaseMockRecorder) ForgotPassword(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockUsersUsecase)(nil).ForgotPassword), ctx, email)
 }
 
 // GenerateToken mocks base method.
-func (m *MockUsersUsecase) GenerateToken(id uuid.UUID, login string) (string, error) {
+func (m *MockUsersUsecase) GenerateToken(id uuid.UUID, login string, version int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", id, login)
+	ret := m.ctrl.Call(m, "GenerateToken", id, login, version)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockUsersUsecaseMockRecorder) GenerateToken(id, login any) *gomock.Call {
+func (mr *MockUsersUsecaseMockRecorder) GenerateToken(id, login, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockUsersUsecase)(nil).GenerateToken), id, login, version)
+}
+
+// GetEmail mocks base method.
+func (m *MockUsersUsecase) GetEmail(ctx context.Context, userID uuid.UUID) (models.UserEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmail", ctx, userID)
+	ret0, _ := ret[0].(models.UserEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmail indicates an expected call of GetEmail.
+func (mr *MockUsersUsecaseMockRecorder) GetEmail(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockUsersUsecase)(nil).GenerateToken), id, login)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmail", reflect.TypeOf((*MockUsersUsecase)(nil).GetEmail), ctx, userID)
 }
 
 // GetUser mocks base method.
@@ -105,6 +227,21 @@ func (mr *MockUsersUsecaseMockRecorder) GetUser(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUsersUsecase)(nil).GetUser), ctx, id)
 }
 
+// GetUserProfile mocks base method.
+func (m *MockUsersUsecase) GetUserProfile(ctx context.Context, id uuid.UUID) (models.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, id)
+	ret0, _ := ret[0].(models.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockUsersUsecaseMockRecorder) GetUserProfile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUsersUsecase)(nil).GetUserProfile), ctx, id)
+}
+
 // ParseToken mocks base method.
 func (m *MockUsersUsecase) ParseToken(token string) (*jwt.Token, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +257,50 @@ func (mr *MockUsersUsecaseMockRecorder) ParseToken(token any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockUsersUsecase)(nil).ParseToken), token)
 }
 
+// RequestAvatarUpload mocks base method.
+func (m *MockUsersUsecase) RequestAvatarUpload(ctx context.Context, userID uuid.UUID, contentType string, size int64) (models.AvatarUploadSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestAvatarUpload", ctx, userID, contentType, size)
+	ret0, _ := ret[0].(models.AvatarUploadSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestAvatarUpload indicates an expected call of RequestAvatarUpload.
+func (mr *MockUsersUsecaseMockRecorder) RequestAvatarUpload(ctx, userID, contentType, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestAvatarUpload", reflect.TypeOf((*MockUsersUsecase)(nil).RequestAvatarUpload), ctx, userID, contentType, size)
+}
+
+// ResetPassword mocks base method.
+func (m *MockUsersUsecase) ResetPassword(ctx context.Context, token, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockUsersUsecaseMockRecorder) ResetPassword(ctx, token, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockUsersUsecase)(nil).ResetPassword), ctx, token, password)
+}
+
+// ResolveLogin mocks base method.
+func (m *MockUsersUsecase) ResolveLogin(ctx context.Context, login string) (models.LoginResolution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLogin", ctx, login)
+	ret0, _ := ret[0].(models.LoginResolution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLogin indicates an expected call of ResolveLogin.
+func (mr *MockUsersUsecaseMockRecorder) ResolveLogin(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLogin", reflect.TypeOf((*MockUsersUsecase)(nil).ResolveLogin), ctx, login)
+}
+
 // ValidateAndGetUser mocks base method.
 func (m *MockUsersUsecase) ValidateAndGetUser(ctx context.Context, token string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -135,6 +316,21 @@ func (mr *MockUsersUsecaseMockRecorder) ValidateAndGetUser(ctx, token any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAndGetUser", reflect.TypeOf((*MockUsersUsecase)(nil).ValidateAndGetUser), ctx, token)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockUsersUsecase) VerifyEmail(ctx context.Context, token string) (models.UserEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(models.UserEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockUsersUsecaseMockRecorder) VerifyEmail(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockUsersUsecase)(nil).VerifyEmail), ctx, token)
+}
+
 // MockUsersRepo is a mock of UsersRepo interface.
 type MockUsersRepo struct {
 	ctrl     *gomock.Controller
@@ -159,6 +355,95 @@ func (m *MockUsersRepo) EXPECT() *MockUsersRepoMockRecorder {
 	return m.recorder
 }
 
+// ConsumeUserToken mocks base method.
+func (m *MockUsersRepo) ConsumeUserToken(ctx context.Context, tokenHash []byte, purpose string) (models.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeUserToken", ctx, tokenHash, purpose)
+	ret0, _ := ret[0].(models.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeUserToken indicates an expected call of ConsumeUserToken.
+func (mr *MockUsersRepoMockRecorder) ConsumeUserToken(ctx, tokenHash, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeUserToken", reflect.TypeOf((*MockUsersRepo)(nil).ConsumeUserToken), ctx, tokenHash, purpose)
+}
+
+// CreateUserToken mocks base method.
+func (m *MockUsersRepo) CreateUserToken(ctx context.Context, token models.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserToken indicates an expected call of CreateUserToken.
+func (mr *MockUsersRepoMockRecorder) CreateUserToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockUsersRepo)(nil).CreateUserToken), ctx, token)
+}
+
+// DeleteDueUsers mocks base method.
+func (m *MockUsersRepo) DeleteDueUsers(ctx context.Context, limit int) ([]models.DeletedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDueUsers", ctx, limit)
+	ret0, _ := ret[0].([]models.DeletedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDueUsers indicates an expected call of DeleteDueUsers.
+func (mr *MockUsersRepoMockRecorder) DeleteDueUsers(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDueUsers", reflect.TypeOf((*MockUsersRepo)(nil).DeleteDueUsers), ctx, limit)
+}
+
+// GetAvatarPaths mocks base method.
+func (m *MockUsersRepo) GetAvatarPaths(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarPaths", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarPaths indicates an expected call of GetAvatarPaths.
+func (mr *MockUsersRepoMockRecorder) GetAvatarPaths(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarPaths", reflect.TypeOf((*MockUsersRepo)(nil).GetAvatarPaths), ctx)
+}
+
+// GetLastLoginChange mocks base method.
+func (m *MockUsersRepo) GetLastLoginChange(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastLoginChange", ctx, userID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastLoginChange indicates an expected call of GetLastLoginChange.
+func (mr *MockUsersRepoMockRecorder) GetLastLoginChange(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastLoginChange", reflect.TypeOf((*MockUsersRepo)(nil).GetLastLoginChange), ctx, userID)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockUsersRepo) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockUsersRepoMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUsersRepo)(nil).GetUserByEmail), ctx, email)
+}
+
 // GetUserByID mocks base method.
 func (m *MockUsersRepo) GetUserByID(ctx context.Context, id uuid.UUID) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -189,6 +474,229 @@ func (mr *MockUsersRepoMockRecorder) GetUserByLogin(ctx, login any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockUsersRepo)(nil).GetUserByLogin), ctx, login)
 }
 
+// GetUserEmail mocks base method.
+func (m *MockUsersRepo) GetUserEmail(ctx context.Context, userID uuid.UUID) (models.UserEmail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEmail", ctx, userID)
+	ret0, _ := ret[0].(models.UserEmail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEmail indicates an expected call of GetUserEmail.
+func (mr *MockUsersRepoMockRecorder) GetUserEmail(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEmail", reflect.TypeOf((*MockUsersRepo)(nil).GetUserEmail), ctx, userID)
+}
+
+// GetUserExport mocks base method.
+func (m *MockUsersRepo) GetUserExport(ctx context.Context, userID uuid.UUID) (models.ExportProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserExport", ctx, userID)
+	ret0, _ := ret[0].(models.ExportProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserExport indicates an expected call of GetUserExport.
+func (mr *MockUsersRepoMockRecorder) GetUserExport(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserExport", reflect.TypeOf((*MockUsersRepo)(nil).GetUserExport), ctx, userID)
+}
+
+// GetUserFavouriteActors mocks base method.
+func (m *MockUsersRepo) GetUserFavouriteActors(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFavouriteActors", ctx, userID, limit)
+	ret0, _ := ret[0].([]models.ProfileActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFavouriteActors indicates an expected call of GetUserFavouriteActors.
+func (mr *MockUsersRepoMockRecorder) GetUserFavouriteActors(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFavouriteActors", reflect.TypeOf((*MockUsersRepo)(nil).GetUserFavouriteActors), ctx, userID, limit)
+}
+
+// GetUserFavouriteGenres mocks base method.
+func (m *MockUsersRepo) GetUserFavouriteGenres(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileGenre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFavouriteGenres", ctx, userID, limit)
+	ret0, _ := ret[0].([]models.ProfileGenre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFavouriteGenres indicates an expected call of GetUserFavouriteGenres.
+func (mr *MockUsersRepoMockRecorder) GetUserFavouriteGenres(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFavouriteGenres", reflect.TypeOf((*MockUsersRepo)(nil).GetUserFavouriteGenres), ctx, userID, limit)
+}
+
+// GetUserFeedbacks mocks base method.
+func (m *MockUsersRepo) GetUserFeedbacks(ctx context.Context, userID uuid.UUID) ([]models.ExportFeedback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFeedbacks", ctx, userID)
+	ret0, _ := ret[0].([]models.ExportFeedback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFeedbacks indicates an expected call of GetUserFeedbacks.
+func (mr *MockUsersRepoMockRecorder) GetUserFeedbacks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFeedbacks", reflect.TypeOf((*MockUsersRepo)(nil).GetUserFeedbacks), ctx, userID)
+}
+
+// GetUserLatestReviews mocks base method.
+func (m *MockUsersRepo) GetUserLatestReviews(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLatestReviews", ctx, userID, limit)
+	ret0, _ := ret[0].([]models.ProfileReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLatestReviews indicates an expected call of GetUserLatestReviews.
+func (mr *MockUsersRepoMockRecorder) GetUserLatestReviews(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLatestReviews", reflect.TypeOf((*MockUsersRepo)(nil).GetUserLatestReviews), ctx, userID, limit)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockUsersRepo) GetUserProfile(ctx context.Context, id uuid.UUID) (models.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, id)
+	ret0, _ := ret[0].(models.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockUsersRepoMockRecorder) GetUserProfile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockUsersRepo)(nil).GetUserProfile), ctx, id)
+}
+
+// GetUserStats mocks base method.
+func (m *MockUsersRepo) GetUserStats(ctx context.Context, userID uuid.UUID) (models.ProfileStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStats", ctx, userID)
+	ret0, _ := ret[0].(models.ProfileStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStats indicates an expected call of GetUserStats.
+func (mr *MockUsersRepoMockRecorder) GetUserStats(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStats", reflect.TypeOf((*MockUsersRepo)(nil).GetUserStats), ctx, userID)
+}
+
+// GetUserToken mocks base method.
+func (m *MockUsersRepo) GetUserToken(ctx context.Context, tokenHash []byte, purpose string) (models.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserToken", ctx, tokenHash, purpose)
+	ret0, _ := ret[0].(models.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserToken indicates an expected call of GetUserToken.
+func (mr *MockUsersRepoMockRecorder) GetUserToken(ctx, tokenHash, purpose any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserToken", reflect.TypeOf((*MockUsersRepo)(nil).GetUserToken), ctx, tokenHash, purpose)
+}
+
+// IsFollower mocks base method.
+func (m *MockUsersRepo) IsFollower(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFollower", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsFollower indicates an expected call of IsFollower.
+func (mr *MockUsersRepoMockRecorder) IsFollower(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFollower", reflect.TypeOf((*MockUsersRepo)(nil).IsFollower), ctx, followerID, followeeID)
+}
+
+// IsLoginAvailable mocks base method.
+func (m *MockUsersRepo) IsLoginAvailable(ctx context.Context, userID uuid.UUID, login string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLoginAvailable", ctx, userID, login)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsLoginAvailable indicates an expected call of IsLoginAvailable.
+func (mr *MockUsersRepoMockRecorder) IsLoginAvailable(ctx, userID, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLoginAvailable", reflect.TypeOf((*MockUsersRepo)(nil).IsLoginAvailable), ctx, userID, login)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUsersRepo) MarkEmailVerified(ctx context.Context, userID uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUsersRepoMockRecorder) MarkEmailVerified(ctx, userID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUsersRepo)(nil).MarkEmailVerified), ctx, userID, email)
+}
+
+// ResetUserPassword mocks base method.
+func (m *MockUsersRepo) ResetUserPassword(ctx context.Context, tokenHash, passwordHash []byte) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPassword", ctx, tokenHash, passwordHash)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetUserPassword indicates an expected call of ResetUserPassword.
+func (mr *MockUsersRepoMockRecorder) ResetUserPassword(ctx, tokenHash, passwordHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPassword", reflect.TypeOf((*MockUsersRepo)(nil).ResetUserPassword), ctx, tokenHash, passwordHash)
+}
+
+// ResolveLogin mocks base method.
+func (m *MockUsersRepo) ResolveLogin(ctx context.Context, login string) (models.LoginResolution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLogin", ctx, login)
+	ret0, _ := ret[0].(models.LoginResolution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLogin indicates an expected call of ResolveLogin.
+func (mr *MockUsersRepoMockRecorder) ResolveLogin(ctx, login any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLogin", reflect.TypeOf((*MockUsersRepo)(nil).ResolveLogin), ctx, login)
+}
+
+// ScheduleUserDeletion mocks base method.
+func (m *MockUsersRepo) ScheduleUserDeletion(ctx context.Context, userID uuid.UUID, deleteAfter time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleUserDeletion", ctx, userID, deleteAfter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleUserDeletion indicates an expected call of ScheduleUserDeletion.
+func (mr *MockUsersRepoMockRecorder) ScheduleUserDeletion(ctx, userID, deleteAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleUserDeletion", reflect.TypeOf((*MockUsersRepo)(nil).ScheduleUserDeletion), ctx, userID, deleteAfter)
+}
+
 // UpdateUserAvatar mocks base method.
 func (m *MockUsersRepo) UpdateUserAvatar(ctx context.Context, version int, userID uuid.UUID, avatarPath string) error {
 	m.ctrl.T.Helper()
@@ -203,6 +711,34 @@ func (mr *MockUsersRepoMockRecorder) UpdateUserAvatar(ctx, version, userID, avat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAvatar", reflect.TypeOf((*MockUsersRepo)(nil).UpdateUserAvatar), ctx, version, userID, avatarPath)
 }
 
+// UpdateUserEmail mocks base method.
+func (m *MockUsersRepo) UpdateUserEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserEmail", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserEmail indicates an expected call of UpdateUserEmail.
+func (mr *MockUsersRepoMockRecorder) UpdateUserEmail(ctx, userID, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserEmail", reflect.TypeOf((*MockUsersRepo)(nil).UpdateUserEmail), ctx, userID, email)
+}
+
+// UpdateUserLogin mocks base method.
+func (m *MockUsersRepo) UpdateUserLogin(ctx context.Context, version int, userID uuid.UUID, login string, reservedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserLogin", ctx, version, userID, login, reservedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserLogin indicates an expected call of UpdateUserLogin.
+func (mr *MockUsersRepoMockRecorder) UpdateUserLogin(ctx, version, userID, login, reservedUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserLogin", reflect.TypeOf((*MockUsersRepo)(nil).UpdateUserLogin), ctx, version, userID, login, reservedUntil)
+}
+
 // UpdateUserPassword mocks base method.
 func (m *MockUsersRepo) UpdateUserPassword(ctx context.Context, version int, userID uuid.UUID, passwordHash []byte) error {
 	m.ctrl.T.Helper()
@@ -217,6 +753,21 @@ func (mr *MockUsersRepoMockRecorder) UpdateUserPassword(ctx, version, userID, pa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockUsersRepo)(nil).UpdateUserPassword), ctx, version, userID, passwordHash)
 }
 
+// UpdateUserPrivacy mocks base method.
+func (m *MockUsersRepo) UpdateUserPrivacy(ctx context.Context, userID uuid.UUID, privacy models.ProfilePrivacy) (models.ProfilePrivacy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPrivacy", ctx, userID, privacy)
+	ret0, _ := ret[0].(models.ProfilePrivacy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserPrivacy indicates an expected call of UpdateUserPrivacy.
+func (mr *MockUsersRepoMockRecorder) UpdateUserPrivacy(ctx, userID, privacy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPrivacy", reflect.TypeOf((*MockUsersRepo)(nil).UpdateUserPrivacy), ctx, userID, privacy)
+}
+
 // MockStorageRepo is a mock of StorageRepo interface.
 type MockStorageRepo struct {
 	ctrl     *gomock.Controller
@@ -255,17 +806,105 @@ func (mr *MockStorageRepoMockRecorder) DeleteAvatar(ctx, avatarPath any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatar", reflect.TypeOf((*MockStorageRepo)(nil).DeleteAvatar), ctx, avatarPath)
 }
 
+// DeleteAvatarObject mocks base method.
+func (m *MockStorageRepo) DeleteAvatarObject(ctx context.Context, avatarPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatarObject", ctx, avatarPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatarObject indicates an expected call of DeleteAvatarObject.
+func (mr *MockStorageRepoMockRecorder) DeleteAvatarObject(ctx, avatarPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatarObject", reflect.TypeOf((*MockStorageRepo)(nil).DeleteAvatarObject), ctx, avatarPath)
+}
+
+// DeleteAvatarUpload mocks base method.
+func (m *MockStorageRepo) DeleteAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAvatarUpload", ctx, userID, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAvatarUpload indicates an expected call of DeleteAvatarUpload.
+func (mr *MockStorageRepoMockRecorder) DeleteAvatarUpload(ctx, userID, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAvatarUpload", reflect.TypeOf((*MockStorageRepo)(nil).DeleteAvatarUpload), ctx, userID, uploadID)
+}
+
+// DeleteStaleAvatarUploads mocks base method.
+func (m *MockStorageRepo) DeleteStaleAvatarUploads(ctx context.Context, olderThan time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleAvatarUploads", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleAvatarUploads indicates an expected call of DeleteStaleAvatarUploads.
+func (mr *MockStorageRepoMockRecorder) DeleteStaleAvatarUploads(ctx, olderThan any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleAvatarUploads", reflect.TypeOf((*MockStorageRepo)(nil).DeleteStaleAvatarUploads), ctx, olderThan)
+}
+
+// GetAvatarUpload mocks base method.
+func (m *MockStorageRepo) GetAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID, limit int64) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAvatarUpload", ctx, userID, uploadID, limit)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAvatarUpload indicates an expected call of GetAvatarUpload.
+func (mr *MockStorageRepoMockRecorder) GetAvatarUpload(ctx, userID, uploadID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvatarUpload", reflect.TypeOf((*MockStorageRepo)(nil).GetAvatarUpload), ctx, userID, uploadID, limit)
+}
+
+// ListAvatarObjects mocks base method.
+func (m *MockStorageRepo) ListAvatarObjects(ctx context.Context) ([]models.AvatarObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAvatarObjects", ctx)
+	ret0, _ := ret[0].([]models.AvatarObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvatarObjects indicates an expected call of ListAvatarObjects.
+func (mr *MockStorageRepoMockRecorder) ListAvatarObjects(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvatarObjects", reflect.TypeOf((*MockStorageRepo)(nil).ListAvatarObjects), ctx)
+}
+
+// PresignAvatarUpload mocks base method.
+func (m *MockStorageRepo) PresignAvatarUpload(ctx context.Context, userID uuid.UUID, contentType string, size int64, ttl time.Duration) (models.AvatarUploadSlot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignAvatarUpload", ctx, userID, contentType, size, ttl)
+	ret0, _ := ret[0].(models.AvatarUploadSlot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignAvatarUpload indicates an expected call of PresignAvatarUpload.
+func (mr *MockStorageRepoMockRecorder) PresignAvatarUpload(ctx, userID, contentType, size, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignAvatarUpload", reflect.TypeOf((*MockStorageRepo)(nil).PresignAvatarUpload), ctx, userID, contentType, size, ttl)
+}
+
 // UploadAvatar mocks base method.
-func (m *MockStorageRepo) UploadAvatar(ctx context.Context, userID string, buffer []byte, fileFormat, avatarExtension string) (string, error) {
+func (m *MockStorageRepo) UploadAvatar(ctx context.Context, images map[int][]byte) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadAvatar", ctx, userID, buffer, fileFormat, avatarExtension)
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, images)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UploadAvatar indicates an expected call of UploadAvatar.
-func (mr *MockStorageRepoMockRecorder) UploadAvatar(ctx, userID, buffer, fileFormat, avatarExtension any) *gomock.Call {
+func (mr *MockStorageRepoMockRecorder) UploadAvatar(ctx, images any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockStorageRepo)(nil).UploadAvatar), ctx, userID, buffer, fileFormat, avatarExtension)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockStorageRepo)(nil).UploadAvatar), ctx, images)
 }
@@ -0,0 +1,211 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/storage"
+	"kinopoisk/internal/pkg/users"
+	"kinopoisk/internal/pkg/utils/log"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	// avatarRoot под этим префиксом лежит статика и в бакете S3, и на диске
+	avatarRoot = "static"
+	// uploadRoot сюда клиенты загружают исходники напрямую; префикс не публичный
+	uploadRoot = "uploads/avatars"
+)
+
+type AvatarRepository struct {
+	bucket    storage.Bucket
+	presigner storage.Presigner
+}
+
+func NewAvatarRepository(bucket storage.Bucket, presigner storage.Presigner) *AvatarRepository {
+	return &AvatarRepository{bucket: bucket, presigner: presigner}
+}
+
+// uploadKey ключ содержит id пользователя, так что подтвердить можно только свою загрузку
+func uploadKey(userID, uploadID uuid.UUID) string {
+	return path.Join(uploadRoot, userID.String(), uploadID.String())
+}
+
+// DeleteAvatar удаляет все размеры аватара; ошибка первого неудачного удаления возвращается после попытки удалить остальные
+func (r *AvatarRepository) DeleteAvatar(ctx context.Context, avatarPath string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	var deleteErr error
+	for _, sizePath := range models.AvatarPaths(avatarPath) {
+		err := r.bucket.Delete(ctx, path.Join(avatarRoot, sizePath))
+		if err != nil && !errors.Is(err, storage.ErrorNotFound) {
+			logger.Warn("failed to delete old avatar", "error", err, "avatar_path", sizePath)
+			if deleteErr == nil {
+				deleteErr = fmt.Errorf("failed to delete old avatar: %w", err)
+			}
+		}
+	}
+	if deleteErr != nil {
+		return deleteErr
+	}
+	logger.Info("successfully deleted old avatar", "avatar_path", avatarPath)
+	return nil
+}
+
+// UploadAvatar кладёт все размеры под общим id и возвращает путь самого большого
+func (r *AvatarRepository) UploadAvatar(ctx context.Context, images map[int][]byte) (string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	picID := uuid.NewV4().String()
+
+	var avatarDBKey string
+	for _, size := range models.AvatarSizes {
+		buffer, ok := images[size]
+		if !ok {
+			return "", fmt.Errorf("no avatar of size %d", size)
+		}
+		avatarDBKey = models.AvatarPath(picID, size)
+		err := r.bucket.Put(ctx, path.Join(avatarRoot, avatarDBKey), bytes.NewReader(buffer), storage.PutOptions{
+			ContentType:  "image/jpeg",
+			CacheControl: storage.DefaultCacheControl,
+			Public:       true,
+		})
+		if err != nil {
+			logger.Error("failed to upload avatar", "error", err)
+			return "", fmt.Errorf("failed to upload avatar: %w", err)
+		}
+	}
+
+	return avatarDBKey, nil
+}
+
+// ListAvatarObjects все файлы аватаров с путями относительно avatarRoot
+func (r *AvatarRepository) ListAvatarObjects(ctx context.Context) ([]models.AvatarObject, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	objects, err := r.bucket.List(ctx, path.Join(avatarRoot, "avatars")+"/")
+	if err != nil {
+		logger.Error("failed to list avatars", "error", err)
+		return nil, users.ErrorInternalServerError
+	}
+
+	result := make([]models.AvatarObject, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, models.AvatarObject{
+			Path:    strings.TrimPrefix(object.Key, avatarRoot+"/"),
+			ModTime: object.ModTime,
+		})
+	}
+	return result, nil
+}
+
+// DeleteAvatarObject удаляет ровно один файл, в отличие от DeleteAvatar, который удаляет все размеры
+func (r *AvatarRepository) DeleteAvatarObject(ctx context.Context, avatarPath string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	err := r.bucket.Delete(ctx, path.Join(avatarRoot, avatarPath))
+	if err != nil && !errors.Is(err, storage.ErrorNotFound) {
+		logger.Error("failed to delete avatar object", "error", err, "avatar_path", avatarPath)
+		return users.ErrorInternalServerError
+	}
+	return nil
+}
+
+// PresignAvatarUpload выдаёт ссылку для загрузки исходника ровно такого типа и размера
+func (r *AvatarRepository) PresignAvatarUpload(ctx context.Context, userID uuid.UUID, contentType string, size int64, ttl time.Duration) (models.AvatarUploadSlot, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if r.presigner == nil {
+		logger.Error("presigned uploads are not configured")
+		return models.AvatarUploadSlot{}, users.ErrorInternalServerError
+	}
+
+	uploadID := uuid.NewV4()
+	signed, err := r.presigner.PresignPut(ctx, uploadKey(userID, uploadID), storage.PresignOptions{
+		ContentType: contentType,
+		Size:        size,
+		Expires:     ttl,
+	})
+	if err != nil {
+		logger.Error("failed to presign avatar upload", "error", err)
+		return models.AvatarUploadSlot{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully presigned avatar upload", "upload_id", uploadID.String())
+	return models.AvatarUploadSlot{
+		ID:        uploadID,
+		URL:       signed.URL,
+		Method:    signed.Method,
+		Headers:   signed.Headers,
+		ExpiresAt: signed.ExpiresAt,
+	}, nil
+}
+
+// GetAvatarUpload читает не больше limit+1 байт, чтобы обработка сама отвергла слишком большой файл
+func (r *AvatarRepository) GetAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID, limit int64) ([]byte, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	body, _, err := r.bucket.Get(ctx, uploadKey(userID, uploadID))
+	if err != nil {
+		if errors.Is(err, storage.ErrorNotFound) {
+			return nil, users.ErrorNotFound
+		}
+		logger.Error("failed to get avatar upload", "error", err)
+		return nil, users.ErrorInternalServerError
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		logger.Error("failed to read avatar upload", "error", err)
+		return nil, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got avatar upload", "upload_id", uploadID.String())
+	return data, nil
+}
+
+func (r *AvatarRepository) DeleteAvatarUpload(ctx context.Context, userID, uploadID uuid.UUID) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	err := r.bucket.Delete(ctx, uploadKey(userID, uploadID))
+	if err != nil && !errors.Is(err, storage.ErrorNotFound) {
+		logger.Error("failed to delete avatar upload", "error", err)
+		return users.ErrorInternalServerError
+	}
+	return nil
+}
+
+// DeleteStaleAvatarUploads удаляет загрузки, которые так и не подтвердили до olderThan
+func (r *AvatarRepository) DeleteStaleAvatarUploads(ctx context.Context, olderThan time.Time) (int, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	objects, err := r.bucket.List(ctx, uploadRoot+"/")
+	if err != nil {
+		logger.Error("failed to list avatar uploads", "error", err)
+		return 0, users.ErrorInternalServerError
+	}
+
+	deleted := 0
+	for _, object := range objects {
+		if !object.ModTime.Before(olderThan) {
+			continue
+		}
+		err := r.bucket.Delete(ctx, object.Key)
+		if err != nil && !errors.Is(err, storage.ErrorNotFound) {
+			logger.Warn("failed to delete stale avatar upload", "error", err, "key", object.Key)
+			continue
+		}
+		deleted++
+	}
+
+	logger.Info("succesfully deleted stale avatar uploads", slog.Int("count", deleted))
+	return deleted, nil
+}
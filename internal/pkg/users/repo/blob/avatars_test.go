@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/storage"
+	"kinopoisk/internal/pkg/users"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContext() context.Context {
+	testLogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
+}
+
+func TestAvatarRepository(t *testing.T) {
+	ctx := testContext()
+	bucket := storage.NewDiskBucket(t.TempDir())
+	repo := NewAvatarRepository(bucket, nil)
+
+	images := map[int][]byte{64: []byte("s"), 256: []byte("m"), 512: []byte("l")}
+	avatarPath, err := repo.UploadAvatar(ctx, images)
+	assert.NoError(t, err)
+
+	for _, sizePath := range models.AvatarPaths(avatarPath) {
+		info, err := bucket.Stat(ctx, "static/"+sizePath)
+		assert.NoError(t, err, sizePath)
+		assert.Equal(t, "image/jpeg", info.ContentType)
+	}
+
+	assert.NoError(t, repo.DeleteAvatar(ctx, avatarPath))
+	objects, err := bucket.List(ctx, "static/avatars/")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+
+	// аватар из старой схемы одним файлом, которого уже нет, удаляется без ошибки
+	assert.NoError(t, repo.DeleteAvatar(ctx, "avatars/legacy.png"))
+
+	_, err = repo.UploadAvatar(ctx, map[int][]byte{64: []byte("s")})
+	assert.Error(t, err)
+}
+
+func TestAvatarObjects(t *testing.T) {
+	ctx := testContext()
+	bucket := storage.NewDiskBucket(t.TempDir())
+	repo := NewAvatarRepository(bucket, nil)
+
+	avatarPath, err := repo.UploadAvatar(ctx, map[int][]byte{64: []byte("s"), 256: []byte("m"), 512: []byte("l")})
+	assert.NoError(t, err)
+	assert.NoError(t, bucket.Put(ctx, "static/avatars/default.png", strings.NewReader("d"), storage.PutOptions{}))
+	assert.NoError(t, bucket.Put(ctx, "static/posters/p.png", strings.NewReader("p"), storage.PutOptions{}))
+
+	objects, err := repo.ListAvatarObjects(ctx)
+	assert.NoError(t, err)
+	paths := []string{}
+	for _, object := range objects {
+		paths = append(paths, object.Path)
+		assert.False(t, object.ModTime.IsZero())
+	}
+	assert.ElementsMatch(t, append(models.AvatarPaths(avatarPath), "avatars/default.png"), paths)
+
+	// удаляется только указанный размер
+	assert.NoError(t, repo.DeleteAvatarObject(ctx, avatarPath))
+	assert.NoError(t, repo.DeleteAvatarObject(ctx, avatarPath))
+	objects, err = repo.ListAvatarObjects(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, objects, len(models.AvatarSizes))
+}
+
+func TestAvatarUploads(t *testing.T) {
+	ctx := testContext()
+	bucket := storage.NewDiskBucket(t.TempDir())
+	presigner, err := storage.NewDiskPresigner(bucket, "http://localhost/api/storage", []byte("secret"))
+	assert.NoError(t, err)
+	repo := NewAvatarRepository(bucket, presigner)
+
+	userID := uuid.NewV4()
+	slot, err := repo.PresignAvatarUpload(ctx, userID, "image/png", 5, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT", slot.Method)
+	assert.Contains(t, slot.URL, "uploads/avatars/"+userID.String()+"/"+slot.ID.String())
+
+	_, err = repo.GetAvatarUpload(ctx, userID, slot.ID, 10)
+	assert.ErrorIs(t, err, users.ErrorNotFound)
+
+	key := "uploads/avatars/" + userID.String() + "/" + slot.ID.String()
+	assert.NoError(t, bucket.Put(ctx, key, strings.NewReader("12345"), storage.PutOptions{}))
+
+	// чужой пользователь по тому же id загрузку не видит
+	_, err = repo.GetAvatarUpload(ctx, uuid.NewV4(), slot.ID, 10)
+	assert.ErrorIs(t, err, users.ErrorNotFound)
+
+	data, err := repo.GetAvatarUpload(ctx, userID, slot.ID, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", string(data))
+
+	deleted, err := repo.DeleteStaleAvatarUploads(ctx, time.Now().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	deleted, err = repo.DeleteStaleAvatarUploads(ctx, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	assert.NoError(t, repo.DeleteAvatarUpload(ctx, userID, slot.ID))
+}
+
+func TestAvatarUploadsWithoutPresigner(t *testing.T) {
+	repo := NewAvatarRepository(storage.NewDiskBucket(t.TempDir()), nil)
+	_, err := repo.PresignAvatarUpload(testContext(), uuid.NewV4(), "image/png", 5, time.Minute)
+	assert.ErrorIs(t, err, users.ErrorInternalServerError)
+}
@@ -3,16 +3,22 @@ package repo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/users"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
+	"strconv"
+	"time"
 
+	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype/pgxtype"
 	"github.com/jackc/pgx/v4"
 	uuid "github.com/satori/go.uuid"
 )
 
+const uniqueViolation = "23505"
+
 type UserRepository struct {
 	db pgxtype.Querier
 }
@@ -30,7 +36,7 @@ func (u *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (models.
 		id,
 	).Scan(
 		&user.ID, &user.Version, &user.Login,
-		&user.PasswordHash, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
+		&user.PasswordHash, &user.PasswordSet, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -54,7 +60,7 @@ func (u *UserRepository) GetUserByLogin(ctx context.Context, login string) (mode
 		login,
 	).Scan(
 		&user.ID, &user.Version, &user.Login,
-		&user.PasswordHash, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
+		&user.PasswordHash, &user.PasswordSet, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -100,3 +106,505 @@ func (u *UserRepository) UpdateUserAvatar(ctx context.Context, version int, user
 	logger.Info("succesfully updated avatar from db")
 	return nil
 }
+
+func roundRating(rating float64) float64 {
+	rounded, _ := strconv.ParseFloat(fmt.Sprintf("%.1f", rating), 64)
+	return rounded
+}
+
+func (u *UserRepository) GetUserProfile(ctx context.Context, id uuid.UUID) (models.UserProfile, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var profile models.UserProfile
+	err := u.db.QueryRow(
+		ctx,
+		GetUserProfileQuery,
+		id,
+	).Scan(
+		&profile.ID, &profile.Login, &profile.Avatar, &profile.CreatedAt,
+		&profile.Privacy.ShowStats, &profile.Privacy.ShowTaste, &profile.Privacy.ShowReviews,
+		&profile.Privacy.FollowersOnly, &profile.FollowersCount, &profile.FollowingCount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return models.UserProfile{}, users.ErrorNotFound
+		}
+		logger.Error("failed to scan profile: " + err.Error())
+		return models.UserProfile{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got profile of user from db")
+	return profile, nil
+}
+
+func (u *UserRepository) UpdateUserPrivacy(ctx context.Context, userID uuid.UUID, privacy models.ProfilePrivacy) (models.ProfilePrivacy, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var updated models.ProfilePrivacy
+	err := u.db.QueryRow(
+		ctx,
+		UpdateUserPrivacyQuery,
+		userID, privacy.ShowStats, privacy.ShowTaste, privacy.ShowReviews, privacy.FollowersOnly,
+	).Scan(&updated.ShowStats, &updated.ShowTaste, &updated.ShowReviews, &updated.FollowersOnly)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return models.ProfilePrivacy{}, users.ErrorNotFound
+		}
+		logger.Error("failed to update privacy: " + err.Error())
+		return models.ProfilePrivacy{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully updated privacy of user in db")
+	return updated, nil
+}
+
+func (u *UserRepository) IsFollower(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var isFollower bool
+	err := u.db.QueryRow(ctx, IsFollowerQuery, followerID, followeeID).Scan(&isFollower)
+	if err != nil {
+		logger.Error("failed to check follower: " + err.Error())
+		return false, users.ErrorInternalServerError
+	}
+	return isFollower, nil
+}
+
+func (u *UserRepository) GetUserStats(ctx context.Context, userID uuid.UUID) (models.ProfileStats, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var stats models.ProfileStats
+	err := u.db.QueryRow(
+		ctx,
+		GetUserStatsQuery,
+		userID,
+	).Scan(&stats.RatingsCount, &stats.ReviewsCount, &stats.AverageRating)
+	if err != nil {
+		logger.Error("failed to scan stats of user: " + err.Error())
+		return models.ProfileStats{}, users.ErrorInternalServerError
+	}
+	stats.AverageRating = roundRating(stats.AverageRating)
+
+	logger.Info("succesfully got stats of user from db")
+	return stats, nil
+}
+
+func (u *UserRepository) GetUserFavouriteGenres(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileGenre, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := u.db.Query(ctx, GetUserFavouriteGenresQuery, userID, limit)
+	if err != nil {
+		logger.Error("failed to query favourite genres: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	genres := []models.ProfileGenre{}
+	for rows.Next() {
+		var genre models.ProfileGenre
+		if err := rows.Scan(&genre.ID, &genre.Title, &genre.RatingsCount, &genre.AverageRating); err != nil {
+			logger.Error("failed to scan favourite genre: " + err.Error())
+			return nil, users.ErrorInternalServerError
+		}
+		genre.AverageRating = roundRating(genre.AverageRating)
+		genres = append(genres, genre)
+	}
+
+	logger.Info("succesfully got favourite genres of user from db")
+	return genres, nil
+}
+
+func (u *UserRepository) GetUserFavouriteActors(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileActor, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := u.db.Query(ctx, GetUserFavouriteActorsQuery, userID, limit)
+	if err != nil {
+		logger.Error("failed to query favourite actors: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	actors := []models.ProfileActor{}
+	for rows.Next() {
+		var actor models.ProfileActor
+		if err := rows.Scan(&actor.ID, &actor.RussianName, &actor.Photo, &actor.RatingsCount, &actor.AverageRating); err != nil {
+			logger.Error("failed to scan favourite actor: " + err.Error())
+			return nil, users.ErrorInternalServerError
+		}
+		actor.AverageRating = roundRating(actor.AverageRating)
+		actors = append(actors, actor)
+	}
+
+	logger.Info("succesfully got favourite actors of user from db")
+	return actors, nil
+}
+
+func (u *UserRepository) GetUserLatestReviews(ctx context.Context, userID uuid.UUID, limit int) ([]models.ProfileReview, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := u.db.Query(ctx, GetUserLatestReviewsQuery, userID, limit)
+	if err != nil {
+		logger.Error("failed to query latest reviews: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	reviews := []models.ProfileReview{}
+	for rows.Next() {
+		var review models.ProfileReview
+		if err := rows.Scan(
+			&review.ID, &review.FilmID, &review.FilmTitle, &review.Title,
+			&review.Text, &review.Rating, &review.CreatedAt,
+		); err != nil {
+			logger.Error("failed to scan review: " + err.Error())
+			return nil, users.ErrorInternalServerError
+		}
+		reviews = append(reviews, review)
+	}
+
+	logger.Info("succesfully got latest reviews of user from db")
+	return reviews, nil
+}
+
+func (u *UserRepository) GetUserEmail(ctx context.Context, userID uuid.UUID) (models.UserEmail, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var email *string
+	var userEmail models.UserEmail
+	err := u.db.QueryRow(ctx, GetUserEmailQuery, userID).Scan(&email, &userEmail.Verified)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return models.UserEmail{}, users.ErrorNotFound
+		}
+		logger.Error("failed to scan email: " + err.Error())
+		return models.UserEmail{}, users.ErrorInternalServerError
+	}
+	if email != nil {
+		userEmail.Email = *email
+	}
+
+	logger.Info("succesfully got email of user from db")
+	return userEmail, nil
+}
+
+func (u *UserRepository) UpdateUserEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := u.db.Exec(ctx, UpdateUserEmailQuery, email, userID)
+	if err != nil {
+		logger.Error("failed to update email: " + err.Error())
+		return users.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("user not exists")
+		return users.ErrorNotFound
+	}
+
+	logger.Info("succesfully updated email of user in db")
+	return nil
+}
+
+func (u *UserRepository) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var user models.User
+	err := u.db.QueryRow(ctx, GetUserByEmailQuery, email).Scan(
+		&user.ID, &user.Version, &user.Login,
+		&user.PasswordHash, &user.PasswordSet, &user.Avatar, &user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user with verified email not exists")
+			return models.User{}, users.ErrorNotFound
+		}
+		logger.Error("failed to scan user: " + err.Error())
+		return models.User{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got user by email from db")
+	return user, nil
+}
+
+// MarkEmailVerified подтверждает адрес, только если пользователь не сменил его после отправки письма;
+// если этот адрес уже подтвержден другим аккаунтом, возвращается конфликт
+func (u *UserRepository) MarkEmailVerified(ctx context.Context, userID uuid.UUID, email string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := u.db.Exec(ctx, MarkEmailVerifiedQuery, userID, email)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			logger.Error("email already verified by another user: " + pgErr.Message)
+			return users.ErrorEmailTaken
+		}
+		logger.Error("failed to verify email: " + err.Error())
+		return users.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("email was changed after token was issued")
+		return users.ErrorNotFound
+	}
+
+	logger.Info("succesfully verified email of user in db")
+	return nil
+}
+
+// CreateUserToken сохраняет токен; прежние неиспользованные токены того же назначения удаляются
+func (u *UserRepository) CreateUserToken(ctx context.Context, token models.UserToken) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := u.db.Exec(
+		ctx,
+		CreateUserTokenQuery,
+		token.ID, token.UserID, token.Purpose, token.TokenHash, token.Email, token.ExpiresAt, token.CreatedAt,
+	)
+	if err != nil {
+		logger.Error("failed to create token: " + err.Error())
+		return users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully created token in db")
+	return nil
+}
+
+// ConsumeUserToken помечает токен использованным и возвращает его. Просроченный, уже
+// использованный или неизвестный токен даёт ErrorNotFound
+func (u *UserRepository) ConsumeUserToken(ctx context.Context, tokenHash []byte, purpose string) (models.UserToken, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var token models.UserToken
+	err := u.db.QueryRow(ctx, ConsumeUserTokenQuery, tokenHash, purpose).Scan(
+		&token.ID, &token.UserID, &token.Purpose, &token.TokenHash,
+		&token.Email, &token.ExpiresAt, &token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("token not exists, expired or already used")
+			return models.UserToken{}, users.ErrorNotFound
+		}
+		logger.Error("failed to consume token: " + err.Error())
+		return models.UserToken{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully consumed token in db")
+	return token, nil
+}
+
+func (u *UserRepository) GetUserToken(ctx context.Context, tokenHash []byte, purpose string) (models.UserToken, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var token models.UserToken
+	err := u.db.QueryRow(ctx, GetUserTokenQuery, tokenHash, purpose).Scan(
+		&token.ID, &token.UserID, &token.Purpose, &token.TokenHash,
+		&token.Email, &token.ExpiresAt, &token.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("token not exists, expired or already used")
+			return models.UserToken{}, users.ErrorNotFound
+		}
+		logger.Error("failed to get token: " + err.Error())
+		return models.UserToken{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got token from db")
+	return token, nil
+}
+
+// ResetUserPassword гасит токен сброса и меняет пароль одним запросом, чтобы ссылка не сгорела без смены пароля
+func (u *UserRepository) ResetUserPassword(ctx context.Context, tokenHash []byte, passwordHash []byte) (uuid.UUID, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var userID uuid.UUID
+	err := u.db.QueryRow(ctx, ResetUserPasswordQuery, tokenHash, models.TokenPasswordReset, passwordHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("token not exists, expired or already used")
+			return uuid.UUID{}, users.ErrorNotFound
+		}
+		logger.Error("failed to reset password: " + err.Error())
+		return uuid.UUID{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully reset password in db")
+	return userID, nil
+}
+
+func (u *UserRepository) GetUserExport(ctx context.Context, userID uuid.UUID) (models.ExportProfile, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var profile models.ExportProfile
+	err := u.db.QueryRow(ctx, GetUserExportQuery, userID).Scan(
+		&profile.ID, &profile.Login, &profile.Email, &profile.EmailVerified, &profile.Avatar, &profile.Role,
+		&profile.Privacy.ShowStats, &profile.Privacy.ShowTaste, &profile.Privacy.ShowReviews, &profile.Privacy.FollowersOnly,
+		&profile.CreatedAt, &profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("user not exists")
+			return models.ExportProfile{}, users.ErrorNotFound
+		}
+		logger.Error("failed to scan user: " + err.Error())
+		return models.ExportProfile{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got user data for export from db")
+	return profile, nil
+}
+
+func (u *UserRepository) GetUserFeedbacks(ctx context.Context, userID uuid.UUID) ([]models.ExportFeedback, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := u.db.Query(ctx, GetUserFeedbacksQuery, userID)
+	if err != nil {
+		logger.Error("failed to query feedbacks: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	feedbacks := []models.ExportFeedback{}
+	for rows.Next() {
+		var feedback models.ExportFeedback
+		if err := rows.Scan(
+			&feedback.ID, &feedback.FilmID, &feedback.FilmTitle, &feedback.Title,
+			&feedback.Text, &feedback.Rating, &feedback.CreatedAt, &feedback.UpdatedAt,
+		); err != nil {
+			logger.Error("failed to scan feedback: " + err.Error())
+			return nil, users.ErrorInternalServerError
+		}
+		feedbacks = append(feedbacks, feedback)
+	}
+
+	logger.Info("succesfully got feedbacks of user from db")
+	return feedbacks, nil
+}
+
+// ScheduleUserDeletion повторный запрос переносит дату удаления
+func (u *UserRepository) ScheduleUserDeletion(ctx context.Context, userID uuid.UUID, deleteAfter time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	_, err := u.db.Exec(ctx, ScheduleUserDeletionQuery, userID, deleteAfter)
+	if err != nil {
+		logger.Error("failed to schedule deletion: " + err.Error())
+		return users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully scheduled deletion of user")
+	return nil
+}
+
+// DeleteDueUsers удаляет не больше limit пользователей с истёкшим сроком; остальное чистят каскады
+func (u *UserRepository) DeleteDueUsers(ctx context.Context, limit int) ([]models.DeletedUser, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := u.db.Query(ctx, DeleteDueUsersQuery, limit)
+	if err != nil {
+		logger.Error("failed to delete users: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	deleted := []models.DeletedUser{}
+	for rows.Next() {
+		var user models.DeletedUser
+		if err := rows.Scan(&user.ID, &user.Login, &user.Avatar); err != nil {
+			logger.Error("failed to scan deleted user: " + err.Error())
+			return nil, users.ErrorInternalServerError
+		}
+		deleted = append(deleted, user)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to delete users: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully deleted users from db")
+	return deleted, nil
+}
+
+// IsLoginAvailable логин свободен, если он ни у кого не стоит и не зарезервирован за другим пользователем
+func (u *UserRepository) IsLoginAvailable(ctx context.Context, userID uuid.UUID, login string) (bool, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var available bool
+	err := u.db.QueryRow(ctx, IsLoginAvailableQuery, userID, login).Scan(&available)
+	if err != nil {
+		logger.Error("failed to check login: " + err.Error())
+		return false, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully checked login")
+	return available, nil
+}
+
+// GetLastLoginChange nil, если пользователь ни разу не менял логин
+func (u *UserRepository) GetLastLoginChange(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var changedAt *time.Time
+	err := u.db.QueryRow(ctx, GetLastLoginChangeQuery, userID).Scan(&changedAt)
+	if err != nil {
+		logger.Error("failed to get last login change: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got last login change from db")
+	return changedAt, nil
+}
+
+// UpdateUserLogin меняет логин и в том же запросе переносит старый в историю с резервом до reservedUntil;
+// version новая версия пользователя, при гонке со сменой другого поля возвращается ErrorConflict
+func (u *UserRepository) UpdateUserLogin(ctx context.Context, version int, userID uuid.UUID, login string, reservedUntil time.Time) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	tag, err := u.db.Exec(ctx, UpdateUserLoginQuery, userID, version, login, reservedUntil)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			logger.Error("login already taken: " + pgErr.ConstraintName)
+			return users.ErrorConflict
+		}
+		logger.Error("failed to update login: " + err.Error())
+		return users.ErrorInternalServerError
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Error("user changed concurrently")
+		return users.ErrorConflict
+	}
+
+	logger.Info("succesfully updated login of user in db")
+	return nil
+}
+
+// ResolveLogin ищет пользователя по текущему логину, а если его нет, то по прежнему
+func (u *UserRepository) ResolveLogin(ctx context.Context, login string) (models.LoginResolution, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	var resolution models.LoginResolution
+	err := u.db.QueryRow(ctx, ResolveLoginQuery, login).Scan(&resolution.ID, &resolution.Login, &resolution.Previous)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Error("login not exists")
+			return models.LoginResolution{}, users.ErrorNotFound
+		}
+		logger.Error("failed to resolve login: " + err.Error())
+		return models.LoginResolution{}, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully resolved login")
+	return resolution, nil
+}
+
+// GetAvatarPaths все аватары, на которые ссылаются пользователи, включая ожидающих удаления
+func (u *UserRepository) GetAvatarPaths(ctx context.Context) ([]string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	rows, err := u.db.Query(ctx, GetAvatarPathsQuery)
+	if err != nil {
+		logger.Error("failed to get avatars: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+	defer rows.Close()
+
+	paths := []string{}
+	for rows.Next() {
+		var avatarPath string
+		if err := rows.Scan(&avatarPath); err != nil {
+			logger.Error("failed to scan avatar: " + err.Error())
+			return nil, users.ErrorInternalServerError
+		}
+		paths = append(paths, avatarPath)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("failed to get avatars: " + err.Error())
+		return nil, users.ErrorInternalServerError
+	}
+
+	logger.Info("succesfully got avatars from db", slog.Int("count", len(paths)))
+	return paths, nil
+}
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
@@ -9,9 +10,12 @@ import (
 
 	"kinopoisk/internal/models"
 	"kinopoisk/internal/pkg/middleware/logger"
+	"kinopoisk/internal/pkg/users"
 
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -43,13 +47,14 @@ func TestGetUserByID(t *testing.T) {
 			userID: userID,
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				rows := pgxpoolmock.NewRows([]string{
-					"id", "version", "login", "password_hash", "avatar", "created_at", "updated_at",
+					"id", "version", "login", "password_hash", "password_set", "avatar", "created_at", "updated_at",
 				}).
 					AddRow(
 						userID,
 						1,
 						"testuser",
 						[]byte("hashedpassword"),
+						true,
 						avatar, // Убрать & - передавать строку, а не указатель
 						createdAt,
 						updatedAt,
@@ -117,13 +122,14 @@ func TestGetUserByLogin(t *testing.T) {
 			login: "testuser",
 			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
 				rows := pgxpoolmock.NewRows([]string{
-					"id", "version", "login", "password_hash", "avatar", "created_at", "updated_at",
+					"id", "version", "login", "password_hash", "password_set", "avatar", "created_at", "updated_at",
 				}).
 					AddRow(
 						userID,
 						1,
 						"testuser",
 						[]byte("hashedpassword"),
+						true,
 						avatar,
 						createdAt,
 						updatedAt,
@@ -290,3 +296,732 @@ func TestUpdateUserAvatar(t *testing.T) {
 		})
 	}
 }
+
+type MockRow struct {
+	err error
+}
+
+func (m MockRow) Scan(dest ...interface{}) error {
+	return m.err
+}
+
+func TestGetUserProfile(t *testing.T) {
+	userID := uuid.NewV4()
+	createdAt := time.Now()
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantProfile models.UserProfile
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{
+					"id", "login", "avatar", "created_at", "show_stats", "show_taste", "show_reviews",
+					"followers_only", "followers_count", "following_count",
+				}).
+					AddRow(userID, "cinephile", "avatars/default.png", createdAt, true, false, true, true, 4, 2).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserProfileQuery, userID).Return(rows)
+			},
+			wantProfile: models.UserProfile{
+				ID:        userID,
+				Login:     "cinephile",
+				Avatar:    "avatars/default.png",
+				CreatedAt: createdAt,
+				Privacy: models.ProfilePrivacy{
+					ShowStats: true, ShowTaste: false, ShowReviews: true, FollowersOnly: true,
+				},
+				FollowersCount: 4,
+				FollowingCount: 2,
+			},
+		},
+		{
+			name: "Not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserProfileQuery, userID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserProfileQuery, userID).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			profile, err := repo.GetUserProfile(testContext(), userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantProfile, profile)
+		})
+	}
+}
+
+func TestGetUserStats(t *testing.T) {
+	userID := uuid.NewV4()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	rows := pgxpoolmock.NewRows([]string{"ratings_count", "reviews_count", "avg"}).
+		AddRow(12, 3, 7.4666).
+		ToPgxRows()
+	rows.Next()
+	mockPool.EXPECT().QueryRow(gomock.Any(), GetUserStatsQuery, userID).Return(rows)
+
+	repo := NewUserRepository(mockPool)
+	stats, err := repo.GetUserStats(testContext(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ProfileStats{RatingsCount: 12, ReviewsCount: 3, AverageRating: 7.5}, stats)
+}
+
+func TestGetUserFavouriteGenres(t *testing.T) {
+	userID := uuid.NewV4()
+	genreID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantGenres []models.ProfileGenre
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "title", "ratings_count", "average_rating"}).
+					AddRow(genreID, "Драма", 4, 8.25).
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetUserFavouriteGenresQuery, userID, 5).Return(rows, nil)
+			},
+			wantGenres: []models.ProfileGenre{{ID: genreID, Title: "Драма", RatingsCount: 4, AverageRating: 8.2}},
+		},
+		{
+			name: "No ratings",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "title", "ratings_count", "average_rating"}).ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetUserFavouriteGenresQuery, userID, 5).Return(rows, nil)
+			},
+			wantGenres: []models.ProfileGenre{},
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetUserFavouriteGenresQuery, userID, 5).Return(nil, errors.New("db error"))
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			genres, err := repo.GetUserFavouriteGenres(testContext(), userID, 5)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantGenres, genres)
+		})
+	}
+}
+
+func TestUpdateUserPrivacy(t *testing.T) {
+	userID := uuid.NewV4()
+	privacy := models.ProfilePrivacy{ShowStats: false, ShowTaste: true, ShowReviews: false, FollowersOnly: true}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	rows := pgxpoolmock.NewRows([]string{"show_stats", "show_taste", "show_reviews", "followers_only"}).
+		AddRow(false, true, false, true).
+		ToPgxRows()
+	rows.Next()
+	mockPool.EXPECT().
+		QueryRow(gomock.Any(), UpdateUserPrivacyQuery, userID, false, true, false, true).
+		Return(rows)
+
+	repo := NewUserRepository(mockPool)
+	updated, err := repo.UpdateUserPrivacy(testContext(), userID, privacy)
+	assert.NoError(t, err)
+	assert.Equal(t, privacy, updated)
+}
+
+func TestGetUserEmail(t *testing.T) {
+	userID := uuid.NewV4()
+	email := "user@example.com"
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		want       models.UserEmail
+		wantErr    error
+	}{
+		{
+			name: "Verified",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"email", "email_verified"}).AddRow(&email, true).ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserEmailQuery, userID).Return(rows)
+			},
+			want: models.UserEmail{Email: email, Verified: true},
+		},
+		{
+			name: "No email",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"email", "email_verified"}).AddRow((*string)(nil), false).ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserEmailQuery, userID).Return(rows)
+			},
+			want: models.UserEmail{},
+		},
+		{
+			name: "User not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserEmailQuery, userID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			result, err := repo.GetUserEmail(testContext(), userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestUpdateUserEmail(t *testing.T) {
+	userID := uuid.NewV4()
+	email := "user@example.com"
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), UpdateUserEmailQuery, email, userID).Return(pgconn.CommandTag("UPDATE 1"), nil)
+			},
+		},
+		{
+			name: "User not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), UpdateUserEmailQuery, email, userID).Return(pgconn.CommandTag("UPDATE 0"), nil)
+			},
+			wantErr: users.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			err := repo.UpdateUserEmail(testContext(), userID, email)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestConsumeUserToken(t *testing.T) {
+	tokenHash := make([]byte, 32)
+	token := models.UserToken{
+		ID:        uuid.NewV4(),
+		UserID:    uuid.NewV4(),
+		Purpose:   models.TokenEmailVerification,
+		TokenHash: tokenHash,
+		Email:     "user@example.com",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "user_id", "purpose", "token_hash", "email", "expires_at", "created_at"}).
+					AddRow(token.ID, token.UserID, token.Purpose, token.TokenHash, token.Email, token.ExpiresAt, token.CreatedAt).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), ConsumeUserTokenQuery, tokenHash, models.TokenEmailVerification).Return(rows)
+			},
+		},
+		{
+			name: "Used or expired",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), ConsumeUserTokenQuery, tokenHash, models.TokenEmailVerification).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), ConsumeUserTokenQuery, tokenHash, models.TokenEmailVerification).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			result, err := repo.ConsumeUserToken(testContext(), tokenHash, models.TokenEmailVerification)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, token.UserID, result.UserID)
+			assert.Equal(t, token.Email, result.Email)
+		})
+	}
+}
+
+func TestGetUserToken(t *testing.T) {
+	tokenHash := make([]byte, 32)
+	token := models.UserToken{
+		ID:        uuid.NewV4(),
+		UserID:    uuid.NewV4(),
+		Purpose:   models.TokenPasswordReset,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "user_id", "purpose", "token_hash", "email", "expires_at", "created_at"}).
+					AddRow(token.ID, token.UserID, token.Purpose, token.TokenHash, token.Email, token.ExpiresAt, token.CreatedAt).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserTokenQuery, tokenHash, models.TokenPasswordReset).Return(rows)
+			},
+		},
+		{
+			name: "Used or expired",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserTokenQuery, tokenHash, models.TokenPasswordReset).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserTokenQuery, tokenHash, models.TokenPasswordReset).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			result, err := repo.GetUserToken(testContext(), tokenHash, models.TokenPasswordReset)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, token.UserID, result.UserID)
+		})
+	}
+}
+
+func TestResetUserPassword(t *testing.T) {
+	tokenHash := make([]byte, 32)
+	passwordHash := []byte("hash")
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id"}).AddRow(userID).ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), ResetUserPasswordQuery, tokenHash, models.TokenPasswordReset, passwordHash).Return(rows)
+			},
+		},
+		{
+			name: "Used or expired",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), ResetUserPasswordQuery, tokenHash, models.TokenPasswordReset, passwordHash).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), ResetUserPasswordQuery, tokenHash, models.TokenPasswordReset, passwordHash).Return(MockRow{err: errors.New("db error")})
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			result, err := repo.ResetUserPassword(testContext(), tokenHash, passwordHash)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, userID, result)
+		})
+	}
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+	repo := NewUserRepository(mockPool)
+	userID := uuid.NewV4()
+
+	mockPool.EXPECT().Exec(gomock.Any(), MarkEmailVerifiedQuery, userID, "user@example.com").Return(pgconn.CommandTag("UPDATE 1"), nil)
+	assert.NoError(t, repo.MarkEmailVerified(testContext(), userID, "user@example.com"))
+
+	mockPool.EXPECT().Exec(gomock.Any(), MarkEmailVerifiedQuery, userID, "old@example.com").Return(pgconn.CommandTag("UPDATE 0"), nil)
+	assert.ErrorIs(t, repo.MarkEmailVerified(testContext(), userID, "old@example.com"), users.ErrorNotFound)
+
+	mockPool.EXPECT().Exec(gomock.Any(), MarkEmailVerifiedQuery, userID, "taken@example.com").Return(nil, &pgconn.PgError{Code: uniqueViolation})
+	assert.ErrorIs(t, repo.MarkEmailVerified(testContext(), userID, "taken@example.com"), users.ErrorEmailTaken)
+}
+
+func TestGetUserExport(t *testing.T) {
+	userID := uuid.NewV4()
+	email := "user@example.com"
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "login", "email", "email_verified", "avatar", "role",
+					"show_stats", "show_taste", "show_reviews", "followers_only", "created_at", "updated_at"}).
+					AddRow(userID, "testuser", &email, true, "avatars/default.png", "user", true, false, true, false, now, now).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserExportQuery, userID).Return(rows)
+			},
+		},
+		{
+			name: "Not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), GetUserExportQuery, userID).Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			profile, err := repo.GetUserExport(testContext(), userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "testuser", profile.Login)
+			assert.Equal(t, email, *profile.Email)
+			assert.Equal(t, models.ProfilePrivacy{ShowStats: true, ShowReviews: true}, profile.Privacy)
+		})
+	}
+}
+
+func TestDeleteDueUsers(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name        string
+		repoMocker  func(*pgxpoolmock.MockPgxPool)
+		wantDeleted []models.DeletedUser
+		wantErr     error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "login", "avatar"}).
+					AddRow(userID, "testuser", "avatars/a.png").
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), DeleteDueUsersQuery, 100).Return(rows, nil)
+			},
+			wantDeleted: []models.DeletedUser{{ID: userID, Login: "testuser", Avatar: "avatars/a.png"}},
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), DeleteDueUsersQuery, 100).Return(nil, errors.New("db error"))
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			deleted, err := repo.DeleteDueUsers(testContext(), 100)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func TestUpdateUserLogin(t *testing.T) {
+	userID := uuid.NewV4()
+	login := "newlogin"
+	reservedUntil := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), UpdateUserLoginQuery, userID, 2, login, reservedUntil).Return(pgconn.CommandTag("INSERT 0 1"), nil)
+			},
+		},
+		{
+			name: "Login taken or reserved",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), UpdateUserLoginQuery, userID, 2, login, reservedUntil).
+					Return(nil, &pgconn.PgError{Code: uniqueViolation, ConstraintName: "user_login_reserved"})
+			},
+			wantErr: users.ErrorConflict,
+		},
+		{
+			name: "Version changed",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), UpdateUserLoginQuery, userID, 2, login, reservedUntil).Return(pgconn.CommandTag("INSERT 0 0"), nil)
+			},
+			wantErr: users.ErrorConflict,
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Exec(gomock.Any(), UpdateUserLoginQuery, userID, 2, login, reservedUntil).Return(nil, errors.New("db error"))
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			err := repo.UpdateUserLogin(testContext(), 2, userID, login, reservedUntil)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResolveLogin(t *testing.T) {
+	userID := uuid.NewV4()
+
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		expected   models.LoginResolution
+		wantErr    error
+	}{
+		{
+			name: "Previous login",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"id", "login", "previous"}).
+					AddRow(userID, "newlogin", true).
+					ToPgxRows()
+				rows.Next()
+				mockPool.EXPECT().QueryRow(gomock.Any(), ResolveLoginQuery, "oldlogin").Return(rows)
+			},
+			expected: models.LoginResolution{ID: userID, Login: "newlogin", Previous: true},
+		},
+		{
+			name: "Not found",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().QueryRow(gomock.Any(), ResolveLoginQuery, "oldlogin").Return(MockRow{err: pgx.ErrNoRows})
+			},
+			wantErr: users.ErrorNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			resolution, err := repo.ResolveLogin(testContext(), "oldlogin")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resolution)
+		})
+	}
+}
+
+func TestGetAvatarPaths(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoMocker func(*pgxpoolmock.MockPgxPool)
+		wantPaths  []string
+		wantErr    error
+	}{
+		{
+			name: "Success",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				rows := pgxpoolmock.NewRows([]string{"avatar"}).
+					AddRow("avatars/default.png").
+					AddRow("avatars/a/512.jpg").
+					ToPgxRows()
+				mockPool.EXPECT().Query(gomock.Any(), GetAvatarPathsQuery).Return(rows, nil)
+			},
+			wantPaths: []string{"avatars/default.png", "avatars/a/512.jpg"},
+		},
+		{
+			name: "Database error",
+			repoMocker: func(mockPool *pgxpoolmock.MockPgxPool) {
+				mockPool.EXPECT().Query(gomock.Any(), GetAvatarPathsQuery).Return(nil, errors.New("db error"))
+			},
+			wantErr: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+			tt.repoMocker(mockPool)
+
+			repo := NewUserRepository(mockPool)
+			paths, err := repo.GetAvatarPaths(testContext())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+}
@@ -13,3 +13,75 @@ var UpdateUserPasswordQuery string
 
 //go:embed sql/updateUserAvatarQuery.sql
 var UpdateUserAvatarQuery string
+
+//go:embed sql/getUserProfileQuery.sql
+var GetUserProfileQuery string
+
+//go:embed sql/updateUserPrivacyQuery.sql
+var UpdateUserPrivacyQuery string
+
+//go:embed sql/getUserStatsQuery.sql
+var GetUserStatsQuery string
+
+//go:embed sql/getUserFavouriteGenresQuery.sql
+var GetUserFavouriteGenresQuery string
+
+//go:embed sql/getUserFavouriteActorsQuery.sql
+var GetUserFavouriteActorsQuery string
+
+//go:embed sql/getUserLatestReviewsQuery.sql
+var GetUserLatestReviewsQuery string
+
+//go:embed sql/isFollowerQuery.sql
+var IsFollowerQuery string
+
+//go:embed sql/getUserEmailQuery.sql
+var GetUserEmailQuery string
+
+//go:embed sql/updateUserEmailQuery.sql
+var UpdateUserEmailQuery string
+
+//go:embed sql/getUserByEmailQuery.sql
+var GetUserByEmailQuery string
+
+//go:embed sql/markEmailVerifiedQuery.sql
+var MarkEmailVerifiedQuery string
+
+//go:embed sql/createUserTokenQuery.sql
+var CreateUserTokenQuery string
+
+//go:embed sql/consumeUserTokenQuery.sql
+var ConsumeUserTokenQuery string
+
+//go:embed sql/getUserTokenQuery.sql
+var GetUserTokenQuery string
+
+//go:embed sql/resetUserPasswordQuery.sql
+var ResetUserPasswordQuery string
+
+//go:embed sql/getUserExportQuery.sql
+var GetUserExportQuery string
+
+//go:embed sql/getUserFeedbacksQuery.sql
+var GetUserFeedbacksQuery string
+
+//go:embed sql/scheduleUserDeletionQuery.sql
+var ScheduleUserDeletionQuery string
+
+//go:embed sql/deleteDueUsersQuery.sql
+var DeleteDueUsersQuery string
+
+//go:embed sql/isLoginAvailableQuery.sql
+var IsLoginAvailableQuery string
+
+//go:embed sql/getLastLoginChangeQuery.sql
+var GetLastLoginChangeQuery string
+
+//go:embed sql/updateUserLoginQuery.sql
+var UpdateUserLoginQuery string
+
+//go:embed sql/resolveLoginQuery.sql
+var ResolveLoginQuery string
+
+//go:embed sql/getAvatarPathsQuery.sql
+var GetAvatarPathsQuery string
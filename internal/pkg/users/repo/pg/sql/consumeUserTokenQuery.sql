@@ -0,0 +1,4 @@
+UPDATE user_token 
+SET used_at = CURRENT_TIMESTAMP 
+WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP 
+RETURNING id, user_id, purpose, token_hash, COALESCE(email, ''), expires_at, created_at
\ No newline at end of file
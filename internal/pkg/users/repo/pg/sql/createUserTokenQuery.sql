@@ -0,0 +1,6 @@
+WITH revoked AS (
+    DELETE FROM user_token 
+    WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL
+)
+INSERT INTO user_token (id, user_id, purpose, token_hash, email, expires_at, created_at) 
+VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
\ No newline at end of file
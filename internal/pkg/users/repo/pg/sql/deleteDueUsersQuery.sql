@@ -0,0 +1,9 @@
+DELETE FROM user_table 
+WHERE id IN (
+    SELECT user_id 
+    FROM user_deletion 
+    WHERE delete_after <= now() 
+    ORDER BY delete_after 
+    LIMIT $1
+) 
+RETURNING id, login, avatar
\ No newline at end of file
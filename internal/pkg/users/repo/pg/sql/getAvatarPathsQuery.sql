@@ -0,0 +1 @@
+SELECT DISTINCT avatar FROM user_table WHERE avatar IS NOT NULL
\ No newline at end of file
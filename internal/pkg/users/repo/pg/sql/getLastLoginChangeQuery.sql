@@ -0,0 +1,3 @@
+SELECT max(changed_at) 
+FROM user_login_history 
+WHERE user_id = $1
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT id, version, login, password_hash, password_set, avatar, created_at, updated_at 
+FROM user_table 
+WHERE lower(email) = lower($1) AND email_verified
\ No newline at end of file
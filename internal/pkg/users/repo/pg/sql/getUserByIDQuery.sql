@@ -1,3 +1,3 @@
-SELECT id, version, login, password_hash, avatar, created_at, updated_at 
+SELECT id, version, login, password_hash, password_set, avatar, created_at, updated_at 
 FROM user_table 
 WHERE id = $1
\ No newline at end of file
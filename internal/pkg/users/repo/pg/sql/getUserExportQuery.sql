@@ -0,0 +1,5 @@
+SELECT u.id, u.login, u.email, u.email_verified, u.avatar, u.role, 
+       u.show_stats, u.show_taste, u.show_reviews, u.followers_only, 
+       u.created_at, u.updated_at 
+FROM user_table u 
+WHERE u.id = $1
\ No newline at end of file
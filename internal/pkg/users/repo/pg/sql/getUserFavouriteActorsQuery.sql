@@ -0,0 +1,13 @@
+SELECT
+    a.id,
+    a.russian_name,
+    COALESCE(a.photo, ''),
+    COUNT(DISTINCT ff.film_id) AS ratings_count,
+    AVG(ff.rating) AS average_rating
+FROM film_feedback ff
+JOIN actor_in_film aif ON aif.film_id = ff.film_id AND aif.role = 'actor'
+JOIN actor a ON a.id = aif.actor_id
+WHERE ff.user_id = $1 AND ff.rating IS NOT NULL
+GROUP BY a.id, a.russian_name, a.photo
+ORDER BY ratings_count DESC, average_rating DESC, a.russian_name
+LIMIT $2
\ No newline at end of file
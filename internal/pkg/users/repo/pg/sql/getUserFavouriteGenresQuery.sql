@@ -0,0 +1,12 @@
+SELECT
+    g.id,
+    g.title,
+    COUNT(*) AS ratings_count,
+    AVG(ff.rating) AS average_rating
+FROM film_feedback ff
+JOIN film f ON f.id = ff.film_id
+JOIN genre g ON g.id = f.genre_id
+WHERE ff.user_id = $1 AND ff.rating IS NOT NULL
+GROUP BY g.id, g.title
+ORDER BY COUNT(*) FILTER (WHERE ff.rating >= 7) DESC, average_rating DESC, g.title
+LIMIT $2
\ No newline at end of file
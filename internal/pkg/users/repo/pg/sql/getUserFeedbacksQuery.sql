@@ -0,0 +1,5 @@
+SELECT ff.id, f.id, f.title, ff.title, ff.text, ff.rating, ff.created_at, ff.updated_at 
+FROM film_feedback ff 
+JOIN film f ON f.id = ff.film_id 
+WHERE ff.user_id = $1 
+ORDER BY ff.created_at, ff.id
\ No newline at end of file
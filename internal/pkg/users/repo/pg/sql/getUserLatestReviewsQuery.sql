@@ -0,0 +1,6 @@
+SELECT ff.id, f.id, f.title, ff.title, ff.text, ff.rating, ff.created_at
+FROM film_feedback ff
+JOIN film f ON f.id = ff.film_id
+WHERE ff.user_id = $1 AND ff.title IS NOT NULL AND ff.title != ''
+ORDER BY ff.created_at DESC
+LIMIT $2
\ No newline at end of file
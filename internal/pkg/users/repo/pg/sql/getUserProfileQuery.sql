@@ -0,0 +1,13 @@
+SELECT
+    u.id,
+    u.login,
+    COALESCE(u.avatar, ''),
+    u.created_at,
+    u.show_stats,
+    u.show_taste,
+    u.show_reviews,
+    u.followers_only,
+    (SELECT COUNT(*) FROM user_follow WHERE followee_id = u.id),
+    (SELECT COUNT(*) FROM user_follow WHERE follower_id = u.id)
+FROM user_table u
+WHERE u.id = $1
\ No newline at end of file
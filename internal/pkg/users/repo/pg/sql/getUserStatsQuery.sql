@@ -0,0 +1,6 @@
+SELECT
+    COUNT(rating),
+    COUNT(*) FILTER (WHERE title IS NOT NULL AND title != ''),
+    COALESCE(AVG(rating), 0)
+FROM film_feedback
+WHERE user_id = $1
\ No newline at end of file
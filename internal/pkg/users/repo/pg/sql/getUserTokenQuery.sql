@@ -0,0 +1,3 @@
+SELECT id, user_id, purpose, token_hash, COALESCE(email, ''), expires_at, created_at 
+FROM user_token 
+WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
\ No newline at end of file
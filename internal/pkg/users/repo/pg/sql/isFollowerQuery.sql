@@ -0,0 +1,3 @@
+SELECT EXISTS (
+    SELECT 1 FROM user_follow WHERE follower_id = $1 AND followee_id = $2
+)
\ No newline at end of file
@@ -0,0 +1,5 @@
+SELECT NOT EXISTS (SELECT 1 FROM user_table WHERE login = $2) 
+  AND NOT EXISTS (
+    SELECT 1 FROM user_login_history 
+    WHERE login = $2 AND user_id <> $1 AND reserved_until > CURRENT_TIMESTAMP
+  )
\ No newline at end of file
@@ -0,0 +1,3 @@
+UPDATE user_table 
+SET email_verified = true, updated_at = CURRENT_TIMESTAMP 
+WHERE id = $1 AND lower(email) = lower($2)
\ No newline at end of file
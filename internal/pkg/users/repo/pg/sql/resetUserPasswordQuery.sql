@@ -0,0 +1,11 @@
+WITH consumed AS (
+    UPDATE user_token 
+    SET used_at = CURRENT_TIMESTAMP 
+    WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP 
+    RETURNING user_id
+)
+UPDATE user_table 
+SET password_hash = $3, password_set = true, version = user_table.version + 1, updated_at = CURRENT_TIMESTAMP 
+FROM consumed 
+WHERE user_table.id = consumed.user_id 
+RETURNING user_table.id
\ No newline at end of file
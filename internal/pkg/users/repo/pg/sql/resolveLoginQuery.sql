@@ -0,0 +1,8 @@
+SELECT id, login, false FROM user_table WHERE login = $1 
+UNION ALL 
+SELECT u.id, u.login, true 
+FROM user_login_history h 
+JOIN user_table u ON u.id = h.user_id 
+WHERE h.login = $1 
+  AND NOT EXISTS (SELECT 1 FROM user_table WHERE login = $1) 
+LIMIT 1
\ No newline at end of file
@@ -0,0 +1,3 @@
+INSERT INTO user_deletion (user_id, delete_after) 
+VALUES ($1, $2) 
+ON CONFLICT (user_id) DO UPDATE SET delete_after = EXCLUDED.delete_after
\ No newline at end of file
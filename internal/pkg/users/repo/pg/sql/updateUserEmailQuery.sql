@@ -0,0 +1,3 @@
+UPDATE user_table 
+SET email = $1, email_verified = false, updated_at = CURRENT_TIMESTAMP 
+WHERE id = $2
\ No newline at end of file
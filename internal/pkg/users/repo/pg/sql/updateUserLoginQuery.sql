@@ -0,0 +1,14 @@
+WITH old AS (
+    SELECT id, login FROM user_table WHERE id = $1 AND version = $2 - 1 FOR UPDATE
+), updated AS (
+    UPDATE user_table u 
+    SET login = $3, version = $2, updated_at = CURRENT_TIMESTAMP 
+    FROM old 
+    WHERE u.id = old.id 
+    RETURNING u.id
+)
+INSERT INTO user_login_history (login, user_id, changed_at, reserved_until) 
+SELECT old.login, old.id, CURRENT_TIMESTAMP, $4 
+FROM old JOIN updated ON updated.id = old.id 
+ON CONFLICT (login) DO UPDATE 
+SET user_id = EXCLUDED.user_id, changed_at = EXCLUDED.changed_at, reserved_until = EXCLUDED.reserved_until
\ No newline at end of file
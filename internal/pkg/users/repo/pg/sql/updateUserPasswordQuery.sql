@@ -1,3 +1,3 @@
 UPDATE user_table 
-SET password_hash = $1, version = $2, updated_at = CURRENT_TIMESTAMP 
+SET password_hash = $1, password_set = true, version = $2, updated_at = CURRENT_TIMESTAMP 
 WHERE id = $3
\ No newline at end of file
@@ -0,0 +1,4 @@
+UPDATE user_table
+SET show_stats = $2, show_taste = $3, show_reviews = $4, followers_only = $5
+WHERE id = $1
+RETURNING show_stats, show_taste, show_reviews, followers_only
\ No newline at end of file
@@ -1,59 +1,109 @@
 package usecase
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"expvar"
 	"fmt"
+	"html"
 	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/auth"
+	"kinopoisk/internal/pkg/avatar"
+	"kinopoisk/internal/pkg/mail"
+	"kinopoisk/internal/pkg/notifications"
+	"kinopoisk/internal/pkg/password"
 	"kinopoisk/internal/pkg/users"
 	"kinopoisk/internal/pkg/utils/log"
 	"log/slog"
 	"os"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
-	"golang.org/x/crypto/argon2"
 )
 
-func HashPass(plainPassword string) []byte {
-	salt := make([]byte, 8)
-	_, err := rand.Read(salt)
-	if err != nil {
-		return []byte{}
-	}
-	hashedPass := argon2.IDKey([]byte(plainPassword), []byte(salt), 1, 64*1024, 4, 32)
-	return append(salt, hashedPass...)
-}
+// размеры разделов публичного профиля
+const (
+	profileGenresLimit  = 5
+	profileActorsLimit  = 5
+	profileReviewsLimit = 3
+)
 
-func CheckPass(passHash []byte, plainPassword string) bool {
-	salt := make([]byte, 8)
-	copy(salt, passHash[:8])
-	userHash := argon2.IDKey([]byte(plainPassword), salt, 1, 64*1024, 4, 32)
-	userHashedPassword := append(salt, userHash...)
-	return bytes.Equal(userHashedPassword, passHash)
-}
+// время жизни токенов из писем
+const (
+	emailVerificationTTL = 24 * time.Hour
+	passwordResetTTL     = time.Hour
+)
+
+const (
+	// accountDeletionGrace сколько аккаунт ждёт удаления; вход в этот срок отменяет удаление
+	accountDeletionGrace = 14 * 24 * time.Hour
+	accountPurgeBatch    = 100
+	defaultAvatar        = "avatars/default.png"
+)
+
+const (
+	// loginChangeCooldown как часто можно менять логин
+	loginChangeCooldown = 30 * 24 * time.Hour
+	// loginReservation сколько прежний логин закреплён за владельцем; должно быть больше срока жизни токена
+	loginReservation = 180 * 24 * time.Hour
+)
+
+const (
+	// avatarUploadTTL сколько действует ссылка на загрузку
+	avatarUploadTTL = 15 * time.Minute
+	// avatarUploadGrace после этого неподтверждённая загрузка считается брошенной
+	avatarUploadGrace = time.Hour
+)
+
+// avatarOrphanGrace файлы моложе не трогаются: аватар мог быть загружен, но ещё не записан в базу
+const avatarOrphanGrace = 24 * time.Hour
+
+// avatarGCMetrics счётчики ReconcileAvatars, отдаются через expvar
+var avatarGCMetrics = expvar.NewMap("avatar_gc")
+
+// avatarUploadTypes типы, на которые выдаётся ссылка; сам формат всё равно проверяется при подтверждении
+var avatarUploadTypes = map[string]bool{"image/jpeg": true, "image/png": true, "image/webp": true}
 
 type UserUsecase struct {
 	secret      string
 	userRepo    users.UsersRepo
 	storageRepo users.StorageRepo
+	notifier    notifications.Producer
+	mailer      mail.Sender
+	passwords   *password.Policy
+	baseURL     string
 }
 
-func NewUserUsecase(userRepo users.UsersRepo, storageRepo users.StorageRepo) *UserUsecase {
+func NewUserUsecase(userRepo users.UsersRepo, storageRepo users.StorageRepo, notifier notifications.Producer, mailer mail.Sender, passwords *password.Policy, baseURL string) *UserUsecase {
 	return &UserUsecase{
 		secret:      os.Getenv("JWT_SECRET"),
 		userRepo:    userRepo,
 		storageRepo: storageRepo,
+		notifier:    notifier,
+		mailer:      mailer,
+		passwords:   passwords,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
 	}
 }
 
-func (uc *UserUsecase) GenerateToken(id uuid.UUID, login string) (string, error) {
+func (uc *UserUsecase) notify(ctx context.Context, event models.NotificationEvent) {
+	if uc.notifier != nil {
+		uc.notifier.Notify(ctx, event)
+	}
+}
+
+func (uc *UserUsecase) GenerateToken(id uuid.UUID, login string, version int) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"id":    id,
-		"login": login,
-		"exp":   time.Now().Add(time.Hour * 24).Unix(),
+		"id":      id,
+		"login":   login,
+		"version": version,
+		"exp":     time.Now().Add(time.Hour * 24).Unix(),
 	})
 	return token.SignedString([]byte(uc.secret))
 }
@@ -103,6 +153,18 @@ func (uc *UserUsecase) ValidateAndGetUser(ctx context.Context, token string) (mo
 		return models.User{}, users.ErrorUnauthorized
 	}
 
+	// логин после смены освобождается, поэтому токен сверяется ещё и по id
+	if id, _ := claims["id"].(string); id != user.ID.String() {
+		logger.Error("login belongs to another user")
+		return models.User{}, users.ErrorUnauthorized
+	}
+
+	// версия растёт при выходе и смене пароля или логина, старые токены после этого не принимаются
+	if version, ok := claims["version"].(float64); !ok || int(version) != user.Version {
+		logger.Error("stale token version")
+		return models.User{}, users.ErrorUnauthorized
+	}
+
 	return user, nil
 }
 
@@ -121,15 +183,15 @@ func (uc *UserUsecase) ChangePassword(ctx context.Context, id uuid.UUID, oldPass
 		return models.User{}, "", err
 	}
 
-	if !CheckPass(neededUser.PasswordHash, oldPassword) {
+	if !passwordMatches(neededUser, oldPassword) {
 		logger.Error("wrong old password")
-		return models.User{}, "", users.ErrorBadRequest
+		return models.User{}, "", users.ErrorWrongPassword
 	}
 
-	msg, passwordIsValid := users.Validaton(neededUser.Login, newPassword)
+	msg, passwordIsValid := uc.passwords.Validate(neededUser.Login, html.UnescapeString(newPassword))
 	if !passwordIsValid {
 		logger.Error(msg)
-		return models.User{}, "", users.ErrorBadRequest
+		return models.User{}, "", users.ErrorWeakPassword
 	}
 
 	if newPassword == oldPassword {
@@ -137,17 +199,24 @@ func (uc *UserUsecase) ChangePassword(ctx context.Context, id uuid.UUID, oldPass
 		return models.User{}, "", users.ErrorBadRequest
 	}
 
+	passwordHash, err := password.Hash(newPassword)
+	if err != nil {
+		logger.Error("failed to hash password: " + err.Error())
+		return models.User{}, "", users.ErrorInternalServerError
+	}
+
 	neededUser.Version += 1
 
-	err = uc.userRepo.UpdateUserPassword(ctx, neededUser.Version, neededUser.ID, HashPass(newPassword))
+	err = uc.userRepo.UpdateUserPassword(ctx, neededUser.Version, neededUser.ID, passwordHash)
 	if err != nil {
 		return models.User{}, "", err
 	}
 
-	neededUser.PasswordHash = HashPass(newPassword)
+	neededUser.PasswordHash = passwordHash
 	neededUser.UpdatedAt = time.Now().UTC()
+	uc.notify(ctx, models.SecurityEvent{UserID: neededUser.ID, Action: models.SecurityPasswordChanged})
 
-	token, err := uc.GenerateToken(neededUser.ID, neededUser.Login)
+	token, err := uc.GenerateToken(neededUser.ID, neededUser.Login, neededUser.Version)
 	if err != nil {
 		return models.User{}, "", err
 	}
@@ -155,47 +224,118 @@ func (uc *UserUsecase) ChangePassword(ctx context.Context, id uuid.UUID, oldPass
 	return neededUser, token, nil
 }
 
-func (uc *UserUsecase) ChangeUserAvatar(ctx context.Context, id uuid.UUID, buffer []byte, fileFormat string) (models.User, string, error) {
+func (uc *UserUsecase) ChangeLogin(ctx context.Context, id uuid.UUID, login string, plainPassword string) (models.User, string, error) {
 	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
 	neededUser, err := uc.userRepo.GetUserByID(ctx, id)
 	if err != nil {
 		return models.User{}, "", err
 	}
 
-	defaultPath := "avatars/default.png"
-	var avatarExtension string
-	switch fileFormat {
-	case "image/jpeg":
-		avatarExtension = ".jpg"
-	case "image/png":
-		avatarExtension = ".png"
-	case "image/webp":
-		avatarExtension = ".webp"
-	default:
-		logger.Error("invalid format of file")
+	if !passwordMatches(neededUser, plainPassword) {
+		logger.Error("wrong password")
+		return models.User{}, "", users.ErrorWrongPassword
+	}
+
+	msg, loginIsValid := auth.ValidateLogin(login)
+	if !loginIsValid {
+		logger.Error(msg)
+		return models.User{}, "", users.ErrorInvalidLogin
+	}
+
+	if login == neededUser.Login {
+		logger.Error("login is not changed")
 		return models.User{}, "", users.ErrorBadRequest
 	}
 
-	if neededUser.Avatar != defaultPath {
-		err := uc.storageRepo.DeleteAvatar(ctx, neededUser.Avatar)
-		if err != nil {
-			logger.Warn("failed to delete old avatar", "error", err)
+	lastChange, err := uc.userRepo.GetLastLoginChange(ctx, neededUser.ID)
+	if err != nil {
+		return models.User{}, "", err
+	}
+	if lastChange != nil && time.Since(*lastChange) < loginChangeCooldown {
+		logger.Error("login was changed recently")
+		return models.User{}, "", users.ErrorTooManyRequests
+	}
+
+	available, err := uc.userRepo.IsLoginAvailable(ctx, neededUser.ID, login)
+	if err != nil {
+		return models.User{}, "", err
+	}
+	if !available {
+		logger.Error("login is taken or reserved")
+		return models.User{}, "", users.ErrorLoginTaken
+	}
+
+	// старые токены несут прежний логин и перестают находить пользователя,
+	// а резерв не даёт никому занять этот логин, пока они не истекли
+	neededUser.Version += 1
+	err = uc.userRepo.UpdateUserLogin(ctx, neededUser.Version, neededUser.ID, login, time.Now().Add(loginReservation))
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	neededUser.Login = login
+	neededUser.UpdatedAt = time.Now().UTC()
+	uc.notify(ctx, models.SecurityEvent{UserID: neededUser.ID, Action: models.SecurityLoginChanged})
+
+	token, err := uc.GenerateToken(neededUser.ID, neededUser.Login, neededUser.Version)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	return neededUser, token, nil
+}
+
+// ResolveLogin нужен для ссылок на профиль по логину, в том числе по прежнему
+func (uc *UserUsecase) ResolveLogin(ctx context.Context, login string) (models.LoginResolution, error) {
+	return uc.userRepo.ResolveLogin(ctx, login)
+}
+
+func (uc *UserUsecase) ChangeUserAvatar(ctx context.Context, id uuid.UUID, buffer []byte) (models.User, string, error) {
+	neededUser, err := uc.userRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return models.User{}, "", err
+	}
+	return uc.setAvatar(ctx, neededUser, buffer)
+}
+
+// setAvatar нарезает картинку, кладёт её в хранилище и меняет аватар пользователя
+func (uc *UserUsecase) setAvatar(ctx context.Context, neededUser models.User, buffer []byte) (models.User, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	images, err := avatar.Process(buffer)
+	if err != nil {
+		logger.Error("failed to process avatar: " + err.Error())
+		if errors.Is(err, avatar.ErrTooLarge) || errors.Is(err, avatar.ErrTooSmall) ||
+			errors.Is(err, avatar.ErrUnsupportedFormat) || errors.Is(err, avatar.ErrInvalidImage) {
+			return models.User{}, "", users.ErrorBadRequest
 		}
+		return models.User{}, "", users.ErrorInternalServerError
 	}
 
-	avatarPath, err := uc.storageRepo.UploadAvatar(ctx, neededUser.ID.String(), buffer, fileFormat, avatarExtension)
+	// сначала новый аватар и запись в базе, старый удаляется последним: при любой
+	// ошибке у пользователя остаётся рабочий аватар, а лишние файлы подберёт ReconcileAvatars
+	avatarPath, err := uc.storageRepo.UploadAvatar(ctx, images)
 	if err != nil {
 		logger.Error("failed to upload avatar", "error", err)
 		return models.User{}, "", users.ErrorInternalServerError
 	}
 
-	neededUser.Avatar = avatarPath
-	err = uc.userRepo.UpdateUserAvatar(ctx, neededUser.Version, neededUser.ID, neededUser.Avatar)
+	err = uc.userRepo.UpdateUserAvatar(ctx, neededUser.Version, neededUser.ID, avatarPath)
 	if err != nil {
+		if err := uc.storageRepo.DeleteAvatar(ctx, avatarPath); err != nil {
+			logger.Warn("failed to delete unused avatar", "error", err)
+		}
 		return models.User{}, "", err
 	}
 
-	token, err := uc.GenerateToken(neededUser.ID, neededUser.Login)
+	if neededUser.Avatar != defaultAvatar {
+		if err := uc.storageRepo.DeleteAvatar(ctx, neededUser.Avatar); err != nil {
+			logger.Warn("failed to delete old avatar", "error", err)
+		}
+	}
+	neededUser.Avatar = avatarPath
+
+	token, err := uc.GenerateToken(neededUser.ID, neededUser.Login, neededUser.Version)
 	if err != nil {
 		return models.User{}, "", err
 	}
@@ -203,3 +343,565 @@ func (uc *UserUsecase) ChangeUserAvatar(ctx context.Context, id uuid.UUID, buffe
 
 	return neededUser, token, nil
 }
+
+// ReconcileAvatars удаляет из хранилища файлы аватаров, на которые никто не ссылается
+// и которые старше avatarOrphanGrace. Смотрятся только нарезанные аватары в своих
+// каталогах: файлы прямо в avatars/ это стоковые картинки и аватары старого формата.
+// При dryRun ничего не удаляется, в отчёте только найденное
+func (uc *UserUsecase) ReconcileAvatars(ctx context.Context, dryRun bool) (models.AvatarReconcileReport, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	report := models.AvatarReconcileReport{DryRun: dryRun}
+
+	// сначала список файлов, потом ссылки: аватар, записанный между двумя запросами,
+	// окажется в ссылках, а загруженный позже в списке не появится
+	objects, err := uc.storageRepo.ListAvatarObjects(ctx)
+	if err != nil {
+		return report, err
+	}
+	avatarPaths, err := uc.userRepo.GetAvatarPaths(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	referenced := make(map[string]bool, len(avatarPaths)*len(models.AvatarSizes))
+	for _, avatarPath := range avatarPaths {
+		for _, sizePath := range models.AvatarPaths(avatarPath) {
+			referenced[sizePath] = true
+		}
+	}
+
+	cutoff := time.Now().Add(-avatarOrphanGrace)
+	for _, object := range objects {
+		if path.Dir(object.Path) == "avatars" {
+			continue
+		}
+		report.Scanned++
+		if referenced[object.Path] || !object.ModTime.Before(cutoff) {
+			continue
+		}
+		report.Orphaned++
+		if dryRun {
+			logger.Info("orphaned avatar found", "avatar_path", object.Path)
+			continue
+		}
+		if err := uc.storageRepo.DeleteAvatarObject(ctx, object.Path); err != nil {
+			report.Failed++
+			continue
+		}
+		report.Deleted++
+	}
+
+	avatarGCMetrics.Add("runs", 1)
+	avatarGCMetrics.Add("scanned", int64(report.Scanned))
+	avatarGCMetrics.Add("orphaned", int64(report.Orphaned))
+	avatarGCMetrics.Add("deleted", int64(report.Deleted))
+	avatarGCMetrics.Add("failed", int64(report.Failed))
+	lastRun := new(expvar.Int)
+	lastRun.Set(time.Now().Unix())
+	avatarGCMetrics.Set("last_run", lastRun)
+
+	return report, nil
+}
+
+// RunAvatarReconciler запускает ReconcileAvatars раз в interval до отмены ctx
+func (uc *UserUsecase) RunAvatarReconciler(ctx context.Context, interval time.Duration, dryRun bool) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := uc.ReconcileAvatars(ctx, dryRun)
+			if err != nil {
+				logger.Error("failed to reconcile avatars: " + err.Error())
+				continue
+			}
+			if report.Orphaned > 0 {
+				logger.Info(fmt.Sprintf("found %d orphaned avatars, deleted %d, failed %d, dry run %t",
+					report.Orphaned, report.Deleted, report.Failed, report.DryRun))
+			}
+		}
+	}
+}
+
+// RequestAvatarUpload выдаёт ссылку, по которой клиент загружает исходник прямо в хранилище
+func (uc *UserUsecase) RequestAvatarUpload(ctx context.Context, userID uuid.UUID, contentType string, size int64) (models.AvatarUploadSlot, error) {
+	if !avatarUploadTypes[contentType] || size <= 0 || size > avatar.MaxBytes {
+		return models.AvatarUploadSlot{}, users.ErrorBadRequest
+	}
+	if _, err := uc.userRepo.GetUserByID(ctx, userID); err != nil {
+		return models.AvatarUploadSlot{}, err
+	}
+	return uc.storageRepo.PresignAvatarUpload(ctx, userID, contentType, size, avatarUploadTTL)
+}
+
+// ConfirmAvatarUpload проверяет загруженный исходник и делает его аватаром.
+// Исходник удаляется и при успехе, и при отказе: загрузить заново можно только по новой ссылке
+func (uc *UserUsecase) ConfirmAvatarUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (models.User, string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	neededUser, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	buffer, err := uc.storageRepo.GetAvatarUpload(ctx, userID, uploadID, avatar.MaxBytes)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	user, token, err := uc.setAvatar(ctx, neededUser, buffer)
+	if err == nil || errors.Is(err, users.ErrorBadRequest) {
+		if err := uc.storageRepo.DeleteAvatarUpload(ctx, userID, uploadID); err != nil {
+			logger.Warn("failed to delete avatar upload", "error", err)
+		}
+	}
+	return user, token, err
+}
+
+// CleanupAvatarUploads удаляет неподтверждённые загрузки старше avatarUploadGrace
+func (uc *UserUsecase) CleanupAvatarUploads(ctx context.Context) (int, error) {
+	return uc.storageRepo.DeleteStaleAvatarUploads(ctx, time.Now().Add(-avatarUploadGrace))
+}
+
+// RunAvatarUploadCleanup запускает CleanupAvatarUploads раз в interval до отмены ctx
+func (uc *UserUsecase) RunAvatarUploadCleanup(ctx context.Context, interval time.Duration) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := uc.CleanupAvatarUploads(ctx)
+			if err != nil {
+				logger.Error("failed to clean up avatar uploads: " + err.Error())
+			}
+			if deleted > 0 {
+				logger.Info(fmt.Sprintf("deleted %d stale avatar uploads", deleted))
+			}
+		}
+	}
+}
+
+func (uc *UserUsecase) GetUserProfile(ctx context.Context, id uuid.UUID) (models.UserProfile, error) {
+	profile, err := uc.userRepo.GetUserProfile(ctx, id)
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+
+	visible, err := uc.activityVisible(ctx, profile)
+	if err != nil {
+		return models.UserProfile{}, err
+	}
+	if !visible {
+		return profile, nil
+	}
+
+	if profile.Privacy.ShowStats {
+		stats, err := uc.userRepo.GetUserStats(ctx, id)
+		if err != nil {
+			return models.UserProfile{}, err
+		}
+		profile.Stats = &stats
+	}
+
+	if profile.Privacy.ShowTaste {
+		profile.FavouriteGenres, err = uc.userRepo.GetUserFavouriteGenres(ctx, id, profileGenresLimit)
+		if err != nil {
+			return models.UserProfile{}, err
+		}
+		profile.FavouriteActors, err = uc.userRepo.GetUserFavouriteActors(ctx, id, profileActorsLimit)
+		if err != nil {
+			return models.UserProfile{}, err
+		}
+	}
+
+	if profile.Privacy.ShowReviews {
+		profile.LatestReviews, err = uc.userRepo.GetUserLatestReviews(ctx, id, profileReviewsLimit)
+		if err != nil {
+			return models.UserProfile{}, err
+		}
+	}
+
+	return profile, nil
+}
+
+// activityVisible проверяет, может ли текущий пользователь видеть активность владельца профиля
+func (uc *UserUsecase) activityVisible(ctx context.Context, profile models.UserProfile) (bool, error) {
+	if !profile.Privacy.FollowersOnly {
+		return true, nil
+	}
+	viewerID, ok := ctx.Value(users.UserKey).(uuid.UUID)
+	if !ok {
+		return false, nil
+	}
+	if viewerID == profile.ID {
+		return true, nil
+	}
+	return uc.userRepo.IsFollower(ctx, viewerID, profile.ID)
+}
+
+func (uc *UserUsecase) ChangePrivacy(ctx context.Context, userID uuid.UUID, privacy models.ProfilePrivacy) (models.ProfilePrivacy, error) {
+	return uc.userRepo.UpdateUserPrivacy(ctx, userID, privacy)
+}
+
+func (uc *UserUsecase) GetEmail(ctx context.Context, userID uuid.UUID) (models.UserEmail, error) {
+	return uc.userRepo.GetUserEmail(ctx, userID)
+}
+
+// ChangeEmail требует пароль, если он задан: через почту восстанавливается доступ, и одной сессии для смены мало
+func (uc *UserUsecase) ChangeEmail(ctx context.Context, userID uuid.UUID, email string, plainPassword string) (models.UserEmail, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	email = strings.TrimSpace(email)
+	msg, emailIsValid := users.ValidateEmail(email)
+	if !emailIsValid {
+		logger.Error(msg)
+		return models.UserEmail{}, users.ErrorBadRequest
+	}
+
+	neededUser, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.UserEmail{}, err
+	}
+
+	if !passwordMatches(neededUser, plainPassword) {
+		logger.Error("wrong password")
+		return models.UserEmail{}, users.ErrorWrongPassword
+	}
+
+	current, err := uc.userRepo.GetUserEmail(ctx, userID)
+	if err != nil {
+		return models.UserEmail{}, err
+	}
+	if current.Verified && strings.EqualFold(current.Email, email) {
+		return current, nil
+	}
+
+	err = uc.userRepo.UpdateUserEmail(ctx, userID, email)
+	if err != nil {
+		return models.UserEmail{}, err
+	}
+
+	link, err := uc.issueToken(ctx, userID, models.TokenEmailVerification, email, emailVerificationTTL, "/verify-email")
+	if err != nil {
+		return models.UserEmail{}, err
+	}
+
+	err = uc.sendMail(ctx, mail.Message{
+		To:      email,
+		Subject: "Подтверждение почты",
+		Body: "Чтобы подтвердить адрес, перейдите по ссылке:\n\n" + link +
+			"\n\nСсылка действует 24 часа. Если вы не указывали эту почту, просто проигнорируйте письмо.",
+	})
+	if err != nil {
+		return models.UserEmail{}, err
+	}
+
+	uc.mailEmailChanged(ctx, neededUser, current)
+	uc.notify(ctx, models.SecurityEvent{UserID: neededUser.ID, Action: models.SecurityEmailChanged})
+
+	return models.UserEmail{Email: email, Verified: false}, nil
+}
+
+// mailEmailChanged предупреждает прежний подтверждённый адрес; без письма смена всё равно состоится
+func (uc *UserUsecase) mailEmailChanged(ctx context.Context, user models.User, previous models.UserEmail) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if previous.Email == "" || !previous.Verified {
+		return
+	}
+
+	err := uc.sendMail(ctx, mail.Message{
+		To:      previous.Email,
+		Subject: "Почта аккаунта изменена",
+		Body: "Здравствуйте, " + user.Login + "!\n\nК вашему аккаунту привязали другой адрес почты. " +
+			"Если это были не вы, смените пароль и обратитесь в поддержку.",
+	})
+	if err != nil {
+		logger.Warn("failed to send email change letter", "error", err)
+	}
+}
+
+func (uc *UserUsecase) VerifyEmail(ctx context.Context, token string) (models.UserEmail, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if token == "" {
+		logger.Error("empty token")
+		return models.UserEmail{}, users.ErrorBadRequest
+	}
+
+	consumed, err := uc.userRepo.ConsumeUserToken(ctx, hashToken(token), models.TokenEmailVerification)
+	if err != nil {
+		if errors.Is(err, users.ErrorNotFound) {
+			return models.UserEmail{}, users.ErrorBadRequest
+		}
+		return models.UserEmail{}, err
+	}
+
+	err = uc.userRepo.MarkEmailVerified(ctx, consumed.UserID, consumed.Email)
+	if err != nil {
+		if errors.Is(err, users.ErrorNotFound) {
+			return models.UserEmail{}, users.ErrorBadRequest
+		}
+		return models.UserEmail{}, err
+	}
+
+	return models.UserEmail{Email: consumed.Email, Verified: true}, nil
+}
+
+// ForgotPassword не сообщает, есть ли такой адрес, чтобы по ответу нельзя было перебирать почты
+func (uc *UserUsecase) ForgotPassword(ctx context.Context, email string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	email = strings.TrimSpace(email)
+	msg, emailIsValid := users.ValidateEmail(email)
+	if !emailIsValid {
+		logger.Error(msg)
+		return users.ErrorBadRequest
+	}
+
+	user, err := uc.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, users.ErrorNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	link, err := uc.issueToken(ctx, user.ID, models.TokenPasswordReset, "", passwordResetTTL, "/reset-password")
+	if err != nil {
+		return err
+	}
+
+	return uc.sendMail(ctx, mail.Message{
+		To:      email,
+		Subject: "Восстановление пароля",
+		Body: "Здравствуйте, " + user.Login + "!\n\nЧтобы задать новый пароль, перейдите по ссылке:\n\n" + link +
+			"\n\nСсылка действует один час и сработает только один раз. Если вы не запрашивали сброс, просто проигнорируйте письмо.",
+	})
+}
+
+func (uc *UserUsecase) ResetPassword(ctx context.Context, token string, newPassword string) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if token == "" {
+		logger.Error("empty token")
+		return users.ErrorBadRequest
+	}
+
+	// токен гасится только вместе со сменой пароля, чтобы опечатка не сжигала ссылку
+	issued, err := uc.userRepo.GetUserToken(ctx, hashToken(token), models.TokenPasswordReset)
+	if err != nil {
+		if errors.Is(err, users.ErrorNotFound) {
+			return users.ErrorBadRequest
+		}
+		return err
+	}
+
+	neededUser, err := uc.userRepo.GetUserByID(ctx, issued.UserID)
+	if err != nil {
+		return err
+	}
+
+	msg, passwordIsValid := uc.passwords.Validate(neededUser.Login, html.UnescapeString(newPassword))
+	if !passwordIsValid {
+		logger.Error(msg)
+		return users.ErrorWeakPassword
+	}
+
+	passwordHash, err := password.Hash(newPassword)
+	if err != nil {
+		logger.Error("failed to hash password: " + err.Error())
+		return users.ErrorInternalServerError
+	}
+
+	userID, err := uc.userRepo.ResetUserPassword(ctx, hashToken(token), passwordHash)
+	if err != nil {
+		if errors.Is(err, users.ErrorNotFound) {
+			return users.ErrorBadRequest
+		}
+		return err
+	}
+
+	uc.notify(ctx, models.SecurityEvent{UserID: userID, Action: models.SecurityPasswordReset})
+	return nil
+}
+
+// issueToken сохраняет хэш нового токена и возвращает ссылку для письма
+func (uc *UserUsecase) issueToken(ctx context.Context, userID uuid.UUID, purpose, email string, ttl time.Duration, path string) (string, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		logger.Error("failed to generate token: " + err.Error())
+		return "", users.ErrorInternalServerError
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now().UTC()
+	err := uc.userRepo.CreateUserToken(ctx, models.UserToken{
+		ID:        uuid.NewV4(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		Email:     email,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return uc.baseURL + path + "?token=" + token, nil
+}
+
+func (uc *UserUsecase) ExportData(ctx context.Context, userID uuid.UUID) (models.UserExport, error) {
+	profile, err := uc.userRepo.GetUserExport(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, err
+	}
+
+	feedbacks, err := uc.userRepo.GetUserFeedbacks(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, err
+	}
+
+	export := models.UserExport{
+		ExportedAt: time.Now().UTC(),
+		Profile:    profile,
+		Ratings:    []models.ExportRating{},
+		Reviews:    []models.ExportFeedback{},
+	}
+	for _, feedback := range feedbacks {
+		if feedback.Rating != nil {
+			export.Ratings = append(export.Ratings, models.ExportRating{
+				FilmID:    feedback.FilmID,
+				FilmTitle: feedback.FilmTitle,
+				Rating:    *feedback.Rating,
+				CreatedAt: feedback.CreatedAt,
+			})
+		}
+		if feedback.IsReview() {
+			export.Reviews = append(export.Reviews, feedback)
+		}
+	}
+	return export, nil
+}
+
+// DeleteAccount откладывает удаление на accountDeletionGrace; до этого аккаунт не пускает по старым токенам
+func (uc *UserUsecase) DeleteAccount(ctx context.Context, userID uuid.UUID, plainPassword string) (models.AccountDeletion, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	neededUser, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.AccountDeletion{}, err
+	}
+
+	if !passwordMatches(neededUser, plainPassword) {
+		logger.Error("wrong password")
+		return models.AccountDeletion{}, users.ErrorWrongPassword
+	}
+
+	deleteAfter := time.Now().UTC().Add(accountDeletionGrace)
+	if err = uc.userRepo.ScheduleUserDeletion(ctx, neededUser.ID, deleteAfter); err != nil {
+		return models.AccountDeletion{}, err
+	}
+
+	uc.mailDeletionScheduled(ctx, neededUser, deleteAfter)
+	return models.AccountDeletion{DeleteAfter: deleteAfter}, nil
+}
+
+// mailDeletionScheduled предупреждает на подтверждённую почту; без письма удаление всё равно состоится
+func (uc *UserUsecase) mailDeletionScheduled(ctx context.Context, user models.User, deleteAfter time.Time) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	email, err := uc.userRepo.GetUserEmail(ctx, user.ID)
+	if err != nil || email.Email == "" || !email.Verified {
+		return
+	}
+
+	err = uc.sendMail(ctx, mail.Message{
+		To:      email.Email,
+		Subject: "Удаление аккаунта",
+		Body: "Здравствуйте, " + user.Login + "!\n\nАккаунт и все ваши оценки и отзывы будут удалены " +
+			deleteAfter.Format("02.01.2006") + ". Чтобы отменить удаление, просто войдите в аккаунт до этой даты.",
+	})
+	if err != nil {
+		logger.Warn("failed to send deletion letter", "error", err)
+	}
+}
+
+// PurgeDeletedAccounts окончательно удаляет аккаунты с истёкшим сроком и их аватары
+func (uc *UserUsecase) PurgeDeletedAccounts(ctx context.Context) (int, error) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+
+	total := 0
+	for {
+		deleted, err := uc.userRepo.DeleteDueUsers(ctx, accountPurgeBatch)
+		if err != nil {
+			return total, err
+		}
+		for _, user := range deleted {
+			if user.Avatar == "" || user.Avatar == defaultAvatar {
+				continue
+			}
+			if err := uc.storageRepo.DeleteAvatar(ctx, user.Avatar); err != nil {
+				logger.Warn("failed to delete avatar of deleted user", "error", err, "user_id", user.ID.String())
+			}
+		}
+		total += len(deleted)
+		if len(deleted) < accountPurgeBatch {
+			return total, nil
+		}
+	}
+}
+
+// RunAccountPurge запускает PurgeDeletedAccounts раз в interval до отмены ctx
+func (uc *UserUsecase) RunAccountPurge(ctx context.Context, interval time.Duration) {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := uc.PurgeDeletedAccounts(ctx)
+			if err != nil {
+				logger.Error("failed to purge deleted accounts: " + err.Error())
+			}
+			if purged > 0 {
+				logger.Info(fmt.Sprintf("purged %d deleted accounts", purged))
+			}
+		}
+	}
+}
+
+func (uc *UserUsecase) sendMail(ctx context.Context, msg mail.Message) error {
+	logger := log.GetLoggerFromContext(ctx).With(slog.String("func", log.GetFuncName()))
+	if uc.mailer == nil {
+		logger.Error("mail sender is not configured")
+		return users.ErrorInternalServerError
+	}
+	if err := uc.mailer.Send(ctx, msg); err != nil {
+		return users.ErrorInternalServerError
+	}
+	return nil
+}
+
+// passwordMatches пропускает проверку, если пароль не задан: случайный пароль OAuth-пользователя
+// никому не известен, а сессия у него есть только после входа через провайдера
+func passwordMatches(user models.User, plainPassword string) bool {
+	if !user.PasswordSet {
+		return true
+	}
+	ok, _ := password.Verify(user.PasswordHash, plainPassword)
+	return ok
+}
+
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
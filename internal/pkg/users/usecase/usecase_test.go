@@ -1,18 +1,27 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"image"
+	"image/png"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"kinopoisk/internal/models"
+	"kinopoisk/internal/pkg/mail"
+	mailMocks "kinopoisk/internal/pkg/mail/mocks"
 	"kinopoisk/internal/pkg/middleware/logger"
+	notificationMocks "kinopoisk/internal/pkg/notifications/mocks"
+	"kinopoisk/internal/pkg/password"
 	"kinopoisk/internal/pkg/users"
 	"kinopoisk/internal/pkg/users/mocks"
 
+	"github.com/golang-jwt/jwt"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
@@ -27,13 +36,18 @@ func testContext() context.Context {
 	return context.WithValue(context.Background(), logger.LoggerKey, testLogger)
 }
 
+func testHash(plain string) []byte {
+	hash, _ := password.Hash(plain)
+	return hash
+}
+
 func TestUserUsecase_GetUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUsersRepo(ctrl)
 	mockStorage := mocks.NewMockStorageRepo(ctrl)
-	usecase := NewUserUsecase(mockRepo, mockStorage)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
 
 	userID := uuid.NewV4()
 	expectedUser := models.User{
@@ -66,7 +80,8 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 
 	mockRepo := mocks.NewMockUsersRepo(ctrl)
 	mockStorage := mocks.NewMockStorageRepo(ctrl)
-	usecase := NewUserUsecase(mockRepo, mockStorage)
+	mockNotifier := notificationMocks.NewMockProducer(ctrl)
+	usecase := NewUserUsecase(mockRepo, mockStorage, mockNotifier, nil, nil, "")
 
 	userID := uuid.NewV4()
 	oldPassword := "oldPassword123"
@@ -76,7 +91,8 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 		ID:           userID,
 		Version:      1,
 		Login:        "testuser",
-		PasswordHash: HashPass(oldPassword),
+		PasswordHash: testHash(oldPassword),
+		PasswordSet:  true,
 		Avatar:       "avatars/default.png",
 		CreatedAt:    time.Now().UTC(),
 		UpdatedAt:    time.Now().UTC(),
@@ -85,6 +101,7 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(existingUser, nil)
 		mockRepo.EXPECT().UpdateUserPassword(gomock.Any(), 2, userID, gomock.Any()).Return(nil)
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: userID, Action: models.SecurityPasswordChanged})
 		result, token, err := usecase.ChangePassword(testContext(), userID, oldPassword, newPassword)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, token)
@@ -118,6 +135,315 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, users.ErrorBadRequest))
 	})
+
+	t.Run("Common new password", func(t *testing.T) {
+		policy, err := password.LoadPolicy("")
+		assert.NoError(t, err)
+		withPolicy := NewUserUsecase(mockRepo, mockStorage, mockNotifier, nil, policy, "")
+
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(existingUser, nil)
+		_, _, err = withPolicy.ChangePassword(testContext(), userID, oldPassword, "qwerty123")
+		assert.True(t, errors.Is(err, users.ErrorBadRequest))
+	})
+}
+
+func TestUserUsecase_ChangeLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, nil, nil, "")
+
+	user := models.User{ID: uuid.NewV4(), Version: 3, Login: "oldlogin", PasswordHash: testHash("password123!"), PasswordSet: true}
+	recent := time.Now().Add(-24 * time.Hour)
+	longAgo := time.Now().Add(-2 * loginChangeCooldown)
+
+	t.Run("Success", func(t *testing.T) {
+		var reservedUntil time.Time
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().GetLastLoginChange(gomock.Any(), user.ID).Return(&longAgo, nil)
+		mockRepo.EXPECT().IsLoginAvailable(gomock.Any(), user.ID, "newlogin").Return(true, nil)
+		mockRepo.EXPECT().UpdateUserLogin(gomock.Any(), 4, user.ID, "newlogin", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ int, _ uuid.UUID, _ string, until time.Time) error {
+				reservedUntil = until
+				return nil
+			})
+
+		result, token, err := usecase.ChangeLogin(testContext(), user.ID, "newlogin", "password123!")
+		assert.NoError(t, err)
+		assert.Equal(t, "newlogin", result.Login)
+		assert.Equal(t, 4, result.Version)
+		assert.WithinDuration(t, time.Now().Add(loginReservation), reservedUntil, time.Minute)
+
+		parsed, err := usecase.ParseToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "newlogin", parsed.Claims.(jwt.MapClaims)["login"])
+	})
+
+	t.Run("First change has no cooldown", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().GetLastLoginChange(gomock.Any(), user.ID).Return(nil, nil)
+		mockRepo.EXPECT().IsLoginAvailable(gomock.Any(), user.ID, "newlogin").Return(true, nil)
+		mockRepo.EXPECT().UpdateUserLogin(gomock.Any(), 4, user.ID, "newlogin", gomock.Any()).Return(nil)
+
+		_, _, err := usecase.ChangeLogin(testContext(), user.ID, "newlogin", "password123!")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		_, _, err := usecase.ChangeLogin(testContext(), user.ID, "newlogin", "wrong")
+		assert.ErrorIs(t, err, users.ErrorWrongPassword)
+	})
+
+	t.Run("Invalid login", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		_, _, err := usecase.ChangeLogin(testContext(), user.ID, "new", "password123!")
+		assert.ErrorIs(t, err, users.ErrorInvalidLogin)
+	})
+
+	t.Run("Same login", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		_, _, err := usecase.ChangeLogin(testContext(), user.ID, "oldlogin", "password123!")
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().GetLastLoginChange(gomock.Any(), user.ID).Return(&recent, nil)
+		_, _, err := usecase.ChangeLogin(testContext(), user.ID, "newlogin", "password123!")
+		assert.ErrorIs(t, err, users.ErrorTooManyRequests)
+	})
+
+	t.Run("Login taken", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().GetLastLoginChange(gomock.Any(), user.ID).Return(nil, nil)
+		mockRepo.EXPECT().IsLoginAvailable(gomock.Any(), user.ID, "newlogin").Return(false, nil)
+		_, _, err := usecase.ChangeLogin(testContext(), user.ID, "newlogin", "password123!")
+		assert.ErrorIs(t, err, users.ErrorLoginTaken)
+	})
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestUserUsecase_ChangeUserAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
+
+	user := models.User{ID: uuid.NewV4(), Version: 1, Login: "testuser", Avatar: "avatars/old.png"}
+	newAvatar := models.AvatarPath("new", 512)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		gomock.InOrder(
+			mockStorage.EXPECT().UploadAvatar(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, images map[int][]byte) (string, error) {
+					assert.Len(t, images, len(models.AvatarSizes))
+					return newAvatar, nil
+				}),
+			mockRepo.EXPECT().UpdateUserAvatar(gomock.Any(), 1, user.ID, newAvatar).Return(nil),
+			mockStorage.EXPECT().DeleteAvatar(gomock.Any(), "avatars/old.png").Return(nil),
+		)
+
+		result, _, err := usecase.ChangeUserAvatar(testContext(), user.ID, testPNG(t, 300, 200))
+		assert.NoError(t, err)
+		assert.Equal(t, newAvatar, result.Avatar)
+	})
+
+	t.Run("Update failure keeps the old avatar", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockStorage.EXPECT().UploadAvatar(gomock.Any(), gomock.Any()).Return(newAvatar, nil)
+		mockRepo.EXPECT().UpdateUserAvatar(gomock.Any(), 1, user.ID, newAvatar).Return(users.ErrorConflict)
+		// удаляется только что загруженный, старый не трогается
+		mockStorage.EXPECT().DeleteAvatar(gomock.Any(), newAvatar).Return(nil)
+
+		_, _, err := usecase.ChangeUserAvatar(testContext(), user.ID, testPNG(t, 300, 200))
+		assert.ErrorIs(t, err, users.ErrorConflict)
+	})
+
+	t.Run("Upload failure keeps the old avatar", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockStorage.EXPECT().UploadAvatar(gomock.Any(), gomock.Any()).Return("", errors.New("storage down"))
+
+		_, _, err := usecase.ChangeUserAvatar(testContext(), user.ID, testPNG(t, 300, 200))
+		assert.ErrorIs(t, err, users.ErrorInternalServerError)
+	})
+
+	t.Run("Not an image", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		_, _, err := usecase.ChangeUserAvatar(testContext(), user.ID, []byte("GIF89a"))
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Too small", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		_, _, err := usecase.ChangeUserAvatar(testContext(), user.ID, testPNG(t, 10, 10))
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+}
+
+func TestUserUsecase_RequestAvatarUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
+
+	userID := uuid.NewV4()
+	slot := models.AvatarUploadSlot{ID: uuid.NewV4(), URL: "https://storage/uploads/avatars/x", Method: "PUT"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(models.User{ID: userID}, nil)
+		mockStorage.EXPECT().PresignAvatarUpload(gomock.Any(), userID, "image/png", int64(1024), avatarUploadTTL).Return(slot, nil)
+
+		result, err := usecase.RequestAvatarUpload(testContext(), userID, "image/png", 1024)
+		assert.NoError(t, err)
+		assert.Equal(t, slot, result)
+	})
+
+	t.Run("Bad content type", func(t *testing.T) {
+		_, err := usecase.RequestAvatarUpload(testContext(), userID, "image/svg+xml", 1024)
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		_, err := usecase.RequestAvatarUpload(testContext(), userID, "image/jpeg", 11*1024*1024)
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := usecase.RequestAvatarUpload(testContext(), userID, "image/jpeg", 0)
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+}
+
+func TestUserUsecase_ConfirmAvatarUpload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
+
+	user := models.User{ID: uuid.NewV4(), Version: 1, Login: "testuser", Avatar: "avatars/default.png"}
+	uploadID := uuid.NewV4()
+	newAvatar := models.AvatarPath("new", 512)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockStorage.EXPECT().GetAvatarUpload(gomock.Any(), user.ID, uploadID, int64(10*1024*1024)).Return(testPNG(t, 300, 300), nil)
+		mockStorage.EXPECT().UploadAvatar(gomock.Any(), gomock.Any()).Return(newAvatar, nil)
+		mockRepo.EXPECT().UpdateUserAvatar(gomock.Any(), 1, user.ID, newAvatar).Return(nil)
+		mockStorage.EXPECT().DeleteAvatarUpload(gomock.Any(), user.ID, uploadID).Return(nil)
+
+		result, token, err := usecase.ConfirmAvatarUpload(testContext(), user.ID, uploadID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, token)
+		assert.Equal(t, newAvatar, result.Avatar)
+	})
+
+	t.Run("Not uploaded", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockStorage.EXPECT().GetAvatarUpload(gomock.Any(), user.ID, uploadID, gomock.Any()).Return(nil, users.ErrorNotFound)
+
+		_, _, err := usecase.ConfirmAvatarUpload(testContext(), user.ID, uploadID)
+		assert.ErrorIs(t, err, users.ErrorNotFound)
+	})
+
+	t.Run("Not an image is discarded", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockStorage.EXPECT().GetAvatarUpload(gomock.Any(), user.ID, uploadID, gomock.Any()).Return([]byte("<svg/>"), nil)
+		mockStorage.EXPECT().DeleteAvatarUpload(gomock.Any(), user.ID, uploadID).Return(nil)
+
+		_, _, err := usecase.ConfirmAvatarUpload(testContext(), user.ID, uploadID)
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Storage failure keeps the upload", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockStorage.EXPECT().GetAvatarUpload(gomock.Any(), user.ID, uploadID, gomock.Any()).Return(testPNG(t, 300, 300), nil)
+		mockStorage.EXPECT().UploadAvatar(gomock.Any(), gomock.Any()).Return("", errors.New("storage down"))
+
+		_, _, err := usecase.ConfirmAvatarUpload(testContext(), user.ID, uploadID)
+		assert.ErrorIs(t, err, users.ErrorInternalServerError)
+	})
+}
+
+func TestUserUsecase_CleanupAvatarUploads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	usecase := NewUserUsecase(mocks.NewMockUsersRepo(ctrl), mockStorage, nil, nil, nil, "")
+
+	mockStorage.EXPECT().DeleteStaleAvatarUploads(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, olderThan time.Time) (int, error) {
+			assert.WithinDuration(t, time.Now().Add(-avatarUploadGrace), olderThan, time.Minute)
+			return 2, nil
+		})
+
+	deleted, err := usecase.CleanupAvatarUploads(testContext())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestUserUsecase_ReconcileAvatars(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
+
+	old := time.Now().Add(-48 * time.Hour)
+	objects := []models.AvatarObject{
+		{Path: "avatars/default.png", ModTime: old},
+		{Path: "avatars/legacy.png", ModTime: old},
+		{Path: "avatars/used/64.jpg", ModTime: old},
+		{Path: "avatars/used/512.jpg", ModTime: old},
+		{Path: "avatars/orphan/64.jpg", ModTime: old},
+		{Path: "avatars/orphan/512.jpg", ModTime: old},
+		{Path: "avatars/fresh/512.jpg", ModTime: time.Now()},
+	}
+	referenced := []string{"avatars/default.png", "avatars/used/512.jpg"}
+
+	t.Run("Dry run", func(t *testing.T) {
+		mockStorage.EXPECT().ListAvatarObjects(gomock.Any()).Return(objects, nil)
+		mockRepo.EXPECT().GetAvatarPaths(gomock.Any()).Return(referenced, nil)
+
+		report, err := usecase.ReconcileAvatars(testContext(), true)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AvatarReconcileReport{Scanned: 5, Orphaned: 2, DryRun: true}, report)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockStorage.EXPECT().ListAvatarObjects(gomock.Any()).Return(objects, nil)
+		mockRepo.EXPECT().GetAvatarPaths(gomock.Any()).Return(referenced, nil)
+		mockStorage.EXPECT().DeleteAvatarObject(gomock.Any(), "avatars/orphan/64.jpg").Return(nil)
+		mockStorage.EXPECT().DeleteAvatarObject(gomock.Any(), "avatars/orphan/512.jpg").Return(users.ErrorInternalServerError)
+
+		report, err := usecase.ReconcileAvatars(testContext(), false)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AvatarReconcileReport{Scanned: 5, Orphaned: 2, Deleted: 1, Failed: 1}, report)
+	})
+
+	t.Run("Database error deletes nothing", func(t *testing.T) {
+		mockStorage.EXPECT().ListAvatarObjects(gomock.Any()).Return(objects, nil)
+		mockRepo.EXPECT().GetAvatarPaths(gomock.Any()).Return(nil, users.ErrorInternalServerError)
+
+		_, err := usecase.ReconcileAvatars(testContext(), false)
+		assert.ErrorIs(t, err, users.ErrorInternalServerError)
+	})
 }
 
 func TestUserUsecase_GenerateAndParseToken(t *testing.T) {
@@ -127,13 +453,13 @@ func TestUserUsecase_GenerateAndParseToken(t *testing.T) {
 	mockRepo := mocks.NewMockUsersRepo(ctrl)
 	mockStorage := mocks.NewMockStorageRepo(ctrl)
 	os.Setenv("JWT_SECRET", "test-secret-key")
-	usecase := NewUserUsecase(mockRepo, mockStorage)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
 
 	userID := uuid.NewV4()
 	login := "testuser"
 
 	t.Run("Generate and parse success", func(t *testing.T) {
-		token, err := usecase.GenerateToken(userID, login)
+		token, err := usecase.GenerateToken(userID, login, 1)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, token)
 
@@ -155,7 +481,7 @@ func TestUserUsecase_ValidateAndGetUser(t *testing.T) {
 	mockRepo := mocks.NewMockUsersRepo(ctrl)
 	mockStorage := mocks.NewMockStorageRepo(ctrl)
 	os.Setenv("JWT_SECRET", "test-secret-key")
-	usecase := NewUserUsecase(mockRepo, mockStorage)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
 
 	userID := uuid.NewV4()
 	login := "testuser"
@@ -168,7 +494,7 @@ func TestUserUsecase_ValidateAndGetUser(t *testing.T) {
 		UpdatedAt: time.Now().UTC(),
 	}
 
-	validToken, _ := usecase.GenerateToken(userID, login)
+	validToken, _ := usecase.GenerateToken(userID, login, expectedUser.Version)
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.EXPECT().GetUserByLogin(gomock.Any(), login).Return(expectedUser, nil)
@@ -190,9 +516,573 @@ func TestUserUsecase_ValidateAndGetUser(t *testing.T) {
 		assert.True(t, errors.Is(err, users.ErrorUnauthorized))
 	})
 
+	t.Run("Login now belongs to another user", func(t *testing.T) {
+		other := expectedUser
+		other.ID = uuid.NewV4()
+		mockRepo.EXPECT().GetUserByLogin(gomock.Any(), login).Return(other, nil)
+		_, err := usecase.ValidateAndGetUser(testContext(), validToken)
+		assert.ErrorIs(t, err, users.ErrorUnauthorized)
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		bumped := expectedUser
+		bumped.Version++
+		mockRepo.EXPECT().GetUserByLogin(gomock.Any(), login).Return(bumped, nil)
+		_, err := usecase.ValidateAndGetUser(testContext(), validToken)
+		assert.ErrorIs(t, err, users.ErrorUnauthorized)
+	})
+
 	t.Run("Invalid token", func(t *testing.T) {
 		_, err := usecase.ValidateAndGetUser(testContext(), "invalid.token.here")
 		assert.Error(t, err)
 		assert.True(t, errors.Is(err, users.ErrorUnauthorized))
 	})
 }
+
+func TestUserUsecase_GetUserProfile(t *testing.T) {
+	userID := uuid.NewV4()
+	text := "Отличное кино"
+	stats := models.ProfileStats{RatingsCount: 12, ReviewsCount: 2, AverageRating: 7.4}
+	genres := []models.ProfileGenre{{ID: uuid.NewV4(), Title: "Драма", RatingsCount: 5, AverageRating: 8.2}}
+	actorsList := []models.ProfileActor{{ID: uuid.NewV4(), RussianName: "Том Хэнкс", RatingsCount: 3, AverageRating: 8.7}}
+	reviews := []models.ProfileReview{{ID: uuid.NewV4(), FilmID: uuid.NewV4(), FilmTitle: "Форрест Гамп", Title: "Шедевр", Text: &text}}
+
+	allVisible := models.ProfilePrivacy{ShowStats: true, ShowTaste: true, ShowReviews: true}
+
+	tests := []struct {
+		name      string
+		privacy   models.ProfilePrivacy
+		setupMock func(mockRepo *mocks.MockUsersRepo)
+		expected  models.UserProfile
+		errorType error
+	}{
+		{
+			name:    "Everything visible",
+			privacy: allVisible,
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().GetUserStats(gomock.Any(), userID).Return(stats, nil)
+				mockRepo.EXPECT().GetUserFavouriteGenres(gomock.Any(), userID, profileGenresLimit).Return(genres, nil)
+				mockRepo.EXPECT().GetUserFavouriteActors(gomock.Any(), userID, profileActorsLimit).Return(actorsList, nil)
+				mockRepo.EXPECT().GetUserLatestReviews(gomock.Any(), userID, profileReviewsLimit).Return(reviews, nil)
+			},
+			expected: models.UserProfile{
+				ID:              userID,
+				Login:           "cinephile",
+				Privacy:         allVisible,
+				Stats:           &stats,
+				FavouriteGenres: genres,
+				FavouriteActors: actorsList,
+				LatestReviews:   reviews,
+			},
+		},
+		{
+			name:    "Only reviews visible",
+			privacy: models.ProfilePrivacy{ShowReviews: true},
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().GetUserLatestReviews(gomock.Any(), userID, profileReviewsLimit).Return(reviews, nil)
+			},
+			expected: models.UserProfile{
+				ID:            userID,
+				Login:         "cinephile",
+				Privacy:       models.ProfilePrivacy{ShowReviews: true},
+				LatestReviews: reviews,
+			},
+		},
+		{
+			name:      "Everything hidden",
+			privacy:   models.ProfilePrivacy{},
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {},
+			expected: models.UserProfile{
+				ID:    userID,
+				Login: "cinephile",
+			},
+		},
+		{
+			name:    "Aggregate fails",
+			privacy: allVisible,
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().GetUserStats(gomock.Any(), userID).Return(models.ProfileStats{}, users.ErrorInternalServerError)
+			},
+			errorType: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUsersRepo(ctrl)
+			mockRepo.EXPECT().GetUserProfile(gomock.Any(), userID).
+				Return(models.UserProfile{ID: userID, Login: "cinephile", Privacy: tt.privacy}, nil)
+			tt.setupMock(mockRepo)
+
+			usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, nil, nil, "")
+			result, err := usecase.GetUserProfile(testContext(), userID)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUserUsecase_GetUserProfile_FollowersOnly(t *testing.T) {
+	ownerID := uuid.NewV4()
+	viewerID := uuid.NewV4()
+	stats := models.ProfileStats{RatingsCount: 3, ReviewsCount: 1, AverageRating: 6.5}
+	privacy := models.ProfilePrivacy{ShowStats: true, FollowersOnly: true}
+
+	tests := []struct {
+		name      string
+		viewer    *uuid.UUID
+		setupMock func(mockRepo *mocks.MockUsersRepo)
+		wantStats *models.ProfileStats
+		errorType error
+	}{
+		{
+			name:      "Anonymous viewer",
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {},
+		},
+		{
+			name:   "Not a follower",
+			viewer: &viewerID,
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().IsFollower(gomock.Any(), viewerID, ownerID).Return(false, nil)
+			},
+		},
+		{
+			name:   "Follower",
+			viewer: &viewerID,
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().IsFollower(gomock.Any(), viewerID, ownerID).Return(true, nil)
+				mockRepo.EXPECT().GetUserStats(gomock.Any(), ownerID).Return(stats, nil)
+			},
+			wantStats: &stats,
+		},
+		{
+			name:   "Owner",
+			viewer: &ownerID,
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().GetUserStats(gomock.Any(), ownerID).Return(stats, nil)
+			},
+			wantStats: &stats,
+		},
+		{
+			name:   "Follower check fails",
+			viewer: &viewerID,
+			setupMock: func(mockRepo *mocks.MockUsersRepo) {
+				mockRepo.EXPECT().IsFollower(gomock.Any(), viewerID, ownerID).Return(false, users.ErrorInternalServerError)
+			},
+			errorType: users.ErrorInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockUsersRepo(ctrl)
+			mockRepo.EXPECT().GetUserProfile(gomock.Any(), ownerID).
+				Return(models.UserProfile{ID: ownerID, Login: "cinephile", Privacy: privacy}, nil)
+			tt.setupMock(mockRepo)
+
+			ctx := testContext()
+			if tt.viewer != nil {
+				ctx = context.WithValue(ctx, users.UserKey, *tt.viewer)
+			}
+
+			usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, nil, nil, "")
+			result, err := usecase.GetUserProfile(ctx, ownerID)
+			if tt.errorType != nil {
+				assert.ErrorIs(t, err, tt.errorType)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStats, result.Stats)
+		})
+	}
+}
+
+func TestUserUsecase_GetUserProfile_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.NewV4()
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockRepo.EXPECT().GetUserProfile(gomock.Any(), userID).Return(models.UserProfile{}, users.ErrorNotFound)
+
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, nil, nil, "")
+	_, err := usecase.GetUserProfile(testContext(), userID)
+	assert.ErrorIs(t, err, users.ErrorNotFound)
+}
+
+// tokenFromMail достаёт токен из ссылки в письме
+func tokenFromMail(msg mail.Message) string {
+	start := strings.Index(msg.Body, "?token=") + len("?token=")
+	end := start + strings.IndexAny(msg.Body[start:], "\n ")
+	return msg.Body[start:end]
+}
+
+func TestUserUsecase_ChangeEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockMailer := mailMocks.NewMockSender(ctrl)
+	mockNotifier := notificationMocks.NewMockProducer(ctrl)
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), mockNotifier, mockMailer, nil, "https://ddfilms.example")
+
+	userID := uuid.NewV4()
+	user := models.User{ID: userID, Login: "testuser", PasswordHash: testHash("Secret1!"), PasswordSet: true}
+
+	t.Run("Success", func(t *testing.T) {
+		var saved models.UserToken
+		var sent mail.Message
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), userID).Return(models.UserEmail{}, nil)
+		mockRepo.EXPECT().UpdateUserEmail(gomock.Any(), userID, "user@example.com").Return(nil)
+		mockRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token models.UserToken) error {
+			saved = token
+			return nil
+		})
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg mail.Message) error {
+			sent = msg
+			return nil
+		})
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: userID, Action: models.SecurityEmailChanged})
+
+		result, err := usecase.ChangeEmail(testContext(), userID, " user@example.com ", "Secret1!")
+		assert.NoError(t, err)
+		assert.Equal(t, models.UserEmail{Email: "user@example.com"}, result)
+
+		assert.Equal(t, "user@example.com", sent.To)
+		assert.Contains(t, sent.Body, "https://ddfilms.example/verify-email?token=")
+		assert.Equal(t, models.TokenEmailVerification, saved.Purpose)
+		assert.Equal(t, "user@example.com", saved.Email)
+		assert.WithinDuration(t, time.Now().Add(emailVerificationTTL), saved.ExpiresAt, time.Minute)
+		// в базе лежит только хэш токена из письма
+		assert.Equal(t, hashToken(tokenFromMail(sent)), saved.TokenHash)
+	})
+
+	t.Run("Previous address is warned", func(t *testing.T) {
+		var sent []mail.Message
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), userID).Return(models.UserEmail{Email: "old@example.com", Verified: true}, nil)
+		mockRepo.EXPECT().UpdateUserEmail(gomock.Any(), userID, "user@example.com").Return(nil)
+		mockRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).Return(nil)
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg mail.Message) error {
+			sent = append(sent, msg)
+			return nil
+		}).Times(2)
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: userID, Action: models.SecurityEmailChanged})
+
+		_, err := usecase.ChangeEmail(testContext(), userID, "user@example.com", "Secret1!")
+		assert.NoError(t, err)
+		assert.Len(t, sent, 2)
+		assert.Equal(t, "old@example.com", sent[1].To)
+		assert.NotContains(t, sent[1].Body, "token=")
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		_, err := usecase.ChangeEmail(testContext(), userID, "user@example.com", "Wrong1!")
+		assert.ErrorIs(t, err, users.ErrorWrongPassword)
+	})
+
+	t.Run("Already verified", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), userID).Return(models.UserEmail{Email: "User@example.com", Verified: true}, nil)
+		result, err := usecase.ChangeEmail(testContext(), userID, "user@example.com", "Secret1!")
+		assert.NoError(t, err)
+		assert.True(t, result.Verified)
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		for _, email := range []string{"", "not-an-email", "Name <user@example.com>", "a@b\r\nBcc: x@y.z"} {
+			_, err := usecase.ChangeEmail(testContext(), userID, email, "Secret1!")
+			assert.ErrorIs(t, err, users.ErrorBadRequest, email)
+		}
+	})
+
+	t.Run("Mail failed", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), userID).Return(models.UserEmail{}, nil)
+		mockRepo.EXPECT().UpdateUserEmail(gomock.Any(), userID, "user@example.com").Return(nil)
+		mockRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).Return(nil)
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(errors.New("smtp down"))
+		_, err := usecase.ChangeEmail(testContext(), userID, "user@example.com", "Secret1!")
+		assert.ErrorIs(t, err, users.ErrorInternalServerError)
+	})
+}
+
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, nil, nil, "")
+
+	userID := uuid.NewV4()
+	token := models.UserToken{UserID: userID, Purpose: models.TokenEmailVerification, Email: "user@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().ConsumeUserToken(gomock.Any(), hashToken("token"), models.TokenEmailVerification).Return(token, nil)
+		mockRepo.EXPECT().MarkEmailVerified(gomock.Any(), userID, "user@example.com").Return(nil)
+		result, err := usecase.VerifyEmail(testContext(), "token")
+		assert.NoError(t, err)
+		assert.Equal(t, models.UserEmail{Email: "user@example.com", Verified: true}, result)
+	})
+
+	t.Run("Used or expired token", func(t *testing.T) {
+		mockRepo.EXPECT().ConsumeUserToken(gomock.Any(), hashToken("token"), models.TokenEmailVerification).Return(models.UserToken{}, users.ErrorNotFound)
+		_, err := usecase.VerifyEmail(testContext(), "token")
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Email changed after letter", func(t *testing.T) {
+		mockRepo.EXPECT().ConsumeUserToken(gomock.Any(), hashToken("token"), models.TokenEmailVerification).Return(token, nil)
+		mockRepo.EXPECT().MarkEmailVerified(gomock.Any(), userID, "user@example.com").Return(users.ErrorNotFound)
+		_, err := usecase.VerifyEmail(testContext(), "token")
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Email verified by another user", func(t *testing.T) {
+		mockRepo.EXPECT().ConsumeUserToken(gomock.Any(), hashToken("token"), models.TokenEmailVerification).Return(token, nil)
+		mockRepo.EXPECT().MarkEmailVerified(gomock.Any(), userID, "user@example.com").Return(users.ErrorEmailTaken)
+		_, err := usecase.VerifyEmail(testContext(), "token")
+		assert.ErrorIs(t, err, users.ErrorEmailTaken)
+	})
+
+	t.Run("Empty token", func(t *testing.T) {
+		_, err := usecase.VerifyEmail(testContext(), "")
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+}
+
+func TestUserUsecase_ForgotPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockMailer := mailMocks.NewMockSender(ctrl)
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, mockMailer, nil, "https://ddfilms.example/")
+
+	user := models.User{ID: uuid.NewV4(), Login: "testuser"}
+
+	t.Run("Success", func(t *testing.T) {
+		var saved models.UserToken
+		mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		mockRepo.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token models.UserToken) error {
+			saved = token
+			return nil
+		})
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg mail.Message) error {
+			assert.Equal(t, "user@example.com", msg.To)
+			assert.Contains(t, msg.Body, "https://ddfilms.example/reset-password?token=")
+			assert.Equal(t, hashToken(tokenFromMail(msg)), saved.TokenHash)
+			return nil
+		})
+
+		assert.NoError(t, usecase.ForgotPassword(testContext(), "user@example.com"))
+		assert.Equal(t, models.TokenPasswordReset, saved.Purpose)
+		assert.Equal(t, user.ID, saved.UserID)
+		assert.WithinDuration(t, time.Now().Add(passwordResetTTL), saved.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Unknown email is not revealed", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "nobody@example.com").Return(models.User{}, users.ErrorNotFound)
+		assert.NoError(t, usecase.ForgotPassword(testContext(), "nobody@example.com"))
+	})
+
+	t.Run("Invalid email", func(t *testing.T) {
+		assert.ErrorIs(t, usecase.ForgotPassword(testContext(), "nope"), users.ErrorBadRequest)
+	})
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockNotifier := notificationMocks.NewMockProducer(ctrl)
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), mockNotifier, nil, nil, "")
+
+	user := models.User{ID: uuid.NewV4(), Version: 3, Login: "testuser"}
+	token := models.UserToken{UserID: user.ID, Purpose: models.TokenPasswordReset}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserToken(gomock.Any(), hashToken("token"), models.TokenPasswordReset).Return(token, nil)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().ResetUserPassword(gomock.Any(), hashToken("token"), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ []byte, passwordHash []byte) (uuid.UUID, error) {
+				ok, _ := password.Verify(passwordHash, "newPassword1")
+				assert.True(t, ok)
+				return user.ID, nil
+			})
+		mockNotifier.EXPECT().Notify(gomock.Any(), models.SecurityEvent{UserID: user.ID, Action: models.SecurityPasswordReset})
+
+		assert.NoError(t, usecase.ResetPassword(testContext(), "token", "newPassword1"))
+	})
+
+	t.Run("Invalid password keeps token", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserToken(gomock.Any(), hashToken("token"), models.TokenPasswordReset).Return(token, nil)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		assert.ErrorIs(t, usecase.ResetPassword(testContext(), "token", "short"), users.ErrorBadRequest)
+	})
+
+	t.Run("Password equals login keeps token", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserToken(gomock.Any(), hashToken("token"), models.TokenPasswordReset).Return(token, nil)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		assert.ErrorIs(t, usecase.ResetPassword(testContext(), "token", "TestUser"), users.ErrorWeakPassword)
+	})
+
+	t.Run("Used or expired token", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserToken(gomock.Any(), hashToken("token"), models.TokenPasswordReset).Return(models.UserToken{}, users.ErrorNotFound)
+		assert.ErrorIs(t, usecase.ResetPassword(testContext(), "token", "newPassword1"), users.ErrorBadRequest)
+	})
+
+	t.Run("Token used meanwhile", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserToken(gomock.Any(), hashToken("token"), models.TokenPasswordReset).Return(token, nil)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().ResetUserPassword(gomock.Any(), hashToken("token"), gomock.Any()).Return(uuid.UUID{}, users.ErrorNotFound)
+		assert.ErrorIs(t, usecase.ResetPassword(testContext(), "token", "newPassword1"), users.ErrorBadRequest)
+	})
+}
+
+func TestUserUsecase_ExportData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, nil, nil, "")
+
+	userID := uuid.NewV4()
+	rating := 8
+	title := "Отзыв"
+	onlyRating := models.ExportFeedback{ID: uuid.NewV4(), FilmTitle: "Брат", Rating: &rating}
+	review := models.ExportFeedback{ID: uuid.NewV4(), FilmTitle: "Брат 2", Title: &title, Rating: &rating}
+	reviewWithoutRating := models.ExportFeedback{ID: uuid.NewV4(), FilmTitle: "Сёстры", Title: &title}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserExport(gomock.Any(), userID).Return(models.ExportProfile{ID: userID, Login: "testuser"}, nil)
+		mockRepo.EXPECT().GetUserFeedbacks(gomock.Any(), userID).Return([]models.ExportFeedback{onlyRating, review, reviewWithoutRating}, nil)
+
+		export, err := usecase.ExportData(testContext(), userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "testuser", export.Profile.Login)
+		assert.Len(t, export.Ratings, 2)
+		assert.Equal(t, []models.ExportFeedback{review, reviewWithoutRating}, export.Reviews)
+	})
+
+	t.Run("No feedbacks", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserExport(gomock.Any(), userID).Return(models.ExportProfile{ID: userID}, nil)
+		mockRepo.EXPECT().GetUserFeedbacks(gomock.Any(), userID).Return([]models.ExportFeedback{}, nil)
+
+		export, err := usecase.ExportData(testContext(), userID)
+		assert.NoError(t, err)
+		assert.NotNil(t, export.Ratings)
+		assert.NotNil(t, export.Reviews)
+	})
+
+	t.Run("User not found", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserExport(gomock.Any(), userID).Return(models.ExportProfile{}, users.ErrorNotFound)
+		_, err := usecase.ExportData(testContext(), userID)
+		assert.ErrorIs(t, err, users.ErrorNotFound)
+	})
+}
+
+func TestUserUsecase_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockMailer := mailMocks.NewMockSender(ctrl)
+	usecase := NewUserUsecase(mockRepo, mocks.NewMockStorageRepo(ctrl), nil, mockMailer, nil, "")
+
+	user := models.User{ID: uuid.NewV4(), Login: "testuser", PasswordHash: testHash("password123!"), PasswordSet: true}
+
+	t.Run("Success", func(t *testing.T) {
+		var scheduled time.Time
+		var sent mail.Message
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().ScheduleUserDeletion(gomock.Any(), user.ID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ uuid.UUID, deleteAfter time.Time) error {
+				scheduled = deleteAfter
+				return nil
+			})
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), user.ID).Return(models.UserEmail{Email: "user@example.com", Verified: true}, nil)
+		mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg mail.Message) error {
+			sent = msg
+			return nil
+		})
+
+		deletion, err := usecase.DeleteAccount(testContext(), user.ID, "password123!")
+		assert.NoError(t, err)
+		assert.Equal(t, scheduled, deletion.DeleteAfter)
+		assert.WithinDuration(t, time.Now().Add(accountDeletionGrace), deletion.DeleteAfter, time.Minute)
+		assert.Equal(t, "user@example.com", sent.To)
+	})
+
+	t.Run("Unverified email gets no letter", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		mockRepo.EXPECT().ScheduleUserDeletion(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), user.ID).Return(models.UserEmail{Email: "user@example.com"}, nil)
+
+		_, err := usecase.DeleteAccount(testContext(), user.ID, "password123!")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Wrong password", func(t *testing.T) {
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+		_, err := usecase.DeleteAccount(testContext(), user.ID, "wrong")
+		assert.ErrorIs(t, err, users.ErrorBadRequest)
+	})
+
+	t.Run("Without own password", func(t *testing.T) {
+		oauthUser := user
+		oauthUser.PasswordSet = false
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(oauthUser, nil)
+		mockRepo.EXPECT().ScheduleUserDeletion(gomock.Any(), user.ID, gomock.Any()).Return(nil)
+		mockRepo.EXPECT().GetUserEmail(gomock.Any(), user.ID).Return(models.UserEmail{}, nil)
+
+		_, err := usecase.DeleteAccount(testContext(), user.ID, "")
+		assert.NoError(t, err)
+	})
+}
+
+func TestUserUsecase_PurgeDeletedAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUsersRepo(ctrl)
+	mockStorage := mocks.NewMockStorageRepo(ctrl)
+	usecase := NewUserUsecase(mockRepo, mockStorage, nil, nil, nil, "")
+
+	t.Run("Deletes avatars in batches", func(t *testing.T) {
+		fullBatch := make([]models.DeletedUser, accountPurgeBatch)
+		for i := range fullBatch {
+			fullBatch[i] = models.DeletedUser{ID: uuid.NewV4(), Avatar: defaultAvatar}
+		}
+		fullBatch[0].Avatar = "avatars/first.png"
+		gomock.InOrder(
+			mockRepo.EXPECT().DeleteDueUsers(gomock.Any(), accountPurgeBatch).Return(fullBatch, nil),
+			mockRepo.EXPECT().DeleteDueUsers(gomock.Any(), accountPurgeBatch).Return([]models.DeletedUser{{ID: uuid.NewV4(), Avatar: "avatars/last.png"}}, nil),
+		)
+		mockStorage.EXPECT().DeleteAvatar(gomock.Any(), "avatars/first.png").Return(nil)
+		mockStorage.EXPECT().DeleteAvatar(gomock.Any(), "avatars/last.png").Return(errors.New("s3 error"))
+
+		purged, err := usecase.PurgeDeletedAccounts(testContext())
+		assert.NoError(t, err)
+		assert.Equal(t, accountPurgeBatch+1, purged)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteDueUsers(gomock.Any(), accountPurgeBatch).Return(nil, users.ErrorInternalServerError)
+		_, err := usecase.PurgeDeletedAccounts(testContext())
+		assert.ErrorIs(t, err, users.ErrorInternalServerError)
+	})
+}
@@ -1,30 +1,18 @@
 package users
 
-import "strings"
-
-const (
-	ValidChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#$%^&*()_+-=[]{}|;:,.<>?`~"
+import (
+	"net/mail"
 )
 
-func Validaton(login, password string) (string, bool) {
-	if len(login) < 6 || len(login) > 15 {
-		return "Invalid login length", false
-	}
-
-	if len(password) < 6 || len(password) > 15 {
-		return "Invalid password length", false
-	}
-
-	for _, char := range login {
-		if !strings.ContainsRune(ValidChars, char) {
-			return "Login contains invalid characters", false
-		}
+// ValidateEmail принимает только голый адрес без имени и угловых скобок
+func ValidateEmail(email string) (string, bool) {
+	if len(email) < 3 || len(email) > 254 {
+		return "Invalid email length", false
 	}
 
-	for _, char := range password {
-		if !strings.ContainsRune(ValidChars, char) {
-			return "Password contains invalid characters", false
-		}
+	parsed, err := mail.ParseAddress(email)
+	if err != nil || parsed.Address != email {
+		return "Invalid email", false
 	}
 	return "Ok", true
 }